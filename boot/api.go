@@ -2,48 +2,88 @@ package boot
 
 import (
 	"github.com/getground/tech-tasks/backend/config"
-	"github.com/getground/tech-tasks/backend/pkg/database"
+	"github.com/getground/tech-tasks/backend/pkg/gql"
+	"github.com/getground/tech-tasks/backend/pkg/modules/attendance"
+	"github.com/getground/tech-tasks/backend/pkg/modules/audit"
+	"github.com/getground/tech-tasks/backend/pkg/modules/events"
 	"github.com/getground/tech-tasks/backend/pkg/modules/guests"
+	"github.com/getground/tech-tasks/backend/pkg/modules/idempotency"
+	"github.com/getground/tech-tasks/backend/pkg/modules/invitations"
+	"github.com/getground/tech-tasks/backend/pkg/modules/ratelimit"
 	"github.com/getground/tech-tasks/backend/pkg/modules/tables"
+	"github.com/getground/tech-tasks/backend/pkg/modules/venue"
+	"github.com/getground/tech-tasks/backend/pkg/modules/waitlist"
+	"github.com/getground/tech-tasks/backend/pkg/modules/webhooks"
 	"github.com/getground/tech-tasks/backend/pkg/router"
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
 )
 
-func API(cfg config.API) *gin.Engine {
-	dbConn, err := database.New(cfg.DB)
-	if err != nil {
+func API(cfg config.API, srv Services) *gin.Engine {
+	engine := gin.New()
+	// the IP of a client, and so its rate limit, is only taken from the X-Forwarded-For header set by a trusted proxy
+	if err := engine.SetTrustedProxies(cfg.TrustedProxies); err != nil {
 		log.Fatal(err)
 	}
-	log.Println(dbConn)
-	engine := gin.New()
 	engine.Use(
 		gin.LoggerWithWriter(
 			gin.DefaultWriter, "/ping",
 		),
 		gin.Recovery(),
+		// the clients making too many requests are answered before the request reaches the routes
+		ratelimit.NewController(srv.RateLimit).Limit,
+		// the routes nested under /events/:event scope the request to their event again
+		events.Default(cfg.Events.Default),
+		audit.Actor(),
+		// the retries of the POST, PUT and DELETE requests sent with an Idempotency-Key get the first response
+		idempotency.NewController(srv.Idempotency).Keys,
 	)
 
 	// inti handlers
+	eventsHdl := events.NewHandler()
 	tablesHdl := tables.NewHandler()
 	guestsHdl := guests.NewHandler()
-
-	// init repositories
-	tablesRepo := tables.NewRepository(dbConn)
-	guestsRepo := guests.NewRepository(dbConn)
-
-	// init services
-	tablesSrv := tables.NewService(tablesRepo)
-	guestsSrv := guests.NewService(guestsRepo, tablesSrv)
+	invitationsHdl := invitations.NewHandler()
+	waitlistHdl := waitlist.NewHandler()
+	auditHdl := audit.NewHandler()
+	attendanceHdl := attendance.NewHandler()
+	webhooksHdl := webhooks.NewHandler()
 
 	// init controllers
-	tablesCtrl := tables.NewController(tablesHdl, tablesSrv)
-	guestsCtrl := guests.NewController(guestsHdl, guestsSrv)
+	eventsCtrl := events.NewController(eventsHdl, srv.Events)
+	tablesCtrl := tables.NewController(tablesHdl, srv.Tables)
+	guestsCtrl := guests.NewController(guestsHdl, srv.Guests)
+	invitationsCtrl := invitations.NewController(invitationsHdl, srv.Invitations)
+	waitlistCtrl := waitlist.NewController(waitlistHdl, srv.Waitlist)
+	venueCtrl := venue.NewController(srv.Venue)
+	auditCtrl := audit.NewController(auditHdl, srv.Audit)
+	attendanceCtrl := attendance.NewController(attendanceHdl, srv.Attendance)
+	webhooksCtrl := webhooks.NewController(webhooksHdl, srv.Webhooks)
+
+	// the changes made through graphql are recorded in the audit log as done by graphql
+	schema, err := gql.NewSchema(srv.Tables.ForActor("graphql"), srv.Guests.ForActor("graphql"), srv.Broker)
+	if err != nil {
+		log.Fatal(err)
+	}
+	graphqlCtrl := gql.NewController(schema)
 
 	// init routers
 	router.HealthCheckInitRoute(engine)
-	router.TablesInitRouter(engine, tablesCtrl)
-	router.GuestsInitRoute(engine, guestsCtrl)
+	event := router.EventsInitRoute(engine, eventsCtrl)
+	// the routes of the default event stay at the root
+	for _, r := range []gin.IRouter{engine, event} {
+		router.TablesInitRouter(r, tablesCtrl)
+		router.GuestsInitRoute(r, guestsCtrl)
+		router.InvitationsInitRoute(r, invitationsCtrl)
+		router.WaitlistInitRoute(r, waitlistCtrl)
+		router.VenueInitRoute(r, venueCtrl)
+		router.AuditInitRoute(r, auditCtrl)
+		router.AttendanceInitRoute(r, attendanceCtrl)
+		router.WebhooksInitRoute(r, webhooksCtrl)
+		router.GraphQLInitRoute(r, graphqlCtrl)
+	}
+	router.GuestsTokenInitRoute(engine, guestsCtrl)
+	router.InvitationsTokenInitRoute(engine, invitationsCtrl)
 
 	return engine
 }
@@ -0,0 +1,20 @@
+package boot
+
+import (
+	"github.com/getground/tech-tasks/backend/config"
+	"github.com/getground/tech-tasks/backend/pkg/rpc"
+	"github.com/getground/tech-tasks/backend/pkg/rpc/partypb"
+	"google.golang.org/grpc"
+)
+
+func GRPC(cfg config.API, srv Services) *grpc.Server {
+	server := grpc.NewServer()
+	// the changes made through grpc are recorded in the audit log as done by grpc, the requests without an event are
+	// about the default event
+	partypb.RegisterPartyServiceServer(
+		server, rpc.NewServer(
+			srv.Tables.ForActor("grpc"), srv.Guests.ForActor("grpc"), srv.Broker, cfg.Events.Default,
+		),
+	)
+	return server
+}
@@ -0,0 +1,25 @@
+package boot
+
+import (
+	"github.com/getground/tech-tasks/backend/config"
+	log "github.com/sirupsen/logrus"
+)
+
+// Reload applies the settings safe to change while the API runs, see config.API.Reload. The buckets of the rate
+// limiter are kept, the clients get the new limits as their buckets refill.
+func Reload(srv Services, cfg config.API) {
+	SetLogLevel(cfg.LogLevel)
+	srv.RateLimit.SetLimits(rateLimit(cfg.RateLimit.Rate, cfg.RateLimit.Burst), rateLimitRoutes(cfg.RateLimit))
+	srv.RateLimit.SetKeys(cfg.RateLimit.Keys)
+	srv.Venue.SetWarning(cfg.Venue.Warning)
+}
+
+// SetLogLevel sets the lowest level logged, an unknown level keeps the current one.
+func SetLogLevel(level string) {
+	l, err := log.ParseLevel(level)
+	if err != nil {
+		log.Warnf("unknown log level %q, the level stays %s", level, log.GetLevel())
+		return
+	}
+	log.SetLevel(l)
+}
@@ -0,0 +1,191 @@
+package boot
+
+import (
+	"crypto/rand"
+	"github.com/getground/tech-tasks/backend/config"
+	attendanceDef "github.com/getground/tech-tasks/backend/definitions/attendance"
+	auditDef "github.com/getground/tech-tasks/backend/definitions/audit"
+	eventsDef "github.com/getground/tech-tasks/backend/definitions/events"
+	guestsDef "github.com/getground/tech-tasks/backend/definitions/guests"
+	idempotencyDef "github.com/getground/tech-tasks/backend/definitions/idempotency"
+	invitationsDef "github.com/getground/tech-tasks/backend/definitions/invitations"
+	notificationsDef "github.com/getground/tech-tasks/backend/definitions/notifications"
+	outboxDef "github.com/getground/tech-tasks/backend/definitions/outbox"
+	ratelimitDef "github.com/getground/tech-tasks/backend/definitions/ratelimit"
+	tablesDef "github.com/getground/tech-tasks/backend/definitions/tables"
+	venueDef "github.com/getground/tech-tasks/backend/definitions/venue"
+	waitlistDef "github.com/getground/tech-tasks/backend/definitions/waitlist"
+	webhooksDef "github.com/getground/tech-tasks/backend/definitions/webhooks"
+	"github.com/getground/tech-tasks/backend/pkg/modules/attendance"
+	"github.com/getground/tech-tasks/backend/pkg/modules/audit"
+	"github.com/getground/tech-tasks/backend/pkg/modules/events"
+	"github.com/getground/tech-tasks/backend/pkg/modules/guests"
+	"github.com/getground/tech-tasks/backend/pkg/modules/idempotency"
+	"github.com/getground/tech-tasks/backend/pkg/modules/invitations"
+	"github.com/getground/tech-tasks/backend/pkg/modules/outbox"
+	"github.com/getground/tech-tasks/backend/pkg/modules/ratelimit"
+	"github.com/getground/tech-tasks/backend/pkg/modules/tables"
+	"github.com/getground/tech-tasks/backend/pkg/modules/venue"
+	"github.com/getground/tech-tasks/backend/pkg/modules/waitlist"
+	"github.com/getground/tech-tasks/backend/pkg/modules/webhooks"
+	"github.com/getground/tech-tasks/backend/pkg/notifications"
+	"github.com/getground/tech-tasks/backend/pkg/search"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"net/http"
+	"time"
+)
+
+// Services are shared by every API the service exposes, so a change made through one of them is seen by the
+// subscribers of the others. The tables, guests, invitations and waitlist services are scoped to the default event,
+// the HTTP API scopes them to the event of every request. The relay delivers the messages of the outbox to its sinks
+// once it runs, the webhooks among them, and the purger removes the expired idempotency keys. The buckets of the rate
+// limits are kept in memory, so every instance of the API limits the clients on its own.
+type Services struct {
+	Broker      notificationsDef.Broker
+	Events      eventsDef.Service
+	Tables      tablesDef.Service
+	Guests      guestsDef.Service
+	Invitations invitationsDef.Service
+	Waitlist    waitlistDef.Service
+	Venue       venueDef.Service
+	Audit       auditDef.Service
+	Attendance  attendanceDef.Service
+	Webhooks    webhooksDef.Service
+	Idempotency idempotencyDef.Service
+	RateLimit   ratelimitDef.Service
+	Buckets     *ratelimit.MemoryStore
+	Relay       outbox.Relay
+	Purger      idempotency.Purger
+}
+
+func NewServices(cfg config.API, dbConn *gorm.DB) Services {
+	broker := notifications.NewBroker()
+
+	// init repositories
+	eventsRepo := events.NewRepository(dbConn)
+	tablesRepo := tables.NewRepository(dbConn)
+	guestsRepo := guests.NewRepository(dbConn)
+	invitationsRepo := invitations.NewRepository(dbConn)
+	waitlistRepo := waitlist.NewRepository(dbConn)
+	venueRepo := venue.NewRepository(dbConn)
+	auditRepo := audit.NewRepository(dbConn)
+	attendanceRepo := attendance.NewRepository(dbConn)
+	webhooksRepo := webhooks.NewRepository(dbConn)
+	outboxRepo := outbox.NewRepository(dbConn)
+	idempotencyRepo := idempotency.NewRepository(dbConn)
+	buckets := ratelimit.NewMemoryStore()
+
+	// init services, the waitlist promotes the parties waiting when the tables and guests services release seats. The
+	// services listing times answer them in the timezone of the venue.
+	loc := venueLocation(cfg.Venue)
+	eventsSrv := events.NewService(eventsRepo)
+	auditSrv := audit.NewService(auditRepo).WithLocation(loc)
+	attendanceSrv := attendance.NewService(attendanceRepo).WithLocation(loc)
+	webhooksSrv := webhooks.NewService(webhooksRepo)
+	idempotencySrv := idempotency.NewService(idempotencyRepo, cfg.Idempotency.TTL)
+	indexes := search.NewIndexes()
+	// the events service tells the other services which changes the status of the event allows
+	invitationsSrv := invitations.NewService(invitationsRepo, invitationSecret(cfg.Invitations), eventsSrv)
+	waitlistSrv := waitlist.NewService(
+		waitlistRepo, tablesRepo, invitationsSrv, broker, indexes, eventsSrv,
+	).WithLocation(loc)
+	tablesSrv := tables.NewService(tablesRepo, broker, waitlistSrv, eventsSrv)
+	venueSrv := venue.NewService(venueRepo, broker, cfg.Venue.Warning)
+	guestsSrv := guests.NewService(
+		guestsRepo, tablesSrv, invitationsSrv, broker, indexes, waitlistSrv, eventsSrv, venueSrv,
+	).WithLocation(loc)
+
+	event := cfg.Events.Default
+	return Services{
+		Broker:      broker,
+		Events:      eventsSrv,
+		Tables:      tablesSrv.ForEvent(event),
+		Guests:      guestsSrv.ForEvent(event),
+		Invitations: invitationsSrv.ForEvent(event),
+		Waitlist:    waitlistSrv.ForEvent(event),
+		Venue:       venueSrv.ForEvent(event),
+		Audit:       auditSrv.ForEvent(event),
+		Attendance:  attendanceSrv.ForEvent(event),
+		Webhooks:    webhooksSrv.ForEvent(event),
+		Idempotency: idempotencySrv,
+		RateLimit: ratelimit.NewService(
+			buckets, rateLimit(cfg.RateLimit.Rate, cfg.RateLimit.Burst), rateLimitRoutes(cfg.RateLimit),
+			cfg.RateLimit.Keys,
+		),
+		Buckets: buckets,
+		Relay: outbox.NewRelay(
+			outboxRepo, cfg.Outbox.Interval, cfg.Outbox.Batch, outboxRetry(cfg.Outbox), append(
+				outboxSinks(cfg.Outbox),
+				webhooks.NewDispatcher(webhooksRepo, &http.Client{Timeout: cfg.Webhooks.Timeout}, cfg.Webhooks.Refresh),
+			)...,
+		),
+		Purger: idempotency.NewPurger(idempotencyRepo, cfg.Idempotency.PurgeInterval),
+	}
+}
+
+func rateLimit(rate float64, burst int) ratelimitDef.Limit {
+	return ratelimitDef.Limit{Rate: rate, Burst: burst}
+}
+
+// rateLimitRoutes returns the limits of the routes listed in the config, the malformed ones are skipped.
+func rateLimitRoutes(cfg config.RateLimit) map[string]ratelimitDef.Limit {
+	routes := map[string]ratelimitDef.Limit{}
+	for _, r := range cfg.Routes {
+		route, rate, burst, err := config.ParseRouteLimit(r)
+		if err != nil {
+			log.Warnf("%s, it is skipped", err)
+			continue
+		}
+		routes[route] = rateLimit(rate, burst)
+	}
+	return routes
+}
+
+func outboxRetry(cfg config.Outbox) outboxDef.Retry {
+	return outboxDef.Retry{MaxAttempts: cfg.MaxAttempts, Backoff: cfg.Backoff}
+}
+
+// outboxSinks returns the sinks named in the config, the unknown ones are skipped.
+func outboxSinks(cfg config.Outbox) []outboxDef.Sink {
+	var sinks []outboxDef.Sink
+	for _, name := range cfg.Sinks {
+		switch name {
+		case "log":
+			sinks = append(sinks, outbox.LogSink{})
+		case "http":
+			if cfg.URL == "" {
+				log.Warn("OUTBOX_HTTP_URL is not set, the http sink is skipped")
+				continue
+			}
+			sinks = append(sinks, outbox.NewHTTPSink(cfg.URL, &http.Client{Timeout: cfg.Timeout}))
+		case "broker":
+			sinks = append(sinks, outbox.NewBrokerSink(outbox.NewMemoryBroker()))
+		default:
+			log.Warnf("unknown outbox sink %q is skipped", name)
+		}
+	}
+	return sinks
+}
+
+// venueLocation returns the timezone of the venue, UTC when it can't be loaded.
+func venueLocation(cfg config.Venue) *time.Location {
+	loc, err := cfg.Location()
+	if err != nil {
+		log.Warnf("%s, the times are answered in UTC", err)
+		return time.UTC
+	}
+	return loc
+}
+
+func invitationSecret(cfg config.Invitations) []byte {
+	if cfg.Secret != "" {
+		return []byte(cfg.Secret)
+	}
+	log.Warn("INVITATION_SECRET is not set, the invitations issued won't be valid after a restart")
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		log.Fatal(err)
+	}
+	return secret
+}
@@ -5,51 +5,134 @@ import (
 	"fmt"
 	"github.com/getground/tech-tasks/backend/boot"
 	"github.com/getground/tech-tasks/backend/config"
+	"github.com/getground/tech-tasks/backend/pkg/database"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"reflect"
 	"syscall"
+	"time"
 )
 
+// grpcShutdownTimeout bounds the graceful stop, open occupancy streams never finish on their own.
+const grpcShutdownTimeout = 5 * time.Second
+
 func API() *cobra.Command {
-	return &cobra.Command{
+	var flags apiFlags
+	cmd := &cobra.Command{
 		Use:   "api",
 		Short: "start get ground party service in api mode",
+		Long: "start get ground party service in api mode, the settings are read from the config file, the env vars " +
+			"and the flags in that order. SIGHUP reloads the log level, the rate limits and the venue warning.",
 		Run: func(cmd *cobra.Command, args []string) {
 			log.Info("Starting get ground service api")
-			runAPI()
+			runAPI(func() (config.API, error) {
+				return flags.load(cmd)
+			})
 		},
 	}
+	flags.register(cmd)
+	return cmd
 }
 
-func runAPI() {
-	cfg, err := config.NewAPI()
+// runAPI serves the API with the config of load, load is called again to reload the config on SIGHUP.
+func runAPI(load func() (config.API, error)) {
+	cfg, err := load()
+	if err == nil {
+		err = cfg.Validate()
+	}
 	if err != nil {
 		log.Fatalln(err)
 	}
+	boot.SetLogLevel(cfg.LogLevel)
 
-	engine := boot.API(cfg)
+	dbConn, err := database.New(cfg.DB)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Println(dbConn)
+
+	services := boot.NewServices(cfg, dbConn)
+	dispatching, stopDispatching := context.WithCancel(context.Background())
+	go services.Relay.Run(dispatching)
+	go services.Purger.Run(dispatching)
+	go services.Buckets.Run(dispatching)
+	engine := boot.API(cfg, services)
 	server := &http.Server{
 		Addr:    fmt.Sprintf(":%d", cfg.HTTPPort),
 		Handler: engine,
 	}
 
-	// Initializing the server in a goroutine so that
-	// it won't block the graceful shutdown handling below
+	grpcServer := boot.GRPC(cfg, services)
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.GRPCPort))
+	if err != nil {
+		log.Fatalf("grpc listen: %s\n", err)
+	}
+
+	// Initializing the servers in goroutines so that
+	// they won't block the graceful shutdown handling below
 	go func() {
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("listen: %s\n", err)
 		}
 	}()
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Fatalf("grpc serve: %s\n", err)
+		}
+	}()
+
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			cfg = reloadAPI(services, cfg, load)
+		}
+	}()
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
+	signal.Stop(reload)
 	log.Println("shutting down the server")
+	stopDispatching()
+
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(grpcShutdownTimeout):
+		grpcServer.Stop()
+	}
 
 	if err := server.Shutdown(context.Background()); err != nil {
 		log.Fatalf("server is forced to shutdown")
 	}
 }
+
+// reloadAPI applies the settings of the config loaded again that are safe to change while the API runs, and returns
+// the config running. An invalid config is ignored, the API keeps running with the current one.
+func reloadAPI(services boot.Services, current config.API, load func() (config.API, error)) config.API {
+	next, err := load()
+	if err == nil {
+		err = next.Validate()
+	}
+	if err != nil {
+		log.Errorf("the config is not reloaded: %s", err)
+		return current
+	}
+
+	reloaded := current.Reload(next)
+	if !reflect.DeepEqual(reloaded, next) {
+		log.Warn("only the log level, the rate limits and the venue warning are reloaded, the other changes need a restart")
+	}
+	boot.Reload(services, reloaded)
+	log.Info("config reloaded")
+	return reloaded
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/getground/tech-tasks/backend/config"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// apiFlags are the settings of the api command given on the command line, they replace the env vars and the file.
+type apiFlags struct {
+	file     string
+	httpPort int
+	grpcPort int
+	logLevel string
+}
+
+func (f *apiFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.file, "config", "", fileUsage)
+	cmd.Flags().IntVar(&f.httpPort, "http-port", 0, "port of the HTTP API, replaces HTTP_PORT")
+	cmd.Flags().IntVar(&f.grpcPort, "grpc-port", 0, "port of the gRPC API, replaces GRPC_PORT")
+	cmd.Flags().StringVar(&f.logLevel, "log-level", "", "lowest level logged, replaces LOG_LEVEL")
+}
+
+// load reads the config file, the env vars and the flags set on cmd, in that order.
+func (f *apiFlags) load(cmd *cobra.Command) (config.API, error) {
+	cfg, err := loadFile(f.file)
+	if err != nil {
+		return cfg, err
+	}
+
+	if cmd.Flags().Changed("http-port") {
+		cfg.HTTPPort = f.httpPort
+	}
+	if cmd.Flags().Changed("grpc-port") {
+		cfg.GRPCPort = f.grpcPort
+	}
+	if cmd.Flags().Changed("log-level") {
+		cfg.LogLevel = f.logLevel
+	}
+	return cfg, nil
+}
+
+// loadFile reads the config file, CONFIG_FILE when file isn't set, then the env vars, so every command runs with the
+// settings of the api command.
+func loadFile(file string) (config.API, error) {
+	if file == "" {
+		file = os.Getenv(config.FileEnv)
+	}
+	return config.Load(file)
+}
+
+// fileUsage is the usage of the --config flag of the commands reading the config of the api command.
+const fileUsage = "YAML or TOML config file, CONFIG_FILE when not set"
+
+// Config groups the commands checking the config of the api command.
+func Config() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "check the config of the party service",
+	}
+	cmd.AddCommand(configValidate())
+	return cmd
+}
+
+func configValidate() *cobra.Command {
+	var flags apiFlags
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "print the config the api command would run with, the secrets redacted, and fail when it is invalid",
+		Args:  cobra.NoArgs,
+		// an invalid config isn't a misuse of the command
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := flags.load(cmd)
+			if err != nil {
+				return err
+			}
+			out, err := cfg.Redacted()
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(cmd.OutOrStdout(), string(out))
+			return cfg.Validate()
+		},
+	}
+	flags.register(cmd)
+	return cmd
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	eventsDef "github.com/getground/tech-tasks/backend/definitions/events"
+	"github.com/getground/tech-tasks/backend/pkg/database"
+	"github.com/getground/tech-tasks/backend/pkg/modules/events"
+	"github.com/spf13/cobra"
+	"strconv"
+)
+
+// Event groups the commands managing the events straight from the database, the API doesn't need to be running.
+func Event() *cobra.Command {
+	var file string
+	cmd := &cobra.Command{
+		Use:   "event",
+		Short: "manage the events of the party service",
+	}
+	cmd.PersistentFlags().StringVar(&file, "config", "", fileUsage)
+	cmd.AddCommand(eventStatus(&file), eventVenue(&file))
+	return cmd
+}
+
+func eventStatus(file *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status ID STATUS",
+		Short: "move the event to its next status: doors_open, in_progress, closed or archived",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid event id %q", args[0])
+			}
+
+			cfg, err := loadFile(*file)
+			if err != nil {
+				return err
+			}
+			dbConn, err := database.New(cfg.DB)
+			if err != nil {
+				return err
+			}
+
+			service := events.NewService(events.NewRepository(dbConn))
+			e, err := service.Transition(eventsDef.TransitionRequest{ID: uint(id), Status: eventsDef.Status(args[1])})
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "event %d %q is %s\n", e.ID, e.Name, e.Status)
+			return nil
+		},
+	}
+}
+
+func eventVenue(file *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "venue ID VENUE_LIMIT STAFF",
+		Short: "set the venue limit of the event and its staff, a zero venue limit doesn't limit the headcount",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid event id %q", args[0])
+			}
+			limit, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil || limit < 0 {
+				return fmt.Errorf("invalid venue limit %q", args[1])
+			}
+			staff, err := strconv.ParseInt(args[2], 10, 64)
+			if err != nil || staff < 0 {
+				return fmt.Errorf("invalid staff %q", args[2])
+			}
+
+			cfg, err := loadFile(*file)
+			if err != nil {
+				return err
+			}
+			dbConn, err := database.New(cfg.DB)
+			if err != nil {
+				return err
+			}
+
+			service := events.NewService(events.NewRepository(dbConn))
+			e, err := service.SetVenue(eventsDef.VenueRequest{ID: uint(id), VenueLimit: limit, Staff: staff})
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(
+				cmd.OutOrStdout(), "event %d %q has a venue limit of %d and %d staff\n", e.ID, e.Name, e.VenueLimit,
+				e.Staff,
+			)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	attendanceDef "github.com/getground/tech-tasks/backend/definitions/attendance"
+	"github.com/getground/tech-tasks/backend/pkg/database"
+	"github.com/getground/tech-tasks/backend/pkg/modules/attendance"
+	"github.com/spf13/cobra"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+// Report prints the attendance report of an event straight from the database, the API doesn't need to be running.
+func Report() *cobra.Command {
+	var bucket = attendanceDef.DefaultBucket
+	var file string
+	cmd := &cobra.Command{
+		Use:   "report [EVENT]",
+		Short: "print the occupancy timeline and the arrival analytics of the event, the default event when not set",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadFile(file)
+			if err != nil {
+				return err
+			}
+			loc, err := cfg.Venue.Location()
+			if err != nil {
+				return err
+			}
+			event := cfg.Events.Default
+			if len(args) > 0 {
+				id, err := strconv.ParseUint(args[0], 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid event id %q", args[0])
+				}
+				event = uint(id)
+			}
+
+			dbConn, err := database.New(cfg.DB)
+			if err != nil {
+				return err
+			}
+
+			service := attendance.NewService(attendance.NewRepository(dbConn)).WithLocation(loc).ForEvent(event)
+			res, err := service.Report(attendanceDef.ReportRequest{Bucket: bucket})
+			if err != nil {
+				return err
+			}
+			printReport(cmd.OutOrStdout(), res, loc)
+			return nil
+		},
+	}
+	cmd.Flags().DurationVar(&bucket, "bucket", bucket, "group the arrivals and departures by this duration")
+	cmd.Flags().StringVar(&file, "config", "", fileUsage)
+	return cmd
+}
+
+// printReport prints the times of the report in the timezone of the venue.
+func printReport(out io.Writer, res attendanceDef.ReportDTO, loc *time.Location) {
+	if res.Peak.At != nil {
+		fmt.Fprintf(out, "peak headcount %d at %s\n", res.Peak.Headcount, res.Peak.At.In(loc).Format("15:04 MST"))
+	} else {
+		fmt.Fprintln(out, "nobody arrived")
+	}
+	fmt.Fprintf(out, "average stay %.0f minutes\n", res.AverageStayMinutes)
+	fmt.Fprintf(
+		out, "accompanying guests %d expected, %d actual, over %d parties\n\n",
+		res.Entourage.Expected, res.Entourage.Actual, res.Entourage.Parties,
+	)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "TIME (%s)\tARRIVALS\tDEPARTURES\tOCCUPANCY\n", loc)
+	for _, b := range res.Timeline {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\n", b.Start.In(loc).Format("15:04"), b.Arrivals, b.Departures, b.Occupancy)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "TABLE\tINVITED\tARRIVED\tWALK-INS\tNO-SHOWS\tNO-SHOW RATE")
+	for _, t := range res.Tables {
+		fmt.Fprintf(
+			w, "%d\t%d\t%d\t%d\t%d\t%.0f%%\n", t.ID, t.Invited, t.Arrived, t.WalkIns, t.NoShows, t.NoShowRate*100,
+		)
+	}
+	w.Flush()
+}
@@ -1,14 +1,69 @@
 package config
 
-import "github.com/caarlos0/env/v6"
+import (
+	"github.com/caarlos0/env/v6"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// FileEnv names the config file of the commands started without a --config flag.
+const FileEnv = "CONFIG_FILE"
 
 type API struct {
-	HTTPPort int `env:"HTTP_PORT" envDefault:"3000"`
-	DB       Database
+	HTTPPort int `env:"HTTP_PORT" envDefault:"3000" yaml:"http_port"`
+	GRPCPort int `env:"GRPC_PORT" envDefault:"3001" yaml:"grpc_port"`
+	// LogLevel is the lowest level logged, among trace, debug, info, warn, error, fatal and panic.
+	LogLevel string `env:"LOG_LEVEL" envDefault:"info" yaml:"log_level"`
+	// TrustedProxies lists the addresses and CIDRs of the proxies trusted to tell the IP of a client in the
+	// X-Forwarded-For header, the IP of the connection is the IP of the client when it is empty.
+	TrustedProxies []string    `env:"TRUSTED_PROXIES" envSeparator:"," yaml:"trusted_proxies"`
+	DB             Database    `yaml:"db"`
+	Invitations    Invitations `yaml:"invitations"`
+	Events         Events      `yaml:"events"`
+	Venue          Venue       `yaml:"venue"`
+	Webhooks       Webhooks    `yaml:"webhooks"`
+	Outbox         Outbox      `yaml:"outbox"`
+	Idempotency    Idempotency `yaml:"idempotency"`
+	RateLimit      RateLimit   `yaml:"rate_limit"`
 }
 
+// NewAPI reads the config of the file named by CONFIG_FILE, when set, under the env vars.
 func NewAPI() (API, error) {
+	return Load(os.Getenv(FileEnv))
+}
+
+// Load reads the config file at path, when set, under the env vars: a setting of the file replaces its default and
+// an env var replaces both. The secrets are read from the files named by their _FILE variant when it is set.
+func Load(path string) (API, error) {
+	vars := map[string]string{}
+	if path != "" {
+		var err error
+		vars, err = readFile(path)
+		if err != nil {
+			return API{}, err
+		}
+	}
+	for _, kv := range os.Environ() {
+		if i := strings.Index(kv, "="); i > 0 {
+			vars[kv[:i]] = kv[i+1:]
+		}
+	}
+
+	if err := secretFiles(reflect.TypeOf(API{}), vars); err != nil {
+		return API{}, err
+	}
+
 	c := API{}
-	err := env.Parse(&c)
+	err := env.Parse(&c, env.Options{Environment: vars})
 	return c, err
 }
+
+// Reload returns the config with the settings safe to change while the API runs taken from next: the log level, the
+// rate limits and the venue warning. The other settings need a restart.
+func (c API) Reload(next API) API {
+	c.LogLevel = next.LogLevel
+	c.RateLimit = next.RateLimit
+	c.Venue.Warning = next.Venue.Warning
+	return c
+}
@@ -0,0 +1,229 @@
+package config_test
+
+import (
+	"github.com/getground/tech-tasks/backend/config"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("an error '%s' was not expected when writing the config file", err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Run(
+		"defaults", func(t *testing.T) {
+			//	method call
+			cfg, err := config.Load("")
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, 3000, cfg.HTTPPort)
+			assert.Equal(t, "info", cfg.LogLevel)
+			assert.Equal(t, []string{"log"}, cfg.Outbox.Sinks)
+			assert.Equal(t, 10, cfg.Outbox.MaxAttempts)
+			assert.Equal(t, time.Hour, cfg.Idempotency.PurgeInterval)
+			assert.NoError(t, cfg.Validate())
+		},
+	)
+
+	t.Run(
+		"yaml file under env vars", func(t *testing.T) {
+			// setup
+			path := writeFile(
+				t, "config.yaml", "http_port: 4000\nvenue:\n  warning: 0.5\nwebhooks:\n  refresh: 2s\n"+
+					"rate_limit:\n  routes:\n    - POST /guest_list/:name=1:5\n    - GET /guest_list=2:10\n",
+			)
+			os.Setenv("VENUE_WARNING", "0.8")
+			defer os.Unsetenv("VENUE_WARNING")
+
+			//	method call
+			cfg, err := config.Load(path)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, 4000, cfg.HTTPPort)
+			assert.Equal(t, 0.8, cfg.Venue.Warning)
+			assert.Equal(t, 2*time.Second, cfg.Webhooks.Refresh)
+			assert.Equal(t, []string{"POST /guest_list/:name=1:5", "GET /guest_list=2:10"}, cfg.RateLimit.Routes)
+		},
+	)
+
+	t.Run(
+		"toml file", func(t *testing.T) {
+			// setup
+			path := writeFile(t, "config.toml", "log_level = \"debug\"\n\n[rate_limit]\nrate = 2.5\nburst = 7\n")
+
+			//	method call
+			cfg, err := config.Load(path)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, "debug", cfg.LogLevel)
+			assert.Equal(t, 2.5, cfg.RateLimit.Rate)
+			assert.Equal(t, 7, cfg.RateLimit.Burst)
+		},
+	)
+
+	t.Run(
+		"unknown setting", func(t *testing.T) {
+			// setup
+			path := writeFile(t, "config.yaml", "venue:\n  limt: 150\n")
+
+			//	method call
+			_, err := config.Load(path)
+
+			//	assert
+			assert.EqualError(t, err, "config file "+path+": unknown setting venue.limt")
+		},
+	)
+
+	t.Run(
+		"secret files", func(t *testing.T) {
+			// setup
+			password := writeFile(t, "db_password", "from-file\n")
+			secret := writeFile(t, "invitation_secret", "signing-key")
+			path := writeFile(t, "config.yaml", "db:\n  password: plain\ninvitations:\n  secret_file: "+secret+"\n")
+			os.Setenv("DB_PASSWORD_FILE", password)
+			defer os.Unsetenv("DB_PASSWORD_FILE")
+
+			//	method call
+			cfg, err := config.Load(path)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, "from-file", cfg.DB.Password)
+			assert.Equal(t, "signing-key", cfg.Invitations.Secret)
+		},
+	)
+
+	t.Run(
+		"missing secret file", func(t *testing.T) {
+			// setup
+			os.Setenv("DB_PASSWORD_FILE", filepath.Join(t.TempDir(), "missing"))
+			defer os.Unsetenv("DB_PASSWORD_FILE")
+
+			//	method call
+			_, err := config.Load("")
+
+			//	assert
+			assert.Error(t, err)
+		},
+	)
+
+	t.Run(
+		"file of a setting that isn't a secret", func(t *testing.T) {
+			// setup
+			path := writeFile(t, "config.yaml", "db:\n  host_file: /run/secrets/host\n")
+
+			//	method call
+			_, err := config.Load(path)
+
+			//	assert
+			assert.EqualError(t, err, "config file "+path+": unknown setting db.host_file")
+		},
+	)
+
+	t.Run(
+		"missing file", func(t *testing.T) {
+			//	method call
+			_, err := config.Load(filepath.Join(t.TempDir(), "missing.yaml"))
+
+			//	assert
+			assert.Error(t, err)
+		},
+	)
+}
+
+func TestAPI_Validate(t *testing.T) {
+	// setup
+	cfg, _ := config.Load("")
+	cfg.HTTPPort = 0
+	cfg.LogLevel = "loud"
+	cfg.TrustedProxies = []string{"10.0.0.0/8", "proxy"}
+	cfg.DB.Timezone = "Mars/Olympus"
+	cfg.DB.TLS.Mode = "always"
+	cfg.DB.TLS.Cert = "client.pem"
+	cfg.Venue.Warning = 2
+	cfg.Venue.Timezone = "Europe/Atlantis"
+	cfg.RateLimit.Routes = []string{"GET /guest_list"}
+
+	//	method call
+	err := cfg.Validate()
+
+	//	assert
+	assert.EqualError(
+		t, err, `invalid config: http_port 0 is not a port; log_level "loud" is not a level; `+
+			`trusted_proxies "proxy" is not an IP or a CIDR; `+
+			`db.timezone "Mars/Olympus" is not a timezone; db.tls.mode "always" is not a mode; `+
+			`db.tls.cert and db.tls.key go together; venue.warning 2 is not in (0, 1]; `+
+			`venue.timezone "Europe/Atlantis" is not a timezone; rate_limit.routes: rate limit "GET /guest_list" has no limit`,
+	)
+}
+
+func TestAPI_Reload(t *testing.T) {
+	t.Run(
+		"safe settings", func(t *testing.T) {
+			// setup
+			current, _ := config.Load("")
+			next := current
+			next.HTTPPort = 4000
+			next.LogLevel = "debug"
+			next.Venue.Warning = 0.5
+			next.RateLimit.Burst = 5
+			next.RateLimit.Keys = []string{"organiser"}
+
+			//	method call
+			reloaded := current.Reload(next)
+
+			//	assert
+			assert.Equal(t, 3000, reloaded.HTTPPort)
+			assert.Equal(t, "debug", reloaded.LogLevel)
+			assert.Equal(t, 0.5, reloaded.Venue.Warning)
+			assert.Equal(t, 5, reloaded.RateLimit.Burst)
+			assert.Equal(t, []string{"organiser"}, reloaded.RateLimit.Keys)
+		},
+	)
+
+	t.Run(
+		"timezone", func(t *testing.T) {
+			// setup
+			current, _ := config.Load("")
+			next := current
+			next.Venue.Timezone = "Europe/London"
+
+			//	method call
+			reloaded := current.Reload(next)
+
+			//	assert, the services keep the timezone they booted with so the change needs a restart
+			assert.Equal(t, "UTC", reloaded.Venue.Timezone)
+			assert.NotEqual(t, next, reloaded)
+		},
+	)
+}
+
+func TestAPI_Redacted(t *testing.T) {
+	// setup
+	cfg, _ := config.Load("")
+	cfg.Invitations.Secret = "s3cret"
+	cfg.RateLimit.Keys = []string{"k3y"}
+
+	//	method call
+	out, err := cfg.Redacted()
+
+	//	assert
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "    password: REDACTED\n")
+	assert.Contains(t, string(out), "    secret: REDACTED\n")
+	assert.Contains(t, string(out), "    keys: REDACTED\n")
+	assert.Contains(t, string(out), "    ttl: 24h0m0s\n")
+	assert.NotContains(t, string(out), "s3cret")
+	assert.NotContains(t, string(out), "k3y")
+}
@@ -1,9 +1,43 @@
 package config
 
+// Database is the connection to MySQL, the secrets can be read from the files named by their _FILE env var instead,
+// e.g. DB_PASSWORD_FILE=/run/secrets/db_password.
 type Database struct {
-	Host     string `env:"DB_HOST" envDefault:"mysql"`
-	Port     string `env:"DB_PORT" envDefault:"3306"`
-	User     string `env:"DB_USER" envDefault:"user"`
-	Password string `env:"DB_PASSWORD" envDefault:"password"`
-	Name     string `env:"DB_NAME" envDefault:"database"`
+	// DSN replaces the address, the credentials and the name of the database when set, e.g.
+	// user:password@tcp(mysql:3306)/database?charset=utf8mb4.
+	DSN      string `env:"DB_DSN" yaml:"dsn" secret:"true"`
+	Host     string `env:"DB_HOST" envDefault:"mysql" yaml:"host"`
+	Port     string `env:"DB_PORT" envDefault:"3306" yaml:"port"`
+	User     string `env:"DB_USER" envDefault:"user" yaml:"user"`
+	Password string `env:"DB_PASSWORD" envDefault:"password" yaml:"password" secret:"true"`
+	Name     string `env:"DB_NAME" envDefault:"database" yaml:"name"`
+	// Timezone is the location the DATETIME values are read and written in, an IANA name such as Europe/London.
+	Timezone string      `env:"DB_TIMEZONE" envDefault:"UTC" yaml:"timezone"`
+	TLS      DatabaseTLS `yaml:"tls"`
+}
+
+// TLS modes of the connection to the database, as the ssl-mode of the mysql client.
+const (
+	TLSDisabled = "disabled"
+	// TLSPreferred encrypts the connection when the server supports it, without verifying the server.
+	TLSPreferred = "preferred"
+	// TLSRequired encrypts the connection without verifying the server.
+	TLSRequired = "required"
+	// TLSVerifyCA verifies the certificate of the server was issued by the CA.
+	TLSVerifyCA = "verify_ca"
+	// TLSVerifyIdentity verifies the certificate of the server and that it was issued to the host.
+	TLSVerifyIdentity = "verify_identity"
+)
+
+type DatabaseTLS struct {
+	// Mode is one of disabled, preferred, required, verify_ca and verify_identity. A DSN keeps its own tls parameter
+	// when the mode is disabled.
+	Mode string `env:"DB_TLS_MODE" envDefault:"disabled" yaml:"mode"`
+	// CA is the PEM file of the certificate authorities verifying the server, the system ones when empty.
+	CA string `env:"DB_TLS_CA" yaml:"ca"`
+	// Cert and Key are the PEM files of the client certificate, for the servers requiring one.
+	Cert string `env:"DB_TLS_CERT" yaml:"cert"`
+	Key  string `env:"DB_TLS_KEY" yaml:"key"`
+	// ServerName is the name verify_identity expects in the certificate of the server, the host when empty.
+	ServerName string `env:"DB_TLS_SERVER_NAME" yaml:"server_name"`
 }
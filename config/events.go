@@ -0,0 +1,6 @@
+package config
+
+type Events struct {
+	// Default is the event of the routes that aren't nested under /events/:event.
+	Default uint `env:"DEFAULT_EVENT" envDefault:"1" yaml:"default"`
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"fmt"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// readFile reads the YAML config file at path, or TOML when its extension is .toml, and returns its settings as the
+// env vars they replace. The keys of the file are the yaml tags of the config, e.g. rate_limit.burst.
+func readFile(path string) (map[string]string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]interface{}{}
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		err = toml.Unmarshal(b, &values)
+	} else {
+		err = yaml.Unmarshal(b, &values)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	vars := map[string]string{}
+	if err = fileVars(reflect.TypeOf(API{}), "", values, vars); err != nil {
+		return nil, fmt.Errorf("config file %s: %w", path, err)
+	}
+	return vars, nil
+}
+
+// fileSuffix ends the names of the settings holding the file of a secret, e.g. password_file or DB_PASSWORD_FILE.
+const fileSuffix = "_file"
+
+func isSecret(f reflect.StructField) bool {
+	return f.Tag.Get("secret") == "true"
+}
+
+// secretFiles replaces the secrets of the config t in vars with the content of the files named by their _FILE
+// variant, the trailing newline of the file dropped.
+func secretFiles(t reflect.Type, vars map[string]string) error {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := f.Tag.Get("env")
+		if name == "" && f.Type.Kind() == reflect.Struct {
+			if err := secretFiles(f.Type, vars); err != nil {
+				return err
+			}
+			continue
+		}
+
+		path := vars[name+strings.ToUpper(fileSuffix)]
+		if !isSecret(f) || path == "" {
+			continue
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("secret %s: %w", name, err)
+		}
+		vars[name] = strings.TrimRight(string(b), "\r\n")
+	}
+	return nil
+}
+
+// fileVars sets in vars the env var of every setting of values, the keys unknown to the config t are an error so a
+// typo isn't silently ignored.
+func fileVars(t reflect.Type, prefix string, values map[string]interface{}, vars map[string]string) error {
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		fields[f.Tag.Get("yaml")] = f
+	}
+
+	for key, value := range values {
+		f, ok := fields[key]
+		if secret, found := fields[strings.TrimSuffix(key, fileSuffix)]; !ok && found && isSecret(secret) {
+			vars[secret.Tag.Get("env")+strings.ToUpper(fileSuffix)] = fmt.Sprint(value)
+			continue
+		}
+		if !ok {
+			return fmt.Errorf("unknown setting %s%s", prefix, key)
+		}
+
+		name := f.Tag.Get("env")
+		if name == "" {
+			nested, ok := value.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("setting %s%s is not a table", prefix, key)
+			}
+			if err := fileVars(f.Type, prefix+key+".", nested, vars); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if list, ok := value.([]interface{}); ok {
+			items := make([]string, len(list))
+			for i, item := range list {
+				items[i] = fmt.Sprint(item)
+			}
+			sep := f.Tag.Get("envSeparator")
+			if sep == "" {
+				sep = ","
+			}
+			vars[name] = strings.Join(items, sep)
+			continue
+		}
+		vars[name] = fmt.Sprint(value)
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+package config
+
+import "time"
+
+type Idempotency struct {
+	// TTL is how long the response of a request made with an idempotency key is replayed to its retries.
+	TTL time.Duration `env:"IDEMPOTENCY_TTL" envDefault:"24h" yaml:"ttl"`
+	// PurgeInterval is the wait between two removals of the expired keys.
+	PurgeInterval time.Duration `env:"IDEMPOTENCY_PURGE_INTERVAL" envDefault:"1h" yaml:"purge_interval"`
+}
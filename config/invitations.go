@@ -0,0 +1,6 @@
+package config
+
+type Invitations struct {
+	// Secret signs the invitation tokens, a random one is used when it is empty so the tokens don't survive a restart.
+	Secret string `env:"INVITATION_SECRET" yaml:"secret" secret:"true"`
+}
@@ -0,0 +1,20 @@
+package config
+
+import "time"
+
+type Outbox struct {
+	// Interval is the wait of the relay between two reads of the outbox.
+	Interval time.Duration `env:"OUTBOX_INTERVAL" envDefault:"1s" yaml:"interval"`
+	// Batch bounds the messages dispatched at every read.
+	Batch int `env:"OUTBOX_BATCH" envDefault:"100" yaml:"batch"`
+	// MaxAttempts bounds the sends of a message to a sink, the first one included, the sink skips the message then.
+	MaxAttempts int `env:"OUTBOX_MAX_ATTEMPTS" envDefault:"10" yaml:"max_attempts"`
+	// Backoff is the wait of a sink before the first retry of a message, it doubles at every retry.
+	Backoff time.Duration `env:"OUTBOX_BACKOFF" envDefault:"1s" yaml:"backoff"`
+	// Sinks lists the sinks the messages are dispatched to, among log, http and broker.
+	Sinks []string `env:"OUTBOX_SINKS" envSeparator:"," envDefault:"log" yaml:"sinks"`
+	// URL is the endpoint of the http sink.
+	URL string `env:"OUTBOX_HTTP_URL" yaml:"url"`
+	// Timeout bounds every request of the http sink.
+	Timeout time.Duration `env:"OUTBOX_HTTP_TIMEOUT" envDefault:"5s" yaml:"timeout"`
+}
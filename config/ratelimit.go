@@ -0,0 +1,12 @@
+package config
+
+type RateLimit struct {
+	// Rate is the number of requests per second a client makes in the long run, zero doesn't limit the requests.
+	Rate float64 `env:"RATE_LIMIT_RATE" envDefault:"10" yaml:"rate"`
+	// Burst is the number of requests a client makes at once.
+	Burst int `env:"RATE_LIMIT_BURST" envDefault:"20" yaml:"burst"`
+	// Routes lists the routes with their own limit as "METHOD /path=rate:burst", e.g. "POST /guest_list/:name=1:5".
+	Routes []string `env:"RATE_LIMIT_ROUTES" envSeparator:"," yaml:"routes"`
+	// Keys lists the API keys given to the clients, a request sending another key is answered with 401.
+	Keys []string `env:"RATE_LIMIT_KEYS" envSeparator:"," yaml:"keys" secret:"true"`
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"reflect"
+	"time"
+)
+
+// redacted replaces the secrets set in the printed config.
+const redacted = "REDACTED"
+
+// Redacted returns the config as the YAML of a config file, the settings tagged secret are redacted when set.
+func (c API) Redacted() ([]byte, error) {
+	return yaml.Marshal(redactedNode(reflect.ValueOf(c)))
+}
+
+func redactedNode(v reflect.Value) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for i := 0; i < v.NumField(); i++ {
+		f, value := v.Type().Field(i), v.Field(i)
+		key := &yaml.Node{Kind: yaml.ScalarNode, Value: f.Tag.Get("yaml")}
+
+		var val *yaml.Node
+		switch {
+		case isSecret(f) && !value.IsZero():
+			val = scalar(redacted)
+		case value.Kind() == reflect.Struct:
+			val = redactedNode(value)
+		case value.Kind() == reflect.Slice:
+			val = &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+			for j := 0; j < value.Len(); j++ {
+				val.Content = append(val.Content, scalar(value.Index(j).Interface()))
+			}
+		default:
+			val = scalar(value.Interface())
+		}
+		node.Content = append(node.Content, key, val)
+	}
+	return node
+}
+
+// scalar writes the durations as a config file does, e.g. 5s.
+func scalar(v interface{}) *yaml.Node {
+	if d, ok := v.(time.Duration); ok {
+		v = d.String()
+	}
+	node := &yaml.Node{}
+	if err := node.Encode(v); err != nil {
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: fmt.Sprint(v)}
+	}
+	return node
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Validate returns the settings out of their range, all of them in one error.
+func (c API) Validate() error {
+	var problems []string
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+
+	check(validPort(c.HTTPPort), "http_port %d is not a port", c.HTTPPort)
+	check(validPort(c.GRPCPort), "grpc_port %d is not a port", c.GRPCPort)
+	_, err := log.ParseLevel(c.LogLevel)
+	check(err == nil, "log_level %q is not a level", c.LogLevel)
+	for _, proxy := range c.TrustedProxies {
+		_, _, err := net.ParseCIDR(proxy)
+		check(err == nil || net.ParseIP(proxy) != nil, "trusted_proxies %q is not an IP or a CIDR", proxy)
+	}
+	_, err = time.LoadLocation(c.DB.Timezone)
+	check(err == nil, "db.timezone %q is not a timezone", c.DB.Timezone)
+	switch c.DB.TLS.Mode {
+	case TLSDisabled, TLSPreferred, TLSRequired, TLSVerifyCA, TLSVerifyIdentity:
+	default:
+		problems = append(problems, fmt.Sprintf("db.tls.mode %q is not a mode", c.DB.TLS.Mode))
+	}
+	check((c.DB.TLS.Cert == "") == (c.DB.TLS.Key == ""), "db.tls.cert and db.tls.key go together")
+	check(c.Venue.Warning > 0 && c.Venue.Warning <= 1, "venue.warning %g is not in (0, 1]", c.Venue.Warning)
+	_, err = c.Venue.Location()
+	check(err == nil, "venue.timezone %q is not a timezone", c.Venue.Timezone)
+	check(c.Webhooks.Refresh >= 0, "webhooks.refresh %s is negative", c.Webhooks.Refresh)
+	check(c.Outbox.Interval > 0, "outbox.interval %s is not positive", c.Outbox.Interval)
+	check(c.Outbox.Batch >= 1, "outbox.batch %d is below 1", c.Outbox.Batch)
+	check(c.Outbox.MaxAttempts >= 1, "outbox.max_attempts %d is below 1", c.Outbox.MaxAttempts)
+	for _, sink := range c.Outbox.Sinks {
+		check(sink == "log" || sink == "http" || sink == "broker", "outbox.sinks %q is not a sink", sink)
+		check(sink != "http" || c.Outbox.URL != "", "outbox.url is required by the http sink")
+	}
+	check(c.Idempotency.TTL > 0, "idempotency.ttl %s is not positive", c.Idempotency.TTL)
+	check(
+		c.Idempotency.PurgeInterval > 0, "idempotency.purge_interval %s is not positive", c.Idempotency.PurgeInterval,
+	)
+	check(c.RateLimit.Rate >= 0, "rate_limit.rate %g is negative", c.RateLimit.Rate)
+	check(c.RateLimit.Burst >= 0, "rate_limit.burst %d is negative", c.RateLimit.Burst)
+	for _, r := range c.RateLimit.Routes {
+		_, _, _, err := ParseRouteLimit(r)
+		check(err == nil, "rate_limit.routes: %v", err)
+	}
+
+	if len(problems) > 0 {
+		return errors.New("invalid config: " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
+}
+
+// ParseRouteLimit parses the limit of a route written "METHOD /path=rate:burst", e.g. "POST /guest_list/:name=1:5".
+func ParseRouteLimit(s string) (route string, rate float64, burst int, err error) {
+	i := strings.LastIndex(s, "=")
+	if i < 0 {
+		return "", 0, 0, fmt.Errorf("rate limit %q has no limit", s)
+	}
+	limit := strings.SplitN(s[i+1:], ":", 2)
+	if len(limit) != 2 {
+		return "", 0, 0, fmt.Errorf("rate limit %q has no burst", s)
+	}
+	rate, err = strconv.ParseFloat(limit[0], 64)
+	if err != nil || rate < 0 {
+		return "", 0, 0, fmt.Errorf("rate limit %q has an invalid rate", s)
+	}
+	burst, err = strconv.Atoi(limit[1])
+	if err != nil || burst < 0 {
+		return "", 0, 0, fmt.Errorf("rate limit %q has an invalid burst", s)
+	}
+	return strings.TrimSpace(s[:i]), rate, burst, nil
+}
@@ -0,0 +1,16 @@
+package config
+
+import "time"
+
+type Venue struct {
+	// Warning is the share of the limit of the event that warns the venue is nearly full once the headcount reaches it.
+	Warning float64 `env:"VENUE_WARNING" envDefault:"0.9" yaml:"warning"`
+	// Timezone is the location of the venue, an IANA name such as Europe/London. Times are stored in UTC and answered
+	// in the timezone, the messages of the outbox and the webhooks keep them in UTC.
+	Timezone string `env:"VENUE_TIMEZONE" envDefault:"UTC" yaml:"timezone"`
+}
+
+// Location loads the timezone of the venue.
+func (v Venue) Location() (*time.Location, error) {
+	return time.LoadLocation(v.Timezone)
+}
@@ -0,0 +1,11 @@
+package config
+
+import "time"
+
+type Webhooks struct {
+	// Refresh bounds the time the subscriptions are kept in memory, a change of a subscription reaches the deliveries
+	// within it.
+	Refresh time.Duration `env:"WEBHOOK_REFRESH" envDefault:"10s" yaml:"refresh"`
+	// Timeout bounds every attempt.
+	Timeout time.Duration `env:"WEBHOOK_TIMEOUT" envDefault:"5s" yaml:"timeout"`
+}
@@ -0,0 +1,7 @@
+package attendance
+
+import "errors"
+
+var (
+	ErrInvalidBucket = errors.New("bucket must be at least a minute")
+)
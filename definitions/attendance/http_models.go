@@ -0,0 +1,82 @@
+package attendance
+
+import (
+	"time"
+)
+
+// OccupancyRequest replays the attendance up to At, both included, the zero Table replays every table.
+type OccupancyRequest struct {
+	At    *time.Time `form:"at" time_format:"2006-01-02T15:04:05Z07:00"`
+	Table uint       `form:"table"`
+}
+
+type OccupancyDTO struct {
+	At     time.Time           `json:"at"`
+	Seated int64               `json:"seated"`
+	Tables []TableOccupancyDTO `json:"tables"`
+}
+
+type TableOccupancyDTO struct {
+	ID         uint             `json:"id"`
+	Seats      int64            `json:"seats"`
+	Seated     int64            `json:"seated"`
+	EmptySeats int64            `json:"empty_seats"`
+	Guests     []SeatedGuestDTO `json:"guests"`
+}
+
+type SeatedGuestDTO struct {
+	Name               string    `json:"name"`
+	AccompanyingGuests int64     `json:"accompanying_guests"`
+	TimeArrived        time.Time `json:"time_arrived"`
+}
+
+// DefaultBucket groups the arrivals and departures of the reports that don't set a bucket.
+const DefaultBucket = 15 * time.Minute
+
+// ReportRequest groups the arrivals and departures of the report by Bucket.
+type ReportRequest struct {
+	Bucket time.Duration `form:"bucket"`
+}
+
+// ReportDTO sums up the attendance of an event, the people count the guests and their accompanying guests.
+type ReportDTO struct {
+	Timeline           []BucketDTO          `json:"timeline"`
+	Peak               PeakDTO              `json:"peak"`
+	Tables             []TableAttendanceDTO `json:"tables"`
+	AverageStayMinutes float64              `json:"average_stay_minutes"`
+	Entourage          EntourageDTO         `json:"entourage"`
+}
+
+// BucketDTO counts the people arrived and left in the bucket starting at Start, Occupancy is the people on site at
+// its end.
+type BucketDTO struct {
+	Start      time.Time `json:"start"`
+	Arrivals   int64     `json:"arrivals"`
+	Departures int64     `json:"departures"`
+	Occupancy  int64     `json:"occupancy"`
+}
+
+// PeakDTO is the highest headcount and the first time it was reached, At is missing when nobody arrived.
+type PeakDTO struct {
+	Headcount int64      `json:"headcount"`
+	At        *time.Time `json:"at,omitempty"`
+}
+
+// TableAttendanceDTO counts the guests invited to the table and the ones that arrived, the walk-ins aren't counted as
+// invited.
+type TableAttendanceDTO struct {
+	ID         uint    `json:"id"`
+	Invited    int64   `json:"invited"`
+	Arrived    int64   `json:"arrived"`
+	WalkIns    int64   `json:"walk_ins"`
+	NoShows    int64   `json:"no_shows"`
+	NoShowRate float64 `json:"no_show_rate"`
+}
+
+// EntourageDTO compares the accompanying guests announced in the invitations of the parties that arrived with the
+// ones that came.
+type EntourageDTO struct {
+	Parties  int64 `json:"parties"`
+	Expected int64 `json:"expected"`
+	Actual   int64 `json:"actual"`
+}
@@ -0,0 +1,58 @@
+package attendance
+
+import (
+	"time"
+)
+
+// Type is the fact a record of the attendance stream states.
+type Type string
+
+const (
+	TypeGuestInvited    Type = "guest.invited"
+	TypeGuestUninvited  Type = "guest.uninvited"
+	TypeGuestResponded  Type = "guest.responded"
+	TypeGuestResized    Type = "guest.resized"
+	TypeGuestCheckedIn  Type = "guest.checked_in"
+	TypeGuestCheckedOut Type = "guest.checked_out"
+	TypeTableCreated    Type = "table.created"
+	TypeTableResized    Type = "table.resized"
+)
+
+// Record is an append-only fact of the attendance of an event, the records are ordered by Seq. People is the size of
+// the party of the guest records and the number of seats of the table records, RSVP is the answer of the guest at the
+// time of the guest records.
+type Record struct {
+	Seq        uint `gorm:"primarykey"`
+	EventID    uint
+	Type       Type
+	Guest      string
+	TableID    uint
+	People     int64
+	RSVP       string
+	OccurredAt time.Time
+}
+
+func (Record) TableName() string {
+	return "attendance"
+}
+
+// Occupancy is the projection of the records of an event up to a point in time.
+type Occupancy struct {
+	Tables []TableOccupancy
+}
+
+// TableOccupancy holds the seats of a table and the parties seated at it, EmptySeats is negative when the table was
+// resized below the people seated.
+type TableOccupancy struct {
+	ID         uint
+	Seats      int64
+	Seated     int64
+	EmptySeats int64
+	Parties    []Party
+}
+
+type Party struct {
+	Guest       string
+	People      int64
+	TimeArrived time.Time
+}
@@ -0,0 +1,12 @@
+package attendance
+
+import (
+	"time"
+)
+
+type Repository interface {
+	// ForEvent returns the repository of the attendance stream of the event.
+	ForEvent(event uint) Repository
+	// Until returns the records occurred up to at in order, the zero table returns the records of every table.
+	Until(at time.Time, table uint) ([]Record, error)
+}
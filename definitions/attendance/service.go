@@ -0,0 +1,9 @@
+package attendance
+
+type Service interface {
+	// ForEvent returns the service of the attendance of the event.
+	ForEvent(event uint) Service
+	Occupancy(req OccupancyRequest) (OccupancyDTO, error)
+	// Report sums up the attendance recorded up to now.
+	Report(req ReportRequest) (ReportDTO, error)
+}
@@ -0,0 +1,42 @@
+package audit
+
+import (
+	"encoding/json"
+	"github.com/getground/tech-tasks/backend/definitions/pagination"
+	"time"
+)
+
+// ListRequest filters the audit log, the zero values don't filter. From and To bound the time of the entries, both
+// included.
+type ListRequest struct {
+	Guest string     `form:"guest"`
+	Table uint       `form:"table"`
+	Actor string     `form:"actor"`
+	From  *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To    *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	pagination.Request
+}
+
+// Page is a page of entries, Total counts every entry matching the filter and NextCursor is empty on the last page.
+type Page struct {
+	Entries    []Entry
+	Total      int64
+	NextCursor string
+}
+
+type ListDTO struct {
+	Entries    []EntryDTO `json:"entries"`
+	Total      int64      `json:"total"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+}
+
+type EntryDTO struct {
+	ID        uint            `json:"id"`
+	Actor     string          `json:"actor"`
+	Action    Action          `json:"action"`
+	Guest     string          `json:"guest,omitempty"`
+	Table     uint            `json:"table,omitempty"`
+	Before    json.RawMessage `json:"before,omitempty"`
+	After     json.RawMessage `json:"after,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
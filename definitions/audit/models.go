@@ -0,0 +1,127 @@
+package audit
+
+import (
+	"encoding/json"
+	"github.com/getground/tech-tasks/backend/definitions/guests"
+	"github.com/getground/tech-tasks/backend/definitions/tables"
+	"time"
+)
+
+// Action is the change an audit entry records.
+type Action string
+
+const (
+	ActionGuestCreated    Action = "guest.created"
+	ActionGuestResponded  Action = "guest.responded"
+	ActionGuestEdited     Action = "guest.edited"
+	ActionGuestDeleted    Action = "guest.deleted"
+	ActionGuestCheckedIn  Action = "guest.checked_in"
+	ActionGuestWalkedIn   Action = "guest.walked_in"
+	ActionGuestCheckedOut Action = "guest.checked_out"
+	ActionGuestPromoted   Action = "guest.promoted"
+	ActionTableCreated    Action = "table.created"
+	ActionTableResized    Action = "table.resized"
+)
+
+const (
+	// ActorHeader names the person or the system making a request to the HTTP API.
+	ActorHeader = "X-Actor"
+	// ContextKey is the key of the actor of the request in the gin context.
+	ContextKey = "actor"
+	// SystemActor is the actor of the changes made without a request, the services record it until given an actor.
+	SystemActor = "system"
+)
+
+// Entry is an append-only record of a change to a guest or a table, Before and After hold the json of the State of
+// the rows the change touched. Before is empty for the rows created and After for the rows deleted.
+type Entry struct {
+	ID        uint `gorm:"primarykey"`
+	EventID   uint
+	Actor     string
+	Action    Action
+	Guest     string
+	TableID   uint
+	Before    string
+	After     string
+	CreatedAt time.Time
+}
+
+func (Entry) TableName() string {
+	return "audit_log"
+}
+
+// NewEntry records the change from before to after made at, the guest and the table of the entry are the ones of the
+// states.
+func NewEntry(event uint, actor string, action Action, before, after State, at time.Time) Entry {
+	e := Entry{
+		EventID:   event,
+		Actor:     actor,
+		Action:    action,
+		Before:    before.json(),
+		After:     after.json(),
+		CreatedAt: at,
+	}
+	for _, s := range []State{after, before} {
+		if s.Guest != nil && e.Guest == "" {
+			e.Guest = s.Guest.Name
+			e.TableID = s.Guest.Table
+		}
+		if s.Table != nil && e.TableID == 0 {
+			e.TableID = s.Table.ID
+		}
+	}
+	return e
+}
+
+// State is the guest and the table touched by a change, either can be missing.
+type State struct {
+	Guest *GuestState `json:"guest,omitempty"`
+	Table *TableState `json:"table,omitempty"`
+}
+
+type GuestState struct {
+	Name         string      `json:"name"`
+	Table        uint        `json:"table"`
+	Accompanying int64       `json:"accompanying_guests"`
+	Companions   []string    `json:"companions,omitempty"`
+	RSVP         guests.RSVP `json:"rsvp"`
+	TimeArrived  *time.Time  `json:"time_arrived,omitempty"`
+	CheckedOut   bool        `json:"checked_out"`
+	WalkIn       bool        `json:"walk_in"`
+}
+
+type TableState struct {
+	ID         uint  `json:"id"`
+	Capacity   int64 `json:"capacity"`
+	EmptySeats int64 `json:"empty_seats"`
+}
+
+// Snapshot returns the state of the guest and the table, nil leaves them out.
+func Snapshot(g *guests.Guest, t *tables.Table) (s State) {
+	if g != nil {
+		s.Guest = &GuestState{
+			Name:         g.Name,
+			Table:        g.TableID,
+			Accompanying: g.Accompanying,
+			RSVP:         g.RSVP,
+			TimeArrived:  g.TimeArrived,
+			CheckedOut:   g.CheckedOut == 1,
+			WalkIn:       g.WalkIn,
+		}
+		for _, c := range g.Companions {
+			s.Guest.Companions = append(s.Guest.Companions, c.Name)
+		}
+	}
+	if t != nil {
+		s.Table = &TableState{ID: t.ID, Capacity: t.Capacity, EmptySeats: t.EmptySeats}
+	}
+	return
+}
+
+func (s State) json() string {
+	if s.Guest == nil && s.Table == nil {
+		return ""
+	}
+	b, _ := json.Marshal(s)
+	return string(b)
+}
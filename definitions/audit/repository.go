@@ -0,0 +1,7 @@
+package audit
+
+type Repository interface {
+	// ForEvent returns the repository of the audit log of the event.
+	ForEvent(event uint) Repository
+	List(request ListRequest) (Page, error)
+}
@@ -0,0 +1,7 @@
+package audit
+
+type Service interface {
+	// ForEvent returns the service of the audit log of the event.
+	ForEvent(event uint) Service
+	List(request ListRequest) (ListDTO, error)
+}
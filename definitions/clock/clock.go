@@ -0,0 +1,23 @@
+// Package clock tells the time to the services, so the tests can fix it.
+package clock
+
+import "time"
+
+// Clock tells the services the time, the times they stamp are in UTC.
+type Clock interface {
+	Now() time.Time
+}
+
+// UTC is the clock of the system.
+type UTC struct{}
+
+func (UTC) Now() time.Time {
+	return time.Now().UTC()
+}
+
+// Fixed always tells the same time, e.g. to know the arrival time of a check in under test.
+type Fixed time.Time
+
+func (f Fixed) Now() time.Time {
+	return time.Time(f)
+}
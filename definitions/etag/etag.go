@@ -0,0 +1,42 @@
+// Package etag maps the version of the guests and tables to the ETag and If-Match headers.
+package etag
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+const (
+	Header        = "ETag"
+	IfMatchHeader = "If-Match"
+)
+
+var (
+	ErrInvalid = errors.New("invalid If-Match header")
+	// ErrStale is returned when the version of a row changed since it was read.
+	ErrStale = errors.New("the resource was changed by another request")
+)
+
+// Format returns the ETag of the version.
+func Format(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// Parse returns the version of an If-Match header, zero when the header is empty or matches any version.
+func Parse(header string) (int64, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+	header = strings.TrimPrefix(header, "W/")
+	s, err := strconv.Unquote(header)
+	if err != nil {
+		return 0, ErrInvalid
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || v < 1 {
+		return 0, ErrInvalid
+	}
+	return v, nil
+}
@@ -0,0 +1,10 @@
+package events
+
+import "errors"
+
+var (
+	ErrNotFound          = errors.New("event not found")
+	ErrInvalidStatus     = errors.New("invalid event status")
+	ErrInvalidTransition = errors.New("event can't move to this status")
+	ErrNotAllowed        = errors.New("operation not allowed in the current status of the event")
+)
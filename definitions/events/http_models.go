@@ -0,0 +1,36 @@
+package events
+
+import "time"
+
+type CreateRequest struct {
+	Name       string    `json:"name" binding:"required"`
+	Venue      string    `json:"venue"`
+	Date       time.Time `json:"date" binding:"required"`
+	VenueLimit int64     `json:"venue_limit" binding:"min=0"`
+	Staff      int64     `json:"staff" binding:"min=0"`
+}
+
+type TransitionRequest struct {
+	ID     uint   `json:"-"`
+	Status Status `json:"status" binding:"required"`
+}
+
+type VenueRequest struct {
+	ID         uint  `json:"-"`
+	VenueLimit int64 `json:"venue_limit" binding:"min=0"`
+	Staff      int64 `json:"staff" binding:"min=0"`
+}
+
+type ListDTO struct {
+	Events []EventDTO `json:"events"`
+}
+
+type EventDTO struct {
+	ID         uint      `json:"id"`
+	Name       string    `json:"name"`
+	Venue      string    `json:"venue"`
+	Date       time.Time `json:"date"`
+	Status     Status    `json:"status"`
+	VenueLimit int64     `json:"venue_limit"`
+	Staff      int64     `json:"staff"`
+}
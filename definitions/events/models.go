@@ -0,0 +1,82 @@
+package events
+
+import "time"
+
+// Status is the stage of the event, a new event is planned. The event moves forward one stage at a time, see
+// CanMoveTo, and every stage allows its own operations, see Allows.
+type Status string
+
+const (
+	StatusPlanning   Status = "planning"
+	StatusDoorsOpen  Status = "doors_open"
+	StatusInProgress Status = "in_progress"
+	StatusClosed     Status = "closed"
+	StatusArchived   Status = "archived"
+)
+
+// Operation is a change to the tables or the guests of an event that only some stages of the event allow.
+type Operation string
+
+const (
+	OpEditTables    Operation = "edit_tables"
+	OpEditGuestList Operation = "edit_guest_list"
+	OpRSVP          Operation = "rsvp"
+	OpCheckIn       Operation = "check_in"
+	OpCheckOut      Operation = "check_out"
+	OpEditVenue     Operation = "edit_venue"
+)
+
+// next is the stage following every stage, archived is the last one.
+var next = map[Status]Status{
+	StatusPlanning:   StatusDoorsOpen,
+	StatusDoorsOpen:  StatusInProgress,
+	StatusInProgress: StatusClosed,
+	StatusClosed:     StatusArchived,
+}
+
+// allowed are the operations of every stage, the tables and the guest list are settled once the doors open and an
+// archived event is read only. The venue limit and the staff may change until then.
+var allowed = map[Status][]Operation{
+	StatusPlanning:   {OpEditTables, OpEditGuestList, OpRSVP, OpEditVenue},
+	StatusDoorsOpen:  {OpCheckIn, OpCheckOut, OpEditVenue},
+	StatusInProgress: {OpCheckIn, OpCheckOut, OpEditVenue},
+	StatusClosed:     {OpCheckOut, OpEditVenue},
+}
+
+// Valid tells if s is one of the stages of an event.
+func (s Status) Valid() bool {
+	_, ok := next[s]
+	return ok || s == StatusArchived
+}
+
+// CanMoveTo tells if to is the stage following s.
+func (s Status) CanMoveTo(to Status) bool {
+	n, ok := next[s]
+	return ok && n == to
+}
+
+// Allows tells if the operation is allowed while the event is at stage s.
+func (s Status) Allows(op Operation) bool {
+	for _, o := range allowed[s] {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is a party, its tables, guests and waitlist belong to it and are never seen from the other events.
+type Event struct {
+	ID     uint `gorm:"primarykey"`
+	Name   string
+	Venue  string
+	Date   time.Time
+	Status Status
+	// VenueLimit is the legal maximum headcount of the venue of the event, the staff included, zero doesn't limit it.
+	VenueLimit int64
+	// Staff is the number of people working at the event, they count towards the venue limit.
+	Staff int64
+}
+
+// ContextKey is the key of the id of the event the request is scoped to in the gin context.
+const ContextKey = "event"
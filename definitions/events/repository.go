@@ -0,0 +1,12 @@
+package events
+
+type Repository interface {
+	Create(event Event) (Event, error)
+	GetByID(id uint) (Event, error)
+	List() ([]Event, error)
+	// SetStatus moves the event from status to status to, ErrInvalidTransition is returned when the event isn't at
+	// status from anymore.
+	SetStatus(id uint, from, to Status) error
+	// SetVenue sets the venue limit of the event and its staff.
+	SetVenue(id uint, limit, staff int64) error
+}
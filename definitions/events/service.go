@@ -0,0 +1,16 @@
+package events
+
+// Gate is asked by the services changing the tables and the guests of an event if the current status of the event
+// allows the change, it returns ErrNotAllowed when it doesn't.
+type Gate interface {
+	Allow(event uint, op Operation) error
+}
+
+type Service interface {
+	Gate
+	Create(request CreateRequest) (EventDTO, error)
+	GetByID(id uint) (EventDTO, error)
+	List() (ListDTO, error)
+	Transition(request TransitionRequest) (EventDTO, error)
+	SetVenue(request VenueRequest) (EventDTO, error)
+}
@@ -0,0 +1,12 @@
+package guests
+
+import "errors"
+
+var (
+	ErrNoCapacity        = errors.New("table have no capacity for accompanying")
+	ErrNotInvited        = errors.New("guest not invited or already checked in")
+	ErrExtraAccompanying = errors.New("extra accompanying than expected")
+	ErrAlreadyListed     = errors.New("guest already on the guest list")
+	ErrNoEmptySeats      = errors.New("no table has enough empty seats for the party")
+	ErrCompanionCount    = errors.New("companions named don't match the accompanying guests")
+)
@@ -0,0 +1,54 @@
+package guests
+
+import "github.com/getground/tech-tasks/backend/definitions/pagination"
+
+type Status string
+
+const (
+	StatusNotArrived Status = "not_arrived"
+	StatusArrived    Status = "arrived"
+	StatusLeft       Status = "left"
+)
+
+type Sort string
+
+const (
+	SortName        Sort = "name"
+	SortTimeArrived Sort = "time_arrived"
+)
+
+type Order string
+
+const (
+	OrderAsc  Order = "asc"
+	OrderDesc Order = "desc"
+)
+
+// Filter narrows down a guests listing, zero values don't filter.
+type Filter struct {
+	Name       string `form:"-"`
+	NamePrefix string `form:"prefix"`
+	Table      uint   `form:"table"`
+	// Status arrived matches the guests at the party, the ones that left are matched by status left.
+	Status     Status `form:"-"`
+	Arrived    *bool  `form:"arrived"`
+	CheckedOut *bool  `form:"checked_out"`
+	RSVP       RSVP   `form:"rsvp" binding:"omitempty,oneof=invited accepted declined tentative"`
+	WalkIn     *bool  `form:"walk_in"`
+}
+
+// ListRequest asks for a page of the guests matching the filter, sorted by name unless Sort says otherwise.
+// Guests that didn't arrive yet come last when sorting by arrival time.
+type ListRequest struct {
+	Filter
+	Sort  Sort  `form:"sort" binding:"omitempty,oneof=name time_arrived"`
+	Order Order `form:"order" binding:"omitempty,oneof=asc desc"`
+	pagination.Request
+}
+
+// Page is a page of guests, Total counts every guest matching the filter and NextCursor is empty on the last page.
+type Page struct {
+	Guests     []Guest
+	Total      int64
+	NextCursor string
+}
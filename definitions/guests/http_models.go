@@ -1,40 +1,181 @@
 package guests
 
+// CreateRequest invites a party, Companions name either none or every accompanying guest.
 type CreateRequest struct {
-	Name         string `json:"name" binding:"required"`
-	Table        uint   `json:"table" binding:"required"`
-	Accompanying int64  `json:"accompanying_guests" binding:"required" gt:"0"`
+	Name         string         `json:"name" binding:"required"`
+	Table        uint           `json:"table" binding:"required"`
+	Accompanying int64          `json:"accompanying_guests" binding:"required" gt:"0"`
+	Companions   []CompanionDTO `json:"companions,omitempty" binding:"omitempty,dive"`
+	ProfileDTO
+}
+
+// CompanionDTO is a named accompanying guest, the dietary notes describe an other diet.
+type CompanionDTO struct {
+	Name         string `json:"name" binding:"required,max=255"`
+	Dietary      Diet   `json:"dietary,omitempty" binding:"omitempty,oneof=vegetarian vegan pescatarian gluten_free dairy_free nut_allergy halal kosher other"`
+	DietaryNotes string `json:"dietary_notes,omitempty" binding:"required_if=Dietary other,max=500"`
+}
+
+// ProfileDTO holds the contact details and the needs of a guest, every field is optional. The phone is written in
+// E.164, e.g. +447700900123, and the dietary notes describe an other diet.
+type ProfileDTO struct {
+	Email         string `json:"email,omitempty" binding:"omitempty,email,max=255"`
+	Phone         string `json:"phone,omitempty" binding:"omitempty,e164"`
+	Department    string `json:"department,omitempty" binding:"max=255"`
+	Dietary       Diet   `json:"dietary,omitempty" binding:"omitempty,oneof=vegetarian vegan pescatarian gluten_free dairy_free nut_allergy halal kosher other"`
+	DietaryNotes  string `json:"dietary_notes,omitempty" binding:"required_if=Dietary other,max=500"`
+	Accessibility string `json:"accessibility,omitempty" binding:"max=500"`
+}
+
+// ProfileRequest replaces the profile of a guest of the guest list, arrived or not. The Version is taken from the
+// If-Match header and a zero Version edits the guest whatever its version.
+type ProfileRequest struct {
+	Name string `json:"-"`
+	ProfileDTO
+	Version int64 `json:"-"`
+}
+
+// CompanionsRequest replaces the companions of a guest that didn't arrive yet, the party becomes as large as the
+// companions named. The Version is taken from the If-Match header and a zero Version edits the guest whatever its
+// version.
+type CompanionsRequest struct {
+	Name       string         `json:"-"`
+	Companions []CompanionDTO `json:"companions" binding:"required,dive"`
+	Version    int64          `json:"-"`
 }
 
 type CreateResponse struct {
-	Name string `json:"name"`
+	Name       string `json:"name"`
+	Invitation string `json:"invitation,omitempty"`
 }
 
 type ListDTO struct {
-	Guests []GuestListDTO `json:"guests"`
+	Guests     []GuestListDTO `json:"guests"`
+	Total      int64          `json:"total"`
+	NextCursor string         `json:"next_cursor,omitempty"`
 }
 
 type GuestListDTO struct {
-	Name         string `json:"name"`
-	Table        uint   `json:"table"`
-	Accompanying int64  `json:"accompanying_guests"`
+	Name         string         `json:"name"`
+	Table        uint           `json:"table"`
+	Accompanying int64          `json:"accompanying_guests"`
+	Companions   []CompanionDTO `json:"companions,omitempty"`
+	RSVP         RSVP           `json:"rsvp"`
+	WalkIn       bool           `json:"walk_in"`
+	Version      int64          `json:"version"`
+	ProfileDTO
 }
 
 type DTO struct {
-	Guests []GuestDTO `json:"guests"`
+	Guests     []GuestDTO `json:"guests"`
+	Total      int64      `json:"total"`
+	NextCursor string     `json:"next_cursor,omitempty"`
 }
 
 type GuestDTO struct {
-	Name         string `json:"name"`
-	Accompanying int64  `json:"accompanying_guests"`
-	TimeArrived  string `json:"time_arrived"`
+	Name         string         `json:"name"`
+	Accompanying int64          `json:"accompanying_guests"`
+	Companions   []CompanionDTO `json:"companions,omitempty"`
+	// TimeArrived is written in RFC 3339 in UTC, e.g. 2022-12-16T20:00:00Z.
+	TimeArrived string `json:"time_arrived"`
+	WalkIn      bool   `json:"walk_in"`
 }
 
+// CheckInRequest lets a guest in, the Version is taken from the If-Match header and a zero Version checks the guest
+// in whatever its version. Companions name the party at the door, they are required when the guest named its
+// companions before and arrives with another number of accompanying guests.
 type CheckInRequest struct {
-	Name         string `json:"name" binding:"required"`
-	Accompanying int64  `json:"accompanying_guests" binding:"required" gt:"0"`
+	Name         string         `json:"name" binding:"required"`
+	Accompanying int64          `json:"accompanying_guests" binding:"required" gt:"0"`
+	Companions   []CompanionDTO `json:"companions,omitempty" binding:"omitempty,dive"`
+	Version      int64          `json:"-"`
+}
+
+type ScanRequest struct {
+	Token        string         `json:"token" binding:"required"`
+	Accompanying int64          `json:"accompanying_guests" binding:"required" gt:"0"`
+	Companions   []CompanionDTO `json:"companions,omitempty" binding:"omitempty,dive"`
+}
+
+// WalkInRequest checks in a guest that isn't on the guest list, a zero Table seats the party at any table.
+type WalkInRequest struct {
+	Name         string         `json:"name" binding:"required"`
+	Table        uint           `json:"table"`
+	Accompanying int64          `json:"accompanying_guests" binding:"min=0"`
+	Companions   []CompanionDTO `json:"companions,omitempty" binding:"omitempty,dive"`
+	ProfileDTO
+}
+
+type WalkInResponse struct {
+	Name  string `json:"name"`
+	Table uint   `json:"table"`
+}
+
+type RSVPRequest struct {
+	Token        string         `json:"-"`
+	Response     RSVP           `json:"response" binding:"required,oneof=accepted declined tentative"`
+	Accompanying int64          `json:"accompanying_guests" binding:"min=0"`
+	Companions   []CompanionDTO `json:"companions,omitempty" binding:"omitempty,dive"`
+}
+
+type RSVPResponse struct {
+	Name         string         `json:"name"`
+	Response     RSVP           `json:"response"`
+	Accompanying int64          `json:"accompanying_guests"`
+	Companions   []CompanionDTO `json:"companions,omitempty"`
+}
+
+type RSVPCountsRequest struct {
+	Table uint `form:"table"`
+}
+
+type RSVPCountsDTO struct {
+	Tables []TableRSVPDTO `json:"tables"`
+}
+
+type TableRSVPDTO struct {
+	Table         uint  `json:"table"`
+	Invited       int64 `json:"invited"`
+	Accepted      int64 `json:"accepted"`
+	Declined      int64 `json:"declined"`
+	Tentative     int64 `json:"tentative"`
+	SeatsReserved int64 `json:"seats_reserved"`
+}
+
+// CateringRequest sums up the diets of a table, every table is summed up when Table is zero.
+type CateringRequest struct {
+	Table uint `form:"table"`
+}
+
+type CateringDTO struct {
+	Tables []TableCateringDTO `json:"tables"`
+}
+
+// TableCateringDTO counts the people of a table by diet, the ones that didn't decline are invited and the ones that
+// arrived are counted again under arrived.
+type TableCateringDTO struct {
+	Table   uint           `json:"table"`
+	Invited map[Diet]int64 `json:"invited"`
+	Arrived map[Diet]int64 `json:"arrived"`
 }
 
 type CheckInResponse struct {
 	Name string `json:"name"`
 }
+
+type SearchRequest struct {
+	Query string `form:"q" binding:"required"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+type SearchDTO struct {
+	Guests []MatchDTO `json:"guests"`
+}
+
+type MatchDTO struct {
+	Name         string  `json:"name"`
+	Table        uint    `json:"table"`
+	Accompanying int64   `json:"accompanying_guests"`
+	Status       Status  `json:"status"`
+	Score        float64 `json:"score"`
+}
@@ -4,10 +4,122 @@ import (
 	"time"
 )
 
+// RSVP is the answer of a guest to the invitation, seats are reserved at the table for the accepted guests only.
+type RSVP string
+
+const (
+	RSVPInvited   RSVP = "invited"
+	RSVPAccepted  RSVP = "accepted"
+	RSVPDeclined  RSVP = "declined"
+	RSVPTentative RSVP = "tentative"
+)
+
+// Diet is a dietary requirement the catering plans for, a guest without one has no Diet.
+type Diet string
+
+const (
+	DietVegetarian  Diet = "vegetarian"
+	DietVegan       Diet = "vegan"
+	DietPescatarian Diet = "pescatarian"
+	DietGlutenFree  Diet = "gluten_free"
+	DietDairyFree   Diet = "dairy_free"
+	DietNutAllergy  Diet = "nut_allergy"
+	DietHalal       Diet = "halal"
+	DietKosher      Diet = "kosher"
+	// DietOther is described by the dietary notes.
+	DietOther Diet = "other"
+	// DietNone counts the people without dietary requirements in the catering summary.
+	DietNone Diet = "none"
+	// DietUnknown counts the accompanying guests that weren't named in the catering summary.
+	DietUnknown Diet = "unknown"
+)
+
+// Guest is a party of the guest list, Version is increased by every change so a change made on a stale read is
+// detected.
 type Guest struct {
 	Name         string
 	TableID      uint
 	Accompanying int64
 	TimeArrived  *time.Time
 	CheckedOut   int
+	RSVP         RSVP `gorm:"column:rsvp"`
+	// WalkIn flags the guests that came without being on the guest list.
+	WalkIn  bool
+	EventID uint
+	Version int64
+	Profile `gorm:"embedded"`
+	// Companions are the accompanying guests named by the party, either none or as many as Accompanying.
+	Companions []Companion `gorm:"-"`
+}
+
+// Companion is an accompanying guest named by the party, the security needs the name of everyone on site and the
+// catering their dietary needs.
+type Companion struct {
+	ID           uint `gorm:"primaryKey"`
+	EventID      uint
+	GuestName    string
+	Name         string
+	Dietary      Diet
+	DietaryNotes string
+}
+
+// Profile is what the facilities and the catering know about a guest, the dietary notes describe the requirements
+// in free text.
+type Profile struct {
+	Email         string
+	Phone         string
+	Department    string
+	Dietary       Diet
+	DietaryNotes  string
+	Accessibility string
+}
+
+// WithCompanions returns the guest with a party of accompanying guests, the companions given replace the ones named so
+// far and the ones named so far are kept when none are given. ErrCompanionCount is returned unless either none or
+// every accompanying guest is named.
+func (g Guest) WithCompanions(accompanying int64, given []Companion) (Guest, error) {
+	g.Accompanying = accompanying
+	if len(given) > 0 {
+		g.Companions = given
+	}
+	if len(g.Companions) > 0 && int64(len(g.Companions)) != accompanying {
+		return g, ErrCompanionCount
+	}
+	return g, nil
+}
+
+// ReservedSeats is the number of seats of the table reserved for the guest and the accompanying guests.
+func (g Guest) ReservedSeats() int64 {
+	if g.RSVP == RSVPAccepted {
+		return g.Accompanying + 1
+	}
+	return 0
+}
+
+// RSVPCount counts the guests of a table that gave the same answer, People includes their accompanying guests.
+type RSVPCount struct {
+	TableID uint
+	RSVP    RSVP `gorm:"column:rsvp"`
+	Guests  int64
+	People  int64
+}
+
+// DietCount counts the people of a table with the same diet, the ones that didn't decline are Invited and Arrived
+// counts the ones that arrived among them.
+type DietCount struct {
+	TableID uint
+	Dietary Diet
+	Invited int64
+	Arrived int64
+}
+
+func (g Guest) Status() Status {
+	switch {
+	case g.CheckedOut == 1:
+		return StatusLeft
+	case g.TimeArrived != nil:
+		return StatusArrived
+	default:
+		return StatusNotArrived
+	}
 }
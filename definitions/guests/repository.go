@@ -1,11 +1,40 @@
 package guests
 
-import "github.com/getground/tech-tasks/backend/definitions/tables"
+import (
+	"github.com/getground/tech-tasks/backend/definitions/tables"
+	"time"
+)
 
+// Repository stores the guest lists of the events, the changes are recorded in the audit log and the attendance stream
+// as made at the time given.
 type Repository interface {
-	Create(request CreateRequest, tableCapacity int64) error
+	// ForEvent returns the repository of the guests of the event.
+	ForEvent(event uint) Repository
+	// ForActor returns the repository recording the changes it makes as done by the actor.
+	ForActor(actor string) Repository
+	Create(request CreateRequest, at time.Time) error
 	GetByName(name string) (Guest, error)
-	GetGuestList(arrived bool) ([]Guest, error)
-	CheckIn(request CheckInRequest, guest Guest, table tables.Table) error
-	CheckOut(name string) error
+	ListPage(request ListRequest) (Page, error)
+	List(filter Filter) ([]Guest, error)
+	// Respond saves the answer of the guest and the capacity left at the table, it returns etag.ErrStale when the
+	// guest or the table changed since they were read.
+	Respond(guest Guest, table tables.Table, at time.Time) error
+	// Delete removes the guest and saves the capacity left at the table, it returns etag.ErrStale when the guest or
+	// the table changed since they were read.
+	Delete(guest Guest, table tables.Table, at time.Time) error
+	// EditCompanions saves the companions and the accompanying guests of the guest and the capacity left at the table,
+	// it returns etag.ErrStale when the guest or the table changed since they were read.
+	EditCompanions(guest Guest, table tables.Table, at time.Time) error
+	// EditProfile saves the profile of the guest, it returns etag.ErrStale when the guest changed since it was read.
+	EditProfile(guest Guest, at time.Time) error
+	CountRSVP(table uint) ([]RSVPCount, error)
+	// CountDiets counts the people by table and diet, every table is counted when table is zero.
+	CountDiets(table uint) ([]DietCount, error)
+	// CheckIn seats the party at the table of the guest, arrived is the arrival time stored. venue.ErrFull is returned
+	// when the party would take the headcount over the venue limit of the event.
+	CheckIn(request CheckInRequest, guest Guest, table tables.Table, arrived time.Time) error
+	// WalkIn adds the guest to the guest list and checks it in at a table with enough empty seats in one transaction,
+	// the venue limit of the event is enforced like CheckIn.
+	WalkIn(request WalkInRequest, arrived time.Time) (Guest, tables.Table, error)
+	CheckOut(name string, at time.Time) (Guest, error)
 }
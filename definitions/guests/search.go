@@ -0,0 +1,25 @@
+package guests
+
+// Index is an in memory search index of the guests, the service puts every guest it writes so the index stays current.
+type Index interface {
+	// Seed adds the guests load returns that are missing from the index, the ones already indexed are kept since they
+	// are newer. Load only runs on the first call, under the index lock, so a guest removed meanwhile isn't put back.
+	Seed(load func() ([]Guest, error)) error
+	// Seeded reports whether the index was seeded.
+	Seeded() bool
+	Put(g Guest)
+	Remove(name string)
+	// Search returns at most limit guests matching the query, the closest matches first.
+	Search(query string, limit int) []Match
+}
+
+// Indexes keeps a search index per event, the searches of an event never match the guests of another.
+type Indexes interface {
+	ForEvent(event uint) Index
+}
+
+// Match is a guest found by a search, Score goes from 0 to 1 for an exact match.
+type Match struct {
+	Guest Guest
+	Score float64
+}
@@ -1,9 +1,31 @@
 package guests
 
+import "github.com/getground/tech-tasks/backend/definitions/invitations"
+
 type Service interface {
+	// ForEvent returns the service of the guests of the event.
+	ForEvent(event uint) Service
+	// ForActor returns the service making the changes on behalf of the actor.
+	ForActor(actor string) Service
 	Create(request CreateRequest) (CreateResponse, error)
-	GetGuestList() (ListDTO, error)
-	GetGuests() (DTO, error)
+	GetGuestList(request ListRequest) (ListDTO, error)
+	GetGuests(request ListRequest) (DTO, error)
+	// Get returns the guest of the guest list with its version.
+	Get(name string) (GuestListDTO, error)
+	List(filter Filter) ([]Guest, error)
+	Search(request SearchRequest) (SearchDTO, error)
+	Respond(req RSVPRequest) (RSVPResponse, error)
+	// EditCompanions replaces the companions of a guest that didn't arrive yet and resizes the party to match.
+	EditCompanions(req CompanionsRequest) (GuestListDTO, error)
+	// EditProfile replaces the contact details and the needs of a guest of the guest list.
+	EditProfile(req ProfileRequest) (GuestListDTO, error)
+	RSVPCounts(req RSVPCountsRequest) (RSVPCountsDTO, error)
+	// Catering counts the people invited and arrived by table and diet.
+	Catering(req CateringRequest) (CateringDTO, error)
 	CheckIn(req CheckInRequest) (CheckInResponse, error)
+	Scan(req ScanRequest) (CheckInResponse, error)
+	WalkIn(req WalkInRequest) (WalkInResponse, error)
+	Reinvite(name string) (invitations.InvitationResponse, error)
+	Uninvite(name string) error
 	CheckOut(name string) error
 }
@@ -0,0 +1,10 @@
+package idempotency
+
+import "errors"
+
+var (
+	ErrNotFound   = errors.New("idempotency key not found")
+	ErrInvalidKey = errors.New("invalid idempotency key")
+	ErrInProgress = errors.New("a request with the same idempotency key is in progress")
+	ErrKeyReused  = errors.New("the idempotency key was used with another request")
+)
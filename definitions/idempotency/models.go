@@ -0,0 +1,48 @@
+package idempotency
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	// Header holds the key a client sends with a POST, PUT or DELETE request and every retry of it.
+	Header = "Idempotency-Key"
+	// ReplayedHeader is set on the responses replayed from a previous request.
+	ReplayedHeader = "Idempotent-Replayed"
+	// MaxKeyLength bounds the keys accepted.
+	MaxKeyLength = 255
+)
+
+// StoredHeaders are the headers of a response stored along its body and replayed to the retries.
+var StoredHeaders = []string{"Content-Type", "ETag", "Location"}
+
+// Record is the first request made with a key and its response once it completed, Status is zero while the request
+// runs. Key is the digest of the key and the scope of the request, the client and the route, so the key itself isn't
+// stored, and the body is sealed with the key so the responses can't be read from the table. Fingerprint identifies
+// the method, the url and the body of the request.
+type Record struct {
+	Key         string `gorm:"primarykey;column:idempotency_key"`
+	Fingerprint string
+	Status      int
+	Headers     string
+	Body        string
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+func (Record) TableName() string {
+	return "idempotency_keys"
+}
+
+// Completed reports whether the response of the request is stored.
+func (r Record) Completed() bool {
+	return r.Status != 0
+}
+
+// Response is the response of a request stored for its retries, Header holds the StoredHeaders it was sent with.
+type Response struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
@@ -0,0 +1,16 @@
+package idempotency
+
+import "time"
+
+type Repository interface {
+	Get(key string) (Record, error)
+	// Reserve stores the record of a request starting in place of the record of the key expired before now, it
+	// returns ErrInProgress when the key is already reserved.
+	Reserve(r Record, now time.Time) error
+	// Complete stores the response of the request.
+	Complete(r Record) error
+	// Release removes the record so the key can be used again.
+	Release(key string) error
+	// Purge removes the records expired before now and returns how many were removed.
+	Purge(now time.Time) (int64, error)
+}
@@ -0,0 +1,10 @@
+package idempotency
+
+type Service interface {
+	// Begin reserves the key of the client for the request, or returns the response of the completed request to
+	// replay when the key was already used by the same request. The scope tells the client and the route apart, the
+	// same key sent by another client or to another route is another key.
+	Begin(scope, key, fingerprint string) (*Response, error)
+	Complete(scope, key string, res Response) error
+	Release(scope, key string) error
+}
@@ -0,0 +1,10 @@
+package invitations
+
+import "errors"
+
+var (
+	ErrInvalidToken = errors.New("invalid invitation token")
+	ErrRevoked      = errors.New("invitation revoked")
+	ErrUsed         = errors.New("invitation already used")
+	ErrNotFound     = errors.New("invitation not found")
+)
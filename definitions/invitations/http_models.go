@@ -0,0 +1,11 @@
+package invitations
+
+type QRRequest struct {
+	Token  string `uri:"token" binding:"required"`
+	Format Format `form:"format" binding:"omitempty,oneof=png svg"`
+	Size   int    `form:"size" binding:"omitempty,min=64,max=1024"`
+}
+
+type InvitationResponse struct {
+	Token string `json:"token"`
+}
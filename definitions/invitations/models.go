@@ -0,0 +1,20 @@
+package invitations
+
+import "time"
+
+// Invitation is the stored part of an invitation token, the token is the ID signed with the server secret.
+type Invitation struct {
+	ID        string `gorm:"primaryKey"`
+	EventID   uint
+	GuestName string
+	CreatedAt time.Time
+	RevokedAt *time.Time
+	UsedAt    *time.Time
+}
+
+type Format string
+
+const (
+	FormatPNG Format = "png"
+	FormatSVG Format = "svg"
+)
@@ -0,0 +1,14 @@
+package invitations
+
+import "time"
+
+// Repository stores the invitations of an event, the lookups by id find the invitations of every event since the id
+// of a token tells its event.
+type Repository interface {
+	// ForEvent returns the repository of the invitations of the event.
+	ForEvent(event uint) Repository
+	Create(invitation Invitation) error
+	GetByID(id string) (Invitation, error)
+	Revoke(guestName string, at time.Time) (int64, error)
+	Use(id string, at time.Time) error
+}
@@ -0,0 +1,11 @@
+package invitations
+
+type Service interface {
+	// ForEvent returns the service of the invitations of the event, the tokens are verified whatever their event.
+	ForEvent(event uint) Service
+	Issue(guestName string) (string, error)
+	Verify(token string) (Invitation, error)
+	Use(id string) error
+	Revoke(guestName string) error
+	QR(request QRRequest) ([]byte, error)
+}
@@ -0,0 +1,12 @@
+package notifications
+
+type Publisher interface {
+	Publish(n Notification)
+}
+
+type Broker interface {
+	Publisher
+	// Subscribe returns a channel receiving every notification published after the call and a function to cancel
+	// the subscription.
+	Subscribe() (<-chan Notification, func())
+}
@@ -0,0 +1,37 @@
+package notifications
+
+import "time"
+
+type Type string
+
+const (
+	TableCreated    Type = "table.created"
+	TableResized    Type = "table.resized"
+	GuestInvited    Type = "guest.invited"
+	GuestUninvited  Type = "guest.uninvited"
+	GuestResponded  Type = "guest.responded"
+	GuestPromoted   Type = "guest.promoted"
+	GuestCheckedIn  Type = "guest.checked_in"
+	GuestCheckedOut Type = "guest.checked_out"
+	VenueNearlyFull Type = "venue.nearly_full"
+)
+
+// Notification describes a change made by the services to the event, the table fields hold the table state after
+// the change and the venue fields the headcount of the venue.
+type Notification struct {
+	Type         Type
+	EventID      uint
+	OccurredAt   time.Time
+	Guest        string
+	Accompanying int64
+	TableID      uint
+	Capacity     int64
+	EmptySeats   int64
+	Headcount    int64
+	VenueLimit   int64
+}
+
+// IsOccupancyChange reports whether the notification changed the number of people seated at a table.
+func (n Notification) IsOccupancyChange() bool {
+	return n.Type == GuestCheckedIn || n.Type == GuestCheckedOut
+}
@@ -0,0 +1,109 @@
+package outbox
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/getground/tech-tasks/backend/definitions/audit"
+	"time"
+)
+
+const (
+	// IDHeader holds the id of the message sent by the http sink, the receivers drop the ids they already handled.
+	IDHeader = "X-Outbox-ID"
+	// TypeHeader holds the type of the message sent by the http sink.
+	TypeHeader = "X-Outbox-Type"
+)
+
+// Message is a domain event stored in the transaction of the change it describes, so it is never lost once the
+// change commits. DispatchedAt is set once every sink received or skipped it.
+type Message struct {
+	ID           string `gorm:"primarykey"`
+	EventID      uint
+	Type         string
+	Payload      string
+	CreatedAt    time.Time
+	DispatchedAt *time.Time
+}
+
+func (Message) TableName() string {
+	return "outbox"
+}
+
+// Sent is the progress of a message on a sink, a message is sent again only to the sinks that didn't receive it.
+// Attempts counts the failed sends, the next one waits until RetryAt and the sink skips the message once they run out.
+type Sent struct {
+	MessageID string `gorm:"primarykey"`
+	Sink      string `gorm:"primarykey"`
+	Attempts  int
+	Error     string
+	RetryAt   *time.Time
+	SentAt    *time.Time
+	SkippedAt *time.Time
+}
+
+func (Sent) TableName() string {
+	return "outbox_sent"
+}
+
+// Done reports whether the sink received the message or skipped it.
+func (s Sent) Done() bool {
+	return s.SentAt != nil || s.SkippedAt != nil
+}
+
+// Retry bounds the sends of a message to a sink, Backoff is the wait before the first retry and doubles at every
+// retry.
+type Retry struct {
+	MaxAttempts int
+	Backoff     time.Duration
+}
+
+// Payload is the json of a message, Before and After hold the states of the audit entry of the change.
+type Payload struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Event      uint            `json:"event"`
+	Actor      string          `json:"actor"`
+	Guest      string          `json:"guest,omitempty"`
+	Table      uint            `json:"table,omitempty"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// NewMessage returns the message announcing the change recorded by the audit entry, the type of the message is the
+// action of the entry and the change occurred when the entry was created.
+func NewMessage(e audit.Entry) (Message, error) {
+	id, err := newID()
+	if err != nil {
+		return Message{}, err
+	}
+	p := Payload{
+		ID:         id,
+		Type:       string(e.Action),
+		Event:      e.EventID,
+		Actor:      e.Actor,
+		Guest:      e.Guest,
+		Table:      e.TableID,
+		OccurredAt: e.CreatedAt,
+	}
+	if e.Before != "" {
+		p.Before = json.RawMessage(e.Before)
+	}
+	if e.After != "" {
+		p.After = json.RawMessage(e.After)
+	}
+	b, err := json.Marshal(p)
+	if err != nil {
+		return Message{}, err
+	}
+	return Message{ID: id, EventID: e.EventID, Type: p.Type, Payload: string(b), CreatedAt: e.CreatedAt}, nil
+}
+
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
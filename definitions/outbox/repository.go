@@ -0,0 +1,14 @@
+package outbox
+
+import "time"
+
+type Repository interface {
+	// Pending returns the oldest messages not dispatched yet first.
+	Pending(limit int) ([]Message, error)
+	// Sinks returns the progress of the message on the sinks that were sent it.
+	Sinks(id string) ([]Sent, error)
+	// Save records the progress of a message on a sink.
+	Save(s Sent) error
+	// Dispatched marks the message as received or skipped by every sink.
+	Dispatched(id string, at time.Time) error
+}
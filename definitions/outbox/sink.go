@@ -0,0 +1,16 @@
+package outbox
+
+import "context"
+
+// Sink receives the messages of the outbox, it may receive a message more than once and drops the ids it already
+// handled.
+type Sink interface {
+	// Name identifies the sink in the outbox_sent table, it must not change between restarts.
+	Name() string
+	Send(ctx context.Context, m Message) error
+}
+
+// Broker is a message broker, the broker sink publishes the messages on the topic of their type.
+type Broker interface {
+	Publish(ctx context.Context, topic string, m Message) error
+}
@@ -0,0 +1,24 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// EncodeCursor returns an opaque cursor holding the sort keys of the last row of a page.
+func EncodeCursor(keys interface{}) string {
+	b, _ := json.Marshal(keys)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor reads the sort keys of a cursor made by EncodeCursor into keys.
+func DecodeCursor(cursor string, keys interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	if err = json.Unmarshal(b, keys); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
@@ -0,0 +1,5 @@
+package pagination
+
+import "errors"
+
+var ErrInvalidCursor = errors.New("invalid cursor")
@@ -0,0 +1,12 @@
+package pagination
+
+const (
+	DefaultLimit = 100
+	MaxLimit     = 1000
+)
+
+// Request asks for the page after Cursor, a zero Limit returns every remaining row.
+type Request struct {
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=1000"`
+}
@@ -0,0 +1,8 @@
+package ratelimit
+
+import "errors"
+
+var (
+	ErrLimited    = errors.New("too many requests")
+	ErrUnknownKey = errors.New("unknown API key")
+)
@@ -0,0 +1,74 @@
+package ratelimit
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// KeyHeader holds the API key of a client, the clients sending none are limited by their IP.
+	KeyHeader = "X-API-Key"
+	// ContextKey is the key of the client of the request in the gin context, its API key or its IP.
+	ContextKey = "client"
+	// LimitHeader is the number of requests a client can burst.
+	LimitHeader = "RateLimit-Limit"
+	// RemainingHeader is the number of requests the client can still make right away.
+	RemainingHeader = "RateLimit-Remaining"
+	// ResetHeader is the number of seconds before the client can burst again.
+	ResetHeader = "RateLimit-Reset"
+	// RetryAfterHeader is the number of seconds a rejected client waits before its next request is accepted.
+	RetryAfterHeader = "Retry-After"
+)
+
+// Limit is a token bucket holding Burst requests and refilled with Rate requests per second. A zero Rate doesn't
+// limit the requests.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Unlimited reports whether the requests are accepted whatever their number.
+func (l Limit) Unlimited() bool {
+	return l.Rate <= 0 || l.Burst <= 0
+}
+
+// Bucket is the state of the requests of a client, the tokens left when it was last updated.
+type Bucket struct {
+	Tokens  float64
+	Updated time.Time
+}
+
+// Result is the outcome of a request, Reset is the wait before the bucket is full again and RetryAfter the wait
+// before a rejected request would be accepted.
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// Take refills the bucket up to now and takes a token for the request when one is left. A bucket never updated is
+// full, every store shares this arithmetic so they limit the clients alike.
+func (b Bucket) Take(l Limit, now time.Time) (Bucket, Result) {
+	burst := float64(l.Burst)
+	tokens := burst
+	if !b.Updated.IsZero() {
+		tokens = math.Min(burst, b.Tokens+now.Sub(b.Updated).Seconds()*l.Rate)
+	}
+
+	res := Result{Limit: l.Burst}
+	if tokens >= 1 {
+		tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = seconds((1 - tokens) / l.Rate)
+	}
+	res.Remaining = int(tokens)
+	res.Reset = seconds((burst - tokens) / l.Rate)
+	return Bucket{Tokens: tokens, Updated: now}, res
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
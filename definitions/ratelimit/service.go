@@ -0,0 +1,13 @@
+package ratelimit
+
+type Service interface {
+	// Allow takes a token for a request of the client to the route, the routes without their own limit share the
+	// bucket of the client.
+	Allow(client, route string) (Result, error)
+	// SetLimits replaces the limits, the buckets of the clients are kept.
+	SetLimits(limit Limit, routes map[string]Limit)
+	// Known reports whether the API key is one of the keys given to the clients.
+	Known(key string) bool
+	// SetKeys replaces the keys given to the clients.
+	SetKeys(keys []string)
+}
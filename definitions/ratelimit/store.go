@@ -0,0 +1,10 @@
+package ratelimit
+
+import "time"
+
+// Store keeps the buckets of the clients, a store shared by several instances of the API limits the clients across
+// them.
+type Store interface {
+	// Take takes a token from the bucket of the key at now, the bucket and the result follow Bucket.Take.
+	Take(key string, limit Limit, now time.Time) (Result, error)
+}
@@ -0,0 +1,8 @@
+package tables
+
+import "errors"
+
+var (
+	ErrNotFound   = errors.New("table not found")
+	ErrSeatsTaken = errors.New("table seats are reserved or taken")
+)
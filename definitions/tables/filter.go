@@ -0,0 +1,16 @@
+package tables
+
+import "github.com/getground/tech-tasks/backend/definitions/pagination"
+
+// ListRequest asks for a page of the tables sorted by id, a zero MinEmptySeats doesn't filter.
+type ListRequest struct {
+	MinEmptySeats int64 `form:"min_empty_seats" binding:"omitempty,min=0"`
+	pagination.Request
+}
+
+// Page is a page of tables, Total counts every table matching the filter and NextCursor is empty on the last page.
+type Page struct {
+	Tables     []Table
+	Total      int64
+	NextCursor string
+}
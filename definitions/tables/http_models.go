@@ -8,3 +8,24 @@ type CreateResponse struct {
 	ID       uint  `json:"id"`
 	Capacity int64 `json:"capacity"`
 }
+
+type ListDTO struct {
+	Tables     []TableDTO `json:"tables"`
+	Total      int64      `json:"total"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+}
+
+type TableDTO struct {
+	ID         uint  `json:"id"`
+	Capacity   int64 `json:"capacity"`
+	EmptySeats int64 `json:"empty_seats"`
+	Version    int64 `json:"version"`
+}
+
+// ResizeRequest sets the number of seats of a table, the ID is taken from the path and the Version from the If-Match
+// header. A zero Version resizes whatever the version of the table.
+type ResizeRequest struct {
+	ID       uint  `json:"-"`
+	Capacity int64 `json:"capacity" binding:"required,min=1"`
+	Version  int64 `json:"-"`
+}
@@ -1,7 +1,10 @@
 package tables
 
+// Table is a table of an event, Version is increased by every change so a change made on a stale read is detected.
 type Table struct {
 	ID         uint `gorm:"primarykey"`
+	EventID    uint
 	Capacity   int64
 	EmptySeats int64
+	Version    int64
 }
@@ -1,7 +1,20 @@
 package tables
 
+import "time"
+
+// Repository stores the tables of an event, the changes are recorded in the audit log and the attendance stream as made
+// at the time given.
 type Repository interface {
-	Create(request CreateRequest) (Table, error)
+	// ForEvent returns the repository of the tables of the event.
+	ForEvent(event uint) Repository
+	// ForActor returns the repository recording the changes it makes as done by the actor.
+	ForActor(actor string) Repository
+	Create(request CreateRequest, at time.Time) (Table, error)
 	GetByID(id uint) (Table, error)
+	List() ([]Table, error)
+	ListPage(request ListRequest) (Page, error)
+	// Resize sets the number of seats of the table, the seats reserved or taken must still fit. It returns
+	// etag.ErrStale when the version isn't zero nor the version of the table.
+	Resize(id uint, seats int64, version int64, at time.Time) (Table, error)
 	CountEmptySeats() int
 }
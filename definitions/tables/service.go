@@ -1,7 +1,16 @@
 package tables
 
 type Service interface {
+	// ForEvent returns the service of the tables of the event.
+	ForEvent(event uint) Service
+	// ForActor returns the service making the changes on behalf of the actor.
+	ForActor(actor string) Service
 	Create(request CreateRequest) (response CreateResponse, err error)
+	// Get returns the table with its version.
+	Get(id uint) (TableDTO, error)
 	GetByID(id uint) (Table, error)
+	List() ([]Table, error)
+	GetTables(request ListRequest) (ListDTO, error)
+	Resize(request ResizeRequest) (TableDTO, error)
 	CountEmptySeats() int
 }
@@ -0,0 +1,7 @@
+package venue
+
+import "errors"
+
+var (
+	ErrFull = errors.New("venue headcount limit reached")
+)
@@ -0,0 +1,9 @@
+package venue
+
+type HeadcountDTO struct {
+	OnSite int64 `json:"on_site"`
+	Guests int64 `json:"guests"`
+	Staff  int64 `json:"staff"`
+	Peak   int64 `json:"peak"`
+	Limit  int64 `json:"limit"`
+}
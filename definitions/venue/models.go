@@ -0,0 +1,7 @@
+package venue
+
+// Limits are the fire safety limits of the venue of an event, a zero Max doesn't limit the headcount.
+type Limits struct {
+	Max   int64
+	Staff int64
+}
@@ -0,0 +1,13 @@
+package venue
+
+type Repository interface {
+	// ForEvent returns the repository of the headcount of the event.
+	ForEvent(event uint) Repository
+	// Guests counts the guests and accompanying guests that arrived and didn't leave yet.
+	Guests() (int64, error)
+	// Limits returns the limits of the venue saved on the event.
+	Limits() (Limits, error)
+	Peak() (int64, error)
+	// RecordPeak saves the headcount as the peak of the event when it is higher than the peak saved.
+	RecordPeak(headcount int64) error
+}
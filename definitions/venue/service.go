@@ -0,0 +1,12 @@
+package venue
+
+type Service interface {
+	// ForEvent returns the service of the headcount of the event.
+	ForEvent(event uint) Service
+	Headcount() (HeadcountDTO, error)
+	// Track records the peak headcount once people got in and warns when the venue gets nearly full.
+	Track(people int64)
+	// SetWarning replaces the share of the limit that warns the venue is nearly full, the services of every event
+	// share it.
+	SetWarning(share float64)
+}
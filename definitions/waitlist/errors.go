@@ -0,0 +1,8 @@
+package waitlist
+
+import "errors"
+
+var (
+	ErrAlreadyListed = errors.New("guest already on the guest list or the waitlist")
+	ErrNotFound      = errors.New("waitlist entry not found")
+)
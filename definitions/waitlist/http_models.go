@@ -0,0 +1,30 @@
+package waitlist
+
+import "time"
+
+type CreateRequest struct {
+	Name         string `json:"name" binding:"required"`
+	Table        uint   `json:"table"`
+	Accompanying int64  `json:"accompanying_guests" binding:"min=0"`
+	Priority     int64  `json:"priority"`
+}
+
+type ListRequest struct {
+	Table    uint  `form:"table"`
+	Promoted *bool `form:"promoted"`
+}
+
+type ListDTO struct {
+	Entries []EntryDTO `json:"entries"`
+}
+
+type EntryDTO struct {
+	ID           uint       `json:"id"`
+	Name         string     `json:"name"`
+	Table        uint       `json:"table"`
+	Accompanying int64      `json:"accompanying_guests"`
+	Priority     int64      `json:"priority"`
+	CreatedAt    time.Time  `json:"created_at"`
+	PromotedAt   *time.Time `json:"promoted_at,omitempty"`
+	PromotedTo   uint       `json:"promoted_to,omitempty"`
+}
@@ -0,0 +1,26 @@
+package waitlist
+
+import "time"
+
+// Entry is a party waiting for seats, a zero TableID waits for any table. The entry records the promotion once the
+// party is added to the guest list.
+type Entry struct {
+	ID           uint `gorm:"primarykey"`
+	EventID      uint
+	Name         string
+	TableID      uint
+	Accompanying int64
+	Priority     int64
+	CreatedAt    time.Time
+	PromotedAt   *time.Time
+	PromotedTo   uint
+}
+
+func (Entry) TableName() string {
+	return "waitlist"
+}
+
+// Seats is the number of seats the party needs.
+func (e Entry) Seats() int64 {
+	return e.Accompanying + 1
+}
@@ -0,0 +1,25 @@
+package waitlist
+
+import (
+	"github.com/getground/tech-tasks/backend/definitions/guests"
+	"github.com/getground/tech-tasks/backend/definitions/tables"
+	"time"
+)
+
+type Repository interface {
+	// ForEvent returns the repository of the waitlist of the event.
+	ForEvent(event uint) Repository
+	// ForActor returns the repository recording the changes it makes as done by the actor.
+	ForActor(actor string) Repository
+	Create(entry Entry) (Entry, error)
+	GetByID(id uint) (Entry, error)
+	Delete(id uint) error
+	List(request ListRequest) ([]Entry, error)
+	// Listed reports whether the name is on the guest list or waiting already.
+	Listed(name string) (bool, error)
+	// Waiting returns the parties waiting for the table or for any table in promotion order.
+	Waiting(tableID uint) ([]Entry, error)
+	// Promote adds the guest to the guest list and saves the capacity left at the table, it returns etag.ErrStale when
+	// the table changed since it was read.
+	Promote(entry Entry, guest guests.Guest, table tables.Table, at time.Time) error
+}
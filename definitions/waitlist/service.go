@@ -0,0 +1,18 @@
+package waitlist
+
+// Promoter is told about the tables that got free seats, it is called by the services releasing seats. The actor is
+// the one whose change freed the seats, the promotions are recorded as made by it.
+type Promoter interface {
+	Promote(event uint, actor string, tableID uint)
+}
+
+type Service interface {
+	Promoter
+	// ForEvent returns the service of the waitlist of the event.
+	ForEvent(event uint) Service
+	// ForActor returns the service making the changes on behalf of the actor.
+	ForActor(actor string) Service
+	Create(request CreateRequest) (EntryDTO, error)
+	Delete(id uint) error
+	List(request ListRequest) (ListDTO, error)
+}
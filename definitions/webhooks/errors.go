@@ -0,0 +1,8 @@
+package webhooks
+
+import "errors"
+
+var (
+	ErrNotFound    = errors.New("webhook subscription not found")
+	ErrInvalidType = errors.New("webhook type must be guest.invited, guest.checked_in, guest.checked_out or table.full")
+)
@@ -0,0 +1,46 @@
+package webhooks
+
+import "time"
+
+// CreateRequest subscribes the url to the types, a random secret is generated when it is empty.
+type CreateRequest struct {
+	URL    string `json:"url" binding:"required,url"`
+	Types  []Type `json:"types"`
+	Secret string `json:"secret"`
+}
+
+// UpdateRequest replaces the url and the types of the subscription, the secret is kept when it is empty.
+type UpdateRequest struct {
+	ID     uint   `json:"-"`
+	URL    string `json:"url" binding:"required,url"`
+	Types  []Type `json:"types"`
+	Secret string `json:"secret"`
+}
+
+// SubscriptionDTO is a subscription, the secret is only returned once the subscription is created.
+type SubscriptionDTO struct {
+	ID        uint      `json:"id"`
+	URL       string    `json:"url"`
+	Types     []Type    `json:"types"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ListDTO struct {
+	Subscriptions []SubscriptionDTO `json:"subscriptions"`
+}
+
+type DeliveryDTO struct {
+	ID         uint      `json:"id"`
+	PayloadID  string    `json:"payload_id"`
+	Type       Type      `json:"type"`
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Succeeded  bool      `json:"succeeded"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type DeliveriesDTO struct {
+	Deliveries []DeliveryDTO `json:"deliveries"`
+}
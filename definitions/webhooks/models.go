@@ -0,0 +1,94 @@
+package webhooks
+
+import (
+	"strings"
+	"time"
+)
+
+// Type is the kind of change a subscription is notified of.
+type Type string
+
+const (
+	GuestInvited    Type = "guest.invited"
+	GuestCheckedIn  Type = "guest.checked_in"
+	GuestCheckedOut Type = "guest.checked_out"
+	// TableFull is sent when a check in takes the last empty seat of the table.
+	TableFull Type = "table.full"
+)
+
+// Types are the types a subscription can filter on.
+var Types = []Type{GuestInvited, GuestCheckedIn, GuestCheckedOut, TableFull}
+
+const (
+	// SignatureHeader holds sha256= and the hex HMAC-SHA256 of the body signed with the secret of the subscription.
+	SignatureHeader = "X-Webhook-Signature"
+	// TypeHeader holds the type of the payload.
+	TypeHeader = "X-Webhook-Type"
+)
+
+// Subscription is an endpoint notified of the changes made to an event, Types is the comma separated list of the
+// types it is notified of, every type when it is empty.
+type Subscription struct {
+	ID        uint `gorm:"primarykey"`
+	EventID   uint
+	URL       string
+	Secret    string
+	Types     string
+	CreatedAt time.Time
+}
+
+func (Subscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// Wants reports whether the subscription is notified of the type.
+func (s Subscription) Wants(t Type) bool {
+	if s.Types == "" {
+		return true
+	}
+	for _, want := range strings.Split(s.Types, ",") {
+		if Type(want) == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Delivery is an attempt to send a payload to a subscription, the attempts of a payload share its PayloadID.
+// StatusCode is zero when the endpoint couldn't be reached.
+type Delivery struct {
+	ID             uint `gorm:"primarykey"`
+	SubscriptionID uint
+	PayloadID      string
+	Type           Type
+	Attempt        int
+	StatusCode     int
+	Error          string
+	CreatedAt      time.Time
+}
+
+func (Delivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// Succeeded reports whether the endpoint accepted the payload.
+func (d Delivery) Succeeded() bool {
+	return d.StatusCode >= 200 && d.StatusCode < 300
+}
+
+// Payload is the json body sent to the subscriptions, the table fields hold the table state after the change.
+type Payload struct {
+	ID                 string    `json:"id"`
+	Type               Type      `json:"type"`
+	Event              uint      `json:"event"`
+	OccurredAt         time.Time `json:"occurred_at"`
+	Guest              string    `json:"guest,omitempty"`
+	AccompanyingGuests int64     `json:"accompanying_guests"`
+	Table              TableDTO  `json:"table"`
+}
+
+type TableDTO struct {
+	ID         uint  `json:"id"`
+	Capacity   int64 `json:"capacity"`
+	EmptySeats int64 `json:"empty_seats"`
+}
@@ -0,0 +1,20 @@
+package webhooks
+
+type Repository interface {
+	// ForEvent returns the repository of the subscriptions of the event.
+	ForEvent(event uint) Repository
+	Create(s Subscription) (Subscription, error)
+	GetByID(id uint) (Subscription, error)
+	List() ([]Subscription, error)
+	// All returns the subscriptions of every event.
+	All() ([]Subscription, error)
+	// Update saves the url, the secret and the types of the subscription, the caller makes sure it exists.
+	Update(s Subscription) error
+	// Delete removes the subscription and its deliveries.
+	Delete(id uint) error
+	Record(d Delivery) error
+	// Attempts counts the deliveries of the payload to the subscription.
+	Attempts(subscription uint, payload string) (int, error)
+	// Deliveries returns the latest deliveries of the subscription first.
+	Deliveries(subscription uint, limit int) ([]Delivery, error)
+}
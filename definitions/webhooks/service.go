@@ -0,0 +1,12 @@
+package webhooks
+
+type Service interface {
+	// ForEvent returns the service of the subscriptions of the event.
+	ForEvent(event uint) Service
+	Create(req CreateRequest) (SubscriptionDTO, error)
+	Get(id uint) (SubscriptionDTO, error)
+	List() (ListDTO, error)
+	Update(req UpdateRequest) (SubscriptionDTO, error)
+	Delete(id uint) error
+	Deliveries(id uint) (DeliveriesDTO, error)
+}
@@ -1,18 +1,185 @@
+CREATE TABLE events
+(
+    id             INT NOT NULL auto_increment,
+    name           VARCHAR(255) UNICODE NOT NULL,
+    venue          VARCHAR(255) UNICODE NOT NULL DEFAULT '',
+    date           TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    status         VARCHAR(16) NOT NULL DEFAULT 'planning',
+    venue_limit    INT NOT NULL DEFAULT 0,
+    staff          INT NOT NULL DEFAULT 0,
+    peak_headcount INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (id),
+    INDEX idx_events_date (date)
+);
+
+-- the routes that aren't nested under an event serve DEFAULT_EVENT
+INSERT INTO events (id, name) VALUES (1, 'Year-end party');
+
 CREATE TABLE tables
 (
     id          INT NOT NULL auto_increment,
+    event_id    INT NOT NULL DEFAULT 1,
     capacity    INT,
     empty_seats INT,
-    PRIMARY KEY (id)
+    version     INT NOT NULL DEFAULT 1,
+    PRIMARY KEY (id),
+    INDEX idx_tables_event_id (event_id),
+    FOREIGN KEY (event_id) REFERENCES events (id)
 );
 
 CREATE TABLE guests
 (
-    name         VARCHAR(255) UNICODE,
-    table_id     INT,
-    accompanying INT,
-    time_arrived TIMESTAMP NULL DEFAULT NULL,
-    checked_out  INT,
-    PRIMARY KEY (name),
+    name          VARCHAR(255) UNICODE,
+    table_id      INT,
+    accompanying  INT,
+    time_arrived  TIMESTAMP NULL DEFAULT NULL,
+    checked_out   INT,
+    rsvp          VARCHAR(16) NOT NULL DEFAULT 'invited',
+    walk_in       BOOLEAN NOT NULL DEFAULT FALSE,
+    event_id      INT NOT NULL DEFAULT 1,
+    version       INT NOT NULL DEFAULT 1,
+    email         VARCHAR(255) UNICODE NOT NULL DEFAULT '',
+    phone         VARCHAR(16) NOT NULL DEFAULT '',
+    department    VARCHAR(255) UNICODE NOT NULL DEFAULT '',
+    dietary       VARCHAR(32) NOT NULL DEFAULT '',
+    dietary_notes VARCHAR(500) UNICODE NOT NULL DEFAULT '',
+    accessibility VARCHAR(500) UNICODE NOT NULL DEFAULT '',
+    PRIMARY KEY (event_id, name),
+    INDEX idx_guests_time_arrived (event_id, time_arrived, name),
+    FOREIGN KEY (event_id) REFERENCES events (id),
     FOREIGN KEY (table_id) REFERENCES tables (id)
-);
\ No newline at end of file
+);
+
+CREATE TABLE invitations
+(
+    id         VARCHAR(22),
+    event_id   INT NOT NULL DEFAULT 1,
+    guest_name VARCHAR(255) UNICODE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP NULL DEFAULT NULL,
+    used_at    TIMESTAMP NULL DEFAULT NULL,
+    PRIMARY KEY (id),
+    INDEX idx_invitations_guest_name (event_id, guest_name),
+    FOREIGN KEY (event_id, guest_name) REFERENCES guests (event_id, name)
+);
+CREATE TABLE companions
+(
+    id            INT NOT NULL auto_increment,
+    event_id      INT NOT NULL DEFAULT 1,
+    guest_name    VARCHAR(255) UNICODE NOT NULL,
+    name          VARCHAR(255) UNICODE NOT NULL,
+    dietary       VARCHAR(32) NOT NULL DEFAULT '',
+    dietary_notes VARCHAR(500) UNICODE NOT NULL DEFAULT '',
+    PRIMARY KEY (id),
+    INDEX idx_companions_guest_name (event_id, guest_name),
+    FOREIGN KEY (event_id, guest_name) REFERENCES guests (event_id, name)
+);
+CREATE TABLE waitlist
+(
+    id           INT NOT NULL auto_increment,
+    event_id     INT NOT NULL DEFAULT 1,
+    name         VARCHAR(255) UNICODE NOT NULL,
+    table_id     INT NOT NULL DEFAULT 0,
+    accompanying INT NOT NULL DEFAULT 0,
+    priority     INT NOT NULL DEFAULT 0,
+    created_at   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    promoted_at  TIMESTAMP NULL DEFAULT NULL,
+    promoted_to  INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (id),
+    INDEX idx_waitlist_waiting (event_id, promoted_at, table_id, priority)
+);
+
+CREATE TABLE audit_log
+(
+    id         INT NOT NULL auto_increment,
+    event_id   INT NOT NULL DEFAULT 1,
+    actor      VARCHAR(255) UNICODE NOT NULL,
+    action     VARCHAR(32) NOT NULL,
+    guest      VARCHAR(255) UNICODE NOT NULL DEFAULT '',
+    table_id   INT NOT NULL DEFAULT 0,
+    `before`   TEXT,
+    `after`    TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    INDEX idx_audit_log_guest (event_id, guest),
+    INDEX idx_audit_log_table_id (event_id, table_id),
+    INDEX idx_audit_log_created_at (event_id, created_at)
+);
+
+CREATE TABLE attendance
+(
+    seq         INT NOT NULL auto_increment,
+    event_id    INT NOT NULL DEFAULT 1,
+    type        VARCHAR(32) NOT NULL,
+    guest       VARCHAR(255) UNICODE NOT NULL DEFAULT '',
+    table_id    INT NOT NULL DEFAULT 0,
+    people      INT NOT NULL DEFAULT 0,
+    rsvp        VARCHAR(16) NOT NULL DEFAULT '',
+    occurred_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    PRIMARY KEY (seq),
+    INDEX idx_attendance_occurred_at (event_id, occurred_at),
+    INDEX idx_attendance_table_id (event_id, table_id, occurred_at)
+);
+
+CREATE TABLE webhook_subscriptions
+(
+    id         INT NOT NULL auto_increment,
+    event_id   INT NOT NULL DEFAULT 1,
+    url        VARCHAR(2048) NOT NULL,
+    secret     VARCHAR(255) NOT NULL,
+    types      VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    INDEX idx_webhook_subscriptions_event_id (event_id)
+);
+
+CREATE TABLE webhook_deliveries
+(
+    id              INT NOT NULL auto_increment,
+    subscription_id INT NOT NULL,
+    payload_id      VARCHAR(32) NOT NULL,
+    type            VARCHAR(32) NOT NULL,
+    attempt         INT NOT NULL,
+    status_code     INT NOT NULL DEFAULT 0,
+    error           TEXT,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    INDEX idx_webhook_deliveries_subscription_id (subscription_id, id)
+);
+
+CREATE TABLE outbox
+(
+    id            VARCHAR(32) NOT NULL,
+    event_id      INT NOT NULL DEFAULT 1,
+    type          VARCHAR(32) NOT NULL,
+    payload       TEXT NOT NULL,
+    created_at    TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    dispatched_at TIMESTAMP(6) NULL,
+    PRIMARY KEY (id),
+    INDEX idx_outbox_pending (dispatched_at, created_at)
+);
+
+CREATE TABLE outbox_sent
+(
+    message_id VARCHAR(32) NOT NULL,
+    sink       VARCHAR(32) NOT NULL,
+    attempts   INT NOT NULL DEFAULT 0,
+    error      TEXT NOT NULL,
+    retry_at   TIMESTAMP NULL,
+    sent_at    TIMESTAMP NULL,
+    skipped_at TIMESTAMP NULL,
+    PRIMARY KEY (message_id, sink)
+);
+
+CREATE TABLE idempotency_keys
+(
+    idempotency_key CHAR(64) NOT NULL,
+    fingerprint     CHAR(64) NOT NULL,
+    status          INT NOT NULL DEFAULT 0,
+    headers         TEXT,
+    body            MEDIUMTEXT,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at      TIMESTAMP NOT NULL,
+    PRIMARY KEY (idempotency_key),
+    INDEX idx_idempotency_keys_expires_at (expires_at)
+);
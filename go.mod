@@ -7,9 +7,18 @@ require (
 	github.com/caarlos0/env/v6 v6.10.1
 	github.com/gin-gonic/gin v1.8.2
 	github.com/go-playground/assert/v2 v2.0.1
+	github.com/go-sql-driver/mysql v1.7.0
+	github.com/graphql-go/graphql v0.8.1
+	github.com/pelletier/go-toml/v2 v2.0.6
 	github.com/sirupsen/logrus v1.9.0
+	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
 	github.com/spf13/cobra v1.6.1
-	github.com/stretchr/testify v1.8.1
+	github.com/spf13/pflag v1.0.5
+	github.com/stretchr/testify v1.8.3
+	golang.org/x/text v0.11.0
+	google.golang.org/grpc v1.58.3
+	google.golang.org/protobuf v1.31.0
+	gopkg.in/yaml.v3 v3.0.1
 	gorm.io/driver/mysql v1.4.5
 	gorm.io/gorm v1.24.3
 )
@@ -13,6 +13,9 @@ func main() {
 
 	rootCmd.AddCommand(
 		cmd.API(),
+		cmd.Event(),
+		cmd.Report(),
+		cmd.Config(),
 	)
 
 	cobra.CheckErr(rootCmd.Execute())
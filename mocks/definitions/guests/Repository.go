@@ -7,6 +7,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	tables "github.com/getground/tech-tasks/backend/definitions/tables"
+
+	time "time"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -14,13 +16,13 @@ type Repository struct {
 	mock.Mock
 }
 
-// CheckIn provides a mock function with given fields: request, guest, table
-func (_m *Repository) CheckIn(request guests.CheckInRequest, guest guests.Guest, table tables.Table) error {
-	ret := _m.Called(request, guest, table)
+// CheckIn provides a mock function with given fields: request, guest, table, arrived
+func (_m *Repository) CheckIn(request guests.CheckInRequest, guest guests.Guest, table tables.Table, arrived time.Time) error {
+	ret := _m.Called(request, guest, table, arrived)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(guests.CheckInRequest, guests.Guest, tables.Table) error); ok {
-		r0 = rf(request, guest, table)
+	if rf, ok := ret.Get(0).(func(guests.CheckInRequest, guests.Guest, tables.Table, time.Time) error); ok {
+		r0 = rf(request, guest, table, arrived)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -28,13 +30,108 @@ func (_m *Repository) CheckIn(request guests.CheckInRequest, guest guests.Guest,
 	return r0
 }
 
-// CheckOut provides a mock function with given fields: name
-func (_m *Repository) CheckOut(name string) error {
-	ret := _m.Called(name)
+// CheckOut provides a mock function with given fields: name, at
+func (_m *Repository) CheckOut(name string, at time.Time) (guests.Guest, error) {
+	ret := _m.Called(name, at)
+
+	var r0 guests.Guest
+	if rf, ok := ret.Get(0).(func(string, time.Time) guests.Guest); ok {
+		r0 = rf(name, at)
+	} else {
+		r0 = ret.Get(0).(guests.Guest)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
+		r1 = rf(name, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountDiets provides a mock function with given fields: table
+func (_m *Repository) CountDiets(table uint) ([]guests.DietCount, error) {
+	ret := _m.Called(table)
+
+	var r0 []guests.DietCount
+	if rf, ok := ret.Get(0).(func(uint) []guests.DietCount); ok {
+		r0 = rf(table)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]guests.DietCount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(table)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountRSVP provides a mock function with given fields: table
+func (_m *Repository) CountRSVP(table uint) ([]guests.RSVPCount, error) {
+	ret := _m.Called(table)
+
+	var r0 []guests.RSVPCount
+	if rf, ok := ret.Get(0).(func(uint) []guests.RSVPCount); ok {
+		r0 = rf(table)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]guests.RSVPCount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(table)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: request, at
+func (_m *Repository) Create(request guests.CreateRequest, at time.Time) error {
+	ret := _m.Called(request, at)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(0).(func(guests.CreateRequest, time.Time) error); ok {
+		r0 = rf(request, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: guest, table, at
+func (_m *Repository) Delete(guest guests.Guest, table tables.Table, at time.Time) error {
+	ret := _m.Called(guest, table, at)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(guests.Guest, tables.Table, time.Time) error); ok {
+		r0 = rf(guest, table, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EditCompanions provides a mock function with given fields: guest, table, at
+func (_m *Repository) EditCompanions(guest guests.Guest, table tables.Table, at time.Time) error {
+	ret := _m.Called(guest, table, at)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(guests.Guest, tables.Table, time.Time) error); ok {
+		r0 = rf(guest, table, at)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -42,13 +139,13 @@ func (_m *Repository) CheckOut(name string) error {
 	return r0
 }
 
-// Create provides a mock function with given fields: request, tableCapacity
-func (_m *Repository) Create(request guests.CreateRequest, tableCapacity int64) error {
-	ret := _m.Called(request, tableCapacity)
+// EditProfile provides a mock function with given fields: guest, at
+func (_m *Repository) EditProfile(guest guests.Guest, at time.Time) error {
+	ret := _m.Called(guest, at)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(guests.CreateRequest, int64) error); ok {
-		r0 = rf(request, tableCapacity)
+	if rf, ok := ret.Get(0).(func(guests.Guest, time.Time) error); ok {
+		r0 = rf(guest, at)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -56,6 +153,38 @@ func (_m *Repository) Create(request guests.CreateRequest, tableCapacity int64)
 	return r0
 }
 
+// ForActor provides a mock function with given fields: actor
+func (_m *Repository) ForActor(actor string) guests.Repository {
+	ret := _m.Called(actor)
+
+	var r0 guests.Repository
+	if rf, ok := ret.Get(0).(func(string) guests.Repository); ok {
+		r0 = rf(actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(guests.Repository)
+		}
+	}
+
+	return r0
+}
+
+// ForEvent provides a mock function with given fields: event
+func (_m *Repository) ForEvent(event uint) guests.Repository {
+	ret := _m.Called(event)
+
+	var r0 guests.Repository
+	if rf, ok := ret.Get(0).(func(uint) guests.Repository); ok {
+		r0 = rf(event)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(guests.Repository)
+		}
+	}
+
+	return r0
+}
+
 // GetByName provides a mock function with given fields: name
 func (_m *Repository) GetByName(name string) (guests.Guest, error) {
 	ret := _m.Called(name)
@@ -77,13 +206,13 @@ func (_m *Repository) GetByName(name string) (guests.Guest, error) {
 	return r0, r1
 }
 
-// GetGuestList provides a mock function with given fields: arrived
-func (_m *Repository) GetGuestList(arrived bool) ([]guests.Guest, error) {
-	ret := _m.Called(arrived)
+// List provides a mock function with given fields: filter
+func (_m *Repository) List(filter guests.Filter) ([]guests.Guest, error) {
+	ret := _m.Called(filter)
 
 	var r0 []guests.Guest
-	if rf, ok := ret.Get(0).(func(bool) []guests.Guest); ok {
-		r0 = rf(arrived)
+	if rf, ok := ret.Get(0).(func(guests.Filter) []guests.Guest); ok {
+		r0 = rf(filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]guests.Guest)
@@ -91,8 +220,29 @@ func (_m *Repository) GetGuestList(arrived bool) ([]guests.Guest, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(bool) error); ok {
-		r1 = rf(arrived)
+	if rf, ok := ret.Get(1).(func(guests.Filter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPage provides a mock function with given fields: request
+func (_m *Repository) ListPage(request guests.ListRequest) (guests.Page, error) {
+	ret := _m.Called(request)
+
+	var r0 guests.Page
+	if rf, ok := ret.Get(0).(func(guests.ListRequest) guests.Page); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Get(0).(guests.Page)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(guests.ListRequest) error); ok {
+		r1 = rf(request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -100,6 +250,48 @@ func (_m *Repository) GetGuestList(arrived bool) ([]guests.Guest, error) {
 	return r0, r1
 }
 
+// Respond provides a mock function with given fields: guest, table, at
+func (_m *Repository) Respond(guest guests.Guest, table tables.Table, at time.Time) error {
+	ret := _m.Called(guest, table, at)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(guests.Guest, tables.Table, time.Time) error); ok {
+		r0 = rf(guest, table, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WalkIn provides a mock function with given fields: request, arrived
+func (_m *Repository) WalkIn(request guests.WalkInRequest, arrived time.Time) (guests.Guest, tables.Table, error) {
+	ret := _m.Called(request, arrived)
+
+	var r0 guests.Guest
+	if rf, ok := ret.Get(0).(func(guests.WalkInRequest, time.Time) guests.Guest); ok {
+		r0 = rf(request, arrived)
+	} else {
+		r0 = ret.Get(0).(guests.Guest)
+	}
+
+	var r1 tables.Table
+	if rf, ok := ret.Get(1).(func(guests.WalkInRequest, time.Time) tables.Table); ok {
+		r1 = rf(request, arrived)
+	} else {
+		r1 = ret.Get(1).(tables.Table)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(guests.WalkInRequest, time.Time) error); ok {
+		r2 = rf(request, arrived)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 type mockConstructorTestingTNewRepository interface {
 	mock.TestingT
 	Cleanup(func())
@@ -4,6 +4,7 @@ package mocks
 
 import (
 	guests "github.com/getground/tech-tasks/backend/definitions/guests"
+	invitations "github.com/getground/tech-tasks/backend/definitions/invitations"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -12,6 +13,27 @@ type Service struct {
 	mock.Mock
 }
 
+// Catering provides a mock function with given fields: req
+func (_m *Service) Catering(req guests.CateringRequest) (guests.CateringDTO, error) {
+	ret := _m.Called(req)
+
+	var r0 guests.CateringDTO
+	if rf, ok := ret.Get(0).(func(guests.CateringRequest) guests.CateringDTO); ok {
+		r0 = rf(req)
+	} else {
+		r0 = ret.Get(0).(guests.CateringDTO)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(guests.CateringRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CheckIn provides a mock function with given fields: req
 func (_m *Service) CheckIn(req guests.CheckInRequest) (guests.CheckInResponse, error) {
 	ret := _m.Called(req)
@@ -68,20 +90,115 @@ func (_m *Service) Create(request guests.CreateRequest) (guests.CreateResponse,
 	return r0, r1
 }
 
-// GetGuestList provides a mock function with given fields:
-func (_m *Service) GetGuestList() (guests.ListDTO, error) {
-	ret := _m.Called()
+// EditCompanions provides a mock function with given fields: req
+func (_m *Service) EditCompanions(req guests.CompanionsRequest) (guests.GuestListDTO, error) {
+	ret := _m.Called(req)
+
+	var r0 guests.GuestListDTO
+	if rf, ok := ret.Get(0).(func(guests.CompanionsRequest) guests.GuestListDTO); ok {
+		r0 = rf(req)
+	} else {
+		r0 = ret.Get(0).(guests.GuestListDTO)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(guests.CompanionsRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EditProfile provides a mock function with given fields: req
+func (_m *Service) EditProfile(req guests.ProfileRequest) (guests.GuestListDTO, error) {
+	ret := _m.Called(req)
+
+	var r0 guests.GuestListDTO
+	if rf, ok := ret.Get(0).(func(guests.ProfileRequest) guests.GuestListDTO); ok {
+		r0 = rf(req)
+	} else {
+		r0 = ret.Get(0).(guests.GuestListDTO)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(guests.ProfileRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ForActor provides a mock function with given fields: actor
+func (_m *Service) ForActor(actor string) guests.Service {
+	ret := _m.Called(actor)
+
+	var r0 guests.Service
+	if rf, ok := ret.Get(0).(func(string) guests.Service); ok {
+		r0 = rf(actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(guests.Service)
+		}
+	}
+
+	return r0
+}
+
+// ForEvent provides a mock function with given fields: event
+func (_m *Service) ForEvent(event uint) guests.Service {
+	ret := _m.Called(event)
+
+	var r0 guests.Service
+	if rf, ok := ret.Get(0).(func(uint) guests.Service); ok {
+		r0 = rf(event)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(guests.Service)
+		}
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: name
+func (_m *Service) Get(name string) (guests.GuestListDTO, error) {
+	ret := _m.Called(name)
+
+	var r0 guests.GuestListDTO
+	if rf, ok := ret.Get(0).(func(string) guests.GuestListDTO); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(guests.GuestListDTO)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGuestList provides a mock function with given fields: request
+func (_m *Service) GetGuestList(request guests.ListRequest) (guests.ListDTO, error) {
+	ret := _m.Called(request)
 
 	var r0 guests.ListDTO
-	if rf, ok := ret.Get(0).(func() guests.ListDTO); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(guests.ListRequest) guests.ListDTO); ok {
+		r0 = rf(request)
 	} else {
 		r0 = ret.Get(0).(guests.ListDTO)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(guests.ListRequest) error); ok {
+		r1 = rf(request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -89,20 +206,183 @@ func (_m *Service) GetGuestList() (guests.ListDTO, error) {
 	return r0, r1
 }
 
-// GetGuests provides a mock function with given fields:
-func (_m *Service) GetGuests() (guests.DTO, error) {
-	ret := _m.Called()
+// GetGuests provides a mock function with given fields: request
+func (_m *Service) GetGuests(request guests.ListRequest) (guests.DTO, error) {
+	ret := _m.Called(request)
 
 	var r0 guests.DTO
-	if rf, ok := ret.Get(0).(func() guests.DTO); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(guests.ListRequest) guests.DTO); ok {
+		r0 = rf(request)
 	} else {
 		r0 = ret.Get(0).(guests.DTO)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(guests.ListRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: filter
+func (_m *Service) List(filter guests.Filter) ([]guests.Guest, error) {
+	ret := _m.Called(filter)
+
+	var r0 []guests.Guest
+	if rf, ok := ret.Get(0).(func(guests.Filter) []guests.Guest); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]guests.Guest)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(guests.Filter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RSVPCounts provides a mock function with given fields: req
+func (_m *Service) RSVPCounts(req guests.RSVPCountsRequest) (guests.RSVPCountsDTO, error) {
+	ret := _m.Called(req)
+
+	var r0 guests.RSVPCountsDTO
+	if rf, ok := ret.Get(0).(func(guests.RSVPCountsRequest) guests.RSVPCountsDTO); ok {
+		r0 = rf(req)
+	} else {
+		r0 = ret.Get(0).(guests.RSVPCountsDTO)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(guests.RSVPCountsRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reinvite provides a mock function with given fields: name
+func (_m *Service) Reinvite(name string) (invitations.InvitationResponse, error) {
+	ret := _m.Called(name)
+
+	var r0 invitations.InvitationResponse
+	if rf, ok := ret.Get(0).(func(string) invitations.InvitationResponse); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(invitations.InvitationResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Respond provides a mock function with given fields: req
+func (_m *Service) Respond(req guests.RSVPRequest) (guests.RSVPResponse, error) {
+	ret := _m.Called(req)
+
+	var r0 guests.RSVPResponse
+	if rf, ok := ret.Get(0).(func(guests.RSVPRequest) guests.RSVPResponse); ok {
+		r0 = rf(req)
+	} else {
+		r0 = ret.Get(0).(guests.RSVPResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(guests.RSVPRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Scan provides a mock function with given fields: req
+func (_m *Service) Scan(req guests.ScanRequest) (guests.CheckInResponse, error) {
+	ret := _m.Called(req)
+
+	var r0 guests.CheckInResponse
+	if rf, ok := ret.Get(0).(func(guests.ScanRequest) guests.CheckInResponse); ok {
+		r0 = rf(req)
+	} else {
+		r0 = ret.Get(0).(guests.CheckInResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(guests.ScanRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Search provides a mock function with given fields: request
+func (_m *Service) Search(request guests.SearchRequest) (guests.SearchDTO, error) {
+	ret := _m.Called(request)
+
+	var r0 guests.SearchDTO
+	if rf, ok := ret.Get(0).(func(guests.SearchRequest) guests.SearchDTO); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Get(0).(guests.SearchDTO)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(guests.SearchRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Uninvite provides a mock function with given fields: name
+func (_m *Service) Uninvite(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WalkIn provides a mock function with given fields: req
+func (_m *Service) WalkIn(req guests.WalkInRequest) (guests.WalkInResponse, error) {
+	ret := _m.Called(req)
+
+	var r0 guests.WalkInResponse
+	if rf, ok := ret.Get(0).(func(guests.WalkInRequest) guests.WalkInResponse); ok {
+		r0 = rf(req)
+	} else {
+		r0 = ret.Get(0).(guests.WalkInResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(guests.WalkInRequest) error); ok {
+		r1 = rf(req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -5,6 +5,8 @@ package mocks
 import (
 	tables "github.com/getground/tech-tasks/backend/definitions/tables"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -26,20 +28,20 @@ func (_m *Repository) CountEmptySeats() int {
 	return r0
 }
 
-// Create provides a mock function with given fields: request
-func (_m *Repository) Create(request tables.CreateRequest) (tables.Table, error) {
-	ret := _m.Called(request)
+// Create provides a mock function with given fields: request, at
+func (_m *Repository) Create(request tables.CreateRequest, at time.Time) (tables.Table, error) {
+	ret := _m.Called(request, at)
 
 	var r0 tables.Table
-	if rf, ok := ret.Get(0).(func(tables.CreateRequest) tables.Table); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(tables.CreateRequest, time.Time) tables.Table); ok {
+		r0 = rf(request, at)
 	} else {
 		r0 = ret.Get(0).(tables.Table)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(tables.CreateRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(tables.CreateRequest, time.Time) error); ok {
+		r1 = rf(request, at)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -47,6 +49,38 @@ func (_m *Repository) Create(request tables.CreateRequest) (tables.Table, error)
 	return r0, r1
 }
 
+// ForActor provides a mock function with given fields: actor
+func (_m *Repository) ForActor(actor string) tables.Repository {
+	ret := _m.Called(actor)
+
+	var r0 tables.Repository
+	if rf, ok := ret.Get(0).(func(string) tables.Repository); ok {
+		r0 = rf(actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(tables.Repository)
+		}
+	}
+
+	return r0
+}
+
+// ForEvent provides a mock function with given fields: event
+func (_m *Repository) ForEvent(event uint) tables.Repository {
+	ret := _m.Called(event)
+
+	var r0 tables.Repository
+	if rf, ok := ret.Get(0).(func(uint) tables.Repository); ok {
+		r0 = rf(event)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(tables.Repository)
+		}
+	}
+
+	return r0
+}
+
 // GetByID provides a mock function with given fields: id
 func (_m *Repository) GetByID(id uint) (tables.Table, error) {
 	ret := _m.Called(id)
@@ -68,6 +102,71 @@ func (_m *Repository) GetByID(id uint) (tables.Table, error) {
 	return r0, r1
 }
 
+// List provides a mock function with given fields:
+func (_m *Repository) List() ([]tables.Table, error) {
+	ret := _m.Called()
+
+	var r0 []tables.Table
+	if rf, ok := ret.Get(0).(func() []tables.Table); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]tables.Table)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPage provides a mock function with given fields: request
+func (_m *Repository) ListPage(request tables.ListRequest) (tables.Page, error) {
+	ret := _m.Called(request)
+
+	var r0 tables.Page
+	if rf, ok := ret.Get(0).(func(tables.ListRequest) tables.Page); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Get(0).(tables.Page)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(tables.ListRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Resize provides a mock function with given fields: id, seats, version, at
+func (_m *Repository) Resize(id uint, seats int64, version int64, at time.Time) (tables.Table, error) {
+	ret := _m.Called(id, seats, version, at)
+
+	var r0 tables.Table
+	if rf, ok := ret.Get(0).(func(uint, int64, int64, time.Time) tables.Table); ok {
+		r0 = rf(id, seats, version, at)
+	} else {
+		r0 = ret.Get(0).(tables.Table)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint, int64, int64, time.Time) error); ok {
+		r1 = rf(id, seats, version, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewRepository interface {
 	mock.TestingT
 	Cleanup(func())
@@ -47,6 +47,59 @@ func (_m *Service) Create(request tables.CreateRequest) (tables.CreateResponse,
 	return r0, r1
 }
 
+// ForActor provides a mock function with given fields: actor
+func (_m *Service) ForActor(actor string) tables.Service {
+	ret := _m.Called(actor)
+
+	var r0 tables.Service
+	if rf, ok := ret.Get(0).(func(string) tables.Service); ok {
+		r0 = rf(actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(tables.Service)
+		}
+	}
+
+	return r0
+}
+
+// ForEvent provides a mock function with given fields: event
+func (_m *Service) ForEvent(event uint) tables.Service {
+	ret := _m.Called(event)
+
+	var r0 tables.Service
+	if rf, ok := ret.Get(0).(func(uint) tables.Service); ok {
+		r0 = rf(event)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(tables.Service)
+		}
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: id
+func (_m *Service) Get(id uint) (tables.TableDTO, error) {
+	ret := _m.Called(id)
+
+	var r0 tables.TableDTO
+	if rf, ok := ret.Get(0).(func(uint) tables.TableDTO); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(tables.TableDTO)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByID provides a mock function with given fields: id
 func (_m *Service) GetByID(id uint) (tables.Table, error) {
 	ret := _m.Called(id)
@@ -68,6 +121,71 @@ func (_m *Service) GetByID(id uint) (tables.Table, error) {
 	return r0, r1
 }
 
+// GetTables provides a mock function with given fields: request
+func (_m *Service) GetTables(request tables.ListRequest) (tables.ListDTO, error) {
+	ret := _m.Called(request)
+
+	var r0 tables.ListDTO
+	if rf, ok := ret.Get(0).(func(tables.ListRequest) tables.ListDTO); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Get(0).(tables.ListDTO)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(tables.ListRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields:
+func (_m *Service) List() ([]tables.Table, error) {
+	ret := _m.Called()
+
+	var r0 []tables.Table
+	if rf, ok := ret.Get(0).(func() []tables.Table); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]tables.Table)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Resize provides a mock function with given fields: request
+func (_m *Service) Resize(request tables.ResizeRequest) (tables.TableDTO, error) {
+	ret := _m.Called(request)
+
+	var r0 tables.TableDTO
+	if rf, ok := ret.Get(0).(func(tables.ResizeRequest) tables.TableDTO); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Get(0).(tables.TableDTO)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(tables.ResizeRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewService interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"context"
+	"github.com/getground/tech-tasks/backend/definitions/attendance"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Occupancy calls GET /attendance/occupancy, the occupancy of now is returned when req.At is nil.
+func (c *Client) Occupancy(ctx context.Context, req attendance.OccupancyRequest) (res attendance.OccupancyDTO, err error) {
+	q := url.Values{}
+	if req.At != nil {
+		q.Set("at", req.At.Format(time.RFC3339))
+	}
+	if req.Table != 0 {
+		q.Set("table", strconv.FormatUint(uint64(req.Table), 10))
+	}
+	err = c.do(ctx, http.MethodGet, c.prefix+"/attendance/occupancy"+encodeQuery(q), nil, &res)
+	return
+}
+
+// AttendanceReport calls GET /attendance/report, the server groups by its default bucket when req.Bucket is zero.
+func (c *Client) AttendanceReport(ctx context.Context, req attendance.ReportRequest) (res attendance.ReportDTO, err error) {
+	q := url.Values{}
+	if req.Bucket != 0 {
+		q.Set("bucket", req.Bucket.String())
+	}
+	err = c.do(ctx, http.MethodGet, c.prefix+"/attendance/report"+encodeQuery(q), nil, &res)
+	return
+}
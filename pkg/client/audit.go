@@ -0,0 +1,31 @@
+package client
+
+import (
+	"context"
+	"github.com/getground/tech-tasks/backend/definitions/audit"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// GetAudit calls GET /audit.
+func (c *Client) GetAudit(ctx context.Context, req audit.ListRequest) (res audit.ListDTO, err error) {
+	q := pageQuery(req.Request)
+	if req.Guest != "" {
+		q.Set("guest", req.Guest)
+	}
+	if req.Table != 0 {
+		q.Set("table", strconv.FormatUint(uint64(req.Table), 10))
+	}
+	if req.Actor != "" {
+		q.Set("actor", req.Actor)
+	}
+	if req.From != nil {
+		q.Set("from", req.From.Format(time.RFC3339))
+	}
+	if req.To != nil {
+		q.Set("to", req.To.Format(time.RFC3339))
+	}
+	err = c.do(ctx, http.MethodGet, c.prefix+"/audit"+encodeQuery(q), nil, &res)
+	return
+}
@@ -0,0 +1,294 @@
+// Package client is a typed Go client for the party service HTTP API.
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/getground/tech-tasks/backend/definitions/audit"
+	"github.com/getground/tech-tasks/backend/definitions/etag"
+	"github.com/getground/tech-tasks/backend/definitions/idempotency"
+	"github.com/getground/tech-tasks/backend/definitions/pagination"
+	"github.com/getground/tech-tasks/backend/definitions/ratelimit"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTimeout = 10 * time.Second
+	defaultBackoff = 100 * time.Millisecond
+)
+
+// Error is returned for every non 2xx response of the API.
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("party api: %d %s", e.StatusCode, e.Message)
+}
+
+// IsStatus reports whether err is an API error with the given status code.
+func IsStatus(err error, code int) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == code
+}
+
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	retries    int
+	backoff    time.Duration
+	// prefix nests the paths of the event scoped endpoints, the default event of the server is used when empty
+	prefix string
+	actor  string
+	apiKey string
+}
+
+type Option func(*Client)
+
+// WithHTTPClient replaces the default http client, e.g. to set a transport or a timeout.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRetries retries a request up to max extra times when the server could not be reached or answered with
+// 429, 502, 503 or 504. The wait between attempts starts at backoff and doubles every attempt, a longer Retry-After of
+// the server is waited instead. The POST, PUT and DELETE requests are sent with an idempotency key so a change the
+// server made before the response got lost isn't made again, a request that may change something twice is only
+// retried after a 429.
+func WithRetries(max int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.retries = max
+		c.backoff = backoff
+	}
+}
+
+// WithActor names the person or the system the changes are made by in the audit log of the server, the address of the
+// client is recorded otherwise.
+func WithActor(actor string) Option {
+	return func(c *Client) {
+		c.actor = actor
+	}
+}
+
+// WithAPIKey sends the key of the client, the server limits the requests of every key instead of every address.
+func WithAPIKey(key string) Option {
+	return func(c *Client) {
+		c.apiKey = key
+	}
+}
+
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid base url %q", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		backoff:    defaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// ForEvent returns a copy of the client calling the tables, guests and waitlist endpoints of the given event. The
+// invitation token endpoints are not nested, the token tells the event.
+func (c *Client) ForEvent(event uint) *Client {
+	scoped := *c
+	scoped.prefix = "/events/" + strconv.FormatUint(uint64(event), 10)
+	return &scoped
+}
+
+// do sends the request and decodes the response body into out when out is not nil, a *[]byte out receives the raw
+// body.
+func (c *Client) do(ctx context.Context, method, path string, in, out interface{}) error {
+	return c.doIfMatch(ctx, method, path, 0, in, out)
+}
+
+// doIfMatch sends the request like do with the version in the If-Match header, the server answers 412 when the
+// resource changed since. A zero version sends no header.
+func (c *Client) doIfMatch(ctx context.Context, method, path string, version int64, in, out interface{}) error {
+	var body []byte
+	if in != nil {
+		var err error
+		body, err = json.Marshal(in)
+		if err != nil {
+			return err
+		}
+	}
+
+	var key string
+	if c.retries > 0 && method != http.MethodGet {
+		var err error
+		key, err = newIdempotencyKey()
+		if err != nil {
+			return err
+		}
+	}
+
+	backoff := c.backoff
+	for attempt := 0; ; attempt++ {
+		res, err := c.send(ctx, method, path, body, key, version)
+		if !retryable(method, key, res, err) || attempt >= c.retries {
+			if err != nil {
+				return err
+			}
+			return decode(res, out)
+		}
+		wait := backoff
+		if res != nil {
+			if after := retryAfter(res); after > wait {
+				wait = after
+			}
+			drain(res)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+func (c *Client) send(
+	ctx context.Context, method, path string, body []byte, key string, version int64,
+) (*http.Response, error) {
+	var reader io.Reader = http.NoBody
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL.String()+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.actor != "" {
+		req.Header.Set(audit.ActorHeader, c.actor)
+	}
+	if c.apiKey != "" {
+		req.Header.Set(ratelimit.KeyHeader, c.apiKey)
+	}
+	if key != "" {
+		req.Header.Set(idempotency.Header, key)
+	}
+	if version != 0 {
+		req.Header.Set(etag.IfMatchHeader, etag.Format(version))
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.httpClient.Do(req)
+}
+
+// retryable reports whether the request is sent again. The rate limiter answers 429 before the request reaches the
+// routes, so every request is retried then. The other failures may come after the server made the change, so only
+// the requests that can't make it twice are retried: the GET and HEAD requests and the ones with an idempotency key.
+func retryable(method, key string, res *http.Response, err error) bool {
+	if res != nil && res.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if method != http.MethodGet && method != http.MethodHead && key == "" {
+		return false
+	}
+	if err != nil {
+		var urlErr *url.Error
+		// context cancellation and deadlines are final, everything else is a transport failure
+		return errors.As(err, &urlErr) && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	switch res.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter returns the wait the server asked for in seconds, zero when it asked for none.
+func retryAfter(res *http.Response) time.Duration {
+	s, err := strconv.Atoi(res.Header.Get(ratelimit.RetryAfterHeader))
+	if err != nil || s < 0 {
+		return 0
+	}
+	return time.Duration(s) * time.Second
+}
+
+func newIdempotencyKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func decode(res *http.Response, out interface{}) error {
+	defer drain(res)
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return readError(res)
+	}
+	if out == nil || res.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if raw, ok := out.(*[]byte); ok {
+		var err error
+		*raw, err = io.ReadAll(res.Body)
+		return err
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+func readError(res *http.Response) error {
+	apiErr := &Error{StatusCode: res.StatusCode, Message: http.StatusText(res.StatusCode)}
+	var body struct {
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err == nil && body.Error != "" {
+		apiErr.Message = body.Error
+	}
+	return apiErr
+}
+
+func drain(res *http.Response) {
+	_, _ = io.Copy(io.Discard, res.Body)
+	_ = res.Body.Close()
+}
+
+func pageQuery(req pagination.Request) url.Values {
+	q := url.Values{}
+	if req.Cursor != "" {
+		q.Set("cursor", req.Cursor)
+	}
+	if req.Limit > 0 {
+		q.Set("limit", strconv.Itoa(req.Limit))
+	}
+	return q
+}
+
+func encodeQuery(q url.Values) string {
+	if len(q) == 0 {
+		return ""
+	}
+	return "?" + q.Encode()
+}
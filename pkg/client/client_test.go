@@ -0,0 +1,1793 @@
+package client_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/getground/tech-tasks/backend/boot"
+	"github.com/getground/tech-tasks/backend/config"
+	attendanceDef "github.com/getground/tech-tasks/backend/definitions/attendance"
+	auditDef "github.com/getground/tech-tasks/backend/definitions/audit"
+	eventsDef "github.com/getground/tech-tasks/backend/definitions/events"
+	guestsDef "github.com/getground/tech-tasks/backend/definitions/guests"
+	idempotencyDef "github.com/getground/tech-tasks/backend/definitions/idempotency"
+	invitationsDef "github.com/getground/tech-tasks/backend/definitions/invitations"
+	"github.com/getground/tech-tasks/backend/definitions/pagination"
+	ratelimitDef "github.com/getground/tech-tasks/backend/definitions/ratelimit"
+	tablesDef "github.com/getground/tech-tasks/backend/definitions/tables"
+	venueDef "github.com/getground/tech-tasks/backend/definitions/venue"
+	waitlistDef "github.com/getground/tech-tasks/backend/definitions/waitlist"
+	webhooksDef "github.com/getground/tech-tasks/backend/definitions/webhooks"
+	"github.com/getground/tech-tasks/backend/pkg/client"
+	"github.com/getground/tech-tasks/backend/pkg/database"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type serverMocks struct {
+	db      *sql.DB
+	sqlMock sqlmock.Sqlmock
+	server  *httptest.Server
+}
+
+// setupServer starts boot.API behind an httptest server, wrapped by the given middleware when not nil.
+func setupServer(t *testing.T, wrap func(http.Handler) http.Handler, opts ...client.Option) (*client.Client, serverMocks) {
+	db, m, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	msc := mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true})
+	gDB, err := database.NewDatabaseForTests(msc)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating grom database connection", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	cfg := config.API{RateLimit: config.RateLimit{Keys: []string{"organiser"}}}
+	var handler http.Handler = boot.API(cfg, boot.NewServices(cfg, gDB))
+	if wrap != nil {
+		handler = wrap(handler)
+	}
+	server := httptest.NewServer(handler)
+
+	c, err := client.New(server.URL, opts...)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating the client", err)
+	}
+	t.Cleanup(
+		func() {
+			server.Close()
+			db.Close()
+		},
+	)
+	return c, serverMocks{db: db, sqlMock: m, server: server}
+}
+
+func TestNew(t *testing.T) {
+	t.Run(
+		"invalid url", func(t *testing.T) {
+			c, err := client.New("localhost:3000")
+
+			assert.Error(t, err)
+			assert.Nil(t, c)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			c, err := client.New("http://localhost:3000/")
+
+			assert.NoError(t, err)
+			assert.NotNil(t, c)
+		},
+	)
+}
+
+func TestClient_Ping(t *testing.T) {
+	c, _ := setupServer(t, nil)
+
+	err := c.Ping(context.Background())
+
+	assert.NoError(t, err)
+}
+
+func TestClient_CreateTable(t *testing.T) {
+	t.Run(
+		"validation error", func(t *testing.T) {
+			c, m := setupServer(t, nil)
+
+			res, err := c.CreateTable(context.Background(), tablesDef.CreateRequest{})
+
+			assert.True(t, client.IsStatus(err, http.StatusBadRequest))
+			assert.Empty(t, res)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			c, m := setupServer(t, nil, client.WithActor("alice"))
+
+			// mocks
+			q := "INSERT INTO `tables` (`event_id`,`capacity`,`empty_seats`,`version`) VALUES (?,?,?,?)"
+			expectEvent(m, eventsDef.StatusPlanning)
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).WithArgs(0, 10, 10, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+			expectAudit(m, "alice", auditDef.ActionTableCreated)
+			expectTrack(m, attendanceDef.TypeTableCreated)
+			m.sqlMock.ExpectCommit()
+			expectWaiting(m, 1, 10, 10)
+
+			res, err := c.CreateTable(context.Background(), tablesDef.CreateRequest{Capacity: 10})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tablesDef.CreateResponse{ID: 1, Capacity: 10}, res)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
+
+func TestClient_ListTables(t *testing.T) {
+	c, m := setupServer(t, nil)
+
+	// mocks
+	count := "SELECT count(*) FROM `tables` WHERE event_id = ? AND empty_seats >= ?"
+	q := "SELECT * FROM `tables` WHERE event_id = ? AND empty_seats >= ? ORDER BY id"
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(count)).
+		WithArgs(0, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+		WithArgs(0, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "capacity", "empty_seats"}).AddRow(1, 4, 6))
+
+	res, err := c.ListTables(context.Background(), tablesDef.ListRequest{MinEmptySeats: 2})
+
+	expected := tablesDef.ListDTO{
+		Tables: []tablesDef.TableDTO{{ID: 1, Capacity: 4, EmptySeats: 6}},
+		Total:  1,
+	}
+	assert.NoError(t, err)
+	assert.Equal(t, expected, res)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestClient_CountEmptySeats(t *testing.T) {
+	c, m := setupServer(t, nil)
+
+	// mocks
+	q := "SELECT SUM(empty_seats) FROM `tables` WHERE event_id = ?"
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(7))
+
+	count, err := c.CountEmptySeats(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, count)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestClient_AddToGuestList(t *testing.T) {
+	tableQuery := "SELECT * FROM `tables` WHERE event_id = ? AND `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1"
+	tColumns := []string{"id", "capacity", "empty_seats"}
+
+	t.Run(
+		"no capacity", func(t *testing.T) {
+			c, m := setupServer(t, nil)
+			req := guestsDef.CreateRequest{Name: "sam smith", Table: 1, Accompanying: 5}
+
+			// mocks
+			expectEvent(m, eventsDef.StatusPlanning)
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(tableQuery)).
+				WithArgs(0, 1).
+				WillReturnRows(sqlmock.NewRows(tColumns).AddRow(1, 3, 3))
+
+			res, err := c.AddToGuestList(context.Background(), req)
+
+			var apiErr *client.Error
+			assert.True(t, errors.As(err, &apiErr))
+			assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+			assert.Equal(t, "table have no capacity for accompanying", apiErr.Message)
+			assert.Empty(t, res)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			c, m := setupServer(t, nil)
+			req := guestsDef.CreateRequest{Name: "sam smith", Table: 1, Accompanying: 2}
+
+			// mocks
+			createGuest := "INSERT INTO `guests` (`name`,`table_id`,`accompanying`,`time_arrived`,`checked_out`,`rsvp`," +
+				"`walk_in`,`event_id`,`version`,`email`,`phone`,`department`,`dietary`,`dietary_notes`," +
+				"`accessibility`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)"
+			expectEvent(m, eventsDef.StatusPlanning)
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(tableQuery)).
+				WithArgs(0, 1).
+				WillReturnRows(sqlmock.NewRows(tColumns).AddRow(1, 10, 10))
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(createGuest)).
+				WithArgs(
+					req.Name, req.Table, req.Accompanying, nil, 0, guestsDef.RSVPInvited, false, 0, 1, "", "", "", "", "", "",
+				).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			expectAudit(m, anonymous, auditDef.ActionGuestCreated)
+			expectTrack(m, attendanceDef.TypeGuestInvited)
+			m.sqlMock.ExpectCommit()
+			expectIssue(m, req.Name)
+
+			res, err := c.AddToGuestList(context.Background(), req)
+
+			assert.NoError(t, err)
+			assert.Equal(t, req.Name, res.Name)
+			assert.NotEmpty(t, res.Invitation)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
+
+func TestClient_GetGuestList(t *testing.T) {
+	c, m := setupServer(t, nil)
+
+	// mocks
+	count := "SELECT count(*) FROM `guests` WHERE event_id = ? AND name LIKE ?"
+	q := "SELECT * FROM `guests` WHERE event_id = ? AND name LIKE ? ORDER BY name ASC LIMIT 2"
+	gColumns := []string{"name", "table_id", "accompanying", "time_arrived", "checked_out"}
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(count)).
+		WithArgs(0, "sam%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+		WithArgs(0, "sam%").
+		WillReturnRows(
+			sqlmock.NewRows(gColumns).
+				AddRow("sam jones", 2, 0, nil, 0).
+				AddRow("sam smith", 1, 2, nil, 0),
+		)
+	expectCompanions(m, "sam jones")
+
+	req := guestsDef.ListRequest{Filter: guestsDef.Filter{NamePrefix: "sam"}}
+	req.Limit = 1
+	res, err := c.GetGuestList(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []guestsDef.GuestListDTO{{Name: "sam jones", Table: 2}}, res.Guests)
+	assert.Equal(t, int64(3), res.Total)
+	assert.NotEmpty(t, res.NextCursor)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestClient_GetGuest(t *testing.T) {
+	c, m := setupServer(t, nil)
+
+	// mocks
+	q := "SELECT * FROM `guests` WHERE event_id = ? AND name = ? ORDER BY name"
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+		WithArgs(0, "sam smith").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"name", "table_id", "accompanying", "rsvp", "version"}).
+				AddRow("sam smith", 1, 2, guestsDef.RSVPAccepted, 4),
+		)
+	expectCompanions(m, "sam smith")
+
+	res, err := c.GetGuest(context.Background(), "sam smith")
+
+	expected := guestsDef.GuestListDTO{
+		Name: "sam smith", Table: 1, Accompanying: 2, RSVP: guestsDef.RSVPAccepted, Version: 4,
+	}
+	assert.NoError(t, err)
+	assert.Equal(t, expected, res)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestClient_EditCompanions(t *testing.T) {
+	c, m := setupServer(t, nil)
+	name := "sam smith"
+	guestQuery := "SELECT * FROM `guests` WHERE event_id = ? AND name = ? AND time_arrived IS NULL ORDER BY `guests`.`name` LIMIT 1"
+	tableQuery := "SELECT * FROM `tables` WHERE event_id = ? AND `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1"
+	editedQuery := "SELECT * FROM `guests` WHERE event_id = ? AND name = ? ORDER BY `guests`.`name` LIMIT 1"
+	guestTable := "SELECT * FROM `tables` WHERE `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1"
+	updateGuest := "UPDATE `guests` SET `accompanying`=?,`version`=version + 1 WHERE event_id = ? AND name = ? " +
+		"AND version = ?"
+	deleteCompanions := "DELETE FROM `companions` WHERE event_id = ? AND guest_name = ?"
+	createCompanions := "INSERT INTO `companions` (`event_id`,`guest_name`,`name`,`dietary`,`dietary_notes`) " +
+		"VALUES (?,?,?,?,?),(?,?,?,?,?)"
+	updateTable := "UPDATE `tables` SET `capacity`=?,`version`=version + 1 WHERE id = ? AND version = ?"
+	gColumns := []string{"name", "table_id", "accompanying", "rsvp", "version"}
+
+	// mocks
+	expectEvent(m, eventsDef.StatusPlanning)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(guestQuery)).
+		WithArgs(0, name).
+		WillReturnRows(sqlmock.NewRows(gColumns).AddRow(name, 1, 1, guestsDef.RSVPAccepted, 4))
+	expectCompanions(m, name)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(tableQuery)).
+		WithArgs(0, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "capacity", "empty_seats"}).AddRow(1, 5, 10))
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(editedQuery)).
+		WithArgs(0, name).
+		WillReturnRows(sqlmock.NewRows(gColumns).AddRow(name, 1, 1, guestsDef.RSVPAccepted, 4))
+	expectCompanions(m, name)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(guestTable)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "capacity", "empty_seats"}).AddRow(1, 5, 10))
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(updateGuest)).
+		WithArgs(2, 0, name, 4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(deleteCompanions)).
+		WithArgs(0, name).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(createCompanions)).
+		WithArgs(0, name, "ana", "vegan", "", 0, name, "ben", "", "").
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	// the second companion takes one more seat
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(updateTable)).
+		WithArgs(4, 1, 0).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectAudit(m, anonymous, auditDef.ActionGuestEdited)
+	expectTrack(m, attendanceDef.TypeGuestResized)
+	m.sqlMock.ExpectCommit()
+
+	res, err := c.EditCompanions(
+		context.Background(),
+		guestsDef.CompanionsRequest{
+			Name:       name,
+			Companions: []guestsDef.CompanionDTO{{Name: "ana", Dietary: "vegan"}, {Name: "ben"}},
+			Version:    4,
+		},
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), res.Accompanying)
+	assert.Equal(t, []guestsDef.CompanionDTO{{Name: "ana", Dietary: "vegan"}, {Name: "ben"}}, res.Companions)
+	assert.Equal(t, int64(5), res.Version)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestClient_CheckIn(t *testing.T) {
+	guestQuery := "SELECT * FROM `guests` WHERE event_id = ? AND name = ? AND time_arrived IS NULL ORDER BY `guests`.`name` LIMIT 1"
+	gColumns := []string{"name", "table_id", "accompanying", "time_arrived", "checked_out", "rsvp"}
+
+	t.Run(
+		"not invited", func(t *testing.T) {
+			c, m := setupServer(t, nil)
+			req := guestsDef.CheckInRequest{Name: "sam smith", Accompanying: 2}
+
+			// mocks
+			expectEvent(m, eventsDef.StatusDoorsOpen)
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(guestQuery)).
+				WithArgs(0, req.Name).
+				WillReturnRows(sqlmock.NewRows(gColumns))
+
+			res, err := c.CheckIn(context.Background(), req)
+
+			assert.True(t, client.IsStatus(err, http.StatusInternalServerError))
+			assert.EqualError(t, err, "party api: 500 guest not invited or already checked in")
+			assert.Empty(t, res)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			c, m := setupServer(t, nil)
+			req := guestsDef.CheckInRequest{Name: "sam smith", Accompanying: 2}
+
+			// mocks
+			tableQuery := "SELECT * FROM `tables` WHERE event_id = ? AND `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1"
+			updateGuest := "UPDATE `guests` SET `accompanying`=?,`time_arrived`=?,`version`=version + 1 WHERE event_id = ? AND " +
+				"name = ? AND version = ?"
+			updateTable := "UPDATE `tables` SET `capacity`=?,`empty_seats`=?,`version`=version + 1 WHERE id = ? AND version = ?"
+			expectEvent(m, eventsDef.StatusDoorsOpen)
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(guestQuery)).
+				WithArgs(0, req.Name).
+				WillReturnRows(sqlmock.NewRows(gColumns).AddRow(req.Name, 1, 2, nil, 0, guestsDef.RSVPAccepted))
+			expectCompanions(m, req.Name)
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(tableQuery)).
+				WithArgs(0, 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "capacity", "empty_seats"}).AddRow(1, 7, 10))
+			m.sqlMock.ExpectBegin()
+			expectAdmit(m)
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(updateGuest)).
+				WithArgs(req.Accompanying, sqlmock.AnyArg(), 0, req.Name, 0).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(updateTable)).
+				WithArgs(7, 7, 1, 0).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			expectAudit(m, anonymous, auditDef.ActionGuestCheckedIn)
+			expectTrack(m, attendanceDef.TypeGuestCheckedIn)
+			m.sqlMock.ExpectCommit()
+			expectPeak(m, 3)
+
+			res, err := c.CheckIn(context.Background(), req)
+
+			assert.NoError(t, err)
+			assert.Equal(t, guestsDef.CheckInResponse{Name: req.Name}, res)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
+
+func TestClient_WalkIn(t *testing.T) {
+	c, m := setupServer(t, nil)
+
+	// mocks
+	countGuest := "SELECT count(*) FROM `guests` WHERE event_id = ? AND name = ?"
+	findTable := "SELECT * FROM `tables` WHERE event_id = ? AND empty_seats >= ? ORDER BY empty_seats,id LIMIT 1 FOR UPDATE"
+	createGuest := "INSERT INTO `guests` (`name`,`table_id`,`accompanying`,`time_arrived`,`checked_out`,`rsvp`," +
+		"`walk_in`,`event_id`,`version`,`email`,`phone`,`department`,`dietary`,`dietary_notes`," +
+		"`accessibility`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)"
+	updateTable := "UPDATE `tables` SET `capacity`=?,`empty_seats`=?,`version`=version + 1 WHERE id = ? AND version = ?"
+	expectEvent(m, eventsDef.StatusInProgress)
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(countGuest)).
+		WithArgs(0, "sam").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	expectAdmit(m)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(findTable)).
+		WithArgs(0, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "capacity", "empty_seats"}).AddRow(3, 4, 4))
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(createGuest)).
+		WithArgs("sam", 3, 1, sqlmock.AnyArg(), 0, guestsDef.RSVPAccepted, true, 0, 1, "", "", "", "", "", "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(updateTable)).
+		WithArgs(2, 2, 3, 0).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectAudit(m, anonymous, auditDef.ActionGuestWalkedIn)
+	expectTrack(m, attendanceDef.TypeGuestCheckedIn)
+	m.sqlMock.ExpectCommit()
+	expectPeak(m, 2)
+
+	res, err := c.WalkIn(context.Background(), guestsDef.WalkInRequest{Name: "sam", Accompanying: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, guestsDef.WalkInResponse{Name: "sam", Table: 3}, res)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestClient_GetGuests(t *testing.T) {
+	c, m := setupServer(t, nil)
+
+	// mocks
+	timeArrived := time.Date(2022, 12, 16, 20, 0, 0, 0, time.UTC)
+	count := "SELECT count(*) FROM `guests` WHERE event_id = ? AND time_arrived IS NOT NULL"
+	q := "SELECT * FROM `guests` WHERE event_id = ? AND time_arrived IS NOT NULL ORDER BY name ASC"
+	gColumns := []string{"name", "table_id", "accompanying", "time_arrived", "checked_out"}
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(count)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+		WillReturnRows(sqlmock.NewRows(gColumns).AddRow("sam smith", 1, 2, timeArrived, 0))
+	expectCompanions(m, "sam smith")
+
+	res, err := c.GetGuests(context.Background(), guestsDef.ListRequest{})
+
+	expected := guestsDef.DTO{
+		Guests: []guestsDef.GuestDTO{{Name: "sam smith", Accompanying: 2, TimeArrived: "2022-12-16T20:00:00Z"}},
+		Total:  1,
+	}
+	assert.NoError(t, err)
+	assert.Equal(t, expected, res)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestClient_SearchGuests(t *testing.T) {
+	c, m := setupServer(t, nil)
+
+	// mocks
+	q := "SELECT * FROM `guests` WHERE event_id = ? ORDER BY name"
+	gColumns := []string{"name", "table_id", "accompanying", "time_arrived", "checked_out"}
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+		WillReturnRows(
+			sqlmock.NewRows(gColumns).
+				AddRow("Kim Jones", 2, 0, nil, 0).
+				AddRow("Sam Smith", 1, 2, nil, 0),
+		)
+
+	expectCompanions(m, "Kim Jones", "Sam Smith")
+	res, err := c.SearchGuests(context.Background(), guestsDef.SearchRequest{Query: "smtih, sam", Limit: 1})
+
+	expected := guestsDef.SearchDTO{
+		Guests: []guestsDef.MatchDTO{
+			{Name: "Sam Smith", Table: 1, Accompanying: 2, Status: guestsDef.StatusNotArrived, Score: 0.9},
+		},
+	}
+	assert.NoError(t, err)
+	assert.Equal(t, expected, res)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestClient_CheckOut(t *testing.T) {
+	guestQuery := "SELECT * FROM `guests` WHERE event_id = ? AND name = ? AND checked_out = 0 AND time_arrived IS NOT NULL ORDER BY `guests`.`name` LIMIT 1"
+	gColumns := []string{"name", "table_id", "accompanying", "time_arrived", "checked_out"}
+
+	t.Run(
+		"not checked in", func(t *testing.T) {
+			c, m := setupServer(t, nil)
+
+			// mocks
+			expectEvent(m, eventsDef.StatusClosed)
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(guestQuery)).
+				WithArgs(0, "sam smith").
+				WillReturnRows(sqlmock.NewRows(gColumns))
+
+			err := c.CheckOut(context.Background(), "sam smith")
+
+			assert.True(t, client.IsStatus(err, http.StatusInternalServerError))
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			c, m := setupServer(t, nil)
+
+			// mocks
+			// the table of the guest is read by id, the guest was found in the event already
+			guestTable := "SELECT * FROM `tables` WHERE `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1"
+			tableQuery := "SELECT * FROM `tables` WHERE event_id = ? AND `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1"
+			updateGuest := "UPDATE `guests` SET `checked_out`=?,`version`=version + 1 WHERE event_id = ? AND name = ? AND version = ?"
+			updateTable := "UPDATE `tables` SET `empty_seats`=?,`version`=version + 1 WHERE id = ? AND version = ?"
+			expectEvent(m, eventsDef.StatusClosed)
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(guestQuery)).
+				WithArgs(0, "sam smith").
+				WillReturnRows(sqlmock.NewRows(gColumns).AddRow("sam smith", 1, 2, time.Now(), 0))
+			expectCompanions(m, "sam smith")
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(guestTable)).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "capacity", "empty_seats"}).AddRow(1, 7, 7))
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(updateGuest)).
+				WithArgs(1, 0, "sam smith", 0).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(updateTable)).
+				WithArgs(10, 1, 0).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			expectAudit(m, anonymous, auditDef.ActionGuestCheckedOut)
+			expectTrack(m, attendanceDef.TypeGuestCheckedOut)
+			m.sqlMock.ExpectCommit()
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(tableQuery)).
+				WithArgs(0, 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "capacity", "empty_seats"}).AddRow(1, 7, 10))
+
+			err := c.CheckOut(context.Background(), "sam smith")
+
+			assert.NoError(t, err)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
+
+// expectEvent expects the status of the default event to be looked up before a change of its tables or guests.
+func expectEvent(m serverMocks, status eventsDef.Status) {
+	q := "SELECT * FROM `events` WHERE id = ? ORDER BY `events`.`id` LIMIT 1"
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+		WithArgs(0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "status"}).AddRow(0, "party", status))
+}
+
+// expectCompanions expects the companions of the guests to be looked up, none of them named any.
+func expectCompanions(m serverMocks, names ...driver.Value) {
+	q := "SELECT * FROM `companions` WHERE event_id = ? AND guest_name IN (?" + strings.Repeat(",?", len(names)-1) +
+		") ORDER BY id"
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+		WithArgs(append([]driver.Value{0}, names...)...).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "guest_name", "name", "dietary"}))
+}
+
+// anonymous is the actor of the requests sent without an actor, the address of the test client.
+const anonymous = "127.0.0.1"
+
+// expectTrack expects the record of a change in the attendance stream.
+func expectTrack(m serverMocks, typ attendanceDef.Type) {
+	q := "INSERT INTO `attendance` (`event_id`,`type`,`guest`,`table_id`,`people`,`rsvp`,`occurred_at`) " +
+		"VALUES (?,?,?,?,?,?,?)"
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).
+		WithArgs(0, typ, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+// expectAudit expects the audit entry of a change made by the actor and its outbox message.
+func expectAudit(m serverMocks, actor string, action auditDef.Action) {
+	q := "INSERT INTO `audit_log` (`event_id`,`actor`,`action`,`guest`,`table_id`,`before`,`after`,`created_at`) " +
+		"VALUES (?,?,?,?,?,?,?,?)"
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).
+		WithArgs(0, actor, action, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	outbox := "INSERT INTO `outbox` (`id`,`event_id`,`type`,`payload`,`created_at`,`dispatched_at`) VALUES (?,?,?,?,?,?)"
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(outbox)).
+		WithArgs(sqlmock.AnyArg(), 0, action, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+// expectAdmit expects the event to be locked by a check in, the event has no venue limit.
+func expectAdmit(m serverMocks) {
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `events` WHERE id = ? LIMIT 1 FOR UPDATE")).
+		WithArgs(0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "venue_limit", "staff"}).AddRow(0, 0, 0))
+}
+
+// expectLimits expects the limits of the venue to be read from the event.
+func expectLimits(m serverMocks, limit, staff int64) {
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT venue_limit AS max, staff FROM `events` WHERE id = ?")).
+		WithArgs(0).
+		WillReturnRows(sqlmock.NewRows([]string{"max", "staff"}).AddRow(limit, staff))
+}
+
+// expectPeak expects the people on site to be counted after a check in and saved as the peak of the event.
+func expectPeak(m serverMocks, headcount int64) {
+	guests := "SELECT COALESCE(SUM(accompanying + 1), 0) FROM `guests` WHERE event_id = ? AND time_arrived IS NOT NULL " +
+		"AND checked_out = 0"
+	peak := "UPDATE `events` SET `peak_headcount`=? WHERE id = ? AND peak_headcount < ?"
+	expectLimits(m, 0, 0)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(guests)).
+		WithArgs(0).
+		WillReturnRows(sqlmock.NewRows([]string{"people"}).AddRow(headcount))
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(peak)).
+		WithArgs(headcount, 0, headcount).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectCommit()
+}
+
+// expectWaiting expects the status of the event to allow promotions and the waitlist of the table to be looked up, the
+// rows returned are the parties waiting.
+func expectWaiting(m serverMocks, table uint, capacity, emptySeats int64, rows ...[]driver.Value) {
+	expectEvent(m, eventsDef.StatusPlanning)
+	tableQuery := "SELECT * FROM `tables` WHERE event_id = ? AND `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1"
+	waiting := "SELECT * FROM `waitlist` WHERE event_id = ? AND promoted_at IS NULL AND (table_id = ? OR table_id = 0) " +
+		"ORDER BY priority DESC,id"
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(tableQuery)).
+		WithArgs(0, table).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "capacity", "empty_seats"}).AddRow(table, capacity, emptySeats))
+	entries := sqlmock.NewRows(wColumns)
+	for _, r := range rows {
+		entries.AddRow(r...)
+	}
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(waiting)).WithArgs(0, table).WillReturnRows(entries)
+}
+
+var wColumns = []string{"id", "name", "table_id", "accompanying", "priority", "created_at", "promoted_at", "promoted_to"}
+
+// expectIssue expects the invitation of the guest to be stored.
+func expectIssue(m serverMocks, name string) {
+	q := "INSERT INTO `invitations` (`id`,`event_id`,`guest_name`,`created_at`,`revoked_at`,`used_at`) " +
+		"VALUES (?,?,?,?,?,?)"
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).
+		WithArgs(sqlmock.AnyArg(), 0, name, sqlmock.AnyArg(), nil, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	m.sqlMock.ExpectCommit()
+}
+
+func TestClient_Invitations(t *testing.T) {
+	c, m := setupServer(t, nil)
+	name := "sam smith"
+	guestQuery := "SELECT * FROM `guests` WHERE event_id = ? AND name = ? AND time_arrived IS NULL ORDER BY `guests`.`name` LIMIT 1"
+	invitationQuery := "SELECT * FROM `invitations` WHERE id = ? ORDER BY `invitations`.`id` LIMIT 1"
+	revoke := "UPDATE `invitations` SET `revoked_at`=? WHERE event_id = ? AND guest_name = ? AND revoked_at IS NULL"
+	gColumns := []string{"name", "table_id", "accompanying", "time_arrived", "checked_out", "rsvp"}
+	iColumns := []string{"id", "guest_name", "created_at", "revoked_at", "used_at"}
+
+	// mocks
+	expectEvent(m, eventsDef.StatusPlanning)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(guestQuery)).
+		WithArgs(0, name).
+		WillReturnRows(sqlmock.NewRows(gColumns).AddRow(name, 1, 2, nil, 0, guestsDef.RSVPAccepted))
+	expectCompanions(m, name)
+	expectEvent(m, eventsDef.StatusPlanning)
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(revoke)).
+		WithArgs(sqlmock.AnyArg(), 0, name).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectCommit()
+	expectIssue(m, name)
+
+	invitation, err := c.Reinvite(context.Background(), name)
+	if !assert.NoError(t, err) {
+		return
+	}
+	id := strings.SplitN(invitation.Token, ".", 2)[0]
+
+	t.Run(
+		"qr", func(t *testing.T) {
+			// mocks
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(invitationQuery)).
+				WithArgs(id).
+				WillReturnRows(sqlmock.NewRows(iColumns).AddRow(id, name, time.Now(), nil, nil))
+
+			res, err := c.InvitationQR(
+				context.Background(), invitationsDef.QRRequest{Token: invitation.Token, Format: invitationsDef.FormatSVG},
+			)
+
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(string(res), "<svg"))
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"forged token", func(t *testing.T) {
+			_, err := c.ScanInvitation(
+				context.Background(), guestsDef.ScanRequest{Token: id + ".forged", Accompanying: 2},
+			)
+
+			assert.EqualError(t, err, "party api: 403 invalid invitation token")
+		},
+	)
+
+	t.Run(
+		"scan", func(t *testing.T) {
+			// mocks
+			tableQuery := "SELECT * FROM `tables` WHERE event_id = ? AND `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1"
+			updateGuest := "UPDATE `guests` SET `accompanying`=?,`time_arrived`=?,`version`=version + 1 WHERE event_id = ? AND " +
+				"name = ? AND version = ?"
+			updateTable := "UPDATE `tables` SET `capacity`=?,`empty_seats`=?,`version`=version + 1 WHERE id = ? AND version = ?"
+			use := "UPDATE `invitations` SET `used_at`=? WHERE id = ? AND used_at IS NULL AND revoked_at IS NULL"
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(invitationQuery)).
+				WithArgs(id).
+				WillReturnRows(sqlmock.NewRows(iColumns).AddRow(id, name, time.Now(), nil, nil))
+			expectEvent(m, eventsDef.StatusDoorsOpen)
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(guestQuery)).
+				WithArgs(0, name).
+				WillReturnRows(sqlmock.NewRows(gColumns).AddRow(name, 1, 2, nil, 0, guestsDef.RSVPAccepted))
+			expectCompanions(m, name)
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(tableQuery)).
+				WithArgs(0, 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "capacity", "empty_seats"}).AddRow(1, 7, 10))
+			m.sqlMock.ExpectBegin()
+			expectAdmit(m)
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(updateGuest)).
+				WithArgs(2, sqlmock.AnyArg(), 0, name, 0).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(updateTable)).
+				WithArgs(7, 7, 1, 0).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			expectAudit(m, anonymous, auditDef.ActionGuestCheckedIn)
+			expectTrack(m, attendanceDef.TypeGuestCheckedIn)
+			m.sqlMock.ExpectCommit()
+			expectPeak(m, 3)
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(use)).
+				WithArgs(sqlmock.AnyArg(), id).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			m.sqlMock.ExpectCommit()
+
+			res, err := c.ScanInvitation(
+				context.Background(), guestsDef.ScanRequest{Token: invitation.Token, Accompanying: 2},
+			)
+
+			assert.NoError(t, err)
+			assert.Equal(t, guestsDef.CheckInResponse{Name: name}, res)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"scan again", func(t *testing.T) {
+			// mocks
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(invitationQuery)).
+				WithArgs(id).
+				WillReturnRows(sqlmock.NewRows(iColumns).AddRow(id, name, time.Now(), nil, time.Now()))
+
+			_, err := c.ScanInvitation(
+				context.Background(), guestsDef.ScanRequest{Token: invitation.Token, Accompanying: 2},
+			)
+
+			assert.True(t, client.IsStatus(err, http.StatusForbidden))
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"revoke", func(t *testing.T) {
+			// mocks
+			expectEvent(m, eventsDef.StatusPlanning)
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(revoke)).
+				WithArgs(sqlmock.AnyArg(), 0, name).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			m.sqlMock.ExpectCommit()
+
+			err := c.RevokeInvitation(context.Background(), name)
+
+			assert.NoError(t, err)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
+
+func TestClient_Respond(t *testing.T) {
+	c, m := setupServer(t, nil)
+	name := "sam smith"
+	guestQuery := "SELECT * FROM `guests` WHERE event_id = ? AND name = ? AND time_arrived IS NULL ORDER BY `guests`.`name` LIMIT 1"
+	gColumns := []string{"name", "table_id", "accompanying", "time_arrived", "checked_out", "rsvp"}
+
+	// mocks
+	expectEvent(m, eventsDef.StatusPlanning)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(guestQuery)).
+		WithArgs(0, name).
+		WillReturnRows(sqlmock.NewRows(gColumns).AddRow(name, 1, 2, nil, 0, guestsDef.RSVPInvited))
+	expectCompanions(m, name)
+	expectEvent(m, eventsDef.StatusPlanning)
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `invitations` SET `revoked_at`=?")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	m.sqlMock.ExpectCommit()
+	expectIssue(m, name)
+
+	invitation, err := c.Reinvite(context.Background(), name)
+	if !assert.NoError(t, err) {
+		return
+	}
+	id := strings.SplitN(invitation.Token, ".", 2)[0]
+
+	// mocks
+	invitationQuery := "SELECT * FROM `invitations` WHERE id = ? ORDER BY `invitations`.`id` LIMIT 1"
+	tableQuery := "SELECT * FROM `tables` WHERE event_id = ? AND `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1"
+	answeredQuery := "SELECT * FROM `guests` WHERE event_id = ? AND name = ? ORDER BY `guests`.`name` LIMIT 1"
+	guestTable := "SELECT * FROM `tables` WHERE `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1"
+	updateGuest := "UPDATE `guests` SET `accompanying`=?,`rsvp`=?,`version`=version + 1 WHERE event_id = ? AND name = ? " +
+		"AND version = ?"
+	updateTable := "UPDATE `tables` SET `capacity`=?,`version`=version + 1 WHERE id = ? AND version = ?"
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(invitationQuery)).
+		WithArgs(id).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "guest_name", "created_at", "revoked_at", "used_at"}).
+				AddRow(id, name, time.Now(), nil, nil),
+		)
+	expectEvent(m, eventsDef.StatusPlanning)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(guestQuery)).
+		WithArgs(0, name).
+		WillReturnRows(sqlmock.NewRows(gColumns).AddRow(name, 1, 2, nil, 0, guestsDef.RSVPInvited))
+	expectCompanions(m, name)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(tableQuery)).
+		WithArgs(0, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "capacity", "empty_seats"}).AddRow(1, 10, 10))
+	m.sqlMock.ExpectBegin()
+	// the guest and the table are read again in the transaction for the audit log
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(answeredQuery)).
+		WithArgs(0, name).
+		WillReturnRows(sqlmock.NewRows(gColumns).AddRow(name, 1, 2, nil, 0, guestsDef.RSVPInvited))
+	expectCompanions(m, name)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(guestTable)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "capacity", "empty_seats"}).AddRow(1, 10, 10))
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(updateGuest)).
+		WithArgs(1, guestsDef.RSVPAccepted, 0, name, 0).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	// the guest and one accompanying guest take two seats
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(updateTable)).
+		WithArgs(8, 1, 0).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectAudit(m, anonymous, auditDef.ActionGuestResponded)
+	expectTrack(m, attendanceDef.TypeGuestResponded)
+	m.sqlMock.ExpectCommit()
+
+	res, err := c.Respond(
+		context.Background(),
+		guestsDef.RSVPRequest{Token: invitation.Token, Response: guestsDef.RSVPAccepted, Accompanying: 1},
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, guestsDef.RSVPResponse{Name: name, Response: guestsDef.RSVPAccepted, Accompanying: 1}, res)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestClient_RSVPCounts(t *testing.T) {
+	c, m := setupServer(t, nil)
+
+	// mocks
+	q := "SELECT table_id, rsvp, COUNT(*) AS guests, SUM(accompanying + 1) AS people FROM `guests` " +
+		"WHERE event_id = ? AND table_id = ? GROUP BY table_id, rsvp ORDER BY table_id"
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+		WithArgs(0, 1).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"table_id", "rsvp", "guests", "people"}).
+				AddRow(1, guestsDef.RSVPAccepted, 2, 5).
+				AddRow(1, guestsDef.RSVPTentative, 1, 1),
+		)
+
+	res, err := c.RSVPCounts(context.Background(), guestsDef.RSVPCountsRequest{Table: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(
+		t, guestsDef.RSVPCountsDTO{
+			Tables: []guestsDef.TableRSVPDTO{{Table: 1, Accepted: 2, Tentative: 1, SeatsReserved: 5}},
+		}, res,
+	)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestClient_Catering(t *testing.T) {
+	c, m := setupServer(t, nil)
+	columns := []string{"table_id", "dietary", "invited", "arrived"}
+
+	// mocks
+	listed := "SELECT table_id, dietary, COUNT(*) AS invited, SUM(time_arrived IS NOT NULL) AS arrived FROM `guests` " +
+		"WHERE event_id = ? AND rsvp <> ? AND table_id = ? GROUP BY table_id, dietary"
+	named := "SELECT guests.table_id, companions.dietary, COUNT(*) AS invited"
+	unnamed := "SELECT table_id, ? AS dietary, SUM(accompanying) AS invited"
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(listed)).
+		WithArgs(0, guestsDef.RSVPDeclined, 1).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "", 1, 1).AddRow(1, guestsDef.DietHalal, 1, 0))
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(named)).
+		WithArgs(0, guestsDef.RSVPDeclined, 1).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(1, guestsDef.DietHalal, 2, 0))
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(unnamed)).
+		WithArgs(guestsDef.DietUnknown, 0, guestsDef.RSVPDeclined, 1).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(1, guestsDef.DietUnknown, 1, 1))
+
+	res, err := c.Catering(context.Background(), guestsDef.CateringRequest{Table: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(
+		t, guestsDef.CateringDTO{
+			Tables: []guestsDef.TableCateringDTO{
+				{
+					Table: 1,
+					Invited: map[guestsDef.Diet]int64{
+						guestsDef.DietNone: 1, guestsDef.DietHalal: 3, guestsDef.DietUnknown: 1,
+					},
+					Arrived: map[guestsDef.Diet]int64{guestsDef.DietNone: 1, guestsDef.DietUnknown: 1},
+				},
+			},
+		}, res,
+	)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestClient_Waitlist(t *testing.T) {
+	created := time.Date(2022, 11, 5, 18, 0, 0, 0, time.UTC)
+
+	t.Run(
+		"join and wait", func(t *testing.T) {
+			c, m := setupServer(t, nil)
+
+			// mocks
+			countGuests := "SELECT count(*) FROM `guests` WHERE event_id = ? AND name = ?"
+			countWaiting := "SELECT count(*) FROM `waitlist` WHERE event_id = ? AND name = ? AND promoted_at IS NULL"
+			tableQuery := "SELECT * FROM `tables` WHERE event_id = ? AND `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1"
+			insert := "INSERT INTO `waitlist` (`event_id`,`name`,`table_id`,`accompanying`,`priority`,`created_at`," +
+				"`promoted_at`,`promoted_to`) VALUES (?,?,?,?,?,?,?,?)"
+			entryQuery := "SELECT * FROM `waitlist` WHERE event_id = ? AND id = ? ORDER BY `waitlist`.`id` LIMIT 1"
+			expectEvent(m, eventsDef.StatusPlanning)
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(countGuests)).
+				WithArgs(0, "sam").
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(countWaiting)).
+				WithArgs(0, "sam").
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(tableQuery)).
+				WithArgs(0, 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "capacity", "empty_seats"}).AddRow(1, 1, 10))
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(insert)).
+				WithArgs(0, "sam", 1, 3, 0, sqlmock.AnyArg(), nil, 0).
+				WillReturnResult(sqlmock.NewResult(4, 1))
+			m.sqlMock.ExpectCommit()
+			expectWaiting(m, 1, 1, 10, []driver.Value{4, "sam", 1, 3, 0, created, nil, 0})
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(entryQuery)).
+				WithArgs(0, 4).
+				WillReturnRows(sqlmock.NewRows(wColumns).AddRow(4, "sam", 1, 3, 0, created, nil, 0))
+
+			res, err := c.JoinWaitlist(
+				context.Background(), waitlistDef.CreateRequest{Name: "sam", Table: 1, Accompanying: 3},
+			)
+
+			assert.NoError(t, err)
+			assert.Equal(
+				t, waitlistDef.EntryDTO{ID: 4, Name: "sam", Table: 1, Accompanying: 3, CreatedAt: created}, res,
+			)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"uninvite promotes", func(t *testing.T) {
+			c, m := setupServer(t, nil)
+
+			// mocks
+			guestQuery := "SELECT * FROM `guests` WHERE event_id = ? AND name = ? AND time_arrived IS NULL ORDER BY `guests`.`name` LIMIT 1"
+			tableQuery := "SELECT * FROM `tables` WHERE event_id = ? AND `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1"
+			deleteInvitations := "DELETE FROM `invitations` WHERE event_id = ? AND guest_name = ?"
+			deleteCompanions := "DELETE FROM `companions` WHERE event_id = ? AND guest_name = ?"
+			deleteGuest := "DELETE FROM `guests` WHERE event_id = ? AND name = ? AND version = ?"
+			updateTable := "UPDATE `tables` SET `capacity`=?,`version`=version + 1 WHERE id = ? AND version = ?"
+			insertGuest := "INSERT INTO `guests` (`name`,`table_id`,`accompanying`,`time_arrived`,`checked_out`," +
+				"`rsvp`,`walk_in`,`event_id`,`version`,`email`,`phone`,`department`,`dietary`,`dietary_notes`," +
+				"`accessibility`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)"
+			updateEntry := "UPDATE `waitlist` SET `promoted_at`=?,`promoted_to`=? WHERE `waitlist`.`id` = ?"
+			guestTable := "SELECT * FROM `tables` WHERE `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1"
+			expectEvent(m, eventsDef.StatusPlanning)
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(guestQuery)).
+				WithArgs(0, "alex").
+				WillReturnRows(
+					sqlmock.NewRows([]string{"name", "table_id", "accompanying", "time_arrived", "checked_out", "rsvp"}).
+						AddRow("alex", 1, 3, nil, 0, guestsDef.RSVPAccepted),
+				)
+			expectCompanions(m, "alex")
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(tableQuery)).
+				WithArgs(0, 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "capacity", "empty_seats"}).AddRow(1, 1, 10))
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(guestTable)).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "capacity", "empty_seats"}).AddRow(1, 1, 10))
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(deleteInvitations)).
+				WithArgs(0, "alex").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(deleteCompanions)).
+				WithArgs(0, "alex").
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(deleteGuest)).
+				WithArgs(0, "alex", 0).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(updateTable)).
+				WithArgs(5, 1, 0).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			expectAudit(m, anonymous, auditDef.ActionGuestDeleted)
+			expectTrack(m, attendanceDef.TypeGuestUninvited)
+			m.sqlMock.ExpectCommit()
+			expectWaiting(m, 1, 5, 10, []driver.Value{4, "sam", 1, 3, 0, created, nil, 0})
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(guestTable)).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "capacity", "empty_seats"}).AddRow(1, 5, 10))
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(insertGuest)).
+				WithArgs("sam", 1, 3, nil, 0, guestsDef.RSVPAccepted, false, 0, 1, "", "", "", "", "", "").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(updateTable)).
+				WithArgs(1, 1, 0).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			expectAudit(m, anonymous, auditDef.ActionGuestPromoted)
+			expectTrack(m, attendanceDef.TypeGuestInvited)
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(updateEntry)).
+				WithArgs(sqlmock.AnyArg(), 1, 4).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			m.sqlMock.ExpectCommit()
+			expectIssue(m, "sam")
+
+			err := c.Uninvite(context.Background(), "alex")
+
+			assert.NoError(t, err)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"list promoted", func(t *testing.T) {
+			c, m := setupServer(t, nil)
+			promoted := true
+
+			// mocks
+			q := "SELECT * FROM `waitlist` WHERE event_id = ? AND table_id = ? AND promoted_at IS NOT NULL " +
+				"ORDER BY priority DESC,id"
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+				WithArgs(0, 1).
+				WillReturnRows(sqlmock.NewRows(wColumns).AddRow(4, "sam", 1, 3, 0, created, created, 1))
+
+			res, err := c.GetWaitlist(context.Background(), waitlistDef.ListRequest{Table: 1, Promoted: &promoted})
+
+			assert.NoError(t, err)
+			if assert.Len(t, res.Entries, 1) {
+				assert.Equal(t, uint(1), res.Entries[0].PromotedTo)
+			}
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"leave", func(t *testing.T) {
+			c, m := setupServer(t, nil)
+
+			// mocks
+			q := "DELETE FROM `waitlist` WHERE event_id = ? AND id = ? AND promoted_at IS NULL"
+			expectEvent(m, eventsDef.StatusPlanning)
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).WithArgs(0, 4).WillReturnResult(sqlmock.NewResult(0, 0))
+			m.sqlMock.ExpectCommit()
+
+			err := c.LeaveWaitlist(context.Background(), 4)
+
+			assert.True(t, client.IsStatus(err, http.StatusNotFound))
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
+
+func TestClient_GetTable(t *testing.T) {
+	c, m := setupServer(t, nil)
+
+	// mocks
+	q := "SELECT * FROM `tables` WHERE event_id = ? AND `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1"
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+		WithArgs(0, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "capacity", "empty_seats", "version"}).AddRow(1, 4, 6, 3))
+
+	res, err := c.GetTable(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, tablesDef.TableDTO{ID: 1, Capacity: 4, EmptySeats: 6, Version: 3}, res)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestClient_ResizeTableStale(t *testing.T) {
+	c, m := setupServer(t, nil)
+
+	// mocks
+	// the table was resized by another organiser since version 2 was read
+	selectTable := "SELECT * FROM `tables` WHERE event_id = ? AND `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1 " +
+		"FOR UPDATE"
+	expectEvent(m, eventsDef.StatusPlanning)
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(selectTable)).
+		WithArgs(0, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "capacity", "empty_seats", "version"}).AddRow(1, 2, 4, 3))
+	m.sqlMock.ExpectRollback()
+
+	res, err := c.ResizeTable(context.Background(), tablesDef.ResizeRequest{ID: 1, Capacity: 8, Version: 2})
+
+	assert.True(t, client.IsStatus(err, http.StatusPreconditionFailed))
+	assert.Empty(t, res)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestClient_ResizeTable(t *testing.T) {
+	c, m := setupServer(t, nil)
+
+	// mocks
+	selectTable := "SELECT * FROM `tables` WHERE event_id = ? AND `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1 " +
+		"FOR UPDATE"
+	sumSeated := "SELECT COALESCE(SUM(accompanying + 1), 0) FROM `guests` " +
+		"WHERE table_id = ? AND time_arrived IS NOT NULL AND checked_out = 0"
+	update := "UPDATE `tables` SET `capacity`=?,`empty_seats`=?,`version`=version + 1 WHERE id = ? AND version = ?"
+	tableQuery := "SELECT * FROM `tables` WHERE event_id = ? AND `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1"
+	expectEvent(m, eventsDef.StatusPlanning)
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(selectTable)).
+		WithArgs(0, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "capacity", "empty_seats"}).AddRow(1, 2, 4))
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(sumSeated)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"seated"}).AddRow(2))
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(update)).
+		WithArgs(4, 6, 1, 0).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectAudit(m, anonymous, auditDef.ActionTableResized)
+	expectTrack(m, attendanceDef.TypeTableResized)
+	m.sqlMock.ExpectCommit()
+	expectWaiting(m, 1, 4, 6)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(tableQuery)).
+		WithArgs(0, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "capacity", "empty_seats"}).AddRow(1, 4, 6))
+
+	res, err := c.ResizeTable(context.Background(), tablesDef.ResizeRequest{ID: 1, Capacity: 8})
+
+	assert.NoError(t, err)
+	assert.Equal(t, tablesDef.TableDTO{ID: 1, Capacity: 4, EmptySeats: 6}, res)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestClient_VenueHeadcount(t *testing.T) {
+	c, m := setupServer(t, nil)
+
+	// mocks
+	guests := "SELECT COALESCE(SUM(accompanying + 1), 0) FROM `guests` WHERE event_id = ? AND time_arrived IS NOT NULL " +
+		"AND checked_out = 0"
+	expectLimits(m, 150, 5)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(guests)).
+		WithArgs(0).
+		WillReturnRows(sqlmock.NewRows([]string{"people"}).AddRow(12))
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT peak_headcount FROM `events` WHERE id = ?")).
+		WithArgs(0).
+		WillReturnRows(sqlmock.NewRows([]string{"peak_headcount"}).AddRow(20))
+
+	res, err := c.VenueHeadcount(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, venueDef.HeadcountDTO{OnSite: 17, Guests: 12, Staff: 5, Peak: 20, Limit: 150}, res)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestClient_GetAudit(t *testing.T) {
+	c, m := setupServer(t, nil)
+	from := time.Date(2023, 6, 21, 18, 0, 0, 0, time.UTC)
+
+	// mocks
+	count := "SELECT count(*) FROM `audit_log` WHERE event_id = ? AND guest = ? AND actor = ? AND created_at >= ?"
+	q := "SELECT * FROM `audit_log` WHERE event_id = ? AND guest = ? AND actor = ? AND created_at >= ? ORDER BY id LIMIT 11"
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(count)).
+		WithArgs(0, "sam", "alice", from).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+		WithArgs(0, "sam", "alice", from).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "event_id", "actor", "action", "guest", "table_id", "before", "after", "created_at"}).
+				AddRow(1, 0, "alice", auditDef.ActionGuestResponded, "sam", 1, `{"guest":{"rsvp":"invited"}}`,
+					`{"guest":{"rsvp":"accepted"}}`, from),
+		)
+
+	res, err := c.GetAudit(
+		context.Background(),
+		auditDef.ListRequest{Guest: "sam", Actor: "alice", From: &from, Request: pagination.Request{Limit: 10}},
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), res.Total)
+	assert.Len(t, res.Entries, 1)
+	assert.Equal(t, auditDef.ActionGuestResponded, res.Entries[0].Action)
+	assert.JSONEq(t, `{"guest":{"rsvp":"accepted"}}`, string(res.Entries[0].After))
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestClient_Occupancy(t *testing.T) {
+	c, m := setupServer(t, nil)
+	at := time.Date(2023, 6, 21, 22, 30, 0, 0, time.UTC)
+	arrived := at.Add(-time.Hour)
+
+	// mocks
+	q := "SELECT * FROM `attendance` WHERE event_id = ? AND occurred_at <= ? AND table_id = ? ORDER BY seq"
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+		WithArgs(0, at, 4).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"seq", "event_id", "type", "guest", "table_id", "people", "occurred_at"}).
+				AddRow(1, 0, attendanceDef.TypeTableCreated, "", 4, 10, arrived.Add(-time.Hour)).
+				AddRow(2, 0, attendanceDef.TypeGuestCheckedIn, "sam", 4, 3, arrived),
+		)
+
+	res, err := c.Occupancy(context.Background(), attendanceDef.OccupancyRequest{At: &at, Table: 4})
+
+	assert.NoError(t, err)
+	assert.Equal(
+		t, attendanceDef.OccupancyDTO{
+			At:     at,
+			Seated: 3,
+			Tables: []attendanceDef.TableOccupancyDTO{
+				{
+					ID: 4, Seats: 10, Seated: 3, EmptySeats: 7,
+					Guests: []attendanceDef.SeatedGuestDTO{{Name: "sam", AccompanyingGuests: 2, TimeArrived: arrived}},
+				},
+			},
+		}, res,
+	)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestClient_AttendanceReport(t *testing.T) {
+	c, m := setupServer(t, nil)
+	start := time.Date(2023, 6, 21, 20, 0, 0, 0, time.UTC)
+	arrived := start.Add(10 * time.Minute)
+
+	// mocks
+	q := "SELECT * FROM `attendance` WHERE event_id = ? AND occurred_at <= ? ORDER BY seq"
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+		WithArgs(0, sqlmock.AnyArg()).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"seq", "event_id", "type", "guest", "table_id", "people", "occurred_at"}).
+				AddRow(1, 0, attendanceDef.TypeTableCreated, "", 4, 10, start).
+				AddRow(2, 0, attendanceDef.TypeGuestInvited, "sam", 4, 2, start).
+				AddRow(3, 0, attendanceDef.TypeGuestCheckedIn, "sam", 4, 3, arrived).
+				AddRow(4, 0, attendanceDef.TypeGuestCheckedOut, "sam", 4, 3, start.Add(time.Hour)),
+		)
+
+	res, err := c.AttendanceReport(context.Background(), attendanceDef.ReportRequest{Bucket: time.Hour})
+
+	assert.NoError(t, err)
+	assert.Equal(
+		t, attendanceDef.ReportDTO{
+			Timeline: []attendanceDef.BucketDTO{
+				{Start: start, Arrivals: 3, Occupancy: 3},
+				{Start: start.Add(time.Hour), Departures: 3},
+			},
+			Peak:               attendanceDef.PeakDTO{Headcount: 3, At: &arrived},
+			Tables:             []attendanceDef.TableAttendanceDTO{{ID: 4, Invited: 1, Arrived: 1}},
+			AverageStayMinutes: 50,
+			Entourage:          attendanceDef.EntourageDTO{Parties: 1, Expected: 1, Actual: 2},
+		}, res,
+	)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestClient_Webhooks(t *testing.T) {
+	created := time.Date(2023, 6, 21, 18, 0, 0, 0, time.UTC)
+	columns := []string{"id", "event_id", "url", "secret", "types", "created_at"}
+	get := "SELECT * FROM `webhook_subscriptions` WHERE event_id = ? AND id = ? ORDER BY " +
+		"`webhook_subscriptions`.`id` LIMIT 1"
+
+	t.Run(
+		"invalid type", func(t *testing.T) {
+			c, m := setupServer(t, nil)
+
+			_, err := c.CreateWebhook(
+				context.Background(),
+				webhooksDef.CreateRequest{URL: "https://example.com/hook", Types: []webhooksDef.Type{"guest.left"}},
+			)
+
+			assert.True(t, client.IsStatus(err, http.StatusBadRequest))
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"create", func(t *testing.T) {
+			c, m := setupServer(t, nil)
+
+			// mocks
+			q := "INSERT INTO `webhook_subscriptions` (`event_id`,`url`,`secret`,`types`,`created_at`) VALUES (?,?,?,?,?)"
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).
+				WithArgs(0, "https://example.com/hook", "secret", "table.full", sqlmock.AnyArg()).
+				WillReturnResult(sqlmock.NewResult(3, 1))
+			m.sqlMock.ExpectCommit()
+
+			res, err := c.CreateWebhook(
+				context.Background(), webhooksDef.CreateRequest{
+					URL: "https://example.com/hook", Types: []webhooksDef.Type{webhooksDef.TableFull}, Secret: "secret",
+				},
+			)
+
+			assert.NoError(t, err)
+			assert.Equal(t, uint(3), res.ID)
+			assert.Equal(t, "secret", res.Secret)
+			assert.Equal(t, []webhooksDef.Type{webhooksDef.TableFull}, res.Types)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"get hides the secret", func(t *testing.T) {
+			c, m := setupServer(t, nil)
+
+			// mocks
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(get)).
+				WithArgs(0, 3).
+				WillReturnRows(
+					sqlmock.NewRows(columns).AddRow(3, 0, "https://example.com/hook", "secret", "", created),
+				)
+
+			res, err := c.GetWebhook(context.Background(), 3)
+
+			assert.NoError(t, err)
+			assert.Equal(
+				t, webhooksDef.SubscriptionDTO{
+					ID: 3, URL: "https://example.com/hook", Types: []webhooksDef.Type{}, CreatedAt: created,
+				}, res,
+			)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"delete not found", func(t *testing.T) {
+			c, m := setupServer(t, nil)
+
+			// mocks
+			q := "DELETE FROM `webhook_subscriptions` WHERE event_id = ? AND id = ?"
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).WithArgs(0, 3).WillReturnResult(sqlmock.NewResult(0, 0))
+			m.sqlMock.ExpectRollback()
+
+			err := c.DeleteWebhook(context.Background(), 3)
+
+			assert.True(t, client.IsStatus(err, http.StatusNotFound))
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"deliveries", func(t *testing.T) {
+			c, m := setupServer(t, nil)
+
+			// mocks
+			q := "SELECT * FROM `webhook_deliveries` WHERE subscription_id = ? ORDER BY id DESC LIMIT 100"
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(get)).
+				WithArgs(0, 3).
+				WillReturnRows(sqlmock.NewRows(columns).AddRow(3, 0, "https://example.com/hook", "secret", "", created))
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+				WithArgs(3).
+				WillReturnRows(
+					sqlmock.NewRows(
+						[]string{
+							"id", "subscription_id", "payload_id", "type", "attempt", "status_code", "error", "created_at",
+						},
+					).
+						AddRow(2, 3, "abc", webhooksDef.GuestCheckedIn, 2, 200, "", created).
+						AddRow(1, 3, "abc", webhooksDef.GuestCheckedIn, 1, 500, "", created),
+				)
+
+			res, err := c.WebhookDeliveries(context.Background(), 3)
+
+			assert.NoError(t, err)
+			assert.Len(t, res.Deliveries, 2)
+			assert.True(t, res.Deliveries[0].Succeeded)
+			assert.False(t, res.Deliveries[1].Succeeded)
+			assert.Equal(t, 500, res.Deliveries[1].StatusCode)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
+
+func TestClient_Events(t *testing.T) {
+	date := time.Date(2023, 6, 21, 18, 0, 0, 0, time.UTC)
+	eventQuery := "SELECT * FROM `events` WHERE id = ? ORDER BY `events`.`id` LIMIT 1"
+	eColumns := []string{"id", "name", "venue", "date", "status"}
+
+	t.Run(
+		"create", func(t *testing.T) {
+			c, m := setupServer(t, nil)
+
+			// mocks
+			q := "INSERT INTO `events` (`name`,`venue`,`date`,`status`,`venue_limit`,`staff`) VALUES (?,?,?,?,?,?)"
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).
+				WithArgs("Summer party", "Rooftop", date, eventsDef.StatusPlanning, 150, 5).
+				WillReturnResult(sqlmock.NewResult(2, 1))
+			m.sqlMock.ExpectCommit()
+
+			res, err := c.CreateEvent(
+				context.Background(),
+				eventsDef.CreateRequest{Name: "Summer party", Venue: "Rooftop", Date: date, VenueLimit: 150, Staff: 5},
+			)
+
+			assert.NoError(t, err)
+			assert.Equal(
+				t,
+				eventsDef.EventDTO{
+					ID: 2, Name: "Summer party", Venue: "Rooftop", Date: date, Status: eventsDef.StatusPlanning,
+					VenueLimit: 150, Staff: 5,
+				},
+				res,
+			)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"open the doors", func(t *testing.T) {
+			c, m := setupServer(t, nil)
+
+			// mocks
+			q := "UPDATE `events` SET `status`=? WHERE id = ? AND status = ?"
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(eventQuery)).
+				WithArgs(2).
+				WillReturnRows(sqlmock.NewRows(eColumns).AddRow(2, "Summer party", "Rooftop", date, "planning"))
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).
+				WithArgs(eventsDef.StatusDoorsOpen, 2, eventsDef.StatusPlanning).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			m.sqlMock.ExpectCommit()
+
+			res, err := c.TransitionEvent(
+				context.Background(), eventsDef.TransitionRequest{ID: 2, Status: eventsDef.StatusDoorsOpen},
+			)
+
+			assert.NoError(t, err)
+			assert.Equal(t, eventsDef.StatusDoorsOpen, res.Status)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"set the venue limit", func(t *testing.T) {
+			c, m := setupServer(t, nil)
+
+			// mocks
+			q := "UPDATE `events` SET `staff`=?,`venue_limit`=? WHERE id = ?"
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(eventQuery)).
+				WithArgs(2).
+				WillReturnRows(sqlmock.NewRows(eColumns).AddRow(2, "Summer party", "Rooftop", date, "doors_open"))
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).WithArgs(5, 150, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+			m.sqlMock.ExpectCommit()
+
+			res, err := c.SetEventVenue(context.Background(), eventsDef.VenueRequest{ID: 2, VenueLimit: 150, Staff: 5})
+
+			assert.NoError(t, err)
+			assert.Equal(t, int64(150), res.VenueLimit)
+			assert.Equal(t, int64(5), res.Staff)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"check in before the doors open", func(t *testing.T) {
+			c, m := setupServer(t, nil)
+
+			// mocks, the event is looked up to scope the request and again to check its status
+			for i := 0; i < 2; i++ {
+				m.sqlMock.ExpectQuery(regexp.QuoteMeta(eventQuery)).
+					WithArgs(2).
+					WillReturnRows(sqlmock.NewRows(eColumns).AddRow(2, "Summer party", "Rooftop", date, "planning"))
+			}
+
+			_, err := c.ForEvent(2).CheckIn(context.Background(), guestsDef.CheckInRequest{Name: "sam", Accompanying: 1})
+
+			assert.True(t, client.IsStatus(err, http.StatusConflict))
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"unknown event", func(t *testing.T) {
+			c, m := setupServer(t, nil)
+
+			// mocks
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(eventQuery)).WithArgs(3).WillReturnRows(sqlmock.NewRows(eColumns))
+
+			_, err := c.ForEvent(3).CountEmptySeats(context.Background())
+
+			assert.True(t, client.IsStatus(err, http.StatusNotFound))
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"scoped to the event", func(t *testing.T) {
+			c, m := setupServer(t, nil)
+
+			// mocks
+			q := "SELECT SUM(empty_seats) FROM `tables` WHERE event_id = ?"
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(eventQuery)).
+				WithArgs(2).
+				WillReturnRows(sqlmock.NewRows(eColumns).AddRow(2, "Summer party", "Rooftop", date, "planning"))
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(4))
+
+			count, err := c.ForEvent(2).CountEmptySeats(context.Background())
+
+			assert.NoError(t, err)
+			assert.Equal(t, 4, count)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
+
+const getKey = "SELECT * FROM `idempotency_keys` WHERE idempotency_key = ? ORDER BY " +
+	"`idempotency_keys`.`idempotency_key` LIMIT 1"
+
+// expectReserve expects the idempotency key of a request to be looked up and reserved.
+func expectReserve(m serverMocks) {
+	purge := "DELETE FROM `idempotency_keys` WHERE idempotency_key = ? AND expires_at <= ?"
+	reserve := "INSERT INTO `idempotency_keys` (`idempotency_key`,`fingerprint`,`status`,`headers`,`body`," +
+		"`created_at`,`expires_at`) VALUES (?,?,?,?,?,?,?) ON DUPLICATE KEY UPDATE `idempotency_key`=`idempotency_key`"
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(getKey)).WillReturnRows(sqlmock.NewRows([]string{"idempotency_key"}))
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(purge)).WillReturnResult(sqlmock.NewResult(0, 0))
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(reserve)).WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectCommit()
+}
+
+// capture is an argument of a query kept for the test.
+type capture struct {
+	value driver.Value
+}
+
+func (c *capture) Match(v driver.Value) bool {
+	c.value = v
+	return true
+}
+
+// expectComplete expects the response of the request made with an idempotency key to be stored, it returns the
+// headers and the sealed body stored.
+func expectComplete(m serverMocks, status int) (headers, body *capture) {
+	complete := "UPDATE `idempotency_keys` SET `status`=?,`headers`=?,`body`=? WHERE idempotency_key = ?"
+	headers, body = &capture{}, &capture{}
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(complete)).
+		WithArgs(status, headers, body, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectCommit()
+	return
+}
+
+func TestClient_APIKey(t *testing.T) {
+	var key string
+	// recorded keeps the API key of the request
+	recorded := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				key = r.Header.Get(ratelimitDef.KeyHeader)
+				next.ServeHTTP(w, r)
+			},
+		)
+	}
+	c, _ := setupServer(t, recorded, client.WithAPIKey("organiser"))
+
+	err := c.Ping(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "organiser", key)
+}
+
+func TestClient_Retries(t *testing.T) {
+	// unavailable fails the first n requests before letting them through to the api
+	unavailable := func(n int32, calls *int32) func(http.Handler) http.Handler {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					if atomic.AddInt32(calls, 1) <= n {
+						w.WriteHeader(http.StatusServiceUnavailable)
+						return
+					}
+					next.ServeHTTP(w, r)
+				},
+			)
+		}
+	}
+
+	t.Run(
+		"retries exhausted", func(t *testing.T) {
+			var calls int32
+			c, _ := setupServer(t, unavailable(3, &calls), client.WithRetries(2, time.Millisecond))
+
+			err := c.Ping(context.Background())
+
+			assert.True(t, client.IsStatus(err, http.StatusServiceUnavailable))
+			assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+		},
+	)
+
+	t.Run(
+		"success after retry", func(t *testing.T) {
+			var calls int32
+			c, _ := setupServer(t, unavailable(2, &calls), client.WithRetries(2, time.Millisecond))
+
+			err := c.Ping(context.Background())
+
+			assert.NoError(t, err)
+			assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+		},
+	)
+
+	t.Run(
+		"retry after", func(t *testing.T) {
+			var calls int32
+			// limited answers the first request with 429 as the rate limiter does
+			limited := func(next http.Handler) http.Handler {
+				return http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						if atomic.AddInt32(&calls, 1) == 1 {
+							w.Header().Set(ratelimitDef.RetryAfterHeader, "1")
+							w.WriteHeader(http.StatusTooManyRequests)
+							return
+						}
+						next.ServeHTTP(w, r)
+					},
+				)
+			}
+			c, _ := setupServer(t, limited, client.WithRetries(1, time.Millisecond))
+			start := time.Now()
+
+			err := c.Ping(context.Background())
+
+			assert.NoError(t, err)
+			assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+			assert.GreaterOrEqual(t, int64(time.Since(start)), int64(time.Second))
+		},
+	)
+
+	t.Run(
+		"client errors are not retried", func(t *testing.T) {
+			var calls int32
+			c, m := setupServer(t, unavailable(0, &calls), client.WithRetries(2, time.Millisecond))
+
+			// mocks
+			expectReserve(m)
+			expectComplete(m, http.StatusBadRequest)
+
+			_, err := c.CreateTable(context.Background(), tablesDef.CreateRequest{})
+
+			assert.True(t, client.IsStatus(err, http.StatusBadRequest))
+			assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"lost response is replayed", func(t *testing.T) {
+			var calls int32
+			var keys []string
+			var m serverMocks
+			var replay func()
+			// lost lets the first request reach the api but answers it with 502, as a proxy losing the response
+			lost := func(next http.Handler) http.Handler {
+				return http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						keys = append(keys, r.Header.Get(idempotencyDef.Header))
+						if atomic.AddInt32(&calls, 1) == 1 {
+							next.ServeHTTP(httptest.NewRecorder(), r)
+							replay()
+							w.WriteHeader(http.StatusBadGateway)
+							return
+						}
+						next.ServeHTTP(w, r)
+					},
+				)
+			}
+			c, m := setupServer(t, lost, client.WithRetries(2, time.Millisecond))
+
+			// mocks
+			q := "INSERT INTO `tables` (`event_id`,`capacity`,`empty_seats`,`version`) VALUES (?,?,?,?)"
+			body := `{"capacity":10}`
+			fingerprint := sha256.Sum256([]byte(http.MethodPost + " /tables\n" + body))
+			expectReserve(m)
+			expectEvent(m, eventsDef.StatusPlanning)
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).WithArgs(0, 10, 10, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+			expectAudit(m, anonymous, auditDef.ActionTableCreated)
+			expectTrack(m, attendanceDef.TypeTableCreated)
+			m.sqlMock.ExpectCommit()
+			expectWaiting(m, 1, 10, 10)
+			headers, sealed := expectComplete(m, http.StatusOK)
+			// the retry finds the response stored by the first request instead of creating another table
+			replay = func() {
+				m.sqlMock.ExpectQuery(regexp.QuoteMeta(getKey)).
+					WillReturnRows(
+						sqlmock.NewRows(
+							[]string{"idempotency_key", "fingerprint", "status", "headers", "body", "expires_at"},
+						).
+							AddRow(
+								"key", hex.EncodeToString(fingerprint[:]), http.StatusOK, headers.value, sealed.value,
+								time.Now().Add(time.Hour),
+							),
+					)
+			}
+
+			res, err := c.CreateTable(context.Background(), tablesDef.CreateRequest{Capacity: 10})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tablesDef.CreateResponse{ID: 1, Capacity: 10}, res)
+			assert.Len(t, keys, 2)
+			assert.NotEmpty(t, keys[0])
+			assert.Equal(t, keys[0], keys[1])
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
+
+func TestClient_ContextCancellation(t *testing.T) {
+	t.Run(
+		"cancelled while waiting to retry", func(t *testing.T) {
+			var calls int32
+			c, _ := setupServer(
+				t, func(http.Handler) http.Handler {
+					return http.HandlerFunc(
+						func(w http.ResponseWriter, r *http.Request) {
+							atomic.AddInt32(&calls, 1)
+							w.WriteHeader(http.StatusServiceUnavailable)
+						},
+					)
+				}, client.WithRetries(5, time.Hour),
+			)
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			err := c.Ping(ctx)
+
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+			assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+		},
+	)
+
+	t.Run(
+		"cancelled before sending", func(t *testing.T) {
+			c, _ := setupServer(t, nil, client.WithRetries(5, time.Millisecond))
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			_, err := c.GetGuestList(ctx, guestsDef.ListRequest{})
+
+			assert.ErrorIs(t, err, context.Canceled)
+		},
+	)
+}
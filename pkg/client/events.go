@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+	"github.com/getground/tech-tasks/backend/definitions/events"
+	"net/http"
+	"strconv"
+)
+
+// CreateEvent calls POST /events.
+func (c *Client) CreateEvent(ctx context.Context, req events.CreateRequest) (res events.EventDTO, err error) {
+	err = c.do(ctx, http.MethodPost, "/events", req, &res)
+	return
+}
+
+// ListEvents calls GET /events.
+func (c *Client) ListEvents(ctx context.Context) (res events.ListDTO, err error) {
+	err = c.do(ctx, http.MethodGet, "/events", nil, &res)
+	return
+}
+
+// GetEvent calls GET /events/:event.
+func (c *Client) GetEvent(ctx context.Context, id uint) (res events.EventDTO, err error) {
+	err = c.do(ctx, http.MethodGet, "/events/"+strconv.FormatUint(uint64(id), 10), nil, &res)
+	return
+}
+
+// TransitionEvent calls PUT /events/:event/status.
+func (c *Client) TransitionEvent(ctx context.Context, req events.TransitionRequest) (res events.EventDTO, err error) {
+	err = c.do(ctx, http.MethodPut, "/events/"+strconv.FormatUint(uint64(req.ID), 10)+"/status", req, &res)
+	return
+}
+
+// SetEventVenue calls PUT /events/:event/venue.
+func (c *Client) SetEventVenue(ctx context.Context, req events.VenueRequest) (res events.EventDTO, err error) {
+	err = c.do(ctx, http.MethodPut, "/events/"+strconv.FormatUint(uint64(req.ID), 10)+"/venue", req, &res)
+	return
+}
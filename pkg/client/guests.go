@@ -0,0 +1,139 @@
+package client
+
+import (
+	"context"
+	"github.com/getground/tech-tasks/backend/definitions/guests"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// AddToGuestList calls POST /guest_list/:name.
+func (c *Client) AddToGuestList(ctx context.Context, req guests.CreateRequest) (res guests.CreateResponse, err error) {
+	err = c.do(ctx, http.MethodPost, c.prefix+"/guest_list/"+url.PathEscape(req.Name), req, &res)
+	return
+}
+
+// GetGuestList calls GET /guest_list, every row is returned when neither req.Limit nor req.Cursor is set.
+func (c *Client) GetGuestList(ctx context.Context, req guests.ListRequest) (res guests.ListDTO, err error) {
+	err = c.do(ctx, http.MethodGet, c.prefix+"/guest_list"+guestsQuery(req), nil, &res)
+	return
+}
+
+// GetGuest calls GET /guest_list/:name, the version of the guest can be sent back to CheckIn.
+func (c *Client) GetGuest(ctx context.Context, name string) (res guests.GuestListDTO, err error) {
+	err = c.do(ctx, http.MethodGet, c.prefix+"/guest_list/"+url.PathEscape(name), nil, &res)
+	return
+}
+
+// SearchGuests calls GET /guests/search.
+func (c *Client) SearchGuests(ctx context.Context, req guests.SearchRequest) (res guests.SearchDTO, err error) {
+	q := url.Values{"q": {req.Query}}
+	if req.Limit > 0 {
+		q.Set("limit", strconv.Itoa(req.Limit))
+	}
+	err = c.do(ctx, http.MethodGet, c.prefix+"/guests/search"+encodeQuery(q), nil, &res)
+	return
+}
+
+// Respond calls POST /rsvp/:token.
+func (c *Client) Respond(ctx context.Context, req guests.RSVPRequest) (res guests.RSVPResponse, err error) {
+	err = c.do(ctx, http.MethodPost, "/rsvp/"+url.PathEscape(req.Token), req, &res)
+	return
+}
+
+// EditCompanions calls PUT /guest_list/:name/companions, a req.Version other than zero is sent in If-Match and the
+// server answers 412 when the guest changed since.
+func (c *Client) EditCompanions(
+	ctx context.Context, req guests.CompanionsRequest,
+) (res guests.GuestListDTO, err error) {
+	path := c.prefix + "/guest_list/" + url.PathEscape(req.Name) + "/companions"
+	err = c.doIfMatch(ctx, http.MethodPut, path, req.Version, req, &res)
+	return
+}
+
+// RSVPCounts calls GET /reports/rsvp, every table is counted when req.Table is zero.
+func (c *Client) RSVPCounts(ctx context.Context, req guests.RSVPCountsRequest) (res guests.RSVPCountsDTO, err error) {
+	q := url.Values{}
+	if req.Table != 0 {
+		q.Set("table", strconv.FormatUint(uint64(req.Table), 10))
+	}
+	err = c.do(ctx, http.MethodGet, c.prefix+"/reports/rsvp"+encodeQuery(q), nil, &res)
+	return
+}
+
+// EditProfile calls PUT /guest_list/:name/profile, a req.Version other than zero is sent in If-Match and the server
+// answers 412 when the guest changed since.
+func (c *Client) EditProfile(ctx context.Context, req guests.ProfileRequest) (res guests.GuestListDTO, err error) {
+	path := c.prefix + "/guest_list/" + url.PathEscape(req.Name) + "/profile"
+	err = c.doIfMatch(ctx, http.MethodPut, path, req.Version, req, &res)
+	return
+}
+
+// Catering calls GET /reports/catering, every table is summed up when req.Table is zero.
+func (c *Client) Catering(ctx context.Context, req guests.CateringRequest) (res guests.CateringDTO, err error) {
+	q := url.Values{}
+	if req.Table != 0 {
+		q.Set("table", strconv.FormatUint(uint64(req.Table), 10))
+	}
+	err = c.do(ctx, http.MethodGet, c.prefix+"/reports/catering"+encodeQuery(q), nil, &res)
+	return
+}
+
+// WalkIn calls POST /checkin/walk_in.
+func (c *Client) WalkIn(ctx context.Context, req guests.WalkInRequest) (res guests.WalkInResponse, err error) {
+	err = c.do(ctx, http.MethodPost, c.prefix+"/checkin/walk_in", req, &res)
+	return
+}
+
+// Uninvite calls DELETE /guest_list/:name.
+func (c *Client) Uninvite(ctx context.Context, name string) error {
+	return c.do(ctx, http.MethodDelete, c.prefix+"/guest_list/"+url.PathEscape(name), nil, nil)
+}
+
+// CheckIn calls PUT /guests/:name, a req.Version other than zero is sent in If-Match and the server answers 412 when
+// the guest changed since.
+func (c *Client) CheckIn(ctx context.Context, req guests.CheckInRequest) (res guests.CheckInResponse, err error) {
+	err = c.doIfMatch(ctx, http.MethodPut, c.prefix+"/guests/"+url.PathEscape(req.Name), req.Version, req, &res)
+	return
+}
+
+// GetGuests calls GET /guests, every row is returned when neither req.Limit nor req.Cursor is set.
+func (c *Client) GetGuests(ctx context.Context, req guests.ListRequest) (res guests.DTO, err error) {
+	err = c.do(ctx, http.MethodGet, c.prefix+"/guests"+guestsQuery(req), nil, &res)
+	return
+}
+
+// CheckOut calls DELETE /guests/:name.
+func (c *Client) CheckOut(ctx context.Context, name string) error {
+	return c.do(ctx, http.MethodDelete, c.prefix+"/guests/"+url.PathEscape(name), nil, nil)
+}
+
+func guestsQuery(req guests.ListRequest) string {
+	q := pageQuery(req.Request)
+	if req.NamePrefix != "" {
+		q.Set("prefix", req.NamePrefix)
+	}
+	if req.Table != 0 {
+		q.Set("table", strconv.FormatUint(uint64(req.Table), 10))
+	}
+	if req.Arrived != nil {
+		q.Set("arrived", strconv.FormatBool(*req.Arrived))
+	}
+	if req.CheckedOut != nil {
+		q.Set("checked_out", strconv.FormatBool(*req.CheckedOut))
+	}
+	if req.WalkIn != nil {
+		q.Set("walk_in", strconv.FormatBool(*req.WalkIn))
+	}
+	if req.RSVP != "" {
+		q.Set("rsvp", string(req.RSVP))
+	}
+	if req.Sort != "" {
+		q.Set("sort", string(req.Sort))
+	}
+	if req.Order != "" {
+		q.Set("order", string(req.Order))
+	}
+	return encodeQuery(q)
+}
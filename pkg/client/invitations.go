@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"github.com/getground/tech-tasks/backend/definitions/guests"
+	"github.com/getground/tech-tasks/backend/definitions/invitations"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ScanInvitation calls POST /checkin/scan.
+func (c *Client) ScanInvitation(ctx context.Context, req guests.ScanRequest) (res guests.CheckInResponse, err error) {
+	err = c.do(ctx, http.MethodPost, "/checkin/scan", req, &res)
+	return
+}
+
+// Reinvite calls POST /guest_list/:name/invitation.
+func (c *Client) Reinvite(ctx context.Context, name string) (res invitations.InvitationResponse, err error) {
+	err = c.do(ctx, http.MethodPost, c.prefix+"/guest_list/"+url.PathEscape(name)+"/invitation", nil, &res)
+	return
+}
+
+// RevokeInvitation calls DELETE /guest_list/:name/invitation.
+func (c *Client) RevokeInvitation(ctx context.Context, name string) error {
+	return c.do(ctx, http.MethodDelete, c.prefix+"/guest_list/"+url.PathEscape(name)+"/invitation", nil, nil)
+}
+
+// InvitationQR calls GET /invitations/:token/qr and returns the image.
+func (c *Client) InvitationQR(ctx context.Context, req invitations.QRRequest) (res []byte, err error) {
+	q := url.Values{}
+	if req.Format != "" {
+		q.Set("format", string(req.Format))
+	}
+	if req.Size > 0 {
+		q.Set("size", strconv.Itoa(req.Size))
+	}
+	err = c.do(ctx, http.MethodGet, "/invitations/"+url.PathEscape(req.Token)+"/qr"+encodeQuery(q), nil, &res)
+	return
+}
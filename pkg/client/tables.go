@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"github.com/getground/tech-tasks/backend/definitions/tables"
+	"net/http"
+	"strconv"
+)
+
+// Ping calls the health check endpoint.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.do(ctx, http.MethodGet, "/ping", nil, nil)
+}
+
+// CreateTable calls POST /tables.
+func (c *Client) CreateTable(ctx context.Context, req tables.CreateRequest) (res tables.CreateResponse, err error) {
+	err = c.do(ctx, http.MethodPost, c.prefix+"/tables", req, &res)
+	return
+}
+
+// ListTables calls GET /tables, every row is returned when neither req.Limit nor req.Cursor is set.
+func (c *Client) ListTables(ctx context.Context, req tables.ListRequest) (res tables.ListDTO, err error) {
+	q := pageQuery(req.Request)
+	if req.MinEmptySeats > 0 {
+		q.Set("min_empty_seats", strconv.FormatInt(req.MinEmptySeats, 10))
+	}
+	err = c.do(ctx, http.MethodGet, c.prefix+"/tables"+encodeQuery(q), nil, &res)
+	return
+}
+
+// GetTable calls GET /tables/:id, the version of the table can be sent back to ResizeTable.
+func (c *Client) GetTable(ctx context.Context, id uint) (res tables.TableDTO, err error) {
+	err = c.do(ctx, http.MethodGet, tablePath(c.prefix, id), nil, &res)
+	return
+}
+
+// ResizeTable calls PUT /tables/:id, a req.Version other than zero is sent in If-Match and the server answers 412
+// when the table changed since.
+func (c *Client) ResizeTable(ctx context.Context, req tables.ResizeRequest) (res tables.TableDTO, err error) {
+	err = c.doIfMatch(ctx, http.MethodPut, tablePath(c.prefix, req.ID), req.Version, req, &res)
+	return
+}
+
+// CountEmptySeats calls GET /seats_empty.
+func (c *Client) CountEmptySeats(ctx context.Context) (int, error) {
+	var res struct {
+		SeatsEmpty int `json:"seats_empty"`
+	}
+	err := c.do(ctx, http.MethodGet, c.prefix+"/seats_empty", nil, &res)
+	return res.SeatsEmpty, err
+}
+
+func tablePath(prefix string, id uint) string {
+	return prefix + "/tables/" + strconv.FormatUint(uint64(id), 10)
+}
@@ -0,0 +1,13 @@
+package client
+
+import (
+	"context"
+	"github.com/getground/tech-tasks/backend/definitions/venue"
+	"net/http"
+)
+
+// VenueHeadcount calls GET /venue/headcount.
+func (c *Client) VenueHeadcount(ctx context.Context) (res venue.HeadcountDTO, err error) {
+	err = c.do(ctx, http.MethodGet, c.prefix+"/venue/headcount", nil, &res)
+	return
+}
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"context"
+	"github.com/getground/tech-tasks/backend/definitions/waitlist"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// JoinWaitlist calls POST /waitlist.
+func (c *Client) JoinWaitlist(ctx context.Context, req waitlist.CreateRequest) (res waitlist.EntryDTO, err error) {
+	err = c.do(ctx, http.MethodPost, c.prefix+"/waitlist", req, &res)
+	return
+}
+
+// GetWaitlist calls GET /waitlist.
+func (c *Client) GetWaitlist(ctx context.Context, req waitlist.ListRequest) (res waitlist.ListDTO, err error) {
+	q := url.Values{}
+	if req.Table != 0 {
+		q.Set("table", strconv.FormatUint(uint64(req.Table), 10))
+	}
+	if req.Promoted != nil {
+		q.Set("promoted", strconv.FormatBool(*req.Promoted))
+	}
+	err = c.do(ctx, http.MethodGet, c.prefix+"/waitlist"+encodeQuery(q), nil, &res)
+	return
+}
+
+// LeaveWaitlist calls DELETE /waitlist/:id.
+func (c *Client) LeaveWaitlist(ctx context.Context, id uint) error {
+	return c.do(ctx, http.MethodDelete, c.prefix+"/waitlist/"+strconv.FormatUint(uint64(id), 10), nil, nil)
+}
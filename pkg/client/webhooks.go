@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+	"github.com/getground/tech-tasks/backend/definitions/webhooks"
+	"net/http"
+	"strconv"
+)
+
+// CreateWebhook calls POST /webhooks, the generated secret is only returned here.
+func (c *Client) CreateWebhook(ctx context.Context, req webhooks.CreateRequest) (res webhooks.SubscriptionDTO, err error) {
+	err = c.do(ctx, http.MethodPost, c.prefix+"/webhooks", req, &res)
+	return
+}
+
+// ListWebhooks calls GET /webhooks.
+func (c *Client) ListWebhooks(ctx context.Context) (res webhooks.ListDTO, err error) {
+	err = c.do(ctx, http.MethodGet, c.prefix+"/webhooks", nil, &res)
+	return
+}
+
+// GetWebhook calls GET /webhooks/:id.
+func (c *Client) GetWebhook(ctx context.Context, id uint) (res webhooks.SubscriptionDTO, err error) {
+	err = c.do(ctx, http.MethodGet, webhookPath(c.prefix, id), nil, &res)
+	return
+}
+
+// UpdateWebhook calls PUT /webhooks/:id.
+func (c *Client) UpdateWebhook(ctx context.Context, req webhooks.UpdateRequest) (res webhooks.SubscriptionDTO, err error) {
+	err = c.do(ctx, http.MethodPut, webhookPath(c.prefix, req.ID), req, &res)
+	return
+}
+
+// DeleteWebhook calls DELETE /webhooks/:id.
+func (c *Client) DeleteWebhook(ctx context.Context, id uint) error {
+	return c.do(ctx, http.MethodDelete, webhookPath(c.prefix, id), nil, nil)
+}
+
+// WebhookDeliveries calls GET /webhooks/:id/deliveries.
+func (c *Client) WebhookDeliveries(ctx context.Context, id uint) (res webhooks.DeliveriesDTO, err error) {
+	err = c.do(ctx, http.MethodGet, webhookPath(c.prefix, id)+"/deliveries", nil, &res)
+	return
+}
+
+func webhookPath(prefix string, id uint) string {
+	return prefix + "/webhooks/" + strconv.FormatUint(uint64(id), 10)
+}
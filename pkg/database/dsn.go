@@ -0,0 +1,137 @@
+package database
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"github.com/getground/tech-tasks/backend/config"
+	"github.com/go-sql-driver/mysql"
+	"net"
+	"os"
+	"time"
+)
+
+// tlsConfigName is the name the TLS config of the connection is registered under in the driver.
+const tlsConfigName = "party"
+
+// DSN returns the data source name of the database. A DSN of the config keeps its parameters but the DATETIME
+// values are always parsed, the repositories read them as time.Time. The TLS config of the verify modes and of the
+// client certificates is registered in the driver.
+func DSN(cfg config.Database) (string, error) {
+	c := mysql.NewConfig()
+	if cfg.DSN != "" {
+		var err error
+		c, err = mysql.ParseDSN(cfg.DSN)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		loc, err := time.LoadLocation(cfg.Timezone)
+		if err != nil {
+			return "", fmt.Errorf("db timezone: %w", err)
+		}
+		c.User = cfg.User
+		c.Passwd = cfg.Password
+		c.Net = "tcp"
+		c.Addr = net.JoinHostPort(cfg.Host, cfg.Port)
+		c.DBName = cfg.Name
+		c.Loc = loc
+		c.Params = map[string]string{"charset": "utf8mb4"}
+	}
+	c.ParseTime = true
+
+	if cfg.TLS.Mode != config.TLSDisabled {
+		host, _, err := net.SplitHostPort(c.Addr)
+		if err != nil {
+			host = c.Addr
+		}
+		c.TLSConfig, err = tlsMode(cfg.TLS, host)
+		if err != nil {
+			return "", err
+		}
+	}
+	return c.FormatDSN(), nil
+}
+
+// tlsMode returns the tls parameter of the DSN for the mode, registering the TLS config it names when the driver
+// doesn't know it.
+func tlsMode(cfg config.DatabaseTLS, host string) (string, error) {
+	if cfg.Cert == "" && cfg.CA == "" {
+		switch cfg.Mode {
+		case config.TLSPreferred:
+			return "preferred", nil
+		case config.TLSRequired:
+			return "skip-verify", nil
+		}
+	}
+
+	tlsCfg, err := newTLSConfig(cfg, host)
+	if err != nil {
+		return "", err
+	}
+	if err = mysql.RegisterTLSConfig(tlsConfigName, tlsCfg); err != nil {
+		return "", err
+	}
+	return tlsConfigName, nil
+}
+
+func newTLSConfig(cfg config.DatabaseTLS, host string) (*tls.Config, error) {
+	tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cfg.Cert != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.Cert, cfg.Key)
+		if err != nil {
+			return nil, fmt.Errorf("db tls client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+	if cfg.CA != "" {
+		pem, err := os.ReadFile(cfg.CA)
+		if err != nil {
+			return nil, fmt.Errorf("db tls ca: %w", err)
+		}
+		tlsCfg.RootCAs = x509.NewCertPool()
+		if !tlsCfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.New("db tls ca: no certificate found")
+		}
+	}
+
+	switch cfg.Mode {
+	case config.TLSPreferred, config.TLSRequired:
+		tlsCfg.InsecureSkipVerify = true
+	case config.TLSVerifyCA:
+		// the chain is verified against the CA, the name the certificate was issued to is not
+		tlsCfg.InsecureSkipVerify = true
+		tlsCfg.VerifyPeerCertificate = verifyChain(tlsCfg.RootCAs)
+	case config.TLSVerifyIdentity:
+		tlsCfg.ServerName = cfg.ServerName
+		if tlsCfg.ServerName == "" {
+			tlsCfg.ServerName = host
+		}
+	default:
+		return nil, fmt.Errorf("unknown db tls mode %q", cfg.Mode)
+	}
+	return tlsCfg, nil
+}
+
+func verifyChain(roots *x509.CertPool) func([][]byte, [][]*x509.Certificate) error {
+	return func(raw [][]byte, _ [][]*x509.Certificate) error {
+		if len(raw) == 0 {
+			return errors.New("db tls: the server sent no certificate")
+		}
+		certs := make([]*x509.Certificate, len(raw))
+		for i, b := range raw {
+			cert, err := x509.ParseCertificate(b)
+			if err != nil {
+				return err
+			}
+			certs[i] = cert
+		}
+		intermediates := x509.NewCertPool()
+		for _, cert := range certs[1:] {
+			intermediates.AddCert(cert)
+		}
+		_, err := certs[0].Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates})
+		return err
+	}
+}
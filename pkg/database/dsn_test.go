@@ -0,0 +1,175 @@
+package database_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/getground/tech-tasks/backend/config"
+	"github.com/getground/tech-tasks/backend/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var db = config.Database{
+	Host:     "mysql",
+	Port:     "3306",
+	User:     "user",
+	Password: "p@ss:word",
+	Name:     "database",
+	Timezone: "UTC",
+	TLS:      config.DatabaseTLS{Mode: config.TLSDisabled},
+}
+
+// writeCert writes a self signed certificate and its key as PEM files and returns their paths.
+func writeCert(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when generating the key", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "party"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating the certificate", err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when marshalling the key", err)
+	}
+
+	dir := t.TempDir()
+	certPath, keyPath := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	_ = os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600)
+	_ = os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600)
+	return certPath, keyPath
+}
+
+func TestDSN(t *testing.T) {
+	cert, key := writeCert(t)
+	invalid := filepath.Join(t.TempDir(), "invalid.pem")
+	_ = os.WriteFile(invalid, []byte("not a certificate"), 0o600)
+
+	with := func(change func(c *config.Database)) config.Database {
+		c := db
+		change(&c)
+		return c
+	}
+
+	cases := []struct {
+		name        string
+		cfg         config.Database
+		expected    string
+		expectedErr bool
+	}{
+		{
+			name:     "default",
+			cfg:      db,
+			expected: "user:p@ss:word@tcp(mysql:3306)/database?parseTime=true&charset=utf8mb4",
+		},
+		{
+			name:     "timezone",
+			cfg:      with(func(c *config.Database) { c.Timezone = "Europe/London" }),
+			expected: "user:p@ss:word@tcp(mysql:3306)/database?loc=Europe%2FLondon&parseTime=true&charset=utf8mb4",
+		},
+		{
+			name:     "ipv6 host",
+			cfg:      with(func(c *config.Database) { c.Host = "::1" }),
+			expected: "user:p@ss:word@tcp([::1]:3306)/database?parseTime=true&charset=utf8mb4",
+		},
+		{
+			name:        "unknown timezone",
+			cfg:         with(func(c *config.Database) { c.Timezone = "Mars/Olympus" }),
+			expectedErr: true,
+		},
+		{
+			name: "dsn",
+			cfg: with(
+				func(c *config.Database) { c.DSN = "admin:secret@tcp(db.internal:3307)/party?loc=Local&tls=true" },
+			),
+			expected: "admin:secret@tcp(db.internal:3307)/party?loc=Local&parseTime=true&tls=true",
+		},
+		{
+			name:        "invalid dsn",
+			cfg:         with(func(c *config.Database) { c.DSN = "admin:secret@tcp(db.internal:3307)" }),
+			expectedErr: true,
+		},
+		{
+			name:     "tls preferred",
+			cfg:      with(func(c *config.Database) { c.TLS.Mode = config.TLSPreferred }),
+			expected: "user:p@ss:word@tcp(mysql:3306)/database?parseTime=true&tls=preferred&charset=utf8mb4",
+		},
+		{
+			name:     "tls required",
+			cfg:      with(func(c *config.Database) { c.TLS.Mode = config.TLSRequired }),
+			expected: "user:p@ss:word@tcp(mysql:3306)/database?parseTime=true&tls=skip-verify&charset=utf8mb4",
+		},
+		{
+			name: "tls required with a client certificate",
+			cfg: with(
+				func(c *config.Database) { c.TLS = config.DatabaseTLS{Mode: config.TLSRequired, Cert: cert, Key: key} },
+			),
+			expected: "user:p@ss:word@tcp(mysql:3306)/database?parseTime=true&tls=party&charset=utf8mb4",
+		},
+		{
+			name:     "tls verify ca",
+			cfg:      with(func(c *config.Database) { c.TLS = config.DatabaseTLS{Mode: config.TLSVerifyCA, CA: cert} }),
+			expected: "user:p@ss:word@tcp(mysql:3306)/database?parseTime=true&tls=party&charset=utf8mb4",
+		},
+		{
+			name: "tls verify identity of a dsn",
+			cfg: with(
+				func(c *config.Database) {
+					c.DSN = "admin:secret@tcp(db.internal:3307)/party"
+					c.TLS.Mode = config.TLSVerifyIdentity
+				},
+			),
+			expected: "admin:secret@tcp(db.internal:3307)/party?parseTime=true&tls=party",
+		},
+		{
+			name:        "invalid ca",
+			cfg:         with(func(c *config.Database) { c.TLS = config.DatabaseTLS{Mode: config.TLSVerifyCA, CA: invalid} }),
+			expectedErr: true,
+		},
+		{
+			name: "missing client key",
+			cfg: with(
+				func(c *config.Database) {
+					c.TLS = config.DatabaseTLS{Mode: config.TLSRequired, Cert: cert, Key: invalid}
+				},
+			),
+			expectedErr: true,
+		},
+		{
+			name:        "unknown tls mode",
+			cfg:         with(func(c *config.Database) { c.TLS.Mode = "always" }),
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				//	method call
+				dsn, err := database.DSN(tc.cfg)
+
+				//	assert
+				assert.Equal(t, tc.expectedErr, err != nil, err)
+				assert.Equal(t, tc.expected, dsn)
+			},
+		)
+	}
+}
@@ -2,19 +2,19 @@ package database
 
 import (
 	"database/sql"
-	"fmt"
 	"github.com/getground/tech-tasks/backend/config"
 	log "github.com/sirupsen/logrus"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"time"
 )
 
 func New(cfg config.Database) (gormDB *gorm.DB, err error) {
-	dsn := fmt.Sprintf(
-		"%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local", cfg.User, cfg.Password, cfg.Host, cfg.Port,
-		cfg.Name,
-	)
+	dsn, err := DSN(cfg)
+	if err != nil {
+		return nil, err
+	}
 	db, err := sql.Open("mysql", dsn)
 	if err != nil {
 		log.Fatal(err)
@@ -24,6 +24,7 @@ func New(cfg config.Database) (gormDB *gorm.DB, err error) {
 		&gorm.Config{
 			Logger:                 logger.Default.LogMode(logger.Info),
 			SkipDefaultTransaction: true,
+			NowFunc:                now,
 		},
 	)
 	return gormDB.Session(&gorm.Session{}), err
@@ -31,10 +32,16 @@ func New(cfg config.Database) (gormDB *gorm.DB, err error) {
 
 func getCfg() gorm.Config {
 	return gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		Logger:  logger.Default.LogMode(logger.Info),
+		NowFunc: now,
 	}
 }
 
+// now stamps the CreatedAt and UpdatedAt columns in UTC, as every time stored.
+func now() time.Time {
+	return time.Now().UTC()
+}
+
 func NewDatabaseForTests(conn gorm.Dialector) (*gorm.DB, error) {
 	gCfg := getCfg()
 	connection, err := gorm.Open(conn, &gCfg)
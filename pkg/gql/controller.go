@@ -0,0 +1,91 @@
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/getground/tech-tasks/backend/definitions/events"
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+	"strings"
+)
+
+type request struct {
+	Query         string                 `json:"query" form:"query" binding:"required"`
+	OperationName string                 `json:"operationName" form:"operationName"`
+	Variables     map[string]interface{} `json:"variables" form:"-"`
+}
+
+// eventKey is the key of the event of the request in the context of the resolvers.
+type eventKey struct{}
+
+type Controller struct {
+	schema graphql.Schema
+}
+
+func NewController(schema graphql.Schema) Controller {
+	return Controller{schema: schema}
+}
+
+// Execute runs queries and mutations, requests accepting text/event-stream run subscriptions instead. The resolvers
+// serve the event the request is scoped to.
+func (ctrl Controller) Execute(c *gin.Context) {
+	req, err := bind(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	params := graphql.Params{
+		Schema:         ctrl.schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        context.WithValue(c.Request.Context(), eventKey{}, c.GetUint(events.ContextKey)),
+	}
+	if strings.Contains(c.GetHeader("Accept"), "text/event-stream") {
+		ctrl.subscribe(c, params)
+		return
+	}
+
+	c.JSON(http.StatusOK, graphql.Do(params))
+}
+
+// subscribe streams every result of the subscription as a server sent "next" event, followed by a "complete"
+// event when the subscription ends. The subscription stops by itself once the client is gone and the request
+// context is done.
+func (ctrl Controller) subscribe(c *gin.Context, params graphql.Params) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	for res := range graphql.Subscribe(params) {
+		c.SSEvent("next", res)
+		c.Writer.Flush()
+	}
+	c.SSEvent("complete", "")
+	c.Writer.Flush()
+}
+
+func bind(c *gin.Context) (req request, err error) {
+	if c.Request.Method == http.MethodGet {
+		err = c.ShouldBindQuery(&req)
+		if err != nil {
+			return
+		}
+		if variables := c.Query("variables"); variables != "" {
+			err = json.Unmarshal([]byte(variables), &req.Variables)
+		}
+		return
+	}
+	err = c.ShouldBindJSON(&req)
+	return
+}
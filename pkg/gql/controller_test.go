@@ -0,0 +1,200 @@
+package gql_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	eventsDef "github.com/getground/tech-tasks/backend/definitions/events"
+	notificationsDef "github.com/getground/tech-tasks/backend/definitions/notifications"
+	"github.com/getground/tech-tasks/backend/definitions/tables"
+	"github.com/getground/tech-tasks/backend/pkg/gql"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func setupController(t *testing.T) (*gin.Engine, schemaMocks) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	schema, m := setupSchema(t)
+	ctrl := gql.NewController(schema)
+	// the requests are scoped to the event 2
+	r.Use(
+		func(c *gin.Context) {
+			c.Set(eventsDef.ContextKey, uint(2))
+		},
+	)
+	r.GET("/graphql", ctrl.Execute)
+	r.POST("/graphql", ctrl.Execute)
+	return r, m
+}
+
+func TestController_Execute(t *testing.T) {
+	t.Run(
+		"query missing", func(t *testing.T) {
+			//	setup
+			r, _ := setupController(t)
+
+			//	request
+			req, err := http.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{}`))
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			//	assert
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+		},
+	)
+
+	t.Run(
+		"post query with variables", func(t *testing.T) {
+			//	setup
+			r, m := setupController(t)
+			m.tableService.On("GetByID", uint(3)).Return(tables.Table{ID: 3, Capacity: 4, EmptySeats: 4}, nil).Once()
+
+			//	request
+			body := `{"query":"query Table($id: Int!) { table(id: $id) { id emptySeats } }","variables":{"id":3}}`
+			req, err := http.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			//	assert
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.JSONEq(t, `{"data":{"table":{"id":3,"emptySeats":4}}}`, rr.Body.String())
+			m.tableService.AssertCalled(t, "ForEvent", uint(2))
+			m.tableService.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"get query", func(t *testing.T) {
+			//	setup
+			r, m := setupController(t)
+			m.tableService.On("CountEmptySeats").Return(2).Once()
+
+			//	request
+			req, err := http.NewRequest(http.MethodGet, "/graphql?query="+url.QueryEscape("{ seatsEmpty }"), http.NoBody)
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			//	assert
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.JSONEq(t, `{"data":{"seatsEmpty":2}}`, rr.Body.String())
+		},
+	)
+
+	t.Run(
+		"get invalid variables", func(t *testing.T) {
+			//	setup
+			r, _ := setupController(t)
+
+			//	request
+			req, err := http.NewRequest(http.MethodGet, "/graphql?query=x&variables=%7B", http.NoBody)
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			//	assert
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+		},
+	)
+
+	t.Run(
+		"subscription over server sent events", func(t *testing.T) {
+			//	setup
+			r, m := setupController(t)
+			server := httptest.NewServer(r)
+			defer server.Close()
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			//	request
+			body := `{"query":"subscription { occupancyChanged { kind guest } }"}`
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/graphql", strings.NewReader(body))
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			req.Header.Set("Accept", "text/event-stream")
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Error requesting test controller: %v\n", err)
+			}
+			defer res.Body.Close()
+
+			// read the first data line of the stream while publishing until it arrives
+			lines := make(chan string)
+			go func() {
+				scanner := bufio.NewScanner(res.Body)
+				for scanner.Scan() {
+					if strings.HasPrefix(scanner.Text(), "data:") {
+						lines <- strings.TrimPrefix(scanner.Text(), "data:")
+						return
+					}
+				}
+			}()
+			var data string
+			for data == "" {
+				m.broker.Publish(notificationsDef.Notification{Type: notificationsDef.GuestCheckedIn, Guest: "kim"})
+				m.broker.Publish(
+					notificationsDef.Notification{Type: notificationsDef.GuestCheckedIn, Guest: "sam", EventID: 2},
+				)
+				select {
+				case data = <-lines:
+				case <-time.After(10 * time.Millisecond):
+				}
+			}
+
+			var result map[string]interface{}
+			assert.NoError(t, json.Unmarshal([]byte(data), &result))
+
+			//	assert
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+			assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+			assert.Equal(
+				t, map[string]interface{}{
+					"data": map[string]interface{}{
+						"occupancyChanged": map[string]interface{}{"kind": "CHECKED_IN", "guest": "sam"},
+					},
+				}, result,
+			)
+		},
+	)
+
+	t.Run(
+		"invalid subscription completes the stream", func(t *testing.T) {
+			//	setup
+			r, _ := setupController(t)
+
+			//	request
+			body := `{"query":"subscription { unknown }"}`
+			req, err := http.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			req.Header.Set("Accept", "text/event-stream")
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			//	assert
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Contains(t, rr.Body.String(), "event:next")
+			assert.Contains(t, rr.Body.String(), "errors")
+			assert.Contains(t, rr.Body.String(), "event:complete")
+		},
+	)
+}
@@ -0,0 +1,265 @@
+package gql
+
+import (
+	"context"
+	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/guests"
+	"github.com/getground/tech-tasks/backend/definitions/notifications"
+	"github.com/getground/tech-tasks/backend/definitions/tables"
+	"github.com/graphql-go/graphql"
+	"time"
+)
+
+var errGuestNotFound = errors.New("guest not found")
+
+type tableView struct {
+	ID         uint  `graphql:"id"`
+	Capacity   int64 `graphql:"capacity"`
+	EmptySeats int64 `graphql:"emptySeats"`
+}
+
+type guestView struct {
+	Name         string        `graphql:"name"`
+	Accompanying int64         `graphql:"accompanyingGuests"`
+	TimeArrived  *string       `graphql:"timeArrived"`
+	Status       guests.Status `graphql:"status"`
+	TableID      uint          `graphql:"-"`
+}
+
+type occupancyView struct {
+	Kind         notifications.Type `graphql:"kind"`
+	Guest        string             `graphql:"guest"`
+	Accompanying int64              `graphql:"accompanyingGuests"`
+	EmptySeats   int64              `graphql:"emptySeats"`
+	OccurredAt   string             `graphql:"occurredAt"`
+	TableID      uint               `graphql:"-"`
+}
+
+type resolver struct {
+	tableSvc tables.Service
+	guestSvc guests.Service
+	broker   notifications.Broker
+	event    uint
+}
+
+// scoped returns the resolver of the event the request is scoped to, the controller puts it in the context.
+func (r resolver) scoped(ctx context.Context) resolver {
+	r.event, _ = ctx.Value(eventKey{}).(uint)
+	r.tableSvc = r.tableSvc.ForEvent(r.event)
+	r.guestSvc = r.guestSvc.ForEvent(r.event)
+	return r
+}
+
+func (r resolver) tables(p graphql.ResolveParams) (interface{}, error) {
+	r = r.scoped(p.Context)
+	list, err := r.tableSvc.List()
+	if err != nil {
+		return nil, err
+	}
+	views := make([]tableView, 0, len(list))
+	for _, t := range list {
+		views = append(views, mapTable(t))
+	}
+	return views, nil
+}
+
+func (r resolver) table(p graphql.ResolveParams) (interface{}, error) {
+	r = r.scoped(p.Context)
+	t, err := r.tableSvc.GetByID(uint(p.Args["id"].(int)))
+	if errors.Is(err, tables.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mapTable(t), nil
+}
+
+func (r resolver) tableGuests(p graphql.ResolveParams) (interface{}, error) {
+	r = r.scoped(p.Context)
+	filter := guests.Filter{Table: p.Source.(tableView).ID}
+	if status, ok := p.Args["status"].(guests.Status); ok {
+		filter.Status = status
+	}
+	return r.listGuests(filter)
+}
+
+func (r resolver) guests(p graphql.ResolveParams) (interface{}, error) {
+	r = r.scoped(p.Context)
+	filter := guests.Filter{}
+	if status, ok := p.Args["status"].(guests.Status); ok {
+		filter.Status = status
+	}
+	if table, ok := p.Args["table"].(int); ok {
+		filter.Table = uint(table)
+	}
+	return r.listGuests(filter)
+}
+
+func (r resolver) guest(p graphql.ResolveParams) (interface{}, error) {
+	r = r.scoped(p.Context)
+	g, err := r.findGuest(p.Args["name"].(string))
+	if errors.Is(err, errGuestNotFound) {
+		return nil, nil
+	}
+	return g, err
+}
+
+func (r resolver) guestTable(p graphql.ResolveParams) (interface{}, error) {
+	r = r.scoped(p.Context)
+	return r.tableByID(p.Source.(guestView).TableID)
+}
+
+func (r resolver) occupancyTable(p graphql.ResolveParams) (interface{}, error) {
+	r = r.scoped(p.Context)
+	return r.tableByID(p.Source.(occupancyView).TableID)
+}
+
+func (r resolver) seatsEmpty(p graphql.ResolveParams) (interface{}, error) {
+	r = r.scoped(p.Context)
+	return r.tableSvc.CountEmptySeats(), nil
+}
+
+func (r resolver) createTable(p graphql.ResolveParams) (interface{}, error) {
+	r = r.scoped(p.Context)
+	capacity := int64(p.Args["capacity"].(int))
+	if capacity <= 0 {
+		return nil, errors.New("capacity must be greater than zero")
+	}
+	res, err := r.tableSvc.Create(tables.CreateRequest{Capacity: capacity})
+	if err != nil {
+		return nil, err
+	}
+	return tableView{ID: res.ID, Capacity: res.Capacity, EmptySeats: res.Capacity}, nil
+}
+
+func (r resolver) inviteGuest(p graphql.ResolveParams) (interface{}, error) {
+	r = r.scoped(p.Context)
+	req := guests.CreateRequest{
+		Name:         p.Args["name"].(string),
+		Table:        uint(p.Args["table"].(int)),
+		Accompanying: int64(p.Args["accompanyingGuests"].(int)),
+	}
+	if req.Accompanying < 0 {
+		return nil, errors.New("accompanying guests can't be negative")
+	}
+	if _, err := r.guestSvc.Create(req); err != nil {
+		return nil, err
+	}
+	return r.findGuest(req.Name)
+}
+
+func (r resolver) checkIn(p graphql.ResolveParams) (interface{}, error) {
+	r = r.scoped(p.Context)
+	req := guests.CheckInRequest{
+		Name:         p.Args["name"].(string),
+		Accompanying: int64(p.Args["accompanyingGuests"].(int)),
+	}
+	if req.Accompanying < 0 {
+		return nil, errors.New("accompanying guests can't be negative")
+	}
+	if _, err := r.guestSvc.CheckIn(req); err != nil {
+		return nil, err
+	}
+	return r.findGuest(req.Name)
+}
+
+func (r resolver) checkOut(p graphql.ResolveParams) (interface{}, error) {
+	r = r.scoped(p.Context)
+	name := p.Args["name"].(string)
+	if err := r.guestSvc.CheckOut(name); err != nil {
+		return nil, err
+	}
+	return r.findGuest(name)
+}
+
+// subscribeOccupancy streams the check ins and check outs of the event until the request context is done.
+func (r resolver) subscribeOccupancy(p graphql.ResolveParams) (interface{}, error) {
+	r = r.scoped(p.Context)
+	table, _ := p.Args["table"].(int)
+	ch, cancel := r.broker.Subscribe()
+	out := make(chan interface{})
+	go func() {
+		defer close(out)
+		defer cancel()
+		for {
+			select {
+			case <-p.Context.Done():
+				return
+			case n, ok := <-ch:
+				if !ok {
+					return
+				}
+				if !n.IsOccupancyChange() || n.EventID != r.event || (table != 0 && uint(table) != n.TableID) {
+					continue
+				}
+				select {
+				case out <- mapOccupancy(n):
+				case <-p.Context.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+func (r resolver) listGuests(filter guests.Filter) ([]guestView, error) {
+	list, err := r.guestSvc.List(filter)
+	if err != nil {
+		return nil, err
+	}
+	views := make([]guestView, 0, len(list))
+	for _, g := range list {
+		views = append(views, mapGuest(g))
+	}
+	return views, nil
+}
+
+func (r resolver) findGuest(name string) (interface{}, error) {
+	list, err := r.guestSvc.List(guests.Filter{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, errGuestNotFound
+	}
+	return mapGuest(list[0]), nil
+}
+
+func (r resolver) tableByID(id uint) (interface{}, error) {
+	t, err := r.tableSvc.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return mapTable(t), nil
+}
+
+func mapTable(t tables.Table) tableView {
+	return tableView{ID: t.ID, Capacity: t.Capacity, EmptySeats: t.EmptySeats}
+}
+
+func mapGuest(g guests.Guest) guestView {
+	v := guestView{
+		Name:         g.Name,
+		Accompanying: g.Accompanying,
+		Status:       g.Status(),
+		TableID:      g.TableID,
+	}
+	if g.TimeArrived != nil {
+		arrived := g.TimeArrived.Format(time.RFC3339)
+		v.TimeArrived = &arrived
+	}
+	return v
+}
+
+func mapOccupancy(n notifications.Notification) occupancyView {
+	return occupancyView{
+		Kind:         n.Type,
+		Guest:        n.Guest,
+		Accompanying: n.Accompanying,
+		EmptySeats:   n.EmptySeats,
+		OccurredAt:   n.OccurredAt.Format(time.RFC3339),
+		TableID:      n.TableID,
+	}
+}
@@ -0,0 +1,193 @@
+// Package gql serves the guests and tables services as a GraphQL schema for the guest dashboard.
+package gql
+
+import (
+	"github.com/getground/tech-tasks/backend/definitions/guests"
+	"github.com/getground/tech-tasks/backend/definitions/notifications"
+	"github.com/getground/tech-tasks/backend/definitions/tables"
+	"github.com/graphql-go/graphql"
+)
+
+// NewSchema builds the schema, every resolver maps onto the given services.
+func NewSchema(tableSvc tables.Service, guestSvc guests.Service, broker notifications.Broker) (graphql.Schema, error) {
+	r := resolver{tableSvc: tableSvc, guestSvc: guestSvc, broker: broker}
+
+	statusEnum := graphql.NewEnum(
+		graphql.EnumConfig{
+			Name: "GuestStatus",
+			Values: graphql.EnumValueConfigMap{
+				"NOT_ARRIVED": &graphql.EnumValueConfig{Value: guests.StatusNotArrived},
+				"ARRIVED":     &graphql.EnumValueConfig{Value: guests.StatusArrived},
+				"LEFT":        &graphql.EnumValueConfig{Value: guests.StatusLeft},
+			},
+		},
+	)
+
+	tableType := graphql.NewObject(
+		graphql.ObjectConfig{
+			Name: "Table",
+			Fields: graphql.Fields{
+				"id":         &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+				"capacity":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+				"emptySeats": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			},
+		},
+	)
+
+	guestType := graphql.NewObject(
+		graphql.ObjectConfig{
+			Name: "Guest",
+			Fields: graphql.Fields{
+				"name":               &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"accompanyingGuests": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+				"timeArrived":        &graphql.Field{Type: graphql.String},
+				"status":             &graphql.Field{Type: graphql.NewNonNull(statusEnum)},
+				"table": &graphql.Field{
+					Type:    tableType,
+					Resolve: r.guestTable,
+				},
+			},
+		},
+	)
+
+	// the relations are added once both types exist
+	tableType.AddFieldConfig(
+		"guests", &graphql.Field{
+			Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(guestType))),
+			Args: graphql.FieldConfigArgument{
+				"status": &graphql.ArgumentConfig{Type: statusEnum},
+			},
+			Resolve: r.tableGuests,
+		},
+	)
+
+	occupancyKindEnum := graphql.NewEnum(
+		graphql.EnumConfig{
+			Name: "OccupancyKind",
+			Values: graphql.EnumValueConfigMap{
+				"CHECKED_IN":  &graphql.EnumValueConfig{Value: notifications.GuestCheckedIn},
+				"CHECKED_OUT": &graphql.EnumValueConfig{Value: notifications.GuestCheckedOut},
+			},
+		},
+	)
+
+	occupancyType := graphql.NewObject(
+		graphql.ObjectConfig{
+			Name: "OccupancyChange",
+			Fields: graphql.Fields{
+				"kind":               &graphql.Field{Type: graphql.NewNonNull(occupancyKindEnum)},
+				"guest":              &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"accompanyingGuests": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+				"emptySeats":         &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+				"occurredAt":         &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"table": &graphql.Field{
+					Type:    tableType,
+					Resolve: r.occupancyTable,
+				},
+			},
+		},
+	)
+
+	query := graphql.NewObject(
+		graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"tables": &graphql.Field{
+					Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(tableType))),
+					Resolve: r.tables,
+				},
+				"table": &graphql.Field{
+					Type: tableType,
+					Args: graphql.FieldConfigArgument{
+						"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+					},
+					Resolve: r.table,
+				},
+				"guests": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(guestType))),
+					Args: graphql.FieldConfigArgument{
+						"status": &graphql.ArgumentConfig{Type: statusEnum},
+						"table":  &graphql.ArgumentConfig{Type: graphql.Int},
+					},
+					Resolve: r.guests,
+				},
+				"guest": &graphql.Field{
+					Type: guestType,
+					Args: graphql.FieldConfigArgument{
+						"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					},
+					Resolve: r.guest,
+				},
+				"seatsEmpty": &graphql.Field{
+					Type:    graphql.NewNonNull(graphql.Int),
+					Resolve: r.seatsEmpty,
+				},
+			},
+		},
+	)
+
+	mutation := graphql.NewObject(
+		graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.Fields{
+				"createTable": &graphql.Field{
+					Type: graphql.NewNonNull(tableType),
+					Args: graphql.FieldConfigArgument{
+						"capacity": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+					},
+					Resolve: r.createTable,
+				},
+				"inviteGuest": &graphql.Field{
+					Type: graphql.NewNonNull(guestType),
+					Args: graphql.FieldConfigArgument{
+						"name":               &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+						"table":              &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+						"accompanyingGuests": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+					},
+					Resolve: r.inviteGuest,
+				},
+				"checkIn": &graphql.Field{
+					Type: graphql.NewNonNull(guestType),
+					Args: graphql.FieldConfigArgument{
+						"name":               &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+						"accompanyingGuests": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+					},
+					Resolve: r.checkIn,
+				},
+				"checkOut": &graphql.Field{
+					Type: graphql.NewNonNull(guestType),
+					Args: graphql.FieldConfigArgument{
+						"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					},
+					Resolve: r.checkOut,
+				},
+			},
+		},
+	)
+
+	subscription := graphql.NewObject(
+		graphql.ObjectConfig{
+			Name: "Subscription",
+			Fields: graphql.Fields{
+				"occupancyChanged": &graphql.Field{
+					Type: graphql.NewNonNull(occupancyType),
+					Args: graphql.FieldConfigArgument{
+						"table": &graphql.ArgumentConfig{Type: graphql.Int},
+					},
+					Subscribe: r.subscribeOccupancy,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Source, nil
+					},
+				},
+			},
+		},
+	)
+
+	return graphql.NewSchema(
+		graphql.SchemaConfig{
+			Query:        query,
+			Mutation:     mutation,
+			Subscription: subscription,
+		},
+	)
+}
@@ -0,0 +1,408 @@
+package gql_test
+
+import (
+	"context"
+	"errors"
+	guestsDef "github.com/getground/tech-tasks/backend/definitions/guests"
+	notificationsDef "github.com/getground/tech-tasks/backend/definitions/notifications"
+	tablesDef "github.com/getground/tech-tasks/backend/definitions/tables"
+	guestsMocks "github.com/getground/tech-tasks/backend/mocks/definitions/guests"
+	tableMocks "github.com/getground/tech-tasks/backend/mocks/definitions/tables"
+	"github.com/getground/tech-tasks/backend/pkg/gql"
+	"github.com/getground/tech-tasks/backend/pkg/notifications"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+type schemaMocks struct {
+	tableService *tableMocks.Service
+	guestService *guestsMocks.Service
+	broker       *notifications.Broker
+}
+
+func setupSchema(t *testing.T) (graphql.Schema, schemaMocks) {
+	tblService := new(tableMocks.Service)
+	guestService := new(guestsMocks.Service)
+	broker := notifications.NewBroker()
+	// the resolvers scope the services to the event of the request
+	tblService.On("ForEvent", mock.Anything).Return(tblService).Maybe()
+	guestService.On("ForEvent", mock.Anything).Return(guestService).Maybe()
+	schema, err := gql.NewSchema(tblService, guestService, broker)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when building the schema", err)
+	}
+	return schema, schemaMocks{tblService, guestService, broker}
+}
+
+func do(schema graphql.Schema, query string) *graphql.Result {
+	return graphql.Do(graphql.Params{Schema: schema, RequestString: query, Context: context.Background()})
+}
+
+func TestSchema_Tables(t *testing.T) {
+	schema, m := setupSchema(t)
+	t.Run(
+		"service error", func(t *testing.T) {
+			//	mocks
+			m.tableService.On("List").Return(nil, errors.New("error listing tables")).Once()
+
+			//	method call
+			res := do(schema, `{ tables { id } }`)
+
+			//	assert
+			assert.True(t, res.HasErrors())
+			m.tableService.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"tables with their guests", func(t *testing.T) {
+			// test data
+			arrived := time.Date(2022, 12, 16, 20, 0, 0, 0, time.UTC)
+
+			//	mocks
+			m.tableService.On("List").Return([]tablesDef.Table{{ID: 1, Capacity: 2, EmptySeats: 5}}, nil).Once()
+			m.guestService.On("List", guestsDef.Filter{Table: 1, Status: guestsDef.StatusArrived}).Return(
+				[]guestsDef.Guest{{Name: "sam", TableID: 1, Accompanying: 2, TimeArrived: &arrived}}, nil,
+			).Once()
+
+			//	method call
+			res := do(schema, `{ tables { id capacity emptySeats guests(status: ARRIVED) { name timeArrived status } } }`)
+
+			// expectation
+			expected := map[string]interface{}{
+				"tables": []interface{}{
+					map[string]interface{}{
+						"id":         1,
+						"capacity":   2,
+						"emptySeats": 5,
+						"guests": []interface{}{
+							map[string]interface{}{
+								"name":        "sam",
+								"timeArrived": "2022-12-16T20:00:00Z",
+								"status":      "ARRIVED",
+							},
+						},
+					},
+				},
+			}
+
+			//	assert
+			assert.False(t, res.HasErrors(), res.Errors)
+			assert.Equal(t, expected, res.Data)
+			m.tableService.AssertExpectations(t)
+			m.guestService.AssertExpectations(t)
+		},
+	)
+}
+
+func TestSchema_Table(t *testing.T) {
+	schema, m := setupSchema(t)
+	t.Run(
+		"not found", func(t *testing.T) {
+			//	mocks
+			m.tableService.On("GetByID", uint(4)).Return(tablesDef.Table{}, tablesDef.ErrNotFound).Once()
+
+			//	method call
+			res := do(schema, `{ table(id: 4) { id } }`)
+
+			//	assert
+			assert.False(t, res.HasErrors(), res.Errors)
+			assert.Equal(t, map[string]interface{}{"table": nil}, res.Data)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			//	mocks
+			m.tableService.On("GetByID", uint(1)).Return(tablesDef.Table{ID: 1, Capacity: 3, EmptySeats: 3}, nil).Once()
+
+			//	method call
+			res := do(schema, `{ table(id: 1) { id emptySeats } }`)
+
+			//	assert
+			assert.False(t, res.HasErrors(), res.Errors)
+			assert.Equal(t, map[string]interface{}{"table": map[string]interface{}{"id": 1, "emptySeats": 3}}, res.Data)
+			m.tableService.AssertExpectations(t)
+		},
+	)
+}
+
+func TestSchema_Guests(t *testing.T) {
+	schema, m := setupSchema(t)
+	t.Run(
+		"service error", func(t *testing.T) {
+			//	mocks
+			m.guestService.On("List", guestsDef.Filter{}).Return(nil, errors.New("error retrieving")).Once()
+
+			//	method call
+			res := do(schema, `{ guests { name } }`)
+
+			//	assert
+			assert.True(t, res.HasErrors())
+		},
+	)
+
+	t.Run(
+		"guests that left with their table", func(t *testing.T) {
+			//	mocks
+			arrived := time.Date(2022, 12, 16, 20, 0, 0, 0, time.UTC)
+			m.guestService.On("List", guestsDef.Filter{Table: 2, Status: guestsDef.StatusLeft}).Return(
+				[]guestsDef.Guest{{Name: "sam", TableID: 2, Accompanying: 1, TimeArrived: &arrived, CheckedOut: 1}}, nil,
+			).Once()
+			m.tableService.On("GetByID", uint(2)).Return(tablesDef.Table{ID: 2, Capacity: 1, EmptySeats: 4}, nil).Once()
+
+			//	method call
+			res := do(schema, `{ guests(status: LEFT, table: 2) { name status table { id emptySeats } } }`)
+
+			// expectation
+			expected := map[string]interface{}{
+				"guests": []interface{}{
+					map[string]interface{}{
+						"name":   "sam",
+						"status": "LEFT",
+						"table":  map[string]interface{}{"id": 2, "emptySeats": 4},
+					},
+				},
+			}
+
+			//	assert
+			assert.False(t, res.HasErrors(), res.Errors)
+			assert.Equal(t, expected, res.Data)
+			m.guestService.AssertExpectations(t)
+			m.tableService.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"guest not arrived", func(t *testing.T) {
+			//	mocks
+			m.guestService.On("List", guestsDef.Filter{Name: "sam"}).Return(
+				[]guestsDef.Guest{{Name: "sam", TableID: 2, Accompanying: 1}}, nil,
+			).Once()
+
+			//	method call
+			res := do(schema, `{ guest(name: "sam") { name timeArrived status } }`)
+
+			// expectation
+			expected := map[string]interface{}{
+				"guest": map[string]interface{}{"name": "sam", "timeArrived": nil, "status": "NOT_ARRIVED"},
+			}
+
+			//	assert
+			assert.False(t, res.HasErrors(), res.Errors)
+			assert.Equal(t, expected, res.Data)
+		},
+	)
+
+	t.Run(
+		"guest not found", func(t *testing.T) {
+			//	mocks
+			m.guestService.On("List", guestsDef.Filter{Name: "nobody"}).Return([]guestsDef.Guest{}, nil).Once()
+
+			//	method call
+			res := do(schema, `{ guest(name: "nobody") { name } }`)
+
+			//	assert
+			assert.False(t, res.HasErrors(), res.Errors)
+			assert.Equal(t, map[string]interface{}{"guest": nil}, res.Data)
+		},
+	)
+}
+
+func TestSchema_SeatsEmpty(t *testing.T) {
+	schema, m := setupSchema(t)
+
+	//	mocks
+	m.tableService.On("CountEmptySeats").Return(9).Once()
+
+	//	method call
+	res := do(schema, `{ seatsEmpty }`)
+
+	//	assert
+	assert.False(t, res.HasErrors(), res.Errors)
+	assert.Equal(t, map[string]interface{}{"seatsEmpty": 9}, res.Data)
+}
+
+func TestSchema_Mutations(t *testing.T) {
+	schema, m := setupSchema(t)
+	t.Run(
+		"create table invalid capacity", func(t *testing.T) {
+			//	method call
+			res := do(schema, `mutation { createTable(capacity: 0) { id } }`)
+
+			//	assert
+			assert.True(t, res.HasErrors())
+			m.tableService.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"create table", func(t *testing.T) {
+			//	mocks
+			m.tableService.On("Create", tablesDef.CreateRequest{Capacity: 6}).Return(
+				tablesDef.CreateResponse{ID: 3, Capacity: 6}, nil,
+			).Once()
+
+			//	method call
+			res := do(schema, `mutation { createTable(capacity: 6) { id capacity emptySeats } }`)
+
+			//	assert
+			assert.False(t, res.HasErrors(), res.Errors)
+			assert.Equal(
+				t, map[string]interface{}{
+					"createTable": map[string]interface{}{"id": 3, "capacity": 6, "emptySeats": 6},
+				}, res.Data,
+			)
+		},
+	)
+
+	t.Run(
+		"invite guest error", func(t *testing.T) {
+			//	mocks
+			req := guestsDef.CreateRequest{Name: "sam", Table: 1, Accompanying: 9}
+			m.guestService.On("Create", req).Return(guestsDef.CreateResponse{}, guestsDef.ErrNoCapacity).Once()
+
+			//	method call
+			res := do(schema, `mutation { inviteGuest(name: "sam", table: 1, accompanyingGuests: 9) { name } }`)
+
+			//	assert
+			assert.True(t, res.HasErrors())
+			assert.Equal(t, guestsDef.ErrNoCapacity.Error(), res.Errors[0].Message)
+		},
+	)
+
+	t.Run(
+		"invite guest", func(t *testing.T) {
+			//	mocks
+			req := guestsDef.CreateRequest{Name: "sam", Table: 1, Accompanying: 2}
+			m.guestService.On("Create", req).Return(guestsDef.CreateResponse{Name: "sam"}, nil).Once()
+			m.guestService.On("List", guestsDef.Filter{Name: "sam"}).Return(
+				[]guestsDef.Guest{{Name: "sam", TableID: 1, Accompanying: 2}}, nil,
+			).Once()
+
+			//	method call
+			res := do(schema, `mutation { inviteGuest(name: "sam", table: 1, accompanyingGuests: 2) { name status } }`)
+
+			//	assert
+			assert.False(t, res.HasErrors(), res.Errors)
+			assert.Equal(
+				t, map[string]interface{}{
+					"inviteGuest": map[string]interface{}{"name": "sam", "status": "NOT_ARRIVED"},
+				}, res.Data,
+			)
+		},
+	)
+
+	t.Run(
+		"check in", func(t *testing.T) {
+			//	mocks
+			arrived := time.Now()
+			req := guestsDef.CheckInRequest{Name: "sam", Accompanying: 3}
+			m.guestService.On("CheckIn", req).Return(guestsDef.CheckInResponse{Name: "sam"}, nil).Once()
+			m.guestService.On("List", guestsDef.Filter{Name: "sam"}).Return(
+				[]guestsDef.Guest{{Name: "sam", TableID: 1, Accompanying: 3, TimeArrived: &arrived}}, nil,
+			).Once()
+
+			//	method call
+			res := do(schema, `mutation { checkIn(name: "sam", accompanyingGuests: 3) { status accompanyingGuests } }`)
+
+			//	assert
+			assert.False(t, res.HasErrors(), res.Errors)
+			assert.Equal(
+				t, map[string]interface{}{
+					"checkIn": map[string]interface{}{"status": "ARRIVED", "accompanyingGuests": 3},
+				}, res.Data,
+			)
+		},
+	)
+
+	t.Run(
+		"check out error", func(t *testing.T) {
+			//	mocks
+			m.guestService.On("CheckOut", "sam").Return(errors.New("guest not checked in")).Once()
+
+			//	method call
+			res := do(schema, `mutation { checkOut(name: "sam") { status } }`)
+
+			//	assert
+			assert.True(t, res.HasErrors())
+		},
+	)
+
+	t.Run(
+		"check out", func(t *testing.T) {
+			//	mocks
+			arrived := time.Now()
+			m.guestService.On("CheckOut", "sam").Return(nil).Once()
+			m.guestService.On("List", guestsDef.Filter{Name: "sam"}).Return(
+				[]guestsDef.Guest{{Name: "sam", TableID: 1, TimeArrived: &arrived, CheckedOut: 1}}, nil,
+			).Once()
+
+			//	method call
+			res := do(schema, `mutation { checkOut(name: "sam") { status } }`)
+
+			//	assert
+			assert.False(t, res.HasErrors(), res.Errors)
+			assert.Equal(t, map[string]interface{}{"checkOut": map[string]interface{}{"status": "LEFT"}}, res.Data)
+			m.guestService.AssertExpectations(t)
+		},
+	)
+}
+
+func TestSchema_OccupancyChanged(t *testing.T) {
+	schema, m := setupSchema(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	//	mocks
+	m.tableService.On("GetByID", uint(1)).Return(tablesDef.Table{ID: 1, Capacity: 0, EmptySeats: 2}, nil)
+
+	//	method call
+	results := graphql.Subscribe(
+		graphql.Params{
+			Schema:        schema,
+			RequestString: `subscription { occupancyChanged(table: 1) { kind guest emptySeats table { id } } }`,
+			Context:       ctx,
+		},
+	)
+
+	// the subscription is registered asynchronously, publish until the first result arrives
+	var res *graphql.Result
+	for res == nil {
+		m.broker.Publish(notificationsDef.Notification{Type: notificationsDef.GuestInvited, TableID: 1})
+		m.broker.Publish(notificationsDef.Notification{Type: notificationsDef.GuestCheckedIn, TableID: 2})
+		// the same table of another event
+		m.broker.Publish(
+			notificationsDef.Notification{Type: notificationsDef.GuestCheckedIn, Guest: "kim", TableID: 1, EventID: 3},
+		)
+		m.broker.Publish(
+			notificationsDef.Notification{
+				Type: notificationsDef.GuestCheckedOut, Guest: "sam", TableID: 1, EmptySeats: 2,
+			},
+		)
+		select {
+		case res = <-results:
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	cancel()
+	for range results {
+	}
+
+	// expectation
+	expected := map[string]interface{}{
+		"occupancyChanged": map[string]interface{}{
+			"kind":       "CHECKED_OUT",
+			"guest":      "sam",
+			"emptySeats": 2,
+			"table":      map[string]interface{}{"id": 1},
+		},
+	}
+
+	//	assert
+	assert.False(t, res.HasErrors(), res.Errors)
+	assert.Equal(t, expected, res.Data)
+}
@@ -0,0 +1,41 @@
+// Package journal writes what a change of the guest list leaves behind in the transaction of the change: the audit
+// entry, the outbox message announcing it and the attendance record. The repositories making the changes share it,
+// so the guest list and the waitlist record their changes alike.
+package journal
+
+import (
+	"github.com/getground/tech-tasks/backend/definitions/attendance"
+	"github.com/getground/tech-tasks/backend/definitions/audit"
+	"github.com/getground/tech-tasks/backend/definitions/guests"
+	"github.com/getground/tech-tasks/backend/definitions/outbox"
+	"gorm.io/gorm"
+	"time"
+)
+
+// Record appends the change made by the actor at the time to the audit log of the event and its message to the outbox.
+func Record(tx *gorm.DB, event uint, actor string, action audit.Action, before, after audit.State, at time.Time) error {
+	e := audit.NewEntry(event, actor, action, before, after, at)
+	err := tx.Create(&e).Error
+	if err != nil {
+		return err
+	}
+	m, err := outbox.NewMessage(e)
+	if err != nil {
+		return err
+	}
+	return tx.Create(&m).Error
+}
+
+// Track appends the fact about the party of the guest to the attendance stream of the event.
+func Track(tx *gorm.DB, event uint, typ attendance.Type, g guests.Guest, at time.Time) error {
+	rec := attendance.Record{
+		EventID:    event,
+		Type:       typ,
+		Guest:      g.Name,
+		TableID:    g.TableID,
+		People:     g.Accompanying + 1,
+		RSVP:       string(g.RSVP),
+		OccurredAt: at,
+	}
+	return tx.Create(&rec).Error
+}
@@ -0,0 +1,78 @@
+package attendance
+
+import (
+	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/attendance"
+	"github.com/getground/tech-tasks/backend/definitions/events"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type Controller struct {
+	handler Handler
+	service attendance.Service
+}
+
+func NewController(handler Handler, service attendance.Service) Controller {
+	return Controller{
+		handler: handler,
+		service: service,
+	}
+}
+
+func (ctrl Controller) Occupancy(c *gin.Context) {
+	req, err := ctrl.handler.Occupancy(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.service.ForEvent(c.GetUint(events.ContextKey)).Occupancy(req)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusInternalServerError, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (ctrl Controller) Report(c *gin.Context) {
+	req, err := ctrl.handler.Report(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.service.ForEvent(c.GetUint(events.ContextKey)).Report(req)
+	if err != nil {
+		log.Error(err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, attendance.ErrInvalidBucket) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(
+			status, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,169 @@
+package attendance_test
+
+import (
+	"errors"
+	attendanceDef "github.com/getground/tech-tasks/backend/definitions/attendance"
+	attendanceMocks "github.com/getground/tech-tasks/backend/mocks/definitions/attendance"
+	"github.com/getground/tech-tasks/backend/pkg/modules/attendance"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestController_Occupancy(t *testing.T) {
+	//	setup
+	r := gin.Default()
+	gin.SetMode(gin.TestMode)
+	service := new(attendanceMocks.Service)
+	// the requests are not nested under an event, they are scoped to the zero event
+	service.On("ForEvent", uint(0)).Return(service).Maybe()
+	r.GET("/attendance/occupancy", attendance.NewController(attendance.NewHandler(), service).Occupancy)
+	at := time.Date(2022, 11, 5, 22, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		query    string
+		req      *attendanceDef.OccupancyRequest
+		res      attendanceDef.OccupancyDTO
+		err      error
+		code     int
+		expected string
+	}{
+		{name: "invalid time", query: "?at=22:30", code: http.StatusBadRequest},
+		{
+			name: "service error",
+			req:  &attendanceDef.OccupancyRequest{},
+			err:  errors.New("internal error"),
+			code: http.StatusInternalServerError,
+		},
+		{
+			name:  "success",
+			query: "?at=2022-11-05T22:30:00Z&table=4",
+			req:   &attendanceDef.OccupancyRequest{At: &at, Table: 4},
+			res: attendanceDef.OccupancyDTO{
+				At:     at,
+				Seated: 3,
+				Tables: []attendanceDef.TableOccupancyDTO{
+					{
+						ID: 4, Seats: 10, Seated: 3, EmptySeats: 7,
+						Guests: []attendanceDef.SeatedGuestDTO{
+							{Name: "sam", AccompanyingGuests: 2, TimeArrived: at.Add(-time.Hour)},
+						},
+					},
+				},
+			},
+			code: http.StatusOK,
+			expected: `{"at":"2022-11-05T22:30:00Z","seated":3,"tables":[{"id":4,"seats":10,"seated":3,` +
+				`"empty_seats":7,"guests":[{"name":"sam","accompanying_guests":2,"time_arrived":"2022-11-05T21:30:00Z"}]}]}`,
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				//	mocks
+				if c.req != nil {
+					service.On("Occupancy", *c.req).Return(c.res, c.err).Once()
+				}
+
+				//	request
+				req, err := http.NewRequest(http.MethodGet, "/attendance/occupancy"+c.query, nil)
+				if err != nil {
+					t.Errorf("Error requesting test controller: %v\n", err)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, req)
+
+				//	assert
+				assert.Equal(t, c.code, rr.Code)
+				if c.expected != "" {
+					assert.Equal(t, c.expected, rr.Body.String())
+				}
+				service.AssertExpectations(t)
+			},
+		)
+	}
+}
+
+func TestController_Report(t *testing.T) {
+	//	setup
+	r := gin.Default()
+	gin.SetMode(gin.TestMode)
+	service := new(attendanceMocks.Service)
+	service.On("ForEvent", uint(0)).Return(service).Maybe()
+	r.GET("/attendance/report", attendance.NewController(attendance.NewHandler(), service).Report)
+	at := time.Date(2022, 11, 5, 22, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		query    string
+		req      *attendanceDef.ReportRequest
+		res      attendanceDef.ReportDTO
+		err      error
+		code     int
+		expected string
+	}{
+		{name: "invalid bucket", query: "?bucket=often", code: http.StatusBadRequest},
+		{
+			name:  "bucket too small",
+			query: "?bucket=30s",
+			req:   &attendanceDef.ReportRequest{Bucket: 30 * time.Second},
+			err:   attendanceDef.ErrInvalidBucket,
+			code:  http.StatusBadRequest,
+		},
+		{
+			name: "service error",
+			req:  &attendanceDef.ReportRequest{Bucket: attendanceDef.DefaultBucket},
+			err:  errors.New("internal error"),
+			code: http.StatusInternalServerError,
+		},
+		{
+			name:  "success",
+			query: "?bucket=1h",
+			req:   &attendanceDef.ReportRequest{Bucket: time.Hour},
+			res: attendanceDef.ReportDTO{
+				Timeline: []attendanceDef.BucketDTO{{Start: at, Arrivals: 3, Occupancy: 3}},
+				Peak:     attendanceDef.PeakDTO{Headcount: 3, At: &at},
+				Tables: []attendanceDef.TableAttendanceDTO{
+					{ID: 1, Invited: 2, Arrived: 1, NoShows: 1, NoShowRate: 0.5},
+				},
+				AverageStayMinutes: 90,
+				Entourage:          attendanceDef.EntourageDTO{Parties: 1, Expected: 1, Actual: 2},
+			},
+			code: http.StatusOK,
+			expected: `{"timeline":[{"start":"2022-11-05T22:30:00Z","arrivals":3,"departures":0,"occupancy":3}],` +
+				`"peak":{"headcount":3,"at":"2022-11-05T22:30:00Z"},"tables":[{"id":1,"invited":2,"arrived":1,` +
+				`"walk_ins":0,"no_shows":1,"no_show_rate":0.5}],"average_stay_minutes":90,` +
+				`"entourage":{"parties":1,"expected":1,"actual":2}}`,
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				//	mocks
+				if c.req != nil {
+					service.On("Report", *c.req).Return(c.res, c.err).Once()
+				}
+
+				//	request
+				req, err := http.NewRequest(http.MethodGet, "/attendance/report"+c.query, nil)
+				if err != nil {
+					t.Errorf("Error requesting test controller: %v\n", err)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, req)
+
+				//	assert
+				assert.Equal(t, c.code, rr.Code)
+				if c.expected != "" {
+					assert.Equal(t, c.expected, rr.Body.String())
+				}
+				service.AssertExpectations(t)
+			},
+		)
+	}
+}
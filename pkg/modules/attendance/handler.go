@@ -0,0 +1,25 @@
+package attendance
+
+import (
+	"github.com/getground/tech-tasks/backend/definitions/attendance"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct{}
+
+func NewHandler() Handler {
+	return Handler{}
+}
+
+func (h Handler) Occupancy(c *gin.Context) (req attendance.OccupancyRequest, err error) {
+	err = c.ShouldBindQuery(&req)
+	return
+}
+
+func (h Handler) Report(c *gin.Context) (req attendance.ReportRequest, err error) {
+	err = c.ShouldBindQuery(&req)
+	if req.Bucket == 0 {
+		req.Bucket = attendance.DefaultBucket
+	}
+	return
+}
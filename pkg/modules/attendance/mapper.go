@@ -0,0 +1,36 @@
+package attendance
+
+import (
+	"github.com/getground/tech-tasks/backend/definitions/attendance"
+	"time"
+)
+
+// mapOccupancyToDTO maps the occupancy at the time, the times are answered in loc.
+func mapOccupancyToDTO(at time.Time, o attendance.Occupancy, loc *time.Location) attendance.OccupancyDTO {
+	dto := attendance.OccupancyDTO{At: at.In(loc), Tables: make([]attendance.TableOccupancyDTO, 0, len(o.Tables))}
+	for _, t := range o.Tables {
+		dto.Seated += t.Seated
+		dto.Tables = append(dto.Tables, mapTableToDTO(t, loc))
+	}
+	return dto
+}
+
+func mapTableToDTO(t attendance.TableOccupancy, loc *time.Location) attendance.TableOccupancyDTO {
+	guests := make([]attendance.SeatedGuestDTO, 0, len(t.Parties))
+	for _, p := range t.Parties {
+		guests = append(
+			guests, attendance.SeatedGuestDTO{
+				Name:               p.Guest,
+				AccompanyingGuests: p.People - 1,
+				TimeArrived:        p.TimeArrived.In(loc),
+			},
+		)
+	}
+	return attendance.TableOccupancyDTO{
+		ID:         t.ID,
+		Seats:      t.Seats,
+		Seated:     t.Seated,
+		EmptySeats: t.EmptySeats,
+		Guests:     guests,
+	}
+}
@@ -0,0 +1,153 @@
+package attendance
+
+import (
+	"github.com/getground/tech-tasks/backend/definitions/attendance"
+	"github.com/getground/tech-tasks/backend/definitions/guests"
+	"sort"
+	"time"
+)
+
+// party is the attendance of a guest and its accompanying guests, invited is zero for the walk-ins.
+type party struct {
+	table    uint
+	invited  int64
+	arrived  int64
+	arrival  time.Time
+	left     time.Time
+	checkIn  bool
+	checkOut bool
+	declined bool
+}
+
+// report folds the records in order into the report of the event, the arrivals and departures are grouped by bucket
+// and the times are answered in loc.
+func report(records []attendance.Record, bucket time.Duration, loc *time.Location) attendance.ReportDTO {
+	var res attendance.ReportDTO
+	tables := make(map[uint]bool)
+	parties := make(map[string]*party)
+	var moves []attendance.Record
+	for _, r := range records {
+		p := parties[r.Guest]
+		switch r.Type {
+		case attendance.TypeTableCreated:
+			tables[r.TableID] = true
+		case attendance.TypeGuestInvited:
+			parties[r.Guest] = &party{table: r.TableID, invited: r.People}
+		case attendance.TypeGuestUninvited:
+			delete(parties, r.Guest)
+		case attendance.TypeGuestResponded, attendance.TypeGuestResized:
+			// the party announced is the last one answered or named before the check in
+			if p != nil {
+				p.invited, p.declined = r.People, r.RSVP == string(guests.RSVPDeclined)
+			}
+		case attendance.TypeGuestCheckedIn:
+			if p == nil {
+				p = &party{}
+				parties[r.Guest] = p
+			}
+			p.table, p.arrived, p.arrival, p.checkIn = r.TableID, r.People, r.OccurredAt, true
+			moves = append(moves, r)
+		case attendance.TypeGuestCheckedOut:
+			if p != nil {
+				p.left, p.checkOut = r.OccurredAt, true
+			}
+			moves = append(moves, r)
+		}
+	}
+
+	res.Timeline, res.Peak = timeline(moves, bucket, loc)
+	res.Tables = tableAttendance(tables, parties)
+
+	var stays time.Duration
+	var left int64
+	for _, p := range parties {
+		if p.checkOut {
+			stays += p.left.Sub(p.arrival)
+			left++
+		}
+		if p.checkIn && p.invited > 0 {
+			res.Entourage.Parties++
+			res.Entourage.Expected += p.invited - 1
+			res.Entourage.Actual += p.arrived - 1
+		}
+	}
+	if left > 0 {
+		res.AverageStayMinutes = (stays / time.Duration(left)).Minutes()
+	}
+	return res
+}
+
+// timeline buckets the check ins and check outs, the buckets between the first arrival and the last move are all
+// listed even when nobody moved. The buckets start on the clock of loc, e.g. on the hour of a venue half an hour off
+// UTC.
+func timeline(
+	moves []attendance.Record, bucket time.Duration, loc *time.Location,
+) ([]attendance.BucketDTO, attendance.PeakDTO) {
+	var peak attendance.PeakDTO
+	buckets := make([]attendance.BucketDTO, 0)
+	var occupancy int64
+	for _, m := range moves {
+		occurred := m.OccurredAt.In(loc)
+		_, offset := occurred.Zone()
+		shift := time.Duration(offset) * time.Second
+		start := occurred.Add(shift).Truncate(bucket).Add(-shift)
+		if len(buckets) == 0 {
+			buckets = append(buckets, attendance.BucketDTO{Start: start})
+		}
+		for buckets[len(buckets)-1].Start.Before(start) {
+			next := buckets[len(buckets)-1].Start.Add(bucket)
+			buckets = append(buckets, attendance.BucketDTO{Start: next, Occupancy: occupancy})
+		}
+
+		b := &buckets[len(buckets)-1]
+		if m.Type == attendance.TypeGuestCheckedIn {
+			b.Arrivals += m.People
+			occupancy += m.People
+		} else {
+			b.Departures += m.People
+			occupancy -= m.People
+		}
+		b.Occupancy = occupancy
+		if occupancy > peak.Headcount {
+			at := occurred
+			peak = attendance.PeakDTO{Headcount: occupancy, At: &at}
+		}
+	}
+	return buckets, peak
+}
+
+func tableAttendance(tables map[uint]bool, parties map[string]*party) []attendance.TableAttendanceDTO {
+	stats := make(map[uint]*attendance.TableAttendanceDTO)
+	for id := range tables {
+		stats[id] = &attendance.TableAttendanceDTO{ID: id}
+	}
+	for _, p := range parties {
+		s, ok := stats[p.table]
+		if !ok {
+			s = &attendance.TableAttendanceDTO{ID: p.table}
+			stats[p.table] = s
+		}
+		switch {
+		case p.invited == 0:
+			s.WalkIns++
+		case p.checkIn:
+			s.Invited++
+			s.Arrived++
+		case p.declined:
+			s.Invited++
+		default:
+			s.Invited++
+			s.NoShows++
+		}
+	}
+
+	list := make([]attendance.TableAttendanceDTO, 0, len(stats))
+	for _, s := range stats {
+		if s.Invited > 0 {
+			s.NoShowRate = float64(s.NoShows) / float64(s.Invited)
+		}
+		list = append(list, *s)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
@@ -0,0 +1,32 @@
+package attendance
+
+import (
+	"github.com/getground/tech-tasks/backend/definitions/attendance"
+	"gorm.io/gorm"
+	"time"
+)
+
+type Repository struct {
+	db    *gorm.DB
+	event uint
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return Repository{
+		db: db,
+	}
+}
+
+func (r Repository) ForEvent(event uint) attendance.Repository {
+	r.event = event
+	return r
+}
+
+func (r Repository) Until(at time.Time, table uint) (records []attendance.Record, err error) {
+	q := r.db.Where("event_id = ?", r.event).Where("occurred_at <= ?", at)
+	if table != 0 {
+		q = q.Where("table_id = ?", table)
+	}
+	err = q.Order("seq").Find(&records).Error
+	return
+}
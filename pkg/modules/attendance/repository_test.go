@@ -0,0 +1,103 @@
+package attendance_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	attendanceDef "github.com/getground/tech-tasks/backend/definitions/attendance"
+	"github.com/getground/tech-tasks/backend/pkg/database"
+	"github.com/getground/tech-tasks/backend/pkg/modules/attendance"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type repoMocks struct {
+	db      *sql.DB
+	sqlMock sqlmock.Sqlmock
+}
+
+var columns = []string{"seq", "event_id", "type", "guest", "table_id", "people", "occurred_at"}
+
+func setupIntegrationRepo(t *testing.T) (attendanceDef.Repository, repoMocks) {
+	db, m, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	msc := mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true})
+	gDB, err := database.NewDatabaseForTests(msc)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating grom database connection", err)
+	}
+	r := attendance.NewRepository(gDB).ForEvent(1)
+	return r, repoMocks{
+		db:      db,
+		sqlMock: m,
+	}
+}
+
+func TestRepository_Until(t *testing.T) {
+	at := time.Date(2022, 11, 5, 22, 30, 0, 0, time.UTC)
+	cases := []struct {
+		name  string
+		table uint
+		q     string
+		args  []driver.Value
+		err   error
+	}{
+		{
+			name: "error",
+			q:    "SELECT * FROM `attendance` WHERE event_id = ? AND occurred_at <= ? ORDER BY seq",
+			args: []driver.Value{1, at},
+			err:  errors.New("internal error"),
+		},
+		{
+			name: "every table",
+			q:    "SELECT * FROM `attendance` WHERE event_id = ? AND occurred_at <= ? ORDER BY seq",
+			args: []driver.Value{1, at},
+		},
+		{
+			name:  "single table",
+			table: 4,
+			q:     "SELECT * FROM `attendance` WHERE event_id = ? AND occurred_at <= ? AND table_id = ? ORDER BY seq",
+			args:  []driver.Value{1, at, 4},
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				// setup
+				repo, m := setupIntegrationRepo(t)
+				defer m.db.Close()
+
+				//	mocks
+				q := m.sqlMock.ExpectQuery(regexp.QuoteMeta(c.q)).WithArgs(c.args...)
+				if c.err != nil {
+					q.WillReturnError(c.err)
+				} else {
+					q.WillReturnRows(
+						sqlmock.NewRows(columns).
+							AddRow(1, 1, attendanceDef.TypeTableCreated, "", 4, 10, at.Add(-time.Hour)).
+							AddRow(2, 1, attendanceDef.TypeGuestCheckedIn, "sam", 4, 3, at.Add(-time.Minute)),
+					)
+				}
+
+				//	method call
+				res, err := repo.Until(at, c.table)
+
+				//	assert
+				if c.err != nil {
+					assert.ErrorIs(t, err, c.err)
+				} else {
+					assert.NoError(t, err)
+					assert.Len(t, res, 2)
+				}
+				assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+			},
+		)
+	}
+}
@@ -0,0 +1,117 @@
+package attendance
+
+import (
+	"github.com/getground/tech-tasks/backend/definitions/attendance"
+	"github.com/getground/tech-tasks/backend/definitions/clock"
+	"sort"
+	"time"
+)
+
+type Service struct {
+	repository attendance.Repository
+	clock      clock.Clock
+	loc        *time.Location
+}
+
+func NewService(repository attendance.Repository) Service {
+	return Service{repository: repository, clock: clock.UTC{}, loc: time.UTC}
+}
+
+// WithClock returns the service telling the time with c.
+func (s Service) WithClock(c clock.Clock) Service {
+	s.clock = c
+	return s
+}
+
+// WithLocation returns the service answering its times in the timezone of the venue.
+func (s Service) WithLocation(loc *time.Location) Service {
+	s.loc = loc
+	return s
+}
+
+func (s Service) ForEvent(event uint) attendance.Service {
+	s.repository = s.repository.ForEvent(event)
+	return s
+}
+
+// Occupancy replays the attendance stream up to the time of the request, or up to now when it is not set. The times
+// are answered in the timezone of the venue whatever the zone of the request.
+func (s Service) Occupancy(req attendance.OccupancyRequest) (res attendance.OccupancyDTO, err error) {
+	at := s.clock.Now()
+	if req.At != nil {
+		at = req.At.UTC()
+	}
+	records, err := s.repository.Until(at, req.Table)
+	if err != nil {
+		return
+	}
+	res = mapOccupancyToDTO(at, project(records), s.loc)
+	return
+}
+
+// Report sums up the attendance recorded up to now, the check ins and check outs are grouped by the bucket of the
+// request on the clock of the venue.
+func (s Service) Report(req attendance.ReportRequest) (res attendance.ReportDTO, err error) {
+	if req.Bucket < time.Minute {
+		err = attendance.ErrInvalidBucket
+		return
+	}
+	records, err := s.repository.Until(s.clock.Now(), 0)
+	if err != nil {
+		return
+	}
+	res = report(records, req.Bucket, s.loc)
+	return
+}
+
+// project folds the records in order into the occupancy of the tables, the parties are seated from their check in to
+// their check out.
+func project(records []attendance.Record) attendance.Occupancy {
+	seats := make(map[uint]int64)
+	parties := make(map[uint][]attendance.Party)
+	for _, r := range records {
+		switch r.Type {
+		case attendance.TypeTableCreated, attendance.TypeTableResized:
+			seats[r.TableID] = r.People
+		case attendance.TypeGuestCheckedIn:
+			parties[r.TableID] = append(
+				parties[r.TableID], attendance.Party{Guest: r.Guest, People: r.People, TimeArrived: r.OccurredAt},
+			)
+		case attendance.TypeGuestCheckedOut:
+			parties[r.TableID] = leave(parties[r.TableID], r.Guest)
+		}
+	}
+
+	ids := make([]uint, 0, len(seats))
+	for id := range seats {
+		ids = append(ids, id)
+	}
+	// the tables created before the stream existed have parties but no seats
+	for id := range parties {
+		if _, ok := seats[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var o attendance.Occupancy
+	for _, id := range ids {
+		t := attendance.TableOccupancy{ID: id, Seats: seats[id], Parties: parties[id]}
+		for _, p := range t.Parties {
+			t.Seated += p.People
+		}
+		t.EmptySeats = t.Seats - t.Seated
+		o.Tables = append(o.Tables, t)
+	}
+	return o
+}
+
+func leave(parties []attendance.Party, guest string) []attendance.Party {
+	left := make([]attendance.Party, 0, len(parties))
+	for _, p := range parties {
+		if p.Guest != guest {
+			left = append(left, p)
+		}
+	}
+	return left
+}
@@ -0,0 +1,273 @@
+package attendance_test
+
+import (
+	"errors"
+	attendanceDef "github.com/getground/tech-tasks/backend/definitions/attendance"
+	attendanceMocks "github.com/getground/tech-tasks/backend/mocks/definitions/attendance"
+	"github.com/getground/tech-tasks/backend/pkg/modules/attendance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestService_Occupancy(t *testing.T) {
+	at := time.Date(2022, 11, 5, 22, 30, 0, 0, time.UTC)
+	arrived := at.Add(-2 * time.Hour)
+
+	t.Run(
+		"repository error", func(t *testing.T) {
+			// setup
+			repo := new(attendanceMocks.Repository)
+			service := attendance.NewService(repo)
+
+			//	mocks
+			repo.On("Until", at, uint(0)).Return(nil, errors.New("internal error")).Once()
+
+			//	method call
+			res, err := service.Occupancy(attendanceDef.OccupancyRequest{At: &at})
+
+			//	assert
+			assert.Error(t, err)
+			assert.Empty(t, res)
+		},
+	)
+
+	t.Run(
+		"now", func(t *testing.T) {
+			// setup
+			repo := new(attendanceMocks.Repository)
+			service := attendance.NewService(repo)
+			before := time.Now()
+
+			//	mocks
+			repo.On("Until", mock.AnythingOfType("time.Time"), uint(4)).Return(nil, nil).Once()
+
+			//	method call
+			res, err := service.Occupancy(attendanceDef.OccupancyRequest{Table: 4})
+
+			//	assert
+			assert.NoError(t, err)
+			assert.False(t, res.At.Before(before))
+			assert.Empty(t, res.Tables)
+		},
+	)
+
+	t.Run(
+		"replay", func(t *testing.T) {
+			// setup
+			repo := new(attendanceMocks.Repository)
+			service := attendance.NewService(repo)
+			records := []attendanceDef.Record{
+				{Type: attendanceDef.TypeTableCreated, TableID: 1, People: 10},
+				{Type: attendanceDef.TypeTableCreated, TableID: 2, People: 4},
+				{Type: attendanceDef.TypeGuestInvited, Guest: "sam", TableID: 1, People: 3},
+				{Type: attendanceDef.TypeGuestCheckedIn, Guest: "sam", TableID: 1, People: 3, OccurredAt: arrived},
+				{Type: attendanceDef.TypeGuestCheckedIn, Guest: "kim", TableID: 1, People: 2, OccurredAt: arrived},
+				{Type: attendanceDef.TypeGuestCheckedIn, Guest: "alex", TableID: 2, People: 1, OccurredAt: arrived},
+				{Type: attendanceDef.TypeGuestCheckedOut, Guest: "kim", TableID: 1, People: 2},
+				{Type: attendanceDef.TypeTableResized, TableID: 2, People: 6},
+			}
+
+			//	mocks
+			repo.On("Until", at, uint(0)).Return(records, nil).Once()
+
+			//	method call
+			res, err := service.Occupancy(attendanceDef.OccupancyRequest{At: &at})
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(
+				t, attendanceDef.OccupancyDTO{
+					At:     at,
+					Seated: 4,
+					Tables: []attendanceDef.TableOccupancyDTO{
+						{
+							ID: 1, Seats: 10, Seated: 3, EmptySeats: 7,
+							Guests: []attendanceDef.SeatedGuestDTO{
+								{Name: "sam", AccompanyingGuests: 2, TimeArrived: arrived},
+							},
+						},
+						{
+							ID: 2, Seats: 6, Seated: 1, EmptySeats: 5,
+							Guests: []attendanceDef.SeatedGuestDTO{
+								{Name: "alex", AccompanyingGuests: 0, TimeArrived: arrived},
+							},
+						},
+					},
+				}, res,
+			)
+		},
+	)
+
+	t.Run(
+		"venue timezone", func(t *testing.T) {
+			// setup
+			repo := new(attendanceMocks.Repository)
+			loc := time.FixedZone("IST", 5*3600+1800)
+			service := attendance.NewService(repo).WithLocation(loc)
+			records := []attendanceDef.Record{
+				{Type: attendanceDef.TypeTableCreated, TableID: 1, People: 10},
+				{Type: attendanceDef.TypeGuestCheckedIn, Guest: "sam", TableID: 1, People: 3, OccurredAt: arrived},
+			}
+
+			//	mocks
+			repo.On("Until", at, uint(0)).Return(records, nil).Once()
+
+			//	method call
+			res, err := service.Occupancy(attendanceDef.OccupancyRequest{At: &at})
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, at.In(loc), res.At)
+			assert.Equal(t, arrived.In(loc), res.Tables[0].Guests[0].TimeArrived)
+		},
+	)
+}
+
+func TestService_Report(t *testing.T) {
+	start := time.Date(2022, 11, 5, 20, 0, 0, 0, time.UTC)
+	minute := func(m time.Duration) time.Time { return start.Add(m * time.Minute) }
+
+	t.Run(
+		"invalid bucket", func(t *testing.T) {
+			// setup
+			repo := new(attendanceMocks.Repository)
+			service := attendance.NewService(repo)
+
+			//	method call
+			_, err := service.Report(attendanceDef.ReportRequest{Bucket: time.Second})
+
+			//	assert
+			assert.ErrorIs(t, err, attendanceDef.ErrInvalidBucket)
+			repo.AssertNotCalled(t, "Until", mock.Anything, mock.Anything)
+		},
+	)
+
+	t.Run(
+		"repository error", func(t *testing.T) {
+			// setup
+			repo := new(attendanceMocks.Repository)
+			service := attendance.NewService(repo)
+
+			//	mocks
+			repo.On("Until", mock.AnythingOfType("time.Time"), uint(0)).Return(nil, errors.New("internal error")).Once()
+
+			//	method call
+			res, err := service.Report(attendanceDef.ReportRequest{Bucket: time.Hour})
+
+			//	assert
+			assert.Error(t, err)
+			assert.Empty(t, res)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			repo := new(attendanceMocks.Repository)
+			service := attendance.NewService(repo)
+			records := []attendanceDef.Record{
+				{Type: attendanceDef.TypeTableCreated, TableID: 1, People: 10},
+				{Type: attendanceDef.TypeTableCreated, TableID: 2, People: 10},
+				{Type: attendanceDef.TypeGuestInvited, Guest: "sam", TableID: 1, People: 3},
+				{Type: attendanceDef.TypeGuestInvited, Guest: "kim", TableID: 1, People: 2},
+				{Type: attendanceDef.TypeGuestInvited, Guest: "alex", TableID: 2, People: 1},
+				{Type: attendanceDef.TypeGuestInvited, Guest: "jo", TableID: 2, People: 1},
+				{Type: attendanceDef.TypeGuestUninvited, Guest: "jo", TableID: 2, People: 1},
+				{Type: attendanceDef.TypeGuestCheckedIn, Guest: "sam", TableID: 1, People: 4, OccurredAt: minute(10)},
+				{Type: attendanceDef.TypeGuestCheckedIn, Guest: "lee", TableID: 2, People: 2, OccurredAt: minute(40)},
+				{Type: attendanceDef.TypeGuestCheckedIn, Guest: "kim", TableID: 1, People: 1, OccurredAt: minute(50)},
+				{Type: attendanceDef.TypeGuestCheckedOut, Guest: "sam", TableID: 1, People: 4, OccurredAt: minute(130)},
+				{Type: attendanceDef.TypeGuestCheckedOut, Guest: "kim", TableID: 1, People: 1, OccurredAt: minute(140)},
+			}
+
+			//	mocks
+			repo.On("Until", mock.AnythingOfType("time.Time"), uint(0)).Return(records, nil).Once()
+
+			//	method call
+			res, err := service.Report(attendanceDef.ReportRequest{Bucket: time.Hour})
+
+			//	assert
+			assert.NoError(t, err)
+			peak := minute(50)
+			assert.Equal(
+				t, attendanceDef.ReportDTO{
+					Timeline: []attendanceDef.BucketDTO{
+						{Start: start, Arrivals: 7, Occupancy: 7},
+						{Start: start.Add(time.Hour), Occupancy: 7},
+						{Start: start.Add(2 * time.Hour), Departures: 5, Occupancy: 2},
+					},
+					Peak: attendanceDef.PeakDTO{Headcount: 7, At: &peak},
+					Tables: []attendanceDef.TableAttendanceDTO{
+						{ID: 1, Invited: 2, Arrived: 2},
+						{ID: 2, Invited: 1, WalkIns: 1, NoShows: 1, NoShowRate: 1},
+					},
+					// sam stayed two hours and kim an hour and a half
+					AverageStayMinutes: 105,
+					Entourage:          attendanceDef.EntourageDTO{Parties: 2, Expected: 3, Actual: 3},
+				}, res,
+			)
+		},
+	)
+	t.Run(
+		"answers and resizes", func(t *testing.T) {
+			// setup
+			repo := new(attendanceMocks.Repository)
+			service := attendance.NewService(repo)
+			records := []attendanceDef.Record{
+				{Type: attendanceDef.TypeTableCreated, TableID: 1, People: 10},
+				{Type: attendanceDef.TypeGuestInvited, Guest: "sam", TableID: 1, People: 3, RSVP: "invited"},
+				{Type: attendanceDef.TypeGuestInvited, Guest: "kim", TableID: 1, People: 2, RSVP: "invited"},
+				{Type: attendanceDef.TypeGuestResponded, Guest: "kim", TableID: 1, People: 2, RSVP: "declined"},
+				{Type: attendanceDef.TypeGuestResponded, Guest: "sam", TableID: 1, People: 3, RSVP: "accepted"},
+				{Type: attendanceDef.TypeGuestResized, Guest: "sam", TableID: 1, People: 4, RSVP: "accepted"},
+				{Type: attendanceDef.TypeGuestCheckedIn, Guest: "sam", TableID: 1, People: 4, OccurredAt: minute(10)},
+			}
+
+			//	mocks
+			repo.On("Until", mock.AnythingOfType("time.Time"), uint(0)).Return(records, nil).Once()
+
+			//	method call
+			res, err := service.Report(attendanceDef.ReportRequest{Bucket: time.Hour})
+
+			//	assert
+			assert.NoError(t, err)
+			// kim declined so isn't a no-show, sam announced the party of four before coming
+			assert.Equal(t, []attendanceDef.TableAttendanceDTO{{ID: 1, Invited: 2, Arrived: 1}}, res.Tables)
+			assert.Equal(t, attendanceDef.EntourageDTO{Parties: 1, Expected: 3, Actual: 3}, res.Entourage)
+		},
+	)
+
+	t.Run(
+		"venue timezone", func(t *testing.T) {
+			// setup
+			repo := new(attendanceMocks.Repository)
+			loc := time.FixedZone("IST", 5*3600+1800)
+			service := attendance.NewService(repo).WithLocation(loc)
+			records := []attendanceDef.Record{
+				{Type: attendanceDef.TypeTableCreated, TableID: 1, People: 10},
+				{Type: attendanceDef.TypeGuestCheckedIn, Guest: "sam", TableID: 1, People: 4, OccurredAt: minute(10)},
+				{Type: attendanceDef.TypeGuestCheckedIn, Guest: "lee", TableID: 1, People: 2, OccurredAt: minute(40)},
+			}
+
+			//	mocks
+			repo.On("Until", mock.AnythingOfType("time.Time"), uint(0)).Return(records, nil).Once()
+
+			//	method call
+			res, err := service.Report(attendanceDef.ReportRequest{Bucket: time.Hour})
+
+			//	assert
+			assert.NoError(t, err)
+			// the venue is half an hour off UTC, its buckets start on its hour: 01:00 and 02:00
+			peak := minute(40).In(loc)
+			assert.Equal(
+				t, []attendanceDef.BucketDTO{
+					{Start: minute(-30).In(loc), Arrivals: 4, Occupancy: 4},
+					{Start: minute(30).In(loc), Arrivals: 2, Occupancy: 6},
+				}, res.Timeline,
+			)
+			assert.Equal(t, attendanceDef.PeakDTO{Headcount: 6, At: &peak}, res.Peak)
+		},
+	)
+}
@@ -0,0 +1,66 @@
+package audit
+
+import (
+	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/audit"
+	"github.com/getground/tech-tasks/backend/definitions/events"
+	"github.com/getground/tech-tasks/backend/definitions/pagination"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type Controller struct {
+	handler Handler
+	service audit.Service
+}
+
+func NewController(handler Handler, service audit.Service) Controller {
+	return Controller{
+		handler: handler,
+		service: service,
+	}
+}
+
+func (ctrl Controller) List(c *gin.Context) {
+	req, err := ctrl.handler.List(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.service.ForEvent(c.GetUint(events.ContextKey)).List(req)
+	if err != nil {
+		log.Error(err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, pagination.ErrInvalidCursor) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(
+			status, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// Actor sets the actor of the request from the X-Actor header, the requests without it are recorded with the address
+// of the client.
+func Actor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor := c.GetHeader(audit.ActorHeader)
+		if actor == "" {
+			actor = c.ClientIP()
+		}
+		c.Set(audit.ContextKey, actor)
+		c.Next()
+	}
+}
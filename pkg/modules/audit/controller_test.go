@@ -0,0 +1,137 @@
+package audit_test
+
+import (
+	"errors"
+	auditDef "github.com/getground/tech-tasks/backend/definitions/audit"
+	"github.com/getground/tech-tasks/backend/definitions/pagination"
+	auditMocks "github.com/getground/tech-tasks/backend/mocks/definitions/audit"
+	"github.com/getground/tech-tasks/backend/pkg/modules/audit"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestController_List(t *testing.T) {
+	//	setup
+	r := gin.Default()
+	gin.SetMode(gin.TestMode)
+	service := new(auditMocks.Service)
+	// the requests are not nested under an event, they are scoped to the zero event
+	service.On("ForEvent", uint(0)).Return(service).Maybe()
+	r.GET("/audit", audit.NewController(audit.NewHandler(), service).List)
+	created := time.Date(2022, 11, 5, 18, 0, 0, 0, time.UTC)
+	from := created.Add(-time.Hour)
+
+	cases := []struct {
+		name     string
+		query    string
+		req      *auditDef.ListRequest
+		res      auditDef.ListDTO
+		err      error
+		code     int
+		expected string
+	}{
+		{name: "invalid time", query: "?from=yesterday", code: http.StatusBadRequest},
+		{
+			name:  "invalid cursor",
+			query: "?cursor=x",
+			req:   &auditDef.ListRequest{Request: pagination.Request{Limit: pagination.DefaultLimit, Cursor: "x"}},
+			err:   pagination.ErrInvalidCursor,
+			code:  http.StatusBadRequest,
+		},
+		{
+			name: "service error",
+			req:  &auditDef.ListRequest{Request: pagination.Request{Limit: pagination.DefaultLimit}},
+			err:  errors.New("internal error"),
+			code: http.StatusInternalServerError,
+		},
+		{
+			name:  "success",
+			query: "?guest=sam&actor=alice&from=2022-11-05T17:00:00Z&limit=1",
+			req: &auditDef.ListRequest{
+				Guest: "sam", Actor: "alice", From: &from, Request: pagination.Request{Limit: 1},
+			},
+			res: auditDef.ListDTO{
+				Entries: []auditDef.EntryDTO{
+					{ID: 1, Actor: "alice", Action: auditDef.ActionGuestDeleted, Guest: "sam", CreatedAt: created},
+				},
+				Total: 1,
+			},
+			code: http.StatusOK,
+			expected: `{"entries":[{"id":1,"actor":"alice","action":"guest.deleted","guest":"sam",` +
+				`"created_at":"2022-11-05T18:00:00Z"}],"total":1}`,
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				//	mocks
+				if c.req != nil {
+					service.On("List", *c.req).Return(c.res, c.err).Once()
+				}
+
+				//	request
+				req, err := http.NewRequest(http.MethodGet, "/audit"+c.query, nil)
+				if err != nil {
+					t.Errorf("Error requesting test controller: %v\n", err)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, req)
+
+				//	assert
+				assert.Equal(t, c.code, rr.Code)
+				if c.expected != "" {
+					assert.Equal(t, c.expected, rr.Body.String())
+				}
+				service.AssertExpectations(t)
+			},
+		)
+	}
+}
+
+func TestActor(t *testing.T) {
+	//	setup
+	r := gin.Default()
+	gin.SetMode(gin.TestMode)
+	r.Use(audit.Actor())
+	r.GET(
+		"/actor", func(c *gin.Context) {
+			c.String(http.StatusOK, c.GetString(auditDef.ContextKey))
+		},
+	)
+
+	cases := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{name: "header", header: "alice", expected: "alice"},
+		{name: "client address", expected: "192.0.2.1"},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				//	request
+				req, err := http.NewRequest(http.MethodGet, "/actor", nil)
+				if err != nil {
+					t.Errorf("Error requesting test controller: %v\n", err)
+				}
+				req.RemoteAddr = "192.0.2.1:1234"
+				if c.header != "" {
+					req.Header.Set(auditDef.ActorHeader, c.header)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, req)
+
+				//	assert
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Equal(t, c.expected, rr.Body.String())
+			},
+		)
+	}
+}
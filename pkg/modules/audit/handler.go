@@ -0,0 +1,21 @@
+package audit
+
+import (
+	"github.com/getground/tech-tasks/backend/definitions/audit"
+	"github.com/getground/tech-tasks/backend/definitions/pagination"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct{}
+
+func NewHandler() Handler {
+	return Handler{}
+}
+
+func (h Handler) List(c *gin.Context) (req audit.ListRequest, err error) {
+	err = c.ShouldBindQuery(&req)
+	if req.Limit == 0 {
+		req.Limit = pagination.DefaultLimit
+	}
+	return
+}
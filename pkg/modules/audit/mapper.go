@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"encoding/json"
+	"github.com/getground/tech-tasks/backend/definitions/audit"
+	"time"
+)
+
+func mapEntriesToDTO(page audit.Page, loc *time.Location) audit.ListDTO {
+	entries := make([]audit.EntryDTO, 0, len(page.Entries))
+	for _, e := range page.Entries {
+		entries = append(entries, mapEntryToDTO(e, loc))
+	}
+	return audit.ListDTO{Entries: entries, Total: page.Total, NextCursor: page.NextCursor}
+}
+
+func mapEntryToDTO(e audit.Entry, loc *time.Location) audit.EntryDTO {
+	dto := audit.EntryDTO{
+		ID:        e.ID,
+		Actor:     e.Actor,
+		Action:    e.Action,
+		Guest:     e.Guest,
+		Table:     e.TableID,
+		CreatedAt: e.CreatedAt.In(loc),
+	}
+	// the states are saved as json already
+	if e.Before != "" {
+		dto.Before = json.RawMessage(e.Before)
+	}
+	if e.After != "" {
+		dto.After = json.RawMessage(e.After)
+	}
+	return dto
+}
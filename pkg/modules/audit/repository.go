@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"github.com/getground/tech-tasks/backend/definitions/audit"
+	"github.com/getground/tech-tasks/backend/definitions/pagination"
+	"gorm.io/gorm"
+)
+
+type Repository struct {
+	db    *gorm.DB
+	event uint
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return Repository{
+		db: db,
+	}
+}
+
+func (r Repository) ForEvent(event uint) audit.Repository {
+	r.event = event
+	return r
+}
+
+// List returns a page of the entries matching the request, the entries are sorted by id so the oldest come first.
+func (r Repository) List(req audit.ListRequest) (page audit.Page, err error) {
+	err = r.filter(req).Count(&page.Total).Error
+	if err != nil {
+		return
+	}
+
+	q := r.filter(req)
+	if req.Cursor != "" {
+		var c cursor
+		err = pagination.DecodeCursor(req.Cursor, &c)
+		if err != nil {
+			return
+		}
+		q = q.Where("id > ?", c.ID)
+	}
+	if req.Limit > 0 {
+		// one more row tells if there is a next page
+		q = q.Limit(req.Limit + 1)
+	}
+	err = q.Order("id").Find(&page.Entries).Error
+	if err != nil {
+		return
+	}
+
+	if req.Limit > 0 && len(page.Entries) > req.Limit {
+		page.Entries = page.Entries[:req.Limit]
+		page.NextCursor = pagination.EncodeCursor(cursor{ID: page.Entries[req.Limit-1].ID})
+	}
+	return
+}
+
+func (r Repository) filter(req audit.ListRequest) *gorm.DB {
+	q := r.db.Model(&audit.Entry{}).Where("event_id = ?", r.event)
+	if req.Guest != "" {
+		q = q.Where("guest = ?", req.Guest)
+	}
+	if req.Table != 0 {
+		q = q.Where("table_id = ?", req.Table)
+	}
+	if req.Actor != "" {
+		q = q.Where("actor = ?", req.Actor)
+	}
+	if req.From != nil {
+		q = q.Where("created_at >= ?", req.From)
+	}
+	if req.To != nil {
+		q = q.Where("created_at <= ?", req.To)
+	}
+	return q
+}
+
+// cursor holds the id of the last entry of a page.
+type cursor struct {
+	ID uint `json:"id"`
+}
@@ -0,0 +1,161 @@
+package audit_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"github.com/DATA-DOG/go-sqlmock"
+	auditDef "github.com/getground/tech-tasks/backend/definitions/audit"
+	"github.com/getground/tech-tasks/backend/definitions/pagination"
+	"github.com/getground/tech-tasks/backend/pkg/database"
+	"github.com/getground/tech-tasks/backend/pkg/modules/audit"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type repoMocks struct {
+	db      *sql.DB
+	sqlMock sqlmock.Sqlmock
+}
+
+var columns = []string{"id", "event_id", "actor", "action", "guest", "table_id", "before", "after", "created_at"}
+
+func setupIntegrationRepo(t *testing.T) (auditDef.Repository, repoMocks) {
+	db, m, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	msc := mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true})
+	gDB, err := database.NewDatabaseForTests(msc)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating grom database connection", err)
+	}
+	r := audit.NewRepository(gDB).ForEvent(1)
+	return r, repoMocks{
+		db:      db,
+		sqlMock: m,
+	}
+}
+
+func TestRepository_List(t *testing.T) {
+	from := time.Date(2022, 11, 5, 18, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	cases := []struct {
+		name  string
+		req   auditDef.ListRequest
+		count string
+		q     string
+		args  []driver.Value
+	}{
+		{
+			name:  "every entry",
+			count: "SELECT count(*) FROM `audit_log` WHERE event_id = ?",
+			q:     "SELECT * FROM `audit_log` WHERE event_id = ? ORDER BY id",
+			args:  []driver.Value{1},
+		},
+		{
+			name: "filtered",
+			req:  auditDef.ListRequest{Guest: "sam", Table: 2, Actor: "alice", From: &from, To: &to},
+			count: "SELECT count(*) FROM `audit_log` WHERE event_id = ? AND guest = ? AND table_id = ? AND actor = ? " +
+				"AND created_at >= ? AND created_at <= ?",
+			q: "SELECT * FROM `audit_log` WHERE event_id = ? AND guest = ? AND table_id = ? AND actor = ? " +
+				"AND created_at >= ? AND created_at <= ? ORDER BY id",
+			args: []driver.Value{1, "sam", 2, "alice", from, to},
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				// setup
+				repo, m := setupIntegrationRepo(t)
+				defer m.db.Close()
+
+				//	mocks
+				m.sqlMock.
+					ExpectQuery(regexp.QuoteMeta(c.count)).
+					WithArgs(c.args...).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				m.sqlMock.
+					ExpectQuery(regexp.QuoteMeta(c.q)).
+					WithArgs(c.args...).
+					WillReturnRows(
+						sqlmock.NewRows(columns).
+							AddRow(1, 1, "alice", auditDef.ActionGuestCreated, "sam", 2, "", `{"guest":{}}`, from),
+					)
+
+				//	method call
+				res, err := repo.List(c.req)
+
+				//	assert
+				assert.NoError(t, err)
+				assert.Equal(t, int64(1), res.Total)
+				assert.Len(t, res.Entries, 1)
+				assert.Empty(t, res.NextCursor)
+				assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+			},
+		)
+	}
+}
+
+func TestRepository_ListPages(t *testing.T) {
+	t.Run(
+		"invalid cursor", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+			req := auditDef.ListRequest{Request: pagination.Request{Limit: 2, Cursor: "not a cursor"}}
+
+			//	mocks
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `audit_log` WHERE event_id = ?")).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+			//	method call
+			_, err := repo.List(req)
+
+			//	assert
+			assert.ErrorIs(t, err, pagination.ErrInvalidCursor)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"next page", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+			created := time.Date(2022, 11, 5, 18, 0, 0, 0, time.UTC)
+			cursor := pagination.EncodeCursor(map[string]uint{"id": 3})
+			req := auditDef.ListRequest{Request: pagination.Request{Limit: 2, Cursor: cursor}}
+
+			//	mocks
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `audit_log` WHERE event_id = ?")).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(6))
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta("SELECT * FROM `audit_log` WHERE event_id = ? AND id > ? ORDER BY id LIMIT 3")).
+				WithArgs(1, 3).
+				WillReturnRows(
+					sqlmock.NewRows(columns).
+						AddRow(4, 1, "alice", auditDef.ActionTableCreated, "", 1, "", `{"table":{}}`, created).
+						AddRow(5, 1, "alice", auditDef.ActionGuestCreated, "sam", 1, "", `{"guest":{}}`, created).
+						AddRow(6, 1, "bob", auditDef.ActionGuestDeleted, "sam", 1, `{"guest":{}}`, "", created),
+				)
+
+			//	method call
+			res, err := repo.List(req)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, int64(6), res.Total)
+			assert.Len(t, res.Entries, 2)
+			assert.Equal(t, pagination.EncodeCursor(map[string]uint{"id": 5}), res.NextCursor)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
@@ -0,0 +1,35 @@
+package audit
+
+import (
+	"github.com/getground/tech-tasks/backend/definitions/audit"
+	"time"
+)
+
+type Service struct {
+	repository audit.Repository
+	loc        *time.Location
+}
+
+func NewService(repository audit.Repository) Service {
+	return Service{repository: repository, loc: time.UTC}
+}
+
+// WithLocation returns the service answering the times of the entries in the timezone of the venue.
+func (s Service) WithLocation(loc *time.Location) Service {
+	s.loc = loc
+	return s
+}
+
+func (s Service) ForEvent(event uint) audit.Service {
+	s.repository = s.repository.ForEvent(event)
+	return s
+}
+
+func (s Service) List(req audit.ListRequest) (list audit.ListDTO, err error) {
+	page, err := s.repository.List(req)
+	if err != nil {
+		return
+	}
+	list = mapEntriesToDTO(page, s.loc)
+	return
+}
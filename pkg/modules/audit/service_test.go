@@ -0,0 +1,98 @@
+package audit_test
+
+import (
+	"encoding/json"
+	"errors"
+	auditDef "github.com/getground/tech-tasks/backend/definitions/audit"
+	auditMocks "github.com/getground/tech-tasks/backend/mocks/definitions/audit"
+	"github.com/getground/tech-tasks/backend/pkg/modules/audit"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestService_List(t *testing.T) {
+	created := time.Date(2022, 11, 5, 18, 0, 0, 0, time.UTC)
+	req := auditDef.ListRequest{Guest: "sam"}
+
+	t.Run(
+		"repository error", func(t *testing.T) {
+			// setup
+			repo := new(auditMocks.Repository)
+			service := audit.NewService(repo)
+
+			//	mocks
+			repo.On("List", req).Return(auditDef.Page{}, errors.New("internal error")).Once()
+
+			//	method call
+			res, err := service.List(req)
+
+			//	assert
+			assert.Error(t, err)
+			assert.Empty(t, res)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			repo := new(auditMocks.Repository)
+			service := audit.NewService(repo)
+			page := auditDef.Page{
+				Entries: []auditDef.Entry{
+					{
+						ID: 1, EventID: 1, Actor: "alice", Action: auditDef.ActionGuestResponded, Guest: "sam", TableID: 2,
+						Before: `{"guest":{"rsvp":"invited"}}`, After: `{"guest":{"rsvp":"accepted"}}`, CreatedAt: created,
+					},
+					{ID: 2, EventID: 1, Actor: "bob", Action: auditDef.ActionGuestDeleted, Guest: "sam", CreatedAt: created},
+				},
+				Total:      3,
+				NextCursor: "next",
+			}
+
+			//	mocks
+			repo.On("List", req).Return(page, nil).Once()
+
+			//	method call
+			res, err := service.List(req)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(
+				t, auditDef.ListDTO{
+					Entries: []auditDef.EntryDTO{
+						{
+							ID: 1, Actor: "alice", Action: auditDef.ActionGuestResponded, Guest: "sam", Table: 2,
+							Before:    json.RawMessage(`{"guest":{"rsvp":"invited"}}`),
+							After:     json.RawMessage(`{"guest":{"rsvp":"accepted"}}`),
+							CreatedAt: created,
+						},
+						{ID: 2, Actor: "bob", Action: auditDef.ActionGuestDeleted, Guest: "sam", CreatedAt: created},
+					},
+					Total:      3,
+					NextCursor: "next",
+				}, res,
+			)
+		},
+	)
+
+	t.Run(
+		"venue timezone", func(t *testing.T) {
+			// setup
+			repo := new(auditMocks.Repository)
+			loc := time.FixedZone("BST", 3600)
+			service := audit.NewService(repo).WithLocation(loc)
+			page := auditDef.Page{Entries: []auditDef.Entry{{ID: 1, Actor: "bob", CreatedAt: created}}, Total: 1}
+
+			//	mocks
+			repo.On("List", req).Return(page, nil).Once()
+
+			//	method call
+			res, err := service.List(req)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, created.In(loc), res.Entries[0].CreatedAt)
+		},
+	)
+}
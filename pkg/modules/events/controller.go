@@ -0,0 +1,191 @@
+package events
+
+import (
+	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/events"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type Controller struct {
+	handler Handler
+	service events.Service
+}
+
+func NewController(handler Handler, service events.Service) Controller {
+	return Controller{
+		handler: handler,
+		service: service,
+	}
+}
+
+func (ctrl Controller) Create(c *gin.Context) {
+	req, err := ctrl.handler.Create(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.service.Create(req)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusInternalServerError, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (ctrl Controller) List(c *gin.Context) {
+	res, err := ctrl.service.List()
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusInternalServerError, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (ctrl Controller) Get(c *gin.Context) {
+	id, err := ctrl.handler.ID(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.service.GetByID(id)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			errorStatus(err), gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// Transition moves the event to the next status.
+func (ctrl Controller) Transition(c *gin.Context) {
+	req, err := ctrl.handler.Transition(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.service.Transition(req)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			errorStatus(err), gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// SetVenue sets the venue limit and the staff of the event.
+func (ctrl Controller) SetVenue(c *gin.Context) {
+	req, err := ctrl.handler.SetVenue(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.service.SetVenue(req)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			errorStatus(err), gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// Scope is the middleware of the routes nested under /events/:event, it scopes the request to the event of the path
+// once it is known to exist.
+func (ctrl Controller) Scope(c *gin.Context) {
+	id, err := ctrl.handler.ID(c)
+	if err != nil {
+		log.Error(err)
+		c.AbortWithStatusJSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	_, err = ctrl.service.GetByID(id)
+	if err != nil {
+		log.Error(err)
+		c.AbortWithStatusJSON(
+			errorStatus(err), gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.Set(events.ContextKey, id)
+	c.Next()
+}
+
+// Default scopes the requests to the given event, the routes nested under /events/:event override it with Scope.
+func Default(id uint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(events.ContextKey, id)
+		c.Next()
+	}
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, events.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, events.ErrInvalidStatus):
+		return http.StatusBadRequest
+	case errors.Is(err, events.ErrInvalidTransition), errors.Is(err, events.ErrNotAllowed):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,408 @@
+package events_test
+
+import (
+	"errors"
+	eventsDef "github.com/getground/tech-tasks/backend/definitions/events"
+	eventsMocks "github.com/getground/tech-tasks/backend/mocks/definitions/events"
+	"github.com/getground/tech-tasks/backend/pkg/modules/events"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func setupController() (*gin.Engine, *eventsMocks.Service) {
+	r := gin.Default()
+	gin.SetMode(gin.TestMode)
+
+	service := new(eventsMocks.Service)
+	ctrl := events.NewController(events.NewHandler(), service)
+	r.Use(events.Default(1))
+	r.POST("/events", ctrl.Create)
+	r.GET("/events", ctrl.List)
+	r.GET("/events/:event", ctrl.Get)
+	r.PUT("/events/:event/status", ctrl.Transition)
+	r.PUT("/events/:event/venue", ctrl.SetVenue)
+	scope := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"event": c.GetUint(eventsDef.ContextKey)})
+	}
+	r.GET("/scope", scope)
+	r.Group("/events/:event", ctrl.Scope).GET("/scope", scope)
+
+	return r, service
+}
+
+func TestController_Create(t *testing.T) {
+	//	setup
+	r, service := setupController()
+	date := time.Date(2023, 6, 21, 18, 0, 0, 0, time.UTC)
+	req := eventsDef.CreateRequest{Name: "Summer party", Venue: "Rooftop", Date: date, VenueLimit: 150}
+
+	cases := []struct {
+		name     string
+		body     string
+		mock     func()
+		code     int
+		expected string
+	}{
+		{
+			name: "missing date",
+			body: `{"name":"Summer party"}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "negative venue limit",
+			body: `{"name":"Summer party","date":"2023-06-21T18:00:00Z","venue_limit":-1}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "service error",
+			body: `{"name":"Summer party","venue":"Rooftop","date":"2023-06-21T18:00:00Z","venue_limit":150}`,
+			mock: func() {
+				service.On("Create", req).Return(eventsDef.EventDTO{}, errors.New("internal error")).Once()
+			},
+			code: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			body: `{"name":"Summer party","venue":"Rooftop","date":"2023-06-21T18:00:00Z","venue_limit":150}`,
+			mock: func() {
+				service.On("Create", req).Return(
+					eventsDef.EventDTO{
+						ID: 2, Name: "Summer party", Venue: "Rooftop", Date: date, Status: eventsDef.StatusPlanning,
+						VenueLimit: 150,
+					},
+					nil,
+				).Once()
+			},
+			code: http.StatusOK,
+			expected: `{"id":2,"name":"Summer party","venue":"Rooftop","date":"2023-06-21T18:00:00Z",` +
+				`"status":"planning","venue_limit":150,"staff":0}`,
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				//	mocks
+				if c.mock != nil {
+					c.mock()
+				}
+
+				//	request
+				httpReq, err := http.NewRequest(http.MethodPost, "/events", strings.NewReader(c.body))
+				if err != nil {
+					t.Errorf("Error requesting test controller: %v\n", err)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, httpReq)
+
+				//	assert
+				assert.Equal(t, c.code, rr.Code)
+				if c.expected != "" {
+					assert.Equal(t, c.expected, rr.Body.String())
+				}
+				service.AssertExpectations(t)
+			},
+		)
+	}
+}
+
+func TestController_List(t *testing.T) {
+	//	setup
+	r, service := setupController()
+	date := time.Date(2023, 6, 21, 18, 0, 0, 0, time.UTC)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			//	mocks
+			service.On("List").Return(
+				eventsDef.ListDTO{
+					Events: []eventsDef.EventDTO{{ID: 1, Name: "Party", Date: date, Status: eventsDef.StatusPlanning}},
+				},
+				nil,
+			).Once()
+
+			//	request
+			req, err := http.NewRequest(http.MethodGet, "/events", http.NoBody)
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			//	assert
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(
+				t,
+				`{"events":[{"id":1,"name":"Party","venue":"","date":"2023-06-21T18:00:00Z","status":"planning",`+
+					`"venue_limit":0,"staff":0}]}`,
+				rr.Body.String(),
+			)
+			service.AssertExpectations(t)
+		},
+	)
+}
+
+func TestController_Get(t *testing.T) {
+	//	setup
+	r, service := setupController()
+
+	cases := []struct {
+		name string
+		url  string
+		mock func()
+		code int
+	}{
+		{
+			name: "invalid id",
+			url:  "/events/summer",
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "not found",
+			url:  "/events/2",
+			mock: func() {
+				service.On("GetByID", uint(2)).Return(eventsDef.EventDTO{}, eventsDef.ErrNotFound).Once()
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name: "success",
+			url:  "/events/2",
+			mock: func() {
+				service.On("GetByID", uint(2)).Return(eventsDef.EventDTO{ID: 2, Name: "Summer party"}, nil).Once()
+			},
+			code: http.StatusOK,
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				//	mocks
+				if c.mock != nil {
+					c.mock()
+				}
+
+				//	request
+				req, err := http.NewRequest(http.MethodGet, c.url, http.NoBody)
+				if err != nil {
+					t.Errorf("Error requesting test controller: %v\n", err)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, req)
+
+				//	assert
+				assert.Equal(t, c.code, rr.Code)
+				service.AssertExpectations(t)
+			},
+		)
+	}
+}
+
+func TestController_Transition(t *testing.T) {
+	//	setup
+	r, service := setupController()
+	req := eventsDef.TransitionRequest{ID: 2, Status: eventsDef.StatusDoorsOpen}
+
+	cases := []struct {
+		name     string
+		path     string
+		body     string
+		err      error
+		code     int
+		expected string
+	}{
+		{name: "invalid id", path: "/events/two/status", body: `{"status":"doors_open"}`, code: http.StatusBadRequest},
+		{name: "missing status", path: "/events/2/status", body: `{}`, code: http.StatusBadRequest},
+		{
+			name: "invalid status",
+			path: "/events/2/status",
+			body: `{"status":"doors_open"}`,
+			err:  eventsDef.ErrInvalidStatus,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "not found",
+			path: "/events/2/status",
+			body: `{"status":"doors_open"}`,
+			err:  eventsDef.ErrNotFound,
+			code: http.StatusNotFound,
+		},
+		{
+			name: "invalid transition",
+			path: "/events/2/status",
+			body: `{"status":"doors_open"}`,
+			err:  eventsDef.ErrInvalidTransition,
+			code: http.StatusConflict,
+		},
+		{
+			name: "success",
+			path: "/events/2/status",
+			body: `{"status":"doors_open"}`,
+			code: http.StatusOK,
+			expected: `{"id":2,"name":"","venue":"","date":"0001-01-01T00:00:00Z","status":"doors_open",` +
+				`"venue_limit":0,"staff":0}`,
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				//	mocks
+				if c.code != http.StatusBadRequest || c.err != nil {
+					service.On("Transition", req).
+						Return(eventsDef.EventDTO{ID: 2, Status: eventsDef.StatusDoorsOpen}, c.err).
+						Once()
+				}
+
+				//	request
+				httpReq, err := http.NewRequest(http.MethodPut, c.path, strings.NewReader(c.body))
+				if err != nil {
+					t.Errorf("Error requesting test controller: %v\n", err)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, httpReq)
+
+				//	assert
+				assert.Equal(t, c.code, rr.Code)
+				if c.expected != "" {
+					assert.Equal(t, c.expected, rr.Body.String())
+				}
+				service.AssertExpectations(t)
+			},
+		)
+	}
+}
+
+func TestController_SetVenue(t *testing.T) {
+	//	setup
+	r, service := setupController()
+	req := eventsDef.VenueRequest{ID: 2, VenueLimit: 150, Staff: 5}
+
+	cases := []struct {
+		name     string
+		body     string
+		err      error
+		code     int
+		expected string
+	}{
+		{name: "negative limit", body: `{"venue_limit":-1,"staff":5}`, code: http.StatusBadRequest},
+		{
+			name: "archived",
+			body: `{"venue_limit":150,"staff":5}`,
+			err:  eventsDef.ErrNotAllowed,
+			code: http.StatusConflict,
+		},
+		{
+			name: "success",
+			body: `{"venue_limit":150,"staff":5}`,
+			code: http.StatusOK,
+			expected: `{"id":2,"name":"","venue":"","date":"0001-01-01T00:00:00Z","status":"in_progress",` +
+				`"venue_limit":150,"staff":5}`,
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				//	mocks
+				if c.code != http.StatusBadRequest {
+					service.On("SetVenue", req).
+						Return(
+							eventsDef.EventDTO{ID: 2, Status: eventsDef.StatusInProgress, VenueLimit: 150, Staff: 5},
+							c.err,
+						).
+						Once()
+				}
+
+				//	request
+				httpReq, err := http.NewRequest(http.MethodPut, "/events/2/venue", strings.NewReader(c.body))
+				if err != nil {
+					t.Errorf("Error requesting test controller: %v\n", err)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, httpReq)
+
+				//	assert
+				assert.Equal(t, c.code, rr.Code)
+				if c.expected != "" {
+					assert.Equal(t, c.expected, rr.Body.String())
+				}
+				service.AssertExpectations(t)
+			},
+		)
+	}
+}
+
+func TestController_Scope(t *testing.T) {
+	//	setup
+	r, service := setupController()
+
+	cases := []struct {
+		name     string
+		url      string
+		mock     func()
+		code     int
+		expected string
+	}{
+		{
+			name:     "default event",
+			url:      "/scope",
+			code:     http.StatusOK,
+			expected: `{"event":1}`,
+		},
+		{
+			name: "invalid id",
+			url:  "/events/0/scope",
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "not found",
+			url:  "/events/2/scope",
+			mock: func() {
+				service.On("GetByID", uint(2)).Return(eventsDef.EventDTO{}, eventsDef.ErrNotFound).Once()
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name: "event of the path",
+			url:  "/events/2/scope",
+			mock: func() {
+				service.On("GetByID", uint(2)).Return(eventsDef.EventDTO{ID: 2}, nil).Once()
+			},
+			code:     http.StatusOK,
+			expected: `{"event":2}`,
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				//	mocks
+				if c.mock != nil {
+					c.mock()
+				}
+
+				//	request
+				req, err := http.NewRequest(http.MethodGet, c.url, http.NoBody)
+				if err != nil {
+					t.Errorf("Error requesting test controller: %v\n", err)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, req)
+
+				//	assert
+				assert.Equal(t, c.code, rr.Code)
+				if c.expected != "" {
+					assert.Equal(t, c.expected, rr.Body.String())
+				}
+				service.AssertExpectations(t)
+			},
+		)
+	}
+}
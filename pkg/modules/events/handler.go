@@ -0,0 +1,48 @@
+package events
+
+import (
+	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/events"
+	"github.com/gin-gonic/gin"
+	"strconv"
+)
+
+type Handler struct{}
+
+func NewHandler() Handler {
+	return Handler{}
+}
+
+func (h Handler) Create(c *gin.Context) (req events.CreateRequest, err error) {
+	err = c.ShouldBindJSON(&req)
+	return
+}
+
+func (h Handler) Transition(c *gin.Context) (req events.TransitionRequest, err error) {
+	req.ID, err = h.ID(c)
+	if err != nil {
+		return
+	}
+	err = c.ShouldBindJSON(&req)
+	return
+}
+
+func (h Handler) SetVenue(c *gin.Context) (req events.VenueRequest, err error) {
+	req.ID, err = h.ID(c)
+	if err != nil {
+		return
+	}
+	err = c.ShouldBindJSON(&req)
+	return
+}
+
+// ID parses the event id of the path.
+func (h Handler) ID(c *gin.Context) (id uint, err error) {
+	n, err := strconv.ParseUint(c.Param("event"), 10, 64)
+	if err != nil || n == 0 {
+		err = errors.New("event must be an event id")
+		return
+	}
+	id = uint(n)
+	return
+}
@@ -0,0 +1,25 @@
+package events
+
+import (
+	"github.com/getground/tech-tasks/backend/definitions/events"
+)
+
+func mapEventsToDTO(list []events.Event) events.ListDTO {
+	res := make([]events.EventDTO, 0, len(list))
+	for _, e := range list {
+		res = append(res, mapEventToDTO(e))
+	}
+	return events.ListDTO{Events: res}
+}
+
+func mapEventToDTO(e events.Event) events.EventDTO {
+	return events.EventDTO{
+		ID:         e.ID,
+		Name:       e.Name,
+		Venue:      e.Venue,
+		Date:       e.Date,
+		Status:     e.Status,
+		VenueLimit: e.VenueLimit,
+		Staff:      e.Staff,
+	}
+}
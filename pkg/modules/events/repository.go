@@ -0,0 +1,61 @@
+package events
+
+import (
+	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/events"
+	"gorm.io/gorm"
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return Repository{
+		db: db,
+	}
+}
+
+func (r Repository) Create(e events.Event) (events.Event, error) {
+	err := r.db.Create(&e).Error
+	if err != nil {
+		return events.Event{}, err
+	}
+	return e, nil
+}
+
+func (r Repository) GetByID(id uint) (e events.Event, err error) {
+	err = r.db.Where("id = ?", id).First(&e).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = events.ErrNotFound
+	}
+	return
+}
+
+func (r Repository) List() (list []events.Event, err error) {
+	err = r.db.Order("date").Order("id").Find(&list).Error
+	return
+}
+
+// SetStatus moves the event forward, the condition makes sure two transitions from the same status can't both apply.
+func (r Repository) SetStatus(id uint, from, to events.Status) error {
+	res := r.db.Model(&events.Event{}).
+		Where("id = ?", id).
+		Where("status = ?", from).
+		Update("status", to)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return events.ErrInvalidTransition
+	}
+	return nil
+}
+
+// SetVenue doesn't count the rows affected, MySQL leaves out the rows whose values don't change.
+func (r Repository) SetVenue(id uint, limit, staff int64) error {
+	return r.db.Model(&events.Event{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"venue_limit": limit, "staff": staff}).
+		Error
+}
@@ -0,0 +1,244 @@
+package events_test
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	eventsDef "github.com/getground/tech-tasks/backend/definitions/events"
+	"github.com/getground/tech-tasks/backend/pkg/database"
+	"github.com/getground/tech-tasks/backend/pkg/modules/events"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type repoMocks struct {
+	db      *sql.DB
+	sqlMock sqlmock.Sqlmock
+}
+
+var columns = []string{"id", "name", "venue", "date", "status"}
+
+func setupIntegrationRepo(t *testing.T) (eventsDef.Repository, repoMocks) {
+	db, m, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	msc := mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true})
+	gDB, err := database.NewDatabaseForTests(msc)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating grom database connection", err)
+	}
+	r := events.NewRepository(gDB)
+	return r, repoMocks{
+		db:      db,
+		sqlMock: m,
+	}
+}
+
+func TestRepository_Create(t *testing.T) {
+	q := "INSERT INTO `events` (`name`,`venue`,`date`,`status`,`venue_limit`,`staff`) VALUES (?,?,?,?,?,?)"
+	date := time.Date(2023, 6, 21, 18, 0, 0, 0, time.UTC)
+	e := eventsDef.Event{
+		Name: "Summer party", Venue: "Rooftop", Date: date, Status: eventsDef.StatusPlanning, VenueLimit: 150, Staff: 5,
+	}
+
+	t.Run(
+		"error", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(q)).
+				WithArgs(e.Name, e.Venue, e.Date, e.Status, e.VenueLimit, e.Staff).
+				WillReturnError(errors.New("error creating event"))
+			m.sqlMock.ExpectRollback()
+
+			//	method call
+			res, err := repo.Create(e)
+
+			//	assert
+			assert.Error(t, err)
+			assert.Empty(t, res)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(q)).
+				WithArgs(e.Name, e.Venue, e.Date, e.Status, e.VenueLimit, e.Staff).
+				WillReturnResult(sqlmock.NewResult(2, 1))
+			m.sqlMock.ExpectCommit()
+
+			//	method call
+			res, err := repo.Create(e)
+
+			//	assert
+			expected := e
+			expected.ID = 2
+			assert.NoError(t, err)
+			assert.Equal(t, expected, res)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
+
+func TestRepository_GetByID(t *testing.T) {
+	q := "SELECT * FROM `events` WHERE id = ? ORDER BY `events`.`id` LIMIT 1"
+
+	t.Run(
+		"not found", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).WithArgs(2).WillReturnRows(sqlmock.NewRows(columns))
+
+			//	method call
+			res, err := repo.GetByID(2)
+
+			//	assert
+			assert.ErrorIs(t, err, eventsDef.ErrNotFound)
+			assert.Empty(t, res)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+			date := time.Date(2023, 6, 21, 18, 0, 0, 0, time.UTC)
+
+			//	mocks
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(q)).
+				WithArgs(2).
+				WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "Summer party", "Rooftop", date, "planning"))
+
+			//	method call
+			res, err := repo.GetByID(2)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(
+				t,
+				eventsDef.Event{
+					ID: 2, Name: "Summer party", Venue: "Rooftop", Date: date, Status: eventsDef.StatusPlanning,
+				},
+				res,
+			)
+		},
+	)
+}
+
+func TestRepository_List(t *testing.T) {
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+			date := time.Date(2023, 6, 21, 18, 0, 0, 0, time.UTC)
+
+			//	mocks
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta("SELECT * FROM `events` ORDER BY date,id")).
+				WillReturnRows(
+					sqlmock.NewRows(columns).
+						AddRow(1, "Party", "", date, "planning").
+						AddRow(2, "Summer party", "Rooftop", date, "planning"),
+				)
+
+			//	method call
+			res, err := repo.List()
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Len(t, res, 2)
+			assert.Equal(t, uint(2), res[1].ID)
+		},
+	)
+}
+
+func TestRepository_SetStatus(t *testing.T) {
+	q := "UPDATE `events` SET `status`=? WHERE id = ? AND status = ?"
+
+	t.Run(
+		"status changed", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(q)).
+				WithArgs(eventsDef.StatusDoorsOpen, 2, eventsDef.StatusPlanning).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			m.sqlMock.ExpectCommit()
+
+			//	method call
+			err := repo.SetStatus(2, eventsDef.StatusPlanning, eventsDef.StatusDoorsOpen)
+
+			//	assert
+			assert.ErrorIs(t, err, eventsDef.ErrInvalidTransition)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(q)).
+				WithArgs(eventsDef.StatusDoorsOpen, 2, eventsDef.StatusPlanning).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			m.sqlMock.ExpectCommit()
+
+			//	method call
+			err := repo.SetStatus(2, eventsDef.StatusPlanning, eventsDef.StatusDoorsOpen)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
+
+func TestRepository_SetVenue(t *testing.T) {
+	// setup
+	repo, m := setupIntegrationRepo(t)
+	defer m.db.Close()
+
+	//	mocks, MySQL counts no row when the values don't change
+	q := "UPDATE `events` SET `staff`=?,`venue_limit`=? WHERE id = ?"
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).WithArgs(5, 150, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+	m.sqlMock.ExpectCommit()
+
+	//	method call
+	err := repo.SetVenue(2, 150, 5)
+
+	//	assert
+	assert.NoError(t, err)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
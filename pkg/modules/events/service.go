@@ -0,0 +1,107 @@
+package events
+
+import (
+	"github.com/getground/tech-tasks/backend/definitions/events"
+)
+
+type Service struct {
+	repository events.Repository
+}
+
+func NewService(repository events.Repository) Service {
+	return Service{repository: repository}
+}
+
+// Create adds an event in planning, its tables and guests are added through the routes nested under the event.
+func (s Service) Create(req events.CreateRequest) (res events.EventDTO, err error) {
+	e, err := s.repository.Create(
+		events.Event{
+			Name:       req.Name,
+			Venue:      req.Venue,
+			Date:       req.Date,
+			Status:     events.StatusPlanning,
+			VenueLimit: req.VenueLimit,
+			Staff:      req.Staff,
+		},
+	)
+	if err != nil {
+		return
+	}
+	res = mapEventToDTO(e)
+	return
+}
+
+func (s Service) GetByID(id uint) (res events.EventDTO, err error) {
+	e, err := s.repository.GetByID(id)
+	if err != nil {
+		return
+	}
+	res = mapEventToDTO(e)
+	return
+}
+
+func (s Service) List() (res events.ListDTO, err error) {
+	list, err := s.repository.List()
+	if err != nil {
+		return
+	}
+	res = mapEventsToDTO(list)
+	return
+}
+
+// Transition moves the event to the next status, the statuses can't be skipped nor moved back.
+func (s Service) Transition(req events.TransitionRequest) (res events.EventDTO, err error) {
+	if !req.Status.Valid() {
+		err = events.ErrInvalidStatus
+		return
+	}
+	e, err := s.repository.GetByID(req.ID)
+	if err != nil {
+		return
+	}
+	if !e.Status.CanMoveTo(req.Status) {
+		err = events.ErrInvalidTransition
+		return
+	}
+
+	err = s.repository.SetStatus(e.ID, e.Status, req.Status)
+	if err != nil {
+		return
+	}
+	e.Status = req.Status
+	res = mapEventToDTO(e)
+	return
+}
+
+// SetVenue sets the venue limit and the staff of the event, the check ins that follow are held to them. The event is
+// read only once archived.
+func (s Service) SetVenue(req events.VenueRequest) (res events.EventDTO, err error) {
+	e, err := s.repository.GetByID(req.ID)
+	if err != nil {
+		return
+	}
+	if !e.Status.Allows(events.OpEditVenue) {
+		err = events.ErrNotAllowed
+		return
+	}
+
+	err = s.repository.SetVenue(e.ID, req.VenueLimit, req.Staff)
+	if err != nil {
+		return
+	}
+	e.VenueLimit, e.Staff = req.VenueLimit, req.Staff
+	res = mapEventToDTO(e)
+	return
+}
+
+// Allow tells the tables and guests services if the current status of the event allows the operation.
+func (s Service) Allow(event uint, op events.Operation) error {
+	e, err := s.repository.GetByID(event)
+	if err != nil {
+		return err
+	}
+	if !e.Status.Allows(op) {
+		return events.ErrNotAllowed
+	}
+	return nil
+}
@@ -0,0 +1,289 @@
+package events_test
+
+import (
+	"errors"
+	eventsDef "github.com/getground/tech-tasks/backend/definitions/events"
+	eventsMocks "github.com/getground/tech-tasks/backend/mocks/definitions/events"
+	"github.com/getground/tech-tasks/backend/pkg/modules/events"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func setupService() (events.Service, *eventsMocks.Repository) {
+	repo := new(eventsMocks.Repository)
+	return events.NewService(repo), repo
+}
+
+func TestService_Create(t *testing.T) {
+	// setup
+	service, repo := setupService()
+	date := time.Date(2023, 6, 21, 18, 0, 0, 0, time.UTC)
+	req := eventsDef.CreateRequest{Name: "Summer party", Venue: "Rooftop", Date: date, VenueLimit: 150, Staff: 5}
+	e := eventsDef.Event{
+		Name: "Summer party", Venue: "Rooftop", Date: date, Status: eventsDef.StatusPlanning, VenueLimit: 150, Staff: 5,
+	}
+
+	t.Run(
+		"repository error", func(t *testing.T) {
+			//	mocks
+			repo.On("Create", e).Return(eventsDef.Event{}, errors.New("error creating event")).Once()
+
+			//	method call
+			res, err := service.Create(req)
+
+			//	assert
+			assert.Error(t, err)
+			assert.Empty(t, res)
+			repo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			//	mocks
+			created := e
+			created.ID = 2
+			repo.On("Create", e).Return(created, nil).Once()
+
+			//	method call
+			res, err := service.Create(req)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(
+				t,
+				eventsDef.EventDTO{
+					ID: 2, Name: "Summer party", Venue: "Rooftop", Date: date, Status: eventsDef.StatusPlanning,
+					VenueLimit: 150, Staff: 5,
+				},
+				res,
+			)
+			repo.AssertExpectations(t)
+		},
+	)
+}
+
+func TestService_GetByID(t *testing.T) {
+	// setup
+	service, repo := setupService()
+
+	t.Run(
+		"not found", func(t *testing.T) {
+			//	mocks
+			repo.On("GetByID", uint(2)).Return(eventsDef.Event{}, eventsDef.ErrNotFound).Once()
+
+			//	method call
+			res, err := service.GetByID(2)
+
+			//	assert
+			assert.ErrorIs(t, err, eventsDef.ErrNotFound)
+			assert.Empty(t, res)
+			repo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			//	mocks
+			repo.On("GetByID", uint(2)).Return(eventsDef.Event{ID: 2, Name: "Summer party"}, nil).Once()
+
+			//	method call
+			res, err := service.GetByID(2)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, eventsDef.EventDTO{ID: 2, Name: "Summer party"}, res)
+			repo.AssertExpectations(t)
+		},
+	)
+}
+
+func TestService_List(t *testing.T) {
+	// setup
+	service, repo := setupService()
+
+	t.Run(
+		"success", func(t *testing.T) {
+			//	mocks
+			repo.On("List").Return([]eventsDef.Event{{ID: 1, Name: "Party"}, {ID: 2, Name: "Summer party"}}, nil).Once()
+
+			//	method call
+			res, err := service.List()
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(
+				t,
+				eventsDef.ListDTO{Events: []eventsDef.EventDTO{{ID: 1, Name: "Party"}, {ID: 2, Name: "Summer party"}}},
+				res,
+			)
+			repo.AssertExpectations(t)
+		},
+	)
+}
+
+func TestService_Transition(t *testing.T) {
+	// setup
+	service, repo := setupService()
+
+	t.Run(
+		"invalid status", func(t *testing.T) {
+			//	method call
+			res, err := service.Transition(eventsDef.TransitionRequest{ID: 2, Status: "started"})
+
+			//	assert
+			assert.ErrorIs(t, err, eventsDef.ErrInvalidStatus)
+			assert.Empty(t, res)
+			repo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"status skipped", func(t *testing.T) {
+			//	mocks
+			repo.On("GetByID", uint(2)).Return(eventsDef.Event{ID: 2, Status: eventsDef.StatusPlanning}, nil).Once()
+
+			//	method call
+			res, err := service.Transition(eventsDef.TransitionRequest{ID: 2, Status: eventsDef.StatusInProgress})
+
+			//	assert
+			assert.ErrorIs(t, err, eventsDef.ErrInvalidTransition)
+			assert.Empty(t, res)
+			repo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"moved concurrently", func(t *testing.T) {
+			//	mocks
+			repo.On("GetByID", uint(2)).Return(eventsDef.Event{ID: 2, Status: eventsDef.StatusPlanning}, nil).Once()
+			repo.On("SetStatus", uint(2), eventsDef.StatusPlanning, eventsDef.StatusDoorsOpen).
+				Return(eventsDef.ErrInvalidTransition).
+				Once()
+
+			//	method call
+			res, err := service.Transition(eventsDef.TransitionRequest{ID: 2, Status: eventsDef.StatusDoorsOpen})
+
+			//	assert
+			assert.ErrorIs(t, err, eventsDef.ErrInvalidTransition)
+			assert.Empty(t, res)
+			repo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			//	mocks
+			repo.On("GetByID", uint(2)).Return(eventsDef.Event{ID: 2, Status: eventsDef.StatusPlanning}, nil).Once()
+			repo.On("SetStatus", uint(2), eventsDef.StatusPlanning, eventsDef.StatusDoorsOpen).Return(nil).Once()
+
+			//	method call
+			res, err := service.Transition(eventsDef.TransitionRequest{ID: 2, Status: eventsDef.StatusDoorsOpen})
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, eventsDef.EventDTO{ID: 2, Status: eventsDef.StatusDoorsOpen}, res)
+			repo.AssertExpectations(t)
+		},
+	)
+}
+
+func TestService_SetVenue(t *testing.T) {
+	// setup
+	service, repo := setupService()
+	req := eventsDef.VenueRequest{ID: 2, VenueLimit: 150, Staff: 5}
+
+	t.Run(
+		"archived", func(t *testing.T) {
+			//	mocks
+			repo.On("GetByID", uint(2)).Return(eventsDef.Event{ID: 2, Status: eventsDef.StatusArchived}, nil).Once()
+
+			//	method call
+			res, err := service.SetVenue(req)
+
+			//	assert
+			assert.ErrorIs(t, err, eventsDef.ErrNotAllowed)
+			assert.Empty(t, res)
+			repo.AssertNotCalled(t, "SetVenue", uint(2), int64(150), int64(5))
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			//	mocks
+			repo.On("GetByID", uint(2)).Return(eventsDef.Event{ID: 2, Status: eventsDef.StatusInProgress}, nil).Once()
+			repo.On("SetVenue", uint(2), int64(150), int64(5)).Return(nil).Once()
+
+			//	method call
+			res, err := service.SetVenue(req)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(
+				t, eventsDef.EventDTO{ID: 2, Status: eventsDef.StatusInProgress, VenueLimit: 150, Staff: 5}, res,
+			)
+			repo.AssertExpectations(t)
+		},
+	)
+}
+
+func TestService_Allow(t *testing.T) {
+	// setup
+	service, repo := setupService()
+
+	cases := []struct {
+		name   string
+		status eventsDef.Status
+		op     eventsDef.Operation
+		err    error
+	}{
+		{name: "tables planned", status: eventsDef.StatusPlanning, op: eventsDef.OpEditTables},
+		{
+			name:   "no check in before doors open",
+			status: eventsDef.StatusPlanning,
+			op:     eventsDef.OpCheckIn,
+			err:    eventsDef.ErrNotAllowed,
+		},
+		{
+			name:   "guest list settled",
+			status: eventsDef.StatusDoorsOpen,
+			op:     eventsDef.OpEditGuestList,
+			err:    eventsDef.ErrNotAllowed,
+		},
+		{name: "check in", status: eventsDef.StatusInProgress, op: eventsDef.OpCheckIn},
+		{name: "check out once closed", status: eventsDef.StatusClosed, op: eventsDef.OpCheckOut},
+		{name: "venue limit once closed", status: eventsDef.StatusClosed, op: eventsDef.OpEditVenue},
+		{name: "archived", status: eventsDef.StatusArchived, op: eventsDef.OpCheckOut, err: eventsDef.ErrNotAllowed},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				//	mocks
+				repo.On("GetByID", uint(2)).Return(eventsDef.Event{ID: 2, Status: c.status}, nil).Once()
+
+				//	method call
+				err := service.Allow(2, c.op)
+
+				//	assert
+				assert.Equal(t, c.err, err)
+				repo.AssertExpectations(t)
+			},
+		)
+	}
+
+	t.Run(
+		"event not found", func(t *testing.T) {
+			//	mocks
+			repo.On("GetByID", uint(3)).Return(eventsDef.Event{}, eventsDef.ErrNotFound).Once()
+
+			//	method call
+			err := service.Allow(3, eventsDef.OpCheckIn)
+
+			//	assert
+			assert.ErrorIs(t, err, eventsDef.ErrNotFound)
+			repo.AssertExpectations(t)
+		},
+	)
+}
@@ -1,7 +1,14 @@
 package guests
 
 import (
+	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/audit"
+	"github.com/getground/tech-tasks/backend/definitions/etag"
+	"github.com/getground/tech-tasks/backend/definitions/events"
 	"github.com/getground/tech-tasks/backend/definitions/guests"
+	"github.com/getground/tech-tasks/backend/definitions/invitations"
+	"github.com/getground/tech-tasks/backend/definitions/pagination"
+	"github.com/getground/tech-tasks/backend/definitions/venue"
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
 	"net/http"
@@ -31,11 +38,11 @@ func (ctrl Controller) Create(c *gin.Context) {
 		return
 	}
 
-	res, err := ctrl.service.Create(req)
+	res, err := ctrl.scoped(c).Create(req)
 	if err != nil {
 		log.Error(err)
 		c.JSON(
-			http.StatusInternalServerError, gin.H{
+			eventErrorStatus(err), gin.H{
 				"error": err.Error(),
 			},
 		)
@@ -46,22 +53,247 @@ func (ctrl Controller) Create(c *gin.Context) {
 }
 
 func (ctrl Controller) GetGuestList(c *gin.Context) {
-	res, err := ctrl.service.GetGuestList()
+	req, err := ctrl.handler.List(c)
 	if err != nil {
 		log.Error(err)
 		c.JSON(
-			http.StatusInternalServerError, gin.H{
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.scoped(c).GetGuestList(req)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			listErrorStatus(err), gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// Get answers the guest with its version in the ETag header, the version can be sent back in If-Match to check it in.
+func (ctrl Controller) Get(c *gin.Context) {
+	name, err := ctrl.handler.Get(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.scoped(c).Get(name)
+	if err != nil {
+		log.Error(err)
+		status := eventErrorStatus(err)
+		if errors.Is(err, guests.ErrNotInvited) {
+			status = http.StatusNotFound
+		}
+		c.JSON(
+			status, gin.H{
 				"error": err.Error(),
 			},
 		)
 		return
 	}
 
+	c.Header(etag.Header, etag.Format(res.Version))
 	c.JSON(http.StatusOK, res)
 }
 
 func (ctrl Controller) GetGuests(c *gin.Context) {
-	res, err := ctrl.service.GetGuests()
+	req, err := ctrl.handler.List(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.scoped(c).GetGuests(req)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			listErrorStatus(err), gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (ctrl Controller) Search(c *gin.Context) {
+	req, err := ctrl.handler.Search(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.scoped(c).Search(req)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusInternalServerError, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (ctrl Controller) Respond(c *gin.Context) {
+	req, err := ctrl.handler.Respond(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.service.ForActor(c.GetString(audit.ContextKey)).Respond(req)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			invitationErrorStatus(err), gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// EditCompanions answers the new party of the guest with its version in the ETag header.
+func (ctrl Controller) EditCompanions(c *gin.Context) {
+	req, err := ctrl.handler.EditCompanions(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.scoped(c).EditCompanions(req)
+	if err != nil {
+		log.Error(err)
+		status := eventErrorStatus(err)
+		switch {
+		case errors.Is(err, guests.ErrNotInvited):
+			status = http.StatusNotFound
+		case errors.Is(err, guests.ErrNoCapacity):
+			status = http.StatusUnprocessableEntity
+		}
+		c.JSON(
+			status, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.Header(etag.Header, etag.Format(res.Version))
+	c.JSON(http.StatusOK, res)
+}
+
+// EditProfile answers the guest with its new profile and its version in the ETag header.
+func (ctrl Controller) EditProfile(c *gin.Context) {
+	req, err := ctrl.handler.EditProfile(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.scoped(c).EditProfile(req)
+	if err != nil {
+		log.Error(err)
+		status := eventErrorStatus(err)
+		if errors.Is(err, guests.ErrNotInvited) {
+			status = http.StatusNotFound
+		}
+		c.JSON(
+			status, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.Header(etag.Header, etag.Format(res.Version))
+	c.JSON(http.StatusOK, res)
+}
+
+func (ctrl Controller) Catering(c *gin.Context) {
+	req, err := ctrl.handler.Catering(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.scoped(c).Catering(req)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusInternalServerError, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (ctrl Controller) RSVPCounts(c *gin.Context) {
+	req, err := ctrl.handler.RSVPCounts(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.scoped(c).RSVPCounts(req)
 	if err != nil {
 		log.Error(err)
 		c.JSON(
@@ -87,11 +319,11 @@ func (ctrl Controller) CheckIn(c *gin.Context) {
 		return
 	}
 
-	res, err := ctrl.service.CheckIn(req)
+	res, err := ctrl.scoped(c).CheckIn(req)
 	if err != nil {
 		log.Error(err)
 		c.JSON(
-			http.StatusInternalServerError, gin.H{
+			admissionErrorStatus(err), gin.H{
 				"error": err.Error(),
 			},
 		)
@@ -101,6 +333,121 @@ func (ctrl Controller) CheckIn(c *gin.Context) {
 	c.JSON(http.StatusOK, res)
 }
 
+func (ctrl Controller) Scan(c *gin.Context) {
+	req, err := ctrl.handler.Scan(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.service.ForActor(c.GetString(audit.ContextKey)).Scan(req)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			invitationErrorStatus(err), gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (ctrl Controller) WalkIn(c *gin.Context) {
+	req, err := ctrl.handler.WalkIn(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.scoped(c).WalkIn(req)
+	if err != nil {
+		log.Error(err)
+		status := admissionErrorStatus(err)
+		switch {
+		case errors.Is(err, guests.ErrAlreadyListed):
+			status = http.StatusConflict
+		case errors.Is(err, guests.ErrNoEmptySeats):
+			status = http.StatusUnprocessableEntity
+		}
+		c.JSON(
+			status, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (ctrl Controller) Reinvite(c *gin.Context) {
+	name, err := ctrl.handler.Reinvite(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.scoped(c).Reinvite(name)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			eventErrorStatus(err), gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (ctrl Controller) Uninvite(c *gin.Context) {
+	name, err := ctrl.handler.Uninvite(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	err = ctrl.scoped(c).Uninvite(name)
+	if err != nil {
+		log.Error(err)
+		status := eventErrorStatus(err)
+		if errors.Is(err, guests.ErrNotInvited) {
+			status = http.StatusNotFound
+		}
+		c.JSON(
+			status, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, http.NoBody)
+}
+
 func (ctrl Controller) CheckOut(c *gin.Context) {
 	name, err := ctrl.handler.CheckOut(c)
 	if err != nil {
@@ -113,11 +460,11 @@ func (ctrl Controller) CheckOut(c *gin.Context) {
 		return
 	}
 
-	err = ctrl.service.CheckOut(name)
+	err = ctrl.scoped(c).CheckOut(name)
 	if err != nil {
 		log.Error(err)
 		c.JSON(
-			http.StatusInternalServerError, gin.H{
+			eventErrorStatus(err), gin.H{
 				"error": err.Error(),
 			},
 		)
@@ -126,3 +473,53 @@ func (ctrl Controller) CheckOut(c *gin.Context) {
 
 	c.JSON(http.StatusNoContent, http.NoBody)
 }
+
+func listErrorStatus(err error) int {
+	if errors.Is(err, pagination.ErrInvalidCursor) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// invitationErrorStatus answers the scans and answers of invalid, revoked or used tokens with 403, the other errors
+// are answered like PUT /guests/:name.
+func invitationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, invitations.ErrInvalidToken), errors.Is(err, invitations.ErrRevoked),
+		errors.Is(err, invitations.ErrUsed):
+		return http.StatusForbidden
+	case errors.Is(err, invitations.ErrNotFound):
+		return http.StatusNotFound
+	}
+	return admissionErrorStatus(err)
+}
+
+// admissionErrorStatus answers the check ins over the headcount limit of the venue with 422.
+func admissionErrorStatus(err error) int {
+	if errors.Is(err, venue.ErrFull) {
+		return http.StatusUnprocessableEntity
+	}
+	return eventErrorStatus(err)
+}
+
+// eventErrorStatus answers the changes the status of the event doesn't allow with 409, the changes made on a stale
+// version with 412 and the parties whose companions don't match the accompanying guests with 400, the other errors
+// stay 500.
+func eventErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, guests.ErrCompanionCount):
+		return http.StatusBadRequest
+	case errors.Is(err, events.ErrNotAllowed):
+		return http.StatusConflict
+	case errors.Is(err, etag.ErrStale):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, events.ErrNotFound):
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+// scoped returns the service of the event the request is scoped to, acting on behalf of the actor of the request.
+func (ctrl Controller) scoped(c *gin.Context) guests.Service {
+	return ctrl.service.ForEvent(c.GetUint(events.ContextKey)).ForActor(c.GetString(audit.ContextKey))
+}
@@ -4,7 +4,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/getground/tech-tasks/backend/definitions/etag"
+	eventsDef "github.com/getground/tech-tasks/backend/definitions/events"
 	guestsDef "github.com/getground/tech-tasks/backend/definitions/guests"
+	invitationsDef "github.com/getground/tech-tasks/backend/definitions/invitations"
+	"github.com/getground/tech-tasks/backend/definitions/pagination"
+	venueDef "github.com/getground/tech-tasks/backend/definitions/venue"
 	guestsMocks "github.com/getground/tech-tasks/backend/mocks/definitions/guests"
 	"github.com/getground/tech-tasks/backend/pkg/modules/guests"
 	"github.com/gin-gonic/gin"
@@ -26,6 +31,10 @@ func setupController() (*gin.Engine, guests.Controller, ctrlMocks) {
 
 	handler := guests.NewHandler()
 	service := new(guestsMocks.Service)
+	// the requests are not nested under an event, they are scoped to the zero event
+	service.On("ForEvent", uint(0)).Return(service).Maybe()
+	// the requests have no actor without the audit middleware
+	service.On("ForActor", "").Return(service).Maybe()
 	ctrl := guests.NewController(handler, service)
 	mocks := ctrlMocks{handler, service}
 
@@ -167,14 +176,60 @@ func TestController_Create(t *testing.T) {
 	)
 }
 
+// listRequest is the request the handler binds when the query sends a cursor without a limit.
+func listRequest(req guestsDef.ListRequest) guestsDef.ListRequest {
+	if req.Limit == 0 && req.Cursor != "" {
+		req.Limit = pagination.DefaultLimit
+	}
+	return req
+}
+
 func TestController_GetGuestList(t *testing.T) {
 	//	setup
 	r, ctrl, m := setupController()
 	r.GET("/guest_list", ctrl.GetGuestList)
+	t.Run(
+		"invalid query", func(t *testing.T) {
+			//	request
+			req, err := http.NewRequest(http.MethodGet, "/guest_list?sort=table&limit=5000", http.NoBody)
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			// assert
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			m.service.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"invalid cursor", func(t *testing.T) {
+			// mocks
+			listReq := listRequest(guestsDef.ListRequest{Request: pagination.Request{Cursor: "abc"}})
+			m.service.On("GetGuestList", listReq).Return(guestsDef.ListDTO{}, pagination.ErrInvalidCursor).Once()
+
+			//	request
+			req, err := http.NewRequest(http.MethodGet, "/guest_list?cursor=abc", http.NoBody)
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			// assert
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			m.service.AssertExpectations(t)
+		},
+	)
+
 	t.Run(
 		"service error", func(t *testing.T) {
 			// mocks
-			m.service.On("GetGuestList").Return(guestsDef.ListDTO{}, errors.New("internal error")).Once()
+			m.service.On("GetGuestList", listRequest(guestsDef.ListRequest{})).
+				Return(guestsDef.ListDTO{}, errors.New("internal error")).
+				Once()
 
 			//	request
 			req, err := http.NewRequest(http.MethodGet, "/guest_list", http.NoBody)
@@ -199,14 +254,26 @@ func TestController_GetGuestList(t *testing.T) {
 						Name:         "test",
 						Table:        1,
 						Accompanying: 10,
+						RSVP:         guestsDef.RSVPAccepted,
 					},
 				},
+				Total:      2,
+				NextCursor: "next",
+			}
+			arrived, checkedOut := true, false
+			listReq := guestsDef.ListRequest{
+				Filter: guestsDef.Filter{NamePrefix: "te", Table: 1, Arrived: &arrived, CheckedOut: &checkedOut},
+				Sort:   guestsDef.SortTimeArrived,
+				Order:  guestsDef.OrderDesc,
 			}
+			listReq.Cursor = "abc"
+			listReq.Limit = 1
 			// mocks
-			m.service.On("GetGuestList").Return(res, nil).Once()
+			m.service.On("GetGuestList", listReq).Return(res, nil).Once()
 
 			//	request
-			req, err := http.NewRequest(http.MethodGet, "/guest_list", http.NoBody)
+			query := "prefix=te&table=1&arrived=true&checked_out=false&sort=time_arrived&order=desc&cursor=abc&limit=1"
+			req, err := http.NewRequest(http.MethodGet, "/guest_list?"+query, http.NoBody)
 			if err != nil {
 				t.Errorf("Error requesting test controller: %v\n", err)
 			}
@@ -214,7 +281,8 @@ func TestController_GetGuestList(t *testing.T) {
 			r.ServeHTTP(rr, req)
 
 			// expectation
-			expected := `{"guests":[{"name":"test","table":1,"accompanying_guests":10}]}`
+			expected := `{"guests":[{"name":"test","table":1,"accompanying_guests":10,"rsvp":"accepted","walk_in":false,` +
+				`"version":0}],"total":2,"next_cursor":"next"}`
 
 			// assert
 			assert.Equal(t, http.StatusOK, rr.Code)
@@ -231,7 +299,9 @@ func TestController_GetGuests(t *testing.T) {
 	t.Run(
 		"service error", func(t *testing.T) {
 			// mocks
-			m.service.On("GetGuests").Return(guestsDef.DTO{}, errors.New("internal error")).Once()
+			m.service.On("GetGuests", listRequest(guestsDef.ListRequest{})).
+				Return(guestsDef.DTO{}, errors.New("internal error")).
+				Once()
 
 			//	request
 			req, err := http.NewRequest(http.MethodGet, "/guests", http.NoBody)
@@ -258,9 +328,10 @@ func TestController_GetGuests(t *testing.T) {
 						TimeArrived:  "14/1/223",
 					},
 				},
+				Total: 1,
 			}
 			// mocks
-			m.service.On("GetGuests").Return(res, nil).Once()
+			m.service.On("GetGuests", listRequest(guestsDef.ListRequest{})).Return(res, nil).Once()
 
 			//	request
 			req, err := http.NewRequest(http.MethodGet, "/guests", http.NoBody)
@@ -271,7 +342,77 @@ func TestController_GetGuests(t *testing.T) {
 			r.ServeHTTP(rr, req)
 
 			// expectation
-			expected := `{"guests":[{"name":"test","accompanying_guests":10,"time_arrived":"14/1/223"}]}`
+			expected := `{"guests":[{"name":"test","accompanying_guests":10,"time_arrived":"14/1/223","walk_in":false}],"total":1}`
+
+			// assert
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, expected, rr.Body.String())
+			m.service.AssertExpectations(t)
+		},
+	)
+}
+
+func TestController_Search(t *testing.T) {
+	//	setup
+	r, ctrl, m := setupController()
+	r.GET("/guests/search", ctrl.Search)
+	t.Run(
+		"query missing", func(t *testing.T) {
+			//	request
+			req, err := http.NewRequest(http.MethodGet, "/guests/search", http.NoBody)
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			// assert
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+		},
+	)
+
+	t.Run(
+		"service error", func(t *testing.T) {
+			// mocks
+			m.service.On("Search", guestsDef.SearchRequest{Query: "sam"}).
+				Return(guestsDef.SearchDTO{}, errors.New("internal error")).
+				Once()
+
+			//	request
+			req, err := http.NewRequest(http.MethodGet, "/guests/search?q=sam", http.NoBody)
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			// assert
+			assert.Equal(t, http.StatusInternalServerError, rr.Code)
+			m.service.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// test data
+			res := guestsDef.SearchDTO{
+				Guests: []guestsDef.MatchDTO{
+					{Name: "Sam Smith", Table: 1, Accompanying: 2, Status: guestsDef.StatusArrived, Score: 0.9},
+				},
+			}
+			// mocks
+			m.service.On("Search", guestsDef.SearchRequest{Query: "Smith, Sam", Limit: 3}).Return(res, nil).Once()
+
+			//	request
+			req, err := http.NewRequest(http.MethodGet, "/guests/search?q=Smith,+Sam&limit=3", http.NoBody)
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			// expectation
+			expected := `{"guests":[{"name":"Sam Smith","table":1,"accompanying_guests":2,"status":"arrived","score":0.9}]}`
 
 			// assert
 			assert.Equal(t, http.StatusOK, rr.Code)
@@ -376,6 +517,93 @@ func TestController_CheckIn(t *testing.T) {
 		},
 	)
 
+	t.Run(
+		"invalid If-Match", func(t *testing.T) {
+			//	setup
+			r, ctrl, m := setupController()
+			r.PUT("/guests/:name", ctrl.CheckIn)
+
+			//	request
+			body := `{"name":"test","accompanying_guests":10}`
+			req, err := http.NewRequest(http.MethodPut, "/guests/test", strings.NewReader(body))
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			req.Header.Set(etag.IfMatchHeader, "W/2")
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			//	assert
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			m.service.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"stale version", func(t *testing.T) {
+			//	setup
+			r, ctrl, m := setupController()
+			r.PUT("/guests/:name", ctrl.CheckIn)
+
+			//	test data
+			checkInReq := guestsDef.CheckInRequest{Name: "test", Accompanying: 10, Version: 2}
+
+			// mocks
+			m.service.On("CheckIn", checkInReq).Return(guestsDef.CheckInResponse{}, etag.ErrStale).Once()
+
+			//	request
+			body := `{"name":"test","accompanying_guests":10}`
+			req, err := http.NewRequest(http.MethodPut, "/guests/test", strings.NewReader(body))
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			req.Header.Set(etag.IfMatchHeader, `"2"`)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			//	assert
+			assert.Equal(t, http.StatusPreconditionFailed, rr.Code)
+			m.service.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"doors not open", func(t *testing.T) {
+			//	setup
+			r, ctrl, m := setupController()
+			r.PUT("/guests/:name", ctrl.CheckIn)
+
+			//	test data
+			checkInReq := guestsDef.CheckInRequest{
+				Name:         "test",
+				Accompanying: 10,
+			}
+
+			// mocks
+			m.service.On("CheckIn", checkInReq).Return(guestsDef.CheckInResponse{}, eventsDef.ErrNotAllowed).Once()
+
+			//	request
+			body, err := json.Marshal(&checkInReq)
+			if err != nil {
+				t.Errorf("Error converting struct to json - test controller: %v\n", err)
+			}
+
+			req, err := http.NewRequest(http.MethodPut, "/guests/:name", strings.NewReader(string(body)))
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			//	assert
+			assert.Equal(t, http.StatusConflict, rr.Code)
+			m.service.AssertExpectations(t)
+		},
+	)
+
 	t.Run(
 		"success", func(t *testing.T) {
 			//	setup
@@ -419,6 +647,622 @@ func TestController_CheckIn(t *testing.T) {
 	)
 }
 
+func TestController_Scan(t *testing.T) {
+	//	setup
+	r, ctrl, m := setupController()
+	r.POST("/checkin/scan", ctrl.Scan)
+
+	cases := []struct {
+		name     string
+		body     string
+		mock     func()
+		code     int
+		expected string
+	}{
+		{
+			name: "token missing",
+			body: `{"accompanying_guests":1}`,
+			mock: func() {},
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "used token",
+			body: `{"token":"id.signature","accompanying_guests":1}`,
+			mock: func() {
+				m.service.On("Scan", guestsDef.ScanRequest{Token: "id.signature", Accompanying: 1}).
+					Return(guestsDef.CheckInResponse{}, invitationsDef.ErrUsed).
+					Once()
+			},
+			code:     http.StatusForbidden,
+			expected: `{"error":"invitation already used"}`,
+		},
+		{
+			name: "no seats left",
+			body: `{"token":"id.signature","accompanying_guests":5}`,
+			mock: func() {
+				m.service.On("Scan", guestsDef.ScanRequest{Token: "id.signature", Accompanying: 5}).
+					Return(guestsDef.CheckInResponse{}, guestsDef.ErrExtraAccompanying).
+					Once()
+			},
+			code:     http.StatusInternalServerError,
+			expected: `{"error":"extra accompanying than expected"}`,
+		},
+		{
+			name: "success",
+			body: `{"token":"id.signature","accompanying_guests":2}`,
+			mock: func() {
+				m.service.On("Scan", guestsDef.ScanRequest{Token: "id.signature", Accompanying: 2}).
+					Return(guestsDef.CheckInResponse{Name: "test"}, nil).
+					Once()
+			},
+			code:     http.StatusOK,
+			expected: `{"name":"test"}`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				// mocks
+				tc.mock()
+
+				//	request
+				req, err := http.NewRequest(http.MethodPost, "/checkin/scan", strings.NewReader(tc.body))
+				if err != nil {
+					t.Errorf("Error requesting test controller: %v\n", err)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, req)
+
+				// assert
+				assert.Equal(t, tc.code, rr.Code)
+				if tc.expected != "" {
+					assert.Equal(t, tc.expected, rr.Body.String())
+				}
+				m.service.AssertExpectations(t)
+			},
+		)
+	}
+}
+
+func TestController_Respond(t *testing.T) {
+	//	setup
+	r, ctrl, m := setupController()
+	r.POST("/rsvp/:token", ctrl.Respond)
+
+	cases := []struct {
+		name     string
+		body     string
+		mock     func()
+		code     int
+		expected string
+	}{
+		{
+			name: "invalid response",
+			body: `{"response":"maybe"}`,
+			mock: func() {},
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "revoked token",
+			body: `{"response":"declined"}`,
+			mock: func() {
+				m.service.On(
+					"Respond", guestsDef.RSVPRequest{Token: "id.signature", Response: guestsDef.RSVPDeclined},
+				).Return(guestsDef.RSVPResponse{}, invitationsDef.ErrRevoked).Once()
+			},
+			code:     http.StatusForbidden,
+			expected: `{"error":"invitation revoked"}`,
+		},
+		{
+			name: "success",
+			body: `{"response":"accepted","accompanying_guests":2}`,
+			mock: func() {
+				m.service.On(
+					"Respond",
+					guestsDef.RSVPRequest{Token: "id.signature", Response: guestsDef.RSVPAccepted, Accompanying: 2},
+				).Return(
+					guestsDef.RSVPResponse{Name: "test", Response: guestsDef.RSVPAccepted, Accompanying: 2}, nil,
+				).Once()
+			},
+			code:     http.StatusOK,
+			expected: `{"name":"test","response":"accepted","accompanying_guests":2}`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				// mocks
+				tc.mock()
+
+				//	request
+				req, err := http.NewRequest(http.MethodPost, "/rsvp/id.signature", strings.NewReader(tc.body))
+				if err != nil {
+					t.Errorf("Error requesting test controller: %v\n", err)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, req)
+
+				// assert
+				assert.Equal(t, tc.code, rr.Code)
+				if tc.expected != "" {
+					assert.Equal(t, tc.expected, rr.Body.String())
+				}
+				m.service.AssertExpectations(t)
+			},
+		)
+	}
+}
+
+func TestController_RSVPCounts(t *testing.T) {
+	//	setup
+	r, ctrl, m := setupController()
+	r.GET("/reports/rsvp", ctrl.RSVPCounts)
+
+	t.Run(
+		"invalid table", func(t *testing.T) {
+			//	request
+			req, err := http.NewRequest(http.MethodGet, "/reports/rsvp?table=one", http.NoBody)
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			// assert
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+		},
+	)
+
+	t.Run(
+		"service error", func(t *testing.T) {
+			// mocks
+			m.service.On("RSVPCounts", guestsDef.RSVPCountsRequest{}).
+				Return(guestsDef.RSVPCountsDTO{}, errors.New("internal error")).
+				Once()
+
+			//	request
+			req, err := http.NewRequest(http.MethodGet, "/reports/rsvp", http.NoBody)
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			// assert
+			assert.Equal(t, http.StatusInternalServerError, rr.Code)
+			m.service.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// test data
+			res := guestsDef.RSVPCountsDTO{
+				Tables: []guestsDef.TableRSVPDTO{{Table: 2, Invited: 1, Accepted: 2, SeatsReserved: 5}},
+			}
+			// mocks
+			m.service.On("RSVPCounts", guestsDef.RSVPCountsRequest{Table: 2}).Return(res, nil).Once()
+
+			//	request
+			req, err := http.NewRequest(http.MethodGet, "/reports/rsvp?table=2", http.NoBody)
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			// expectation
+			expected := `{"tables":[{"table":2,"invited":1,"accepted":2,"declined":0,"tentative":0,"seats_reserved":5}]}`
+
+			// assert
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, expected, rr.Body.String())
+			m.service.AssertExpectations(t)
+		},
+	)
+}
+
+func TestController_WalkIn(t *testing.T) {
+	//	setup
+	r, ctrl, m := setupController()
+	r.POST("/checkin/walk_in", ctrl.WalkIn)
+	walkIn := guestsDef.WalkInRequest{Name: "test", Accompanying: 2}
+
+	cases := []struct {
+		name       string
+		body       string
+		serviceErr error
+		status     int
+		expected   string
+	}{
+		{name: "missing name", body: `{"accompanying_guests":2}`, status: http.StatusBadRequest},
+		{
+			name: "already listed", body: `{"name":"test","accompanying_guests":2}`,
+			serviceErr: guestsDef.ErrAlreadyListed, status: http.StatusConflict,
+		},
+		{
+			name: "no empty seats", body: `{"name":"test","accompanying_guests":2}`,
+			serviceErr: guestsDef.ErrNoEmptySeats, status: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "venue full", body: `{"name":"test","accompanying_guests":2}`,
+			serviceErr: venueDef.ErrFull, status: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "service error", body: `{"name":"test","accompanying_guests":2}`,
+			serviceErr: errors.New("internal error"), status: http.StatusInternalServerError,
+		},
+		{
+			name: "success", body: `{"name":"test","accompanying_guests":2}`, status: http.StatusOK,
+			expected: `{"name":"test","table":4}`,
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				// mocks
+				if c.status != http.StatusBadRequest {
+					m.service.On("WalkIn", walkIn).
+						Return(guestsDef.WalkInResponse{Name: "test", Table: 4}, c.serviceErr).
+						Once()
+				}
+
+				//	request
+				req, err := http.NewRequest(http.MethodPost, "/checkin/walk_in", strings.NewReader(c.body))
+				if err != nil {
+					t.Errorf("Error requesting test controller: %v\n", err)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, req)
+
+				// assert
+				assert.Equal(t, c.status, rr.Code)
+				if c.expected != "" {
+					assert.Equal(t, c.expected, rr.Body.String())
+				}
+				m.service.AssertExpectations(t)
+			},
+		)
+	}
+}
+
+func TestController_Reinvite(t *testing.T) {
+	//	setup
+	r, ctrl, m := setupController()
+	r.POST("/guest_list/:name/invitation", ctrl.Reinvite)
+
+	t.Run(
+		"service error", func(t *testing.T) {
+			// mocks
+			m.service.On("Reinvite", "test").
+				Return(invitationsDef.InvitationResponse{}, guestsDef.ErrNotInvited).
+				Once()
+
+			//	request
+			req, err := http.NewRequest(http.MethodPost, "/guest_list/test/invitation", http.NoBody)
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			// assert
+			assert.Equal(t, http.StatusInternalServerError, rr.Code)
+			m.service.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// mocks
+			m.service.On("Reinvite", "test").Return(invitationsDef.InvitationResponse{Token: "id.signature"}, nil).Once()
+
+			//	request
+			req, err := http.NewRequest(http.MethodPost, "/guest_list/test/invitation", http.NoBody)
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			// assert
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, `{"token":"id.signature"}`, rr.Body.String())
+			m.service.AssertExpectations(t)
+		},
+	)
+}
+
+func TestController_Get(t *testing.T) {
+	//	setup
+	r, ctrl, m := setupController()
+	r.GET("/guest_list/:name", ctrl.Get)
+
+	cases := []struct {
+		name       string
+		serviceErr error
+		status     int
+		expected   string
+	}{
+		{name: "guest not invited", serviceErr: guestsDef.ErrNotInvited, status: http.StatusNotFound},
+		{name: "service error", serviceErr: errors.New("internal error"), status: http.StatusInternalServerError},
+		{
+			name: "success", status: http.StatusOK,
+			expected: `{"name":"test","table":1,"accompanying_guests":2,"rsvp":"accepted","walk_in":false,"version":3}`,
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				// mocks
+				g := guestsDef.GuestListDTO{
+					Name: "test", Table: 1, Accompanying: 2, RSVP: guestsDef.RSVPAccepted, Version: 3,
+				}
+				m.service.On("Get", "test").Return(g, c.serviceErr).Once()
+
+				//	request
+				req, err := http.NewRequest(http.MethodGet, "/guest_list/test", http.NoBody)
+				if err != nil {
+					t.Errorf("Error requesting test controller: %v\n", err)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, req)
+
+				// assert
+				assert.Equal(t, c.status, rr.Code)
+				if c.expected != "" {
+					assert.Equal(t, c.expected, rr.Body.String())
+					assert.Equal(t, `"3"`, rr.Header().Get(etag.Header))
+				}
+				m.service.AssertExpectations(t)
+			},
+		)
+	}
+}
+
+func TestController_EditCompanions(t *testing.T) {
+	//	setup
+	r, ctrl, m := setupController()
+	r.PUT("/guest_list/:name/companions", ctrl.EditCompanions)
+
+	companions := []guestsDef.CompanionDTO{{Name: "ana", Dietary: "vegan"}}
+	cases := []struct {
+		name       string
+		body       string
+		serviceErr error
+		status     int
+		expected   string
+	}{
+		{name: "companions not sent", body: `{}`, status: http.StatusBadRequest},
+		{name: "companion without a name", body: `{"companions":[{"dietary":"vegan"}]}`, status: http.StatusBadRequest},
+		{name: "guest not invited", serviceErr: guestsDef.ErrNotInvited, status: http.StatusNotFound},
+		{name: "no capacity", serviceErr: guestsDef.ErrNoCapacity, status: http.StatusUnprocessableEntity},
+		{name: "stale version", serviceErr: etag.ErrStale, status: http.StatusPreconditionFailed},
+		{
+			name: "success", status: http.StatusOK,
+			expected: `{"name":"test","table":1,"accompanying_guests":1,"companions":[{"name":"ana","dietary":"vegan"}],` +
+				`"rsvp":"accepted","walk_in":false,"version":3}`,
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				// mocks
+				body := c.body
+				if body == "" {
+					body = `{"companions":[{"name":"ana","dietary":"vegan"}]}`
+					g := guestsDef.GuestListDTO{
+						Name: "test", Table: 1, Accompanying: 1, Companions: companions, RSVP: guestsDef.RSVPAccepted,
+						Version: 3,
+					}
+					m.service.On(
+						"EditCompanions", guestsDef.CompanionsRequest{Name: "test", Companions: companions, Version: 2},
+					).Return(g, c.serviceErr).Once()
+				}
+
+				//	request
+				req, err := http.NewRequest(http.MethodPut, "/guest_list/test/companions", strings.NewReader(body))
+				if err != nil {
+					t.Errorf("Error requesting test controller: %v\n", err)
+				}
+				req.Header.Set(etag.IfMatchHeader, `"2"`)
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, req)
+
+				// assert
+				assert.Equal(t, c.status, rr.Code)
+				if c.expected != "" {
+					assert.Equal(t, c.expected, rr.Body.String())
+					assert.Equal(t, `"3"`, rr.Header().Get(etag.Header))
+				}
+				m.service.AssertExpectations(t)
+			},
+		)
+	}
+}
+
+func TestController_EditProfile(t *testing.T) {
+	//	setup
+	r, ctrl, m := setupController()
+	r.PUT("/guest_list/:name/profile", ctrl.EditProfile)
+
+	profile := guestsDef.ProfileDTO{
+		Email: "sam@example.com", Phone: "+447700900123", Department: "sales", Dietary: guestsDef.DietOther,
+		DietaryNotes: "no shellfish", Accessibility: "step free access",
+	}
+	cases := []struct {
+		name       string
+		body       string
+		serviceErr error
+		status     int
+		expected   string
+	}{
+		{name: "invalid email", body: `{"email":"sam"}`, status: http.StatusBadRequest},
+		{name: "invalid phone", body: `{"phone":"07700 900123"}`, status: http.StatusBadRequest},
+		{name: "unknown diet", body: `{"dietary":"carnivore"}`, status: http.StatusBadRequest},
+		{name: "other diet without notes", body: `{"dietary":"other"}`, status: http.StatusBadRequest},
+		{name: "guest not invited", serviceErr: guestsDef.ErrNotInvited, status: http.StatusNotFound},
+		{name: "stale version", serviceErr: etag.ErrStale, status: http.StatusPreconditionFailed},
+		{
+			name: "success", status: http.StatusOK,
+			expected: `{"name":"test","table":1,"accompanying_guests":0,"rsvp":"accepted","walk_in":false,"version":3,` +
+				`"email":"sam@example.com","phone":"+447700900123","department":"sales","dietary":"other",` +
+				`"dietary_notes":"no shellfish","accessibility":"step free access"}`,
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				// mocks
+				body := c.body
+				if body == "" {
+					body = `{"email":"sam@example.com","phone":"+447700900123","department":"sales",` +
+						`"dietary":"other","dietary_notes":"no shellfish","accessibility":"step free access"}`
+					g := guestsDef.GuestListDTO{
+						Name: "test", Table: 1, RSVP: guestsDef.RSVPAccepted, Version: 3, ProfileDTO: profile,
+					}
+					m.service.On(
+						"EditProfile", guestsDef.ProfileRequest{Name: "test", ProfileDTO: profile, Version: 2},
+					).Return(g, c.serviceErr).Once()
+				}
+
+				//	request
+				req, err := http.NewRequest(http.MethodPut, "/guest_list/test/profile", strings.NewReader(body))
+				if err != nil {
+					t.Errorf("Error requesting test controller: %v\n", err)
+				}
+				req.Header.Set(etag.IfMatchHeader, `"2"`)
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, req)
+
+				// assert
+				assert.Equal(t, c.status, rr.Code)
+				if c.expected != "" {
+					assert.Equal(t, c.expected, rr.Body.String())
+					assert.Equal(t, `"3"`, rr.Header().Get(etag.Header))
+				}
+				m.service.AssertExpectations(t)
+			},
+		)
+	}
+}
+
+func TestController_Catering(t *testing.T) {
+	//	setup
+	r, ctrl, m := setupController()
+	r.GET("/reports/catering", ctrl.Catering)
+
+	t.Run(
+		"invalid table", func(t *testing.T) {
+			//	request
+			req, err := http.NewRequest(http.MethodGet, "/reports/catering?table=one", http.NoBody)
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			// assert
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+		},
+	)
+
+	t.Run(
+		"service error", func(t *testing.T) {
+			// mocks
+			m.service.On("Catering", guestsDef.CateringRequest{}).
+				Return(guestsDef.CateringDTO{}, errors.New("internal error")).
+				Once()
+
+			//	request
+			req, err := http.NewRequest(http.MethodGet, "/reports/catering", http.NoBody)
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			// assert
+			assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// mocks
+			m.service.On("Catering", guestsDef.CateringRequest{Table: 1}).
+				Return(
+					guestsDef.CateringDTO{
+						Tables: []guestsDef.TableCateringDTO{
+							{
+								Table:   1,
+								Invited: map[guestsDef.Diet]int64{guestsDef.DietVegan: 3, guestsDef.DietNone: 2},
+								Arrived: map[guestsDef.Diet]int64{guestsDef.DietVegan: 2},
+							},
+						},
+					}, nil,
+				).
+				Once()
+
+			//	request
+			req, err := http.NewRequest(http.MethodGet, "/reports/catering?table=1", http.NoBody)
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			// assert
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(
+				t, `{"tables":[{"table":1,"invited":{"none":2,"vegan":3},"arrived":{"vegan":2}}]}`, rr.Body.String(),
+			)
+			m.service.AssertExpectations(t)
+		},
+	)
+}
+
+func TestController_Uninvite(t *testing.T) {
+	//	setup
+	r, ctrl, m := setupController()
+	r.DELETE("/guest_list/:name", ctrl.Uninvite)
+
+	cases := []struct {
+		name       string
+		serviceErr error
+		status     int
+	}{
+		{name: "guest not invited", serviceErr: guestsDef.ErrNotInvited, status: http.StatusNotFound},
+		{name: "service error", serviceErr: errors.New("internal error"), status: http.StatusInternalServerError},
+		{name: "success", status: http.StatusNoContent},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				// mocks
+				m.service.On("Uninvite", "test").Return(c.serviceErr).Once()
+
+				//	request
+				req, err := http.NewRequest(http.MethodDelete, "/guest_list/test", http.NoBody)
+				if err != nil {
+					t.Errorf("Error requesting test controller: %v\n", err)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, req)
+
+				// assert
+				assert.Equal(t, c.status, rr.Code)
+				m.service.AssertExpectations(t)
+			},
+		)
+	}
+}
+
 func TestController_CheckOut(t *testing.T) {
 	t.Run(
 		"handler err", func(t *testing.T) {
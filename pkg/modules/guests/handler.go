@@ -2,7 +2,9 @@ package guests
 
 import (
 	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/etag"
 	"github.com/getground/tech-tasks/backend/definitions/guests"
+	"github.com/getground/tech-tasks/backend/definitions/pagination"
 	"github.com/gin-gonic/gin"
 )
 
@@ -22,6 +24,37 @@ func (h Handler) Create(c *gin.Context) (req guests.CreateRequest, err error) {
 	return
 }
 
+func (h Handler) List(c *gin.Context) (req guests.ListRequest, err error) {
+	err = c.ShouldBindQuery(&req)
+	// a request without a limit or a cursor gets every row as it did before the listing was paginated
+	if req.Limit == 0 && req.Cursor != "" {
+		req.Limit = pagination.DefaultLimit
+	}
+	return
+}
+
+func (h Handler) Search(c *gin.Context) (req guests.SearchRequest, err error) {
+	err = c.ShouldBindQuery(&req)
+	return
+}
+
+func (h Handler) Respond(c *gin.Context) (req guests.RSVPRequest, err error) {
+	token := c.Param("token")
+	if token == "" {
+		err = errors.New("token is required")
+		return
+	}
+	req.Token = token
+	err = c.ShouldBindJSON(&req)
+	return
+}
+
+func (h Handler) RSVPCounts(c *gin.Context) (req guests.RSVPCountsRequest, err error) {
+	err = c.ShouldBindQuery(&req)
+	return
+}
+
+// CheckIn takes the version the guest must still be at from the If-Match header.
 func (h Handler) CheckIn(c *gin.Context) (req guests.CheckInRequest, err error) {
 	name := c.Param("name")
 	if name == "" {
@@ -29,10 +62,85 @@ func (h Handler) CheckIn(c *gin.Context) (req guests.CheckInRequest, err error)
 		return
 	}
 	req.Name = name
+	req.Version, err = etag.Parse(c.GetHeader(etag.IfMatchHeader))
+	if err != nil {
+		return
+	}
+	err = c.ShouldBindJSON(&req)
+	return
+}
+
+// EditCompanions takes the version the guest must still be at from the If-Match header.
+func (h Handler) EditCompanions(c *gin.Context) (req guests.CompanionsRequest, err error) {
+	name := c.Param("name")
+	if name == "" {
+		err = errors.New("name is required")
+		return
+	}
+	req.Name = name
+	req.Version, err = etag.Parse(c.GetHeader(etag.IfMatchHeader))
+	if err != nil {
+		return
+	}
+	err = c.ShouldBindJSON(&req)
+	return
+}
+
+// EditProfile takes the version the guest must still be at from the If-Match header.
+func (h Handler) EditProfile(c *gin.Context) (req guests.ProfileRequest, err error) {
+	name := c.Param("name")
+	if name == "" {
+		err = errors.New("name is required")
+		return
+	}
+	req.Name = name
+	req.Version, err = etag.Parse(c.GetHeader(etag.IfMatchHeader))
+	if err != nil {
+		return
+	}
 	err = c.ShouldBindJSON(&req)
 	return
 }
 
+func (h Handler) Catering(c *gin.Context) (req guests.CateringRequest, err error) {
+	err = c.ShouldBindQuery(&req)
+	return
+}
+
+func (h Handler) Scan(c *gin.Context) (req guests.ScanRequest, err error) {
+	err = c.ShouldBindJSON(&req)
+	return
+}
+
+func (h Handler) WalkIn(c *gin.Context) (req guests.WalkInRequest, err error) {
+	err = c.ShouldBindJSON(&req)
+	return
+}
+
+func (h Handler) Get(c *gin.Context) (name string, err error) {
+	name = c.Param("name")
+	if name == "" {
+		err = errors.New("name is required")
+	}
+	return
+}
+
+func (h Handler) Reinvite(c *gin.Context) (name string, err error) {
+	name = c.Param("name")
+	if name == "" {
+		err = errors.New("name is required")
+	}
+	return
+}
+
+func (h Handler) Uninvite(c *gin.Context) (name string, err error) {
+	name = c.Param("name")
+	if name == "" {
+		err = errors.New("name is required")
+	}
+	return
+}
+
 func (h Handler) CheckOut(c *gin.Context) (name string, err error) {
 	name = c.Param("name")
 	if name == "" {
@@ -2,15 +2,21 @@ package guests
 
 import (
 	"github.com/getground/tech-tasks/backend/definitions/guests"
+	"sort"
+	"time"
 )
 
-func mapGuestsListToDTO(gs []guests.Guest) guests.ListDTO {
-	list := make([]guests.GuestListDTO, 0, len(gs))
+// mapGuestsListToDTO maps a page of the guest list, the contact details of a guest are only answered for the guest
+// alone so a listing can't harvest them.
+func mapGuestsListToDTO(page guests.Page) guests.ListDTO {
+	list := make([]guests.GuestListDTO, 0, len(page.Guests))
 
-	for _, g := range gs {
-		list = append(list, mapGuestListToDTO(g))
+	for _, g := range page.Guests {
+		dto := mapGuestListToDTO(g)
+		dto.Email, dto.Phone = "", ""
+		list = append(list, dto)
 	}
-	return guests.ListDTO{Guests: list}
+	return guests.ListDTO{Guests: list, Total: page.Total, NextCursor: page.NextCursor}
 }
 
 func mapGuestListToDTO(g guests.Guest) guests.GuestListDTO {
@@ -18,21 +24,159 @@ func mapGuestListToDTO(g guests.Guest) guests.GuestListDTO {
 		Name:         g.Name,
 		Table:        g.TableID,
 		Accompanying: g.Accompanying,
+		Companions:   mapCompanionsToDTO(g.Companions),
+		RSVP:         g.RSVP,
+		WalkIn:       g.WalkIn,
+		Version:      g.Version,
+		ProfileDTO:   mapProfileToDTO(g.Profile),
 	}
 }
 
-func mapGuestsToDTO(gs []guests.Guest) guests.DTO {
-	list := make([]guests.GuestDTO, 0, len(gs))
-	for _, g := range gs {
-		list = append(list, mapGuestToDTO(g))
+func mapProfileToDTO(p guests.Profile) guests.ProfileDTO {
+	return guests.ProfileDTO{
+		Email:         p.Email,
+		Phone:         p.Phone,
+		Department:    p.Department,
+		Dietary:       p.Dietary,
+		DietaryNotes:  p.DietaryNotes,
+		Accessibility: p.Accessibility,
 	}
-	return guests.DTO{Guests: list}
 }
 
-func mapGuestToDTO(g guests.Guest) guests.GuestDTO {
+func mapProfileFromDTO(p guests.ProfileDTO) guests.Profile {
+	return guests.Profile{
+		Email:         p.Email,
+		Phone:         p.Phone,
+		Department:    p.Department,
+		Dietary:       p.Dietary,
+		DietaryNotes:  p.DietaryNotes,
+		Accessibility: p.Accessibility,
+	}
+}
+
+func mapGuestsToDTO(page guests.Page, loc *time.Location) guests.DTO {
+	list := make([]guests.GuestDTO, 0, len(page.Guests))
+	for _, g := range page.Guests {
+		list = append(list, mapGuestToDTO(g, loc))
+	}
+	return guests.DTO{Guests: list, Total: page.Total, NextCursor: page.NextCursor}
+}
+
+func mapGuestToDTO(g guests.Guest, loc *time.Location) guests.GuestDTO {
 	return guests.GuestDTO{
 		Name:         g.Name,
 		Accompanying: g.Accompanying,
-		TimeArrived:  g.TimeArrived.String(),
+		Companions:   mapCompanionsToDTO(g.Companions),
+		TimeArrived:  formatTime(g.TimeArrived, loc),
+		WalkIn:       g.WalkIn,
+	}
+}
+
+func mapCompanionsToDTO(cs []guests.Companion) []guests.CompanionDTO {
+	if len(cs) == 0 {
+		return nil
+	}
+	list := make([]guests.CompanionDTO, 0, len(cs))
+	for _, c := range cs {
+		list = append(list, guests.CompanionDTO{Name: c.Name, Dietary: c.Dietary, DietaryNotes: c.DietaryNotes})
+	}
+	return list
+}
+
+// mapCompanionsFromDTO maps the companions of a request, the repository fills in the event and the guest once it saves
+// them.
+func mapCompanionsFromDTO(list []guests.CompanionDTO) []guests.Companion {
+	if len(list) == 0 {
+		return nil
+	}
+	cs := make([]guests.Companion, 0, len(list))
+	for _, c := range list {
+		cs = append(cs, guests.Companion{Name: c.Name, Dietary: c.Dietary, DietaryNotes: c.DietaryNotes})
+	}
+	return cs
+}
+
+// formatTime writes the time in RFC 3339 in loc, a guest that didn't arrive has no time.
+func formatTime(t *time.Time, loc *time.Location) string {
+	if t == nil {
+		return ""
+	}
+	return t.In(loc).Format(time.RFC3339)
+}
+
+func mapMatchesToDTO(ms []guests.Match) guests.SearchDTO {
+	list := make([]guests.MatchDTO, 0, len(ms))
+	for _, m := range ms {
+		list = append(list, mapMatchToDTO(m))
+	}
+	return guests.SearchDTO{Guests: list}
+}
+
+func mapMatchToDTO(m guests.Match) guests.MatchDTO {
+	return guests.MatchDTO{
+		Name:         m.Guest.Name,
+		Table:        m.Guest.TableID,
+		Accompanying: m.Guest.Accompanying,
+		Status:       m.Guest.Status(),
+		Score:        m.Score,
+	}
+}
+
+// mapRSVPCountsToDTO folds the counts of every answer of a table into a single row, the counts are sorted by table.
+func mapRSVPCountsToDTO(counts []guests.RSVPCount) guests.RSVPCountsDTO {
+	list := make([]guests.TableRSVPDTO, 0, len(counts))
+	for _, c := range counts {
+		if len(list) == 0 || list[len(list)-1].Table != c.TableID {
+			list = append(list, guests.TableRSVPDTO{Table: c.TableID})
+		}
+		t := &list[len(list)-1]
+		switch c.RSVP {
+		case guests.RSVPInvited:
+			t.Invited += c.Guests
+		case guests.RSVPAccepted:
+			t.Accepted += c.Guests
+			t.SeatsReserved += c.People
+		case guests.RSVPDeclined:
+			t.Declined += c.Guests
+		case guests.RSVPTentative:
+			t.Tentative += c.Guests
+		}
+	}
+	return guests.RSVPCountsDTO{Tables: list}
+}
+
+// mapCateringToDTO folds the counts of the guests, their companions and the accompanying guests that weren't named
+// into a row per table, the people without a diet are counted under none. The counts are sorted by table.
+func mapCateringToDTO(counts []guests.DietCount) guests.CateringDTO {
+	rows := map[uint]*guests.TableCateringDTO{}
+	ids := make([]uint, 0)
+	for _, c := range counts {
+		t, ok := rows[c.TableID]
+		if !ok {
+			t = &guests.TableCateringDTO{
+				Table:   c.TableID,
+				Invited: map[guests.Diet]int64{},
+				Arrived: map[guests.Diet]int64{},
+			}
+			rows[c.TableID] = t
+			ids = append(ids, c.TableID)
+		}
+		diet := c.Dietary
+		if diet == "" {
+			diet = guests.DietNone
+		}
+		if c.Invited > 0 {
+			t.Invited[diet] += c.Invited
+		}
+		if c.Arrived > 0 {
+			t.Arrived[diet] += c.Arrived
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	list := make([]guests.TableCateringDTO, 0, len(ids))
+	for _, id := range ids {
+		list = append(list, *rows[id])
 	}
+	return guests.CateringDTO{Tables: list}
 }
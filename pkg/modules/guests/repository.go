@@ -2,120 +2,724 @@ package guests
 
 import (
 	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/attendance"
+	"github.com/getground/tech-tasks/backend/definitions/audit"
+	"github.com/getground/tech-tasks/backend/definitions/etag"
+	"github.com/getground/tech-tasks/backend/definitions/events"
 	"github.com/getground/tech-tasks/backend/definitions/guests"
+	"github.com/getground/tech-tasks/backend/definitions/invitations"
+	"github.com/getground/tech-tasks/backend/definitions/pagination"
 	"github.com/getground/tech-tasks/backend/definitions/tables"
+	"github.com/getground/tech-tasks/backend/definitions/venue"
+	"github.com/getground/tech-tasks/backend/pkg/journal"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
 	"time"
 )
 
 type Repository struct {
-	db *gorm.DB
+	db    *gorm.DB
+	event uint
+	actor string
 }
 
 func NewRepository(db *gorm.DB) Repository {
 	return Repository{
-		db: db,
+		db:    db,
+		actor: audit.SystemActor,
 	}
 }
 
-func (r Repository) Create(req guests.CreateRequest, tableCapacity int64) (err error) {
+func (r Repository) ForEvent(event uint) guests.Repository {
+	r.event = event
+	return r
+}
+
+func (r Repository) ForActor(actor string) guests.Repository {
+	r.actor = actor
+	return r
+}
+
+// Create adds an invited guest and its companions to the guest list, the seats are reserved once the guest accepts.
+func (r Repository) Create(req guests.CreateRequest, at time.Time) error {
+	g := guests.Guest{
+		Name:         req.Name,
+		TableID:      req.Table,
+		Accompanying: req.Accompanying,
+		RSVP:         guests.RSVPInvited,
+		EventID:      r.event,
+		Version:      1,
+		Profile:      mapProfileFromDTO(req.ProfileDTO),
+		Companions:   mapCompanionsFromDTO(req.Companions),
+	}
 	return r.db.Transaction(
 		func(tx *gorm.DB) error {
-			// create guest
-			err := tx.Model(&guests.Guest{}).Create(
-				guests.Guest{
-					Name:         req.Name,
-					TableID:      req.Table,
-					Accompanying: req.Accompanying,
-				},
-			).Error
+			err := tx.Create(&g).Error
 			if err != nil {
-				return errors.New(err.Error())
+				return err
 			}
-
-			// update table capacity if guest created
-			err = tx.Where(&tables.Table{ID: req.Table}).Updates(tables.Table{Capacity: tableCapacity}).Error
+			err = r.addCompanions(tx, g)
 			if err != nil {
-				return errors.New("error reserving table seats")
+				return err
 			}
-			return nil
+			err = r.record(tx, audit.ActionGuestCreated, audit.State{}, audit.Snapshot(&g, nil), at)
+			if err != nil {
+				return err
+			}
+			return r.track(tx, attendance.TypeGuestInvited, g, at)
 		},
 	)
 }
 
+// GetByName returns the guest that didn't arrive yet with its companions.
 func (r Repository) GetByName(name string) (g guests.Guest, err error) {
-	err = r.db.Where("name = ?", name).Where("time_arrived IS NULL").First(&g).Error
+	err = r.scoped(r.db).Where("name = ?", name).Where("time_arrived IS NULL").First(&g).Error
+	if err != nil {
+		return
+	}
+	g.Companions, err = r.companionsOf(r.db, g.Name)
 	return
 }
 
-func (r Repository) GetGuestList(arrived bool) (list []guests.Guest, err error) {
-	if arrived {
-		err = r.db.Where("time_arrived IS NOT NULL").Find(&list).Error
+func (r Repository) List(filter guests.Filter) (list []guests.Guest, err error) {
+	err = r.filter(filter).Order("name").Find(&list).Error
+	if err != nil {
 		return
 	}
-	err = r.db.Find(&list).Error // get all arrived or not
+	err = r.companions(r.db, list)
 	return
 }
 
-func (r Repository) CheckIn(req guests.CheckInRequest, g guests.Guest, t tables.Table) (err error) {
+func (r Repository) ListPage(req guests.ListRequest) (page guests.Page, err error) {
+	err = r.filter(req.Filter).Count(&page.Total).Error
+	if err != nil {
+		return
+	}
+
+	q := r.filter(req.Filter)
+	if req.Cursor != "" {
+		var c cursor
+		err = pagination.DecodeCursor(req.Cursor, &c)
+		if err != nil {
+			return
+		}
+		q = c.after(q, req.Sort, req.Order)
+	}
+	q = order(q, req.Sort, req.Order)
+	if req.Limit > 0 {
+		// one more row tells if there is a next page
+		q = q.Limit(req.Limit + 1)
+	}
+	err = q.Find(&page.Guests).Error
+	if err != nil {
+		return
+	}
+
+	if req.Limit > 0 && len(page.Guests) > req.Limit {
+		page.Guests = page.Guests[:req.Limit]
+		last := page.Guests[req.Limit-1]
+		page.NextCursor = pagination.EncodeCursor(cursor{Name: last.Name, TimeArrived: last.TimeArrived})
+	}
+	err = r.companions(r.db, page.Guests)
+	return
+}
+
+// Respond saves the answer and the companions of the guest and the capacity left at the table once its seats are
+// reserved or given back, the guest and the table must still be at the versions the service read them at.
+func (r Repository) Respond(g guests.Guest, left tables.Table, at time.Time) error {
+	return r.resize(
+		g, left, audit.ActionGuestResponded, attendance.TypeGuestResponded, map[string]interface{}{"rsvp": g.RSVP}, at,
+	)
+}
+
+// EditCompanions saves the companions and the accompanying guests of the guest and the capacity left at the table once
+// the seats of the party are resized, the guest and the table must still be at the versions the service read them at.
+func (r Repository) EditCompanions(g guests.Guest, left tables.Table, at time.Time) error {
+	return r.resize(g, left, audit.ActionGuestEdited, attendance.TypeGuestResized, map[string]interface{}{}, at)
+}
+
+// resize sets the columns of the guest along with its party and saves the capacity left at the table, the companions
+// named before are replaced by the ones of the guest. The party after the change is appended to the attendance stream
+// as a record of typ occurred at.
+func (r Repository) resize(
+	g guests.Guest, left tables.Table, action audit.Action, typ attendance.Type, columns map[string]interface{},
+	at time.Time,
+) error {
+	return r.db.Transaction(
+		func(tx *gorm.DB) error {
+			var before guests.Guest
+			err := r.scoped(tx).Where("name = ?", g.Name).First(&before).Error
+			if err != nil {
+				return err
+			}
+			before.Companions, err = r.companionsOf(tx, before.Name)
+			if err != nil {
+				return err
+			}
+			t, err := table(tx, g.TableID)
+			if err != nil {
+				return err
+			}
+
+			columns["accompanying"] = g.Accompanying
+			err = r.update(tx, g, columns)
+			if err != nil {
+				return err
+			}
+			if len(g.Companions) > 0 || len(before.Companions) > 0 {
+				err = r.replaceCompanions(tx, g)
+				if err != nil {
+					return err
+				}
+			}
+
+			err = updateTable(tx, left, map[string]interface{}{"capacity": left.Capacity})
+			if err != nil {
+				return err
+			}
+
+			after, resized := before, t
+			after.RSVP, after.Accompanying, after.Companions = g.RSVP, g.Accompanying, g.Companions
+			resized.Capacity = left.Capacity
+			err = r.record(tx, action, audit.Snapshot(&before, &t), audit.Snapshot(&after, &resized), at)
+			if err != nil {
+				return err
+			}
+			return r.track(tx, typ, after, at)
+		},
+	)
+}
+
+// Delete removes a guest and its invitations from the guest list and saves the capacity left at the table once the
+// seats reserved are given back, the guest and the table must still be at the versions the service read them at.
+func (r Repository) Delete(g guests.Guest, left tables.Table, at time.Time) error {
+	return r.db.Transaction(
+		func(tx *gorm.DB) error {
+			t, err := table(tx, g.TableID)
+			if err != nil {
+				return err
+			}
+
+			err = r.scoped(tx).Where("guest_name = ?", g.Name).Delete(&invitations.Invitation{}).Error
+			if err != nil {
+				return err
+			}
+			err = r.scoped(tx).Where("guest_name = ?", g.Name).Delete(&guests.Companion{}).Error
+			if err != nil {
+				return err
+			}
+
+			res := r.scoped(tx).Where("name = ?", g.Name).Where("version = ?", g.Version).Delete(&guests.Guest{})
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return etag.ErrStale
+			}
+
+			err = updateTable(tx, left, map[string]interface{}{"capacity": left.Capacity})
+			if err != nil {
+				return err
+			}
+
+			resized := t
+			resized.Capacity = left.Capacity
+			err = r.record(tx, audit.ActionGuestDeleted, audit.Snapshot(&g, &t), audit.Snapshot(nil, &resized), at)
+			if err != nil {
+				return err
+			}
+			return r.track(tx, attendance.TypeGuestUninvited, g, at)
+		},
+	)
+}
+
+// EditProfile saves the profile of the guest, the guest must still be at the version the service read it at. The
+// profile holds personal data and is left out of the audit entry, which records who edited the guest.
+func (r Repository) EditProfile(g guests.Guest, at time.Time) error {
 	return r.db.Transaction(
 		func(tx *gorm.DB) error {
-			ts := time.Now()
-			err := tx.Where(&guests.Guest{Name: req.Name}).Updates(
-				guests.Guest{
-					TimeArrived:  &ts,
-					Accompanying: req.Accompanying,
+			err := r.update(
+				tx, g, map[string]interface{}{
+					"email":         g.Email,
+					"phone":         g.Phone,
+					"department":    g.Department,
+					"dietary":       g.Dietary,
+					"dietary_notes": g.DietaryNotes,
+					"accessibility": g.Accessibility,
 				},
-			).Error
+			)
+			if err != nil {
+				return err
+			}
+			state := audit.Snapshot(&g, nil)
+			return r.record(tx, audit.ActionGuestEdited, state, state, at)
+		},
+	)
+}
+
+// CountRSVP counts the guests by table and answer, every table is counted when table is zero.
+func (r Repository) CountRSVP(table uint) (counts []guests.RSVPCount, err error) {
+	q := r.scoped(r.db.Model(&guests.Guest{})).
+		Select("table_id, rsvp, COUNT(*) AS guests, SUM(accompanying + 1) AS people")
+	if table != 0 {
+		q = q.Where("table_id = ?", table)
+	}
+	err = q.Group("table_id, rsvp").Order("table_id").Scan(&counts).Error
+	return
+}
+
+// CountDiets counts the people that didn't decline by table and diet, the guests and their companions by their own
+// diet and the accompanying guests that weren't named as unknown. Every table is counted when table is zero.
+func (r Repository) CountDiets(table uint) (counts []guests.DietCount, err error) {
+	count := func(q *gorm.DB, tableColumn, group string) (counts []guests.DietCount, err error) {
+		if table != 0 {
+			q = q.Where(tableColumn+" = ?", table)
+		}
+		err = q.Group(group).Scan(&counts).Error
+		return
+	}
+
+	listed, err := count(
+		r.scoped(r.db.Model(&guests.Guest{})).
+			Select("table_id, dietary, COUNT(*) AS invited, SUM(time_arrived IS NOT NULL) AS arrived").
+			Where("rsvp <> ?", guests.RSVPDeclined),
+		"table_id", "table_id, dietary",
+	)
+	if err != nil {
+		return
+	}
+
+	named, err := count(
+		r.db.Model(&guests.Companion{}).
+			Select(
+				"guests.table_id, companions.dietary, COUNT(*) AS invited, "+
+					"SUM(guests.time_arrived IS NOT NULL) AS arrived",
+			).
+			Joins("JOIN guests ON guests.event_id = companions.event_id AND guests.name = companions.guest_name").
+			Where("companions.event_id = ?", r.event).
+			Where("guests.rsvp <> ?", guests.RSVPDeclined),
+		"guests.table_id", "guests.table_id, companions.dietary",
+	)
+	if err != nil {
+		return
+	}
+
+	// the parties name either none or every accompanying guest
+	unnamed, err := count(
+		r.scoped(r.db.Model(&guests.Guest{})).
+			Select(
+				"table_id, ? AS dietary, SUM(accompanying) AS invited, "+
+					"SUM(CASE WHEN time_arrived IS NULL THEN 0 ELSE accompanying END) AS arrived",
+				guests.DietUnknown,
+			).
+			Where("rsvp <> ?", guests.RSVPDeclined).
+			Where("accompanying > 0").
+			Where(
+				"NOT EXISTS (SELECT 1 FROM companions WHERE companions.event_id = guests.event_id "+
+					"AND companions.guest_name = guests.name)",
+			),
+		"table_id", "table_id",
+	)
+	if err != nil {
+		return
+	}
+
+	counts = append(append(listed, named...), unnamed...)
+	return
+}
+
+// CheckIn seats the party at the table of the guest, the companions named at the door replace the ones named before.
+func (r Repository) CheckIn(req guests.CheckInRequest, g guests.Guest, t tables.Table, ts time.Time) (err error) {
+	return r.db.Transaction(
+		func(tx *gorm.DB) error {
+			err := r.admit(tx, req.Accompanying+1)
 			if err != nil {
 				return err
 			}
+			err = r.update(tx, g, map[string]interface{}{"time_arrived": &ts, "accompanying": req.Accompanying})
+			if err != nil {
+				return err
+			}
+			arrived := g
+			arrived.TimeArrived, arrived.Accompanying = &ts, req.Accompanying
+			if len(req.Companions) > 0 {
+				arrived.Companions = mapCompanionsFromDTO(req.Companions)
+				err = r.replaceCompanions(tx, arrived)
+				if err != nil {
+					return err
+				}
+			}
 
-			err = tx.
-				Where(&tables.Table{ID: t.ID}).
-				Select("capacity", "empty_seats").
-				Updates(
-					tables.Table{
-						Capacity:   t.Capacity - (req.Accompanying - g.Accompanying),
-						EmptySeats: t.EmptySeats - req.Accompanying - 1,
-					},
-				).
-				Error
-			return err
+			seated := tables.Table{
+				ID: t.ID,
+				// the guests that didn't accept take their seats now
+				Capacity:   t.Capacity - (req.Accompanying + 1 - g.ReservedSeats()),
+				EmptySeats: t.EmptySeats - req.Accompanying - 1,
+			}
+			err = updateTable(
+				tx, t, map[string]interface{}{"capacity": seated.Capacity, "empty_seats": seated.EmptySeats},
+			)
+			if err != nil {
+				return err
+			}
+
+			err = r.record(
+				tx, audit.ActionGuestCheckedIn, audit.Snapshot(&g, &t), audit.Snapshot(&arrived, &seated), ts,
+			)
+			if err != nil {
+				return err
+			}
+			return r.track(tx, attendance.TypeGuestCheckedIn, arrived, ts)
+		},
+	)
+}
+
+// WalkIn seats the party at the table with the fewest empty seats fitting it, the seats taken are the ones seen empty
+// at the party so seats reserved for the guests yet to come can be taken. The capacity left to reserve is lowered by
+// the party down to zero.
+func (r Repository) WalkIn(req guests.WalkInRequest, arrived time.Time) (g guests.Guest, t tables.Table, err error) {
+	err = r.db.Transaction(
+		func(tx *gorm.DB) error {
+			var n int64
+			err := r.scoped(tx.Model(&guests.Guest{})).Where("name = ?", req.Name).Count(&n).Error
+			if err != nil {
+				return err
+			}
+			if n > 0 {
+				return guests.ErrAlreadyListed
+			}
+
+			party := req.Accompanying + 1
+			err = r.admit(tx, party)
+			if err != nil {
+				return err
+			}
+			q := r.scoped(tx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("empty_seats >= ?", party)
+			if req.Table != 0 {
+				q = q.Where("id = ?", req.Table)
+			}
+			err = q.Order("empty_seats").Order("id").Take(&t).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return guests.ErrNoEmptySeats
+			}
+			if err != nil {
+				return err
+			}
+
+			g = guests.Guest{
+				Name:         req.Name,
+				TableID:      t.ID,
+				Accompanying: req.Accompanying,
+				TimeArrived:  &arrived,
+				RSVP:         guests.RSVPAccepted,
+				WalkIn:       true,
+				EventID:      r.event,
+				Version:      1,
+				Profile:      mapProfileFromDTO(req.ProfileDTO),
+				Companions:   mapCompanionsFromDTO(req.Companions),
+			}
+			err = tx.Create(&g).Error
+			if err != nil {
+				return err
+			}
+			err = r.addCompanions(tx, g)
+			if err != nil {
+				return err
+			}
+
+			before := t
+			t.EmptySeats -= party
+			t.Capacity -= party
+			if t.Capacity < 0 {
+				t.Capacity = 0
+			}
+			t.Version++
+			err = updateTable(
+				tx, before, map[string]interface{}{"capacity": t.Capacity, "empty_seats": t.EmptySeats},
+			)
+			if err != nil {
+				return err
+			}
+			err = r.record(
+				tx, audit.ActionGuestWalkedIn, audit.Snapshot(nil, &before), audit.Snapshot(&g, &t), arrived,
+			)
+			if err != nil {
+				return err
+			}
+			return r.track(tx, attendance.TypeGuestCheckedIn, g, arrived)
 		},
 	)
+	if err != nil {
+		g, t = guests.Guest{}, tables.Table{}
+	}
+	return
 }
 
-func (r Repository) CheckOut(name string) (err error) {
+func (r Repository) CheckOut(name string, at time.Time) (g guests.Guest, err error) {
 	// check if guest exists and already checked in
-	g := guests.Guest{}
-	err = r.db.
+	err = r.scoped(r.db).
 		Where("name = ?", name).
 		Where("checked_out = 0").
 		Where("time_arrived IS NOT NULL").
 		First(&g).Error
 	if err != nil {
-		return err
+		return
+	}
+	g.Companions, err = r.companionsOf(r.db, g.Name)
+	if err != nil {
+		return
 	}
 	t := tables.Table{ID: g.TableID}
 	err = r.db.First(&t).Error
 	if err != nil {
-		return err
+		return
 	}
 
 	tx := r.db.Begin()
 	// set guest checked out flag
-	err = tx.Where(&guests.Guest{Name: name}).Updates(guests.Guest{CheckedOut: 1}).Error
+	err = r.update(tx, g, map[string]interface{}{"checked_out": 1})
 	if err != nil {
 		tx.Rollback()
 		return
 	}
 	// update empty seats
-	err = tx.Where(&tables.Table{ID: t.ID}).Updates(tables.Table{EmptySeats: t.EmptySeats + g.Accompanying + 1}).Error
+	err = updateTable(tx, t, map[string]interface{}{"empty_seats": t.EmptySeats + g.Accompanying + 1})
 	if err != nil {
 		tx.Rollback()
 		return
 	}
+	left, freed := g, t
+	left.CheckedOut = 1
+	freed.EmptySeats += g.Accompanying + 1
+	err = r.record(tx, audit.ActionGuestCheckedOut, audit.Snapshot(&g, &t), audit.Snapshot(&left, &freed), at)
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+	err = r.track(tx, attendance.TypeGuestCheckedOut, g, at)
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	err = tx.Commit().Error
+	g.CheckedOut = 1
+	g.Version++
+	return
+}
+
+// update sets the columns of the guest if it is still at the version it was read at and increases its version, it
+// returns etag.ErrStale when the guest changed since.
+func (r Repository) update(tx *gorm.DB, g guests.Guest, columns map[string]interface{}) error {
+	columns["version"] = gorm.Expr("version + 1")
+	res := r.scoped(tx.Model(&guests.Guest{})).Where("name = ?", g.Name).Where("version = ?", g.Version).Updates(columns)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return etag.ErrStale
+	}
+	return nil
+}
 
-	return tx.Commit().Error
+// companions loads the companions of the guests in one query, the guests that named none keep none.
+func (r Repository) companions(q *gorm.DB, gs []guests.Guest) error {
+	if len(gs) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(gs))
+	for _, g := range gs {
+		names = append(names, g.Name)
+	}
+	var cs []guests.Companion
+	err := r.scoped(q).Where("guest_name IN ?", names).Order("id").Find(&cs).Error
+	if err != nil {
+		return err
+	}
+
+	byGuest := make(map[string][]guests.Companion, len(gs))
+	for _, c := range cs {
+		byGuest[c.GuestName] = append(byGuest[c.GuestName], c)
+	}
+	for i := range gs {
+		gs[i].Companions = byGuest[gs[i].Name]
+	}
+	return nil
+}
+
+// companionsOf loads the companions of a single guest.
+func (r Repository) companionsOf(q *gorm.DB, name string) ([]guests.Companion, error) {
+	gs := []guests.Guest{{Name: name}}
+	err := r.companions(q, gs)
+	return gs[0].Companions, err
+}
+
+// addCompanions saves the companions of a party that named none before.
+func (r Repository) addCompanions(tx *gorm.DB, g guests.Guest) error {
+	if len(g.Companions) == 0 {
+		return nil
+	}
+	cs := make([]guests.Companion, 0, len(g.Companions))
+	for _, c := range g.Companions {
+		cs = append(
+			cs, guests.Companion{
+				EventID: r.event, GuestName: g.Name, Name: c.Name, Dietary: c.Dietary, DietaryNotes: c.DietaryNotes,
+			},
+		)
+	}
+	return tx.Create(&cs).Error
+}
+
+// replaceCompanions drops the companions named before by the party and saves the ones it names now.
+func (r Repository) replaceCompanions(tx *gorm.DB, g guests.Guest) error {
+	err := r.scoped(tx).Where("guest_name = ?", g.Name).Delete(&guests.Companion{}).Error
+	if err != nil {
+		return err
+	}
+	return r.addCompanions(tx, g)
+}
+
+// admit locks the event so its check ins take turns, and returns venue.ErrFull when the people would take the
+// headcount over the venue limit of the event.
+func (r Repository) admit(tx *gorm.DB, people int64) error {
+	var e events.Event
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", r.event).Take(&e).Error
+	if err != nil {
+		return err
+	}
+	if e.VenueLimit == 0 {
+		return nil
+	}
+
+	var onSite int64
+	err = r.scoped(tx.Model(&guests.Guest{})).
+		Select("COALESCE(SUM(accompanying + 1), 0)").
+		Where("time_arrived IS NOT NULL").
+		Where("checked_out = 0").
+		Scan(&onSite).
+		Error
+	if err != nil {
+		return err
+	}
+	if onSite+e.Staff+people > e.VenueLimit {
+		return venue.ErrFull
+	}
+	return nil
+}
+
+// updateTable sets the columns of the table if it is still at the version it was read at and increases its version,
+// it returns etag.ErrStale when the table changed since.
+func updateTable(tx *gorm.DB, t tables.Table, columns map[string]interface{}) error {
+	columns["version"] = gorm.Expr("version + 1")
+	res := tx.Model(&tables.Table{}).Where("id = ? AND version = ?", t.ID, t.Version).Updates(columns)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return etag.ErrStale
+	}
+	return nil
+}
+
+// record appends the change to the audit log and its message to the outbox in the transaction of the change.
+func (r Repository) record(tx *gorm.DB, action audit.Action, before, after audit.State, at time.Time) error {
+	return journal.Record(tx, r.event, r.actor, action, before, after, at)
+}
+
+// track appends the fact about the party of the guest to the attendance stream in the transaction of the change.
+func (r Repository) track(tx *gorm.DB, typ attendance.Type, g guests.Guest, at time.Time) error {
+	return journal.Track(tx, r.event, typ, g, at)
+}
+
+// table reads the table a change is about to touch, the audit entry of the change records it as it was.
+func table(tx *gorm.DB, id uint) (t tables.Table, err error) {
+	err = tx.Where(&tables.Table{ID: id}).First(&t).Error
+	return
+}
+
+// likeEscaper escapes the wildcards of a LIKE pattern, backslash is the default escape character in mysql
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func (r Repository) filter(filter guests.Filter) *gorm.DB {
+	q := r.scoped(r.db.Model(&guests.Guest{}))
+	if filter.Name != "" {
+		q = q.Where("name = ?", filter.Name)
+	}
+	if filter.NamePrefix != "" {
+		q = q.Where("name LIKE ?", likeEscaper.Replace(filter.NamePrefix)+"%")
+	}
+	if filter.Table != 0 {
+		q = q.Where("table_id = ?", filter.Table)
+	}
+	switch filter.Status {
+	case guests.StatusNotArrived:
+		q = q.Where("time_arrived IS NULL")
+	case guests.StatusArrived:
+		q = q.Where("time_arrived IS NOT NULL").Where("checked_out = 0")
+	case guests.StatusLeft:
+		q = q.Where("checked_out = 1")
+	}
+	if filter.Arrived != nil {
+		if *filter.Arrived {
+			q = q.Where("time_arrived IS NOT NULL")
+		} else {
+			q = q.Where("time_arrived IS NULL")
+		}
+	}
+	if filter.CheckedOut != nil {
+		if *filter.CheckedOut {
+			q = q.Where("checked_out = 1")
+		} else {
+			q = q.Where("checked_out = 0")
+		}
+	}
+	if filter.RSVP != "" {
+		q = q.Where("rsvp = ?", filter.RSVP)
+	}
+	if filter.WalkIn != nil {
+		q = q.Where("walk_in = ?", *filter.WalkIn)
+	}
+	return q
+}
+
+// scoped narrows down q to the rows of the event of the repository, the tables and the guests have the same event_id
+// column.
+func (r Repository) scoped(q *gorm.DB) *gorm.DB {
+	return q.Where("event_id = ?", r.event)
+}
+
+// cursor holds the sort keys of the last guest of a page, the name breaks ties between equal arrival times.
+type cursor struct {
+	Name        string     `json:"name"`
+	TimeArrived *time.Time `json:"time_arrived,omitempty"`
+}
+
+// after narrows down q to the guests sorted after the cursor, guests that didn't arrive are sorted last by arrival time.
+func (c cursor) after(q *gorm.DB, sort guests.Sort, ord guests.Order) *gorm.DB {
+	op := ">"
+	if ord == guests.OrderDesc {
+		op = "<"
+	}
+	if sort != guests.SortTimeArrived {
+		return q.Where("name "+op+" ?", c.Name)
+	}
+	if c.TimeArrived == nil {
+		return q.Where("time_arrived IS NULL AND name "+op+" ?", c.Name)
+	}
+	return q.Where(
+		"time_arrived "+op+" ? OR (time_arrived = ? AND name "+op+" ?) OR time_arrived IS NULL",
+		c.TimeArrived, c.TimeArrived, c.Name,
+	)
+}
+
+func order(q *gorm.DB, sort guests.Sort, ord guests.Order) *gorm.DB {
+	dir := " ASC"
+	if ord == guests.OrderDesc {
+		dir = " DESC"
+	}
+	if sort == guests.SortTimeArrived {
+		q = q.Order("time_arrived IS NULL").Order("time_arrived" + dir)
+	}
+	return q.Order("name" + dir)
 }
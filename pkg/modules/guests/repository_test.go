@@ -2,15 +2,22 @@ package guests_test
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
+	attendanceDef "github.com/getground/tech-tasks/backend/definitions/attendance"
+	auditDef "github.com/getground/tech-tasks/backend/definitions/audit"
+	"github.com/getground/tech-tasks/backend/definitions/etag"
 	guestsDef "github.com/getground/tech-tasks/backend/definitions/guests"
+	"github.com/getground/tech-tasks/backend/definitions/pagination"
 	tablesDef "github.com/getground/tech-tasks/backend/definitions/tables"
+	venueDef "github.com/getground/tech-tasks/backend/definitions/venue"
 	"github.com/getground/tech-tasks/backend/pkg/database"
 	"github.com/getground/tech-tasks/backend/pkg/modules/guests"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/mysql"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 )
@@ -31,33 +38,97 @@ func setupIntegrationRepo(t *testing.T) (guestsDef.Repository, repoMocks) {
 		t.Fatalf("an error '%s' was not expected when creating grom database connection", err)
 	}
 	m.MatchExpectationsInOrder(false)
-	r := guests.NewRepository(gDB)
+	r := guests.NewRepository(gDB).ForEvent(1)
 	return r, repoMocks{
 		db:      db,
 		sqlMock: m,
 	}
 }
 
+// expectAudit expects the audit entry of a change made by the system actor of a repository without actor and its
+// outbox message.
+func expectAudit(m repoMocks, action auditDef.Action, guest string, table uint, before, after driver.Value) {
+	q := "INSERT INTO `audit_log` (`event_id`,`actor`,`action`,`guest`,`table_id`,`before`,`after`,`created_at`) " +
+		"VALUES (?,?,?,?,?,?,?,?)"
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).
+		WithArgs(1, auditDef.SystemActor, action, guest, table, before, after, now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectOutbox(m, action)
+}
+
+// expectOutbox expects the message of the change in the outbox.
+func expectOutbox(m repoMocks, action auditDef.Action) {
+	q := "INSERT INTO `outbox` (`id`,`event_id`,`type`,`payload`,`created_at`,`dispatched_at`) VALUES (?,?,?,?,?,?)"
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).
+		WithArgs(sqlmock.AnyArg(), 1, action, sqlmock.AnyArg(), now, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+// expectTrack expects the record of the party of the guest in the attendance stream.
+func expectTrack(m repoMocks, typ attendanceDef.Type, guest string, table uint, people int64) {
+	q := "INSERT INTO `attendance` (`event_id`,`type`,`guest`,`table_id`,`people`,`rsvp`,`occurred_at`) " +
+		"VALUES (?,?,?,?,?,?,?)"
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).
+		WithArgs(1, typ, guest, table, people, sqlmock.AnyArg(), now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+// expectAdmit expects the event to be locked and, when it has a venue limit, the people on site to be counted.
+func expectAdmit(m repoMocks, limit, staff, onSite int64) {
+	lock := "SELECT * FROM `events` WHERE id = ? LIMIT 1 FOR UPDATE"
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(lock)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "venue_limit", "staff"}).AddRow(1, limit, staff))
+	if limit == 0 {
+		return
+	}
+	q := "SELECT COALESCE(SUM(accompanying + 1), 0) FROM `guests` WHERE event_id = ? AND time_arrived IS NOT NULL " +
+		"AND checked_out = 0"
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"people"}).AddRow(onSite))
+}
+
+// cColumns are the columns of the companions.
+var cColumns = []string{"id", "event_id", "guest_name", "name", "dietary"}
+
+// expectCompanions expects the companions of the guests to be read in one query, rows are the companions they named.
+func expectCompanions(m repoMocks, rows *sqlmock.Rows, names ...driver.Value) {
+	q := "SELECT * FROM `companions` WHERE event_id = ? AND guest_name IN (?" + strings.Repeat(",?", len(names)-1) +
+		") ORDER BY id"
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).WithArgs(append([]driver.Value{1}, names...)...).WillReturnRows(rows)
+}
+
 func TestRepository_Create(t *testing.T) {
+	createGuest := "INSERT INTO `guests` (`name`,`table_id`,`accompanying`,`time_arrived`,`checked_out`,`rsvp`," +
+		"`walk_in`,`event_id`,`version`,`email`,`phone`,`department`,`dietary`,`dietary_notes`," +
+		"`accessibility`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)"
+	createReq := guestsDef.CreateRequest{
+		Name:         "test",
+		Table:        1,
+		Accompanying: 1,
+		ProfileDTO: guestsDef.ProfileDTO{
+			Email: "test@example.com", Phone: "+447700900123", Dietary: guestsDef.DietOther, DietaryNotes: "no shellfish",
+		},
+	}
+	// the profile is saved along with the guest
+	createArgs := func(req guestsDef.CreateRequest) []driver.Value {
+		return []driver.Value{
+			req.Name, req.Table, req.Accompanying, nil, 0, guestsDef.RSVPInvited, false, 1, 1,
+			req.Email, req.Phone, req.Department, req.Dietary, req.DietaryNotes, req.Accessibility,
+		}
+	}
+
 	t.Run(
 		"error create guest", func(t *testing.T) {
 			//	setup
 			repo, m := setupIntegrationRepo(t)
 
-			// test data
-			createReq := guestsDef.CreateRequest{
-				Name:         "test",
-				Table:        1,
-				Accompanying: 1,
-			}
-			capacity := int64(5)
-
 			//	mocks
-			createGuest := "INSERT INTO `guests` (`name`,`table_id`,`accompanying`,`time_arrived`,`checked_out`) VALUES (?,?,?,?,?)"
 			m.sqlMock.ExpectBegin()
 			m.sqlMock.
 				ExpectExec(regexp.QuoteMeta(createGuest)).
-				WithArgs(createReq.Name, createReq.Table, createReq.Accompanying, nil, 0).
+				WithArgs(createArgs(createReq)...).
 				WillReturnError(
 					errors.New(
 						"error adding guest",
@@ -66,7 +137,7 @@ func TestRepository_Create(t *testing.T) {
 			m.sqlMock.ExpectRollback()
 
 			//	method call
-			err := repo.Create(createReq, capacity)
+			err := repo.Create(createReq, now)
 
 			//	assert
 			assert.Error(t, err)
@@ -74,72 +145,69 @@ func TestRepository_Create(t *testing.T) {
 	)
 
 	t.Run(
-		"error update table", func(t *testing.T) {
+		"success", func(t *testing.T) {
 			//	setup
 			repo, m := setupIntegrationRepo(t)
 
-			// test data
-			createReq := guestsDef.CreateRequest{
-				Name:         "test",
-				Table:        1,
-				Accompanying: 1,
-			}
-			capacity := int64(5)
-
 			//	mocks
-			createGuest := "INSERT INTO `guests` (`name`,`table_id`,`accompanying`,`time_arrived`,`checked_out`) VALUES (?,?,?,?,?)"
-			updateTable := "UPDATE `tables` SET `capacity`=? WHERE `tables`.`id` = ?"
+			// the seats aren't reserved before the guest accepts, the table isn't updated
 			m.sqlMock.ExpectBegin()
 			m.sqlMock.
 				ExpectExec(regexp.QuoteMeta(createGuest)).
-				WithArgs(createReq.Name, createReq.Table, createReq.Accompanying, nil, 0).
+				WithArgs(createArgs(createReq)...).
 				WillReturnResult(sqlmock.NewResult(1, 1))
-			m.sqlMock.
-				ExpectExec(regexp.QuoteMeta(updateTable)).
-				WithArgs(capacity, createReq.Table).
-				WillReturnError(errors.New("error update table"))
-			m.sqlMock.ExpectRollback()
+			expectAudit(
+				m, auditDef.ActionGuestCreated, "test", 1, "",
+				`{"guest":{"name":"test","table":1,"accompanying_guests":1,"rsvp":"invited","checked_out":false,`+
+					`"walk_in":false}}`,
+			)
+			expectTrack(m, attendanceDef.TypeGuestInvited, "test", 1, 2)
+			m.sqlMock.ExpectCommit()
 
 			//	method call
-			err := repo.Create(createReq, capacity)
+			err := repo.Create(createReq, now)
 
 			//	assert
-			assert.Error(t, err)
+			assert.NoError(t, err)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
 		},
 	)
 
 	t.Run(
-		"success", func(t *testing.T) {
+		"success with companions", func(t *testing.T) {
 			//	setup
 			repo, m := setupIntegrationRepo(t)
 
 			// test data
-			createReq := guestsDef.CreateRequest{
-				Name:         "test",
-				Table:        1,
-				Accompanying: 1,
-			}
-			capacity := int64(5)
+			req := createReq
+			req.Companions = []guestsDef.CompanionDTO{{Name: "ana", Dietary: "vegan"}}
 
 			//	mocks
-			createGuest := "INSERT INTO `guests` (`name`,`table_id`,`accompanying`,`time_arrived`,`checked_out`) VALUES (?,?,?,?,?)"
-			updateTable := "UPDATE `tables` SET `capacity`=? WHERE `tables`.`id` = ?"
+			createCompanions := "INSERT INTO `companions` (`event_id`,`guest_name`,`name`,`dietary`,`dietary_notes`) " +
+				"VALUES (?,?,?,?,?)"
 			m.sqlMock.ExpectBegin()
 			m.sqlMock.
 				ExpectExec(regexp.QuoteMeta(createGuest)).
-				WithArgs(createReq.Name, createReq.Table, createReq.Accompanying, nil, 0).
+				WithArgs(createArgs(req)...).
 				WillReturnResult(sqlmock.NewResult(1, 1))
 			m.sqlMock.
-				ExpectExec(regexp.QuoteMeta(updateTable)).
-				WithArgs(capacity, createReq.Table).
+				ExpectExec(regexp.QuoteMeta(createCompanions)).
+				WithArgs(1, "test", "ana", "vegan", "").
 				WillReturnResult(sqlmock.NewResult(1, 1))
+			expectAudit(
+				m, auditDef.ActionGuestCreated, "test", 1, "",
+				`{"guest":{"name":"test","table":1,"accompanying_guests":1,"companions":["ana"],"rsvp":"invited",`+
+					`"checked_out":false,"walk_in":false}}`,
+			)
+			expectTrack(m, attendanceDef.TypeGuestInvited, "test", 1, 2)
 			m.sqlMock.ExpectCommit()
 
 			//	method call
-			err := repo.Create(createReq, capacity)
+			err := repo.Create(req, now)
 
 			//	assert
 			assert.NoError(t, err)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
 		},
 	)
 }
@@ -155,8 +223,8 @@ func TestRepository_GetByName(t *testing.T) {
 			name := "test"
 
 			//	mocks
-			q := "SELECT * FROM `guests` WHERE name = ? AND time_arrived IS NULL ORDER BY `guests`.`name` LIMIT 1"
-			m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).WithArgs(name).WillReturnError(errors.New("record not found"))
+			q := "SELECT * FROM `guests` WHERE event_id = ? AND name = ? AND time_arrived IS NULL ORDER BY `guests`.`name` LIMIT 1"
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).WithArgs(1, name).WillReturnError(errors.New("record not found"))
 
 			//	method call
 			res, err := repo.GetByName(name)
@@ -178,15 +246,16 @@ func TestRepository_GetByName(t *testing.T) {
 			g := guestsDef.Guest{
 				Name:         "test",
 				TableID:      1,
-				Accompanying: 10,
+				Accompanying: 1,
 				TimeArrived:  nil,
+				Companions:   []guestsDef.Companion{{ID: 3, EventID: 1, GuestName: "test", Name: "ana", Dietary: "vegan"}},
 			}
 
 			//	mocks
-			q := "SELECT * FROM `guests` WHERE name = ? AND time_arrived IS NULL ORDER BY `guests`.`name` LIMIT 1"
+			q := "SELECT * FROM `guests` WHERE event_id = ? AND name = ? AND time_arrived IS NULL ORDER BY `guests`.`name` LIMIT 1"
 			m.sqlMock.
 				ExpectQuery(regexp.QuoteMeta(q)).
-				WithArgs(name).
+				WithArgs(1, name).
 				WillReturnRows(
 					sqlmock.NewRows(
 						[]string{
@@ -195,6 +264,7 @@ func TestRepository_GetByName(t *testing.T) {
 						},
 					).AddRow(g.Name, g.TableID, g.Accompanying, g.TimeArrived),
 				)
+			expectCompanions(m, sqlmock.NewRows(cColumns).AddRow(3, 1, "test", "ana", "vegan"), name)
 
 			//	method call
 			res, err := repo.GetByName(name)
@@ -206,22 +276,23 @@ func TestRepository_GetByName(t *testing.T) {
 	)
 }
 
-func TestRepository_GetGuestList(t *testing.T) {
+func TestRepository_ListPage(t *testing.T) {
+	gColumns := []string{"name", "table_id", "accompanying", "time_arrived"}
+
 	t.Run(
-		"error arrived", func(t *testing.T) {
+		"error count", func(t *testing.T) {
 			// setup
 			repo, m := setupIntegrationRepo(t)
 			defer m.db.Close()
 
 			//	mocks
-			q := "SELECT * FROM `guests` WHERE time_arrived IS NOT NULL"
+			q := "SELECT count(*) FROM `guests` WHERE event_id = ?"
 			m.sqlMock.
 				ExpectQuery(regexp.QuoteMeta(q)).
-				WithArgs().
-				WillReturnError(errors.New("record not found"))
+				WillReturnError(errors.New("error counting guests"))
 
 			//	method call
-			res, err := repo.GetGuestList(true)
+			res, err := repo.ListPage(guestsDef.ListRequest{})
 
 			//	assert
 			assert.Error(t, err)
@@ -230,106 +301,297 @@ func TestRepository_GetGuestList(t *testing.T) {
 	)
 
 	t.Run(
-		"success arrived", func(t *testing.T) {
+		"invalid cursor", func(t *testing.T) {
 			// setup
 			repo, m := setupIntegrationRepo(t)
 			defer m.db.Close()
 
-			// test data
-			timeArrived := time.Now()
-			gs := []guestsDef.Guest{
-				{
-					Name:         "test",
-					TableID:      1,
-					Accompanying: 10,
-					TimeArrived:  &timeArrived,
-				},
-			}
+			//	mocks
+			q := "SELECT count(*) FROM `guests` WHERE event_id = ?"
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(q)).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+			//	method call
+			req := guestsDef.ListRequest{}
+			req.Cursor = "not a cursor"
+			_, err := repo.ListPage(req)
+
+			//	assert
+			assert.ErrorIs(t, err, pagination.ErrInvalidCursor)
+		},
+	)
+
+	t.Run(
+		"error find", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
 
 			//	mocks
-			q := "SELECT * FROM `guests` WHERE time_arrived IS NOT NULL"
+			count := "SELECT count(*) FROM `guests` WHERE event_id = ? AND time_arrived IS NOT NULL"
+			q := "SELECT * FROM `guests` WHERE event_id = ? AND time_arrived IS NOT NULL ORDER BY name ASC"
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(count)).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
 			m.sqlMock.
 				ExpectQuery(regexp.QuoteMeta(q)).
-				WithArgs().
+				WillReturnError(errors.New("error listing guests"))
+
+			//	method call
+			arrived := true
+			res, err := repo.ListPage(guestsDef.ListRequest{Filter: guestsDef.Filter{Arrived: &arrived}})
+
+			//	assert
+			assert.Error(t, err)
+			assert.Empty(t, res.Guests)
+		},
+	)
+
+	t.Run(
+		"first page by name", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			count := "SELECT count(*) FROM `guests` WHERE event_id = ? AND name LIKE ? AND table_id = ?"
+			q := "SELECT * FROM `guests` WHERE event_id = ? AND name LIKE ? AND table_id = ? ORDER BY name ASC LIMIT 3"
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(count)).
+				WithArgs(1, `sam\_%`, 1).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(q)).
+				WithArgs(1, `sam\_%`, 1).
 				WillReturnRows(
-					sqlmock.NewRows(
-						[]string{
-							"name", "table_id", "accompanying",
-							"time_arrived",
-						},
-					).AddRow(gs[0].Name, gs[0].TableID, gs[0].Accompanying, gs[0].TimeArrived),
+					sqlmock.NewRows(gColumns).
+						AddRow("sam_a", 1, 0, nil).
+						AddRow("sam_b", 1, 0, nil).
+						AddRow("sam_c", 1, 0, nil),
 				)
+			// the row telling there is a next page isn't part of the page
+			expectCompanions(m, sqlmock.NewRows(cColumns).AddRow(4, 1, "sam_b", "ana", ""), "sam_a", "sam_b")
 
 			//	method call
-			res, err := repo.GetGuestList(true)
+			req := guestsDef.ListRequest{Filter: guestsDef.Filter{NamePrefix: "sam_", Table: 1}}
+			req.Limit = 2
+			res, err := repo.ListPage(req)
 
 			//	assert
 			assert.NoError(t, err)
-			assert.NotEmpty(t, res)
+			assert.Equal(
+				t, []guestsDef.Guest{
+					{Name: "sam_a", TableID: 1},
+					{
+						Name: "sam_b", TableID: 1,
+						Companions: []guestsDef.Companion{{ID: 4, EventID: 1, GuestName: "sam_b", Name: "ana"}},
+					},
+				}, res.Guests,
+			)
+			assert.Equal(t, int64(5), res.Total)
+			assert.Equal(t, pagination.EncodeCursor(map[string]string{"name": "sam_b"}), res.NextCursor)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
 		},
 	)
 
 	t.Run(
-		"error not arrived", func(t *testing.T) {
+		"last page by name descending", func(t *testing.T) {
 			// setup
 			repo, m := setupIntegrationRepo(t)
 			defer m.db.Close()
 
 			//	mocks
-			q := "SELECT * FROM `guests`"
+			count := "SELECT count(*) FROM `guests` WHERE event_id = ?"
+			q := "SELECT * FROM `guests` WHERE event_id = ? AND name < ? ORDER BY name DESC LIMIT 3"
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(count)).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
 			m.sqlMock.
 				ExpectQuery(regexp.QuoteMeta(q)).
-				WithArgs().
-				WillReturnError(errors.New("record not found"))
+				WithArgs(1, "sam").
+				WillReturnRows(sqlmock.NewRows(gColumns).AddRow("alex", 2, 1, nil))
+			expectCompanions(m, sqlmock.NewRows(cColumns), "alex")
 
 			//	method call
-			res, err := repo.GetGuestList(false)
+			req := guestsDef.ListRequest{Order: guestsDef.OrderDesc}
+			req.Cursor = pagination.EncodeCursor(map[string]string{"name": "sam"})
+			req.Limit = 2
+			res, err := repo.ListPage(req)
 
 			//	assert
-			assert.Error(t, err)
-			assert.Empty(t, res)
+			assert.NoError(t, err)
+			assert.Equal(t, []guestsDef.Guest{{Name: "alex", TableID: 2, Accompanying: 1}}, res.Guests)
+			assert.Empty(t, res.NextCursor)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
 		},
 	)
 
 	t.Run(
-		"success not arrived", func(t *testing.T) {
+		"next page by arrival time", func(t *testing.T) {
 			// setup
 			repo, m := setupIntegrationRepo(t)
 			defer m.db.Close()
 
 			// test data
-			timeArrived := time.Now()
-			gs := []guestsDef.Guest{
-				{
-					Name:         "test",
-					TableID:      1,
-					Accompanying: 10,
-					TimeArrived:  &timeArrived,
-				},
-			}
+			timeArrived := time.Date(2022, 12, 16, 20, 0, 0, 0, time.UTC)
+			later := timeArrived.Add(time.Minute)
 
 			//	mocks
-			q := "SELECT * FROM `guests`"
+			count := "SELECT count(*) FROM `guests` WHERE event_id = ? AND checked_out = 0"
+			q := "SELECT * FROM `guests` WHERE event_id = ? AND checked_out = 0 AND " +
+				"(time_arrived > ? OR (time_arrived = ? AND name > ?) OR time_arrived IS NULL) " +
+				"ORDER BY time_arrived IS NULL,time_arrived ASC,name ASC LIMIT 2"
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(count)).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
 			m.sqlMock.
 				ExpectQuery(regexp.QuoteMeta(q)).
-				WithArgs().
+				WithArgs(1, timeArrived, timeArrived, "sam").
 				WillReturnRows(
-					sqlmock.NewRows(
-						[]string{
-							"name", "table_id", "accompanying",
-							"time_arrived",
-						},
-					).AddRow(gs[0].Name, gs[0].TableID, gs[0].Accompanying, gs[0].TimeArrived),
+					sqlmock.NewRows(gColumns).
+						AddRow("alex", 1, 0, later).
+						AddRow("kim", 1, 0, nil),
 				)
+			expectCompanions(m, sqlmock.NewRows(cColumns), "alex")
+
+			//	method call
+			checkedOut := false
+			req := guestsDef.ListRequest{Filter: guestsDef.Filter{CheckedOut: &checkedOut}, Sort: guestsDef.SortTimeArrived}
+			req.Cursor = pagination.EncodeCursor(map[string]interface{}{"name": "sam", "time_arrived": timeArrived})
+			req.Limit = 1
+			res, err := repo.ListPage(req)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, []guestsDef.Guest{{Name: "alex", TableID: 1, TimeArrived: &later}}, res.Guests)
+			assert.Equal(
+				t, pagination.EncodeCursor(map[string]interface{}{"name": "alex", "time_arrived": later}), res.NextCursor,
+			)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"next page of not arrived by arrival time", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			count := "SELECT count(*) FROM `guests` WHERE event_id = ?"
+			q := "SELECT * FROM `guests` WHERE event_id = ? AND (time_arrived IS NULL AND name > ?) " +
+				"ORDER BY time_arrived IS NULL,time_arrived ASC,name ASC"
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(count)).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(q)).
+				WithArgs(1, "kim").
+				WillReturnRows(sqlmock.NewRows(gColumns).AddRow("sam", 1, 0, nil))
+			expectCompanions(m, sqlmock.NewRows(cColumns), "sam")
 
 			//	method call
-			res, err := repo.GetGuestList(false)
+			req := guestsDef.ListRequest{Sort: guestsDef.SortTimeArrived}
+			req.Cursor = pagination.EncodeCursor(map[string]string{"name": "kim"})
+			res, err := repo.ListPage(req)
 
 			//	assert
 			assert.NoError(t, err)
-			assert.NotEmpty(t, res)
+			assert.Equal(t, []guestsDef.Guest{{Name: "sam", TableID: 1}}, res.Guests)
+			assert.Empty(t, res.NextCursor)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
+
+func TestRepository_List(t *testing.T) {
+	t.Run(
+		"error", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			q := "SELECT * FROM `guests` WHERE event_id = ? ORDER BY name"
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(q)).
+				WillReturnError(errors.New("error listing guests"))
+
+			//	method call
+			res, err := repo.List(guestsDef.Filter{})
+
+			//	assert
+			assert.Error(t, err)
+			assert.Empty(t, res)
 		},
 	)
+
+	walkIn := true
+	filters := []struct {
+		name   string
+		filter guestsDef.Filter
+		query  string
+		args   []driver.Value
+	}{
+		{
+			name:   "by name",
+			filter: guestsDef.Filter{Name: "test"},
+			query:  "SELECT * FROM `guests` WHERE event_id = ? AND name = ? ORDER BY name",
+			args:   []driver.Value{1, "test"},
+		},
+		{
+			name:   "not arrived at table",
+			filter: guestsDef.Filter{Table: 1, Status: guestsDef.StatusNotArrived},
+			query:  "SELECT * FROM `guests` WHERE event_id = ? AND table_id = ? AND time_arrived IS NULL ORDER BY name",
+			args:   []driver.Value{1, 1},
+		},
+		{
+			name:   "arrived",
+			filter: guestsDef.Filter{Status: guestsDef.StatusArrived},
+			query:  "SELECT * FROM `guests` WHERE event_id = ? AND time_arrived IS NOT NULL AND checked_out = 0 ORDER BY name",
+			args:   []driver.Value{1},
+		},
+		{
+			name:   "left",
+			filter: guestsDef.Filter{Status: guestsDef.StatusLeft},
+			query:  "SELECT * FROM `guests` WHERE event_id = ? AND checked_out = 1 ORDER BY name",
+			args:   []driver.Value{1},
+		},
+		{
+			name:   "walk-ins",
+			filter: guestsDef.Filter{WalkIn: &walkIn},
+			query:  "SELECT * FROM `guests` WHERE event_id = ? AND walk_in = ? ORDER BY name",
+			args:   []driver.Value{1, true},
+		},
+	}
+	for _, f := range filters {
+		f := f
+		t.Run(
+			"success "+f.name, func(t *testing.T) {
+				// setup
+				repo, m := setupIntegrationRepo(t)
+				defer m.db.Close()
+
+				//	mocks
+				m.sqlMock.
+					ExpectQuery(regexp.QuoteMeta(f.query)).
+					WithArgs(f.args...).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"name", "table_id", "accompanying"}).AddRow("test", 1, 2),
+					)
+				expectCompanions(m, sqlmock.NewRows(cColumns), "test")
+
+				//	method call
+				res, err := repo.List(f.filter)
+
+				//	assert
+				assert.NoError(t, err)
+				assert.Equal(t, []guestsDef.Guest{{Name: "test", TableID: 1, Accompanying: 2}}, res)
+				assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+			},
+		)
+	}
 }
 
 func TestRepository_CheckIn(t *testing.T) {
@@ -357,16 +619,18 @@ func TestRepository_CheckIn(t *testing.T) {
 			}
 
 			//	mocks
-			updateGuest := "UPDATE `guests` SET `accompanying`=?,`time_arrived`=? WHERE `guests`.`name` = ?"
+			updateGuest := "UPDATE `guests` SET `accompanying`=?,`time_arrived`=?,`version`=version + 1 WHERE event_id = ? AND name = ? " +
+				"AND version = ?"
 			m.sqlMock.ExpectBegin()
+			expectAdmit(m, 0, 0, 0)
 			m.sqlMock.
 				ExpectExec(regexp.QuoteMeta(updateGuest)).
-				WithArgs(checkInReq.Accompanying, sqlmock.AnyArg(), checkInReq.Name).
+				WithArgs(checkInReq.Accompanying, sqlmock.AnyArg(), 1, checkInReq.Name, g.Version).
 				WillReturnError(errors.New("error update guest"))
 			m.sqlMock.ExpectRollback()
 
 			//	method call
-			err := repo.CheckIn(checkInReq, g, tbl)
+			err := repo.CheckIn(checkInReq, g, tbl, now)
 
 			//	assert
 			assert.Error(t, err)
@@ -397,27 +661,53 @@ func TestRepository_CheckIn(t *testing.T) {
 			}
 
 			//	mocks
-			updateGuest := "UPDATE `guests` SET `accompanying`=?,`time_arrived`=? WHERE `guests`.`name` = ?"
-			updateTable := "UPDATE `tables` SET `capacity`=?,`empty_seats`=? WHERE `tables`.`id` = ?"
+			updateGuest := "UPDATE `guests` SET `accompanying`=?,`time_arrived`=?,`version`=version + 1 WHERE event_id = ? AND name = ? " +
+				"AND version = ?"
+			updateTable := "UPDATE `tables` SET `capacity`=?,`empty_seats`=?,`version`=version + 1 WHERE id = ? AND version = ?"
 			m.sqlMock.ExpectBegin()
+			expectAdmit(m, 0, 0, 0)
 			m.sqlMock.
 				ExpectExec(regexp.QuoteMeta(updateGuest)).
-				WithArgs(checkInReq.Accompanying, sqlmock.AnyArg(), checkInReq.Name).
+				WithArgs(checkInReq.Accompanying, sqlmock.AnyArg(), 1, checkInReq.Name, g.Version).
 				WillReturnResult(sqlmock.NewResult(1, 1))
 			m.sqlMock.
 				ExpectExec(regexp.QuoteMeta(updateTable)).
-				WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+				WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), tbl.ID, tbl.Version).
 				WillReturnError(errors.New("error updating table"))
 			m.sqlMock.ExpectRollback()
 
 			//	method call
-			err := repo.CheckIn(checkInReq, g, tbl)
+			err := repo.CheckIn(checkInReq, g, tbl, now)
 
 			//	assert
 			assert.Error(t, err)
 		},
 	)
 
+	t.Run(
+		"venue full", func(t *testing.T) {
+			//	setup
+			repo, m := setupIntegrationRepo(t)
+
+			// test data
+			checkInReq := guestsDef.CheckInRequest{Name: "test", Accompanying: 10}
+			g := guestsDef.Guest{Name: "test", TableID: 1, Accompanying: 10}
+			tbl := tablesDef.Table{ID: 1, Capacity: 10, EmptySeats: 10}
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			expectAdmit(m, 100, 10, 80)
+			m.sqlMock.ExpectRollback()
+
+			//	method call
+			err := repo.CheckIn(checkInReq, g, tbl, now)
+
+			//	assert
+			assert.ErrorIs(t, err, venueDef.ErrFull)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
 	t.Run(
 		"success", func(t *testing.T) {
 			//	setup
@@ -442,21 +732,31 @@ func TestRepository_CheckIn(t *testing.T) {
 			}
 
 			//	mocks
-			updateGuest := "UPDATE `guests` SET `accompanying`=?,`time_arrived`=? WHERE `guests`.`name` = ?"
-			updateTable := "UPDATE `tables` SET `capacity`=?,`empty_seats`=? WHERE `tables`.`id` = ?"
+			updateGuest := "UPDATE `guests` SET `accompanying`=?,`time_arrived`=?,`version`=version + 1 WHERE event_id = ? AND name = ? " +
+				"AND version = ?"
+			updateTable := "UPDATE `tables` SET `capacity`=?,`empty_seats`=?,`version`=version + 1 WHERE id = ? AND version = ?"
 			m.sqlMock.ExpectBegin()
+			// the party takes the headcount to the limit of the venue
+			expectAdmit(m, 100, 10, 79)
 			m.sqlMock.
 				ExpectExec(regexp.QuoteMeta(updateGuest)).
-				WithArgs(checkInReq.Accompanying, sqlmock.AnyArg(), checkInReq.Name).
+				WithArgs(checkInReq.Accompanying, sqlmock.AnyArg(), 1, checkInReq.Name, g.Version).
 				WillReturnResult(sqlmock.NewResult(1, 1))
 			m.sqlMock.
 				ExpectExec(regexp.QuoteMeta(updateTable)).
-				WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+				WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), tbl.ID, tbl.Version).
 				WillReturnResult(sqlmock.NewResult(1, 1))
+			expectAudit(
+				m, auditDef.ActionGuestCheckedIn, "test", 1,
+				`{"guest":{"name":"test","table":1,"accompanying_guests":10,"rsvp":"","checked_out":false,`+
+					`"walk_in":false},"table":{"id":1,"capacity":10,"empty_seats":10}}`,
+				sqlmock.AnyArg(),
+			)
+			expectTrack(m, attendanceDef.TypeGuestCheckedIn, "test", 1, 11)
 			m.sqlMock.ExpectCommit()
 
 			//	method call
-			err := repo.CheckIn(checkInReq, g, tbl)
+			err := repo.CheckIn(checkInReq, g, tbl, now)
 
 			//	assert
 			assert.NoError(t, err)
@@ -464,6 +764,710 @@ func TestRepository_CheckIn(t *testing.T) {
 	)
 }
 
+func TestRepository_CheckInWithCompanions(t *testing.T) {
+	//	setup
+	repo, m := setupIntegrationRepo(t)
+
+	// test data
+	// the party named one companion and arrives with two, they are named again at the door
+	checkInReq := guestsDef.CheckInRequest{
+		Name:         "test",
+		Accompanying: 2,
+		Companions:   []guestsDef.CompanionDTO{{Name: "ana"}, {Name: "ben", Dietary: guestsDef.DietNutAllergy}},
+	}
+	g := guestsDef.Guest{
+		Name: "test", TableID: 1, Accompanying: 1, RSVP: guestsDef.RSVPAccepted,
+		Companions: []guestsDef.Companion{{ID: 3, EventID: 1, GuestName: "test", Name: "ana"}},
+	}
+	tbl := tablesDef.Table{ID: 1, Capacity: 6, EmptySeats: 10}
+
+	//	mocks
+	updateGuest := "UPDATE `guests` SET `accompanying`=?,`time_arrived`=?,`version`=version + 1 WHERE event_id = ? AND name = ? " +
+		"AND version = ?"
+	deleteCompanions := "DELETE FROM `companions` WHERE event_id = ? AND guest_name = ?"
+	createCompanions := "INSERT INTO `companions` (`event_id`,`guest_name`,`name`,`dietary`,`dietary_notes`) " +
+		"VALUES (?,?,?,?,?),(?,?,?,?,?)"
+	updateTable := "UPDATE `tables` SET `capacity`=?,`empty_seats`=?,`version`=version + 1 WHERE id = ? AND version = ?"
+	m.sqlMock.ExpectBegin()
+	expectAdmit(m, 0, 0, 0)
+	m.sqlMock.
+		ExpectExec(regexp.QuoteMeta(updateGuest)).
+		WithArgs(checkInReq.Accompanying, now, 1, checkInReq.Name, g.Version).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	m.sqlMock.
+		ExpectExec(regexp.QuoteMeta(deleteCompanions)).
+		WithArgs(1, "test").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.
+		ExpectExec(regexp.QuoteMeta(createCompanions)).
+		WithArgs(1, "test", "ana", "", "", 1, "test", "ben", guestsDef.DietNutAllergy, "").
+		WillReturnResult(sqlmock.NewResult(4, 2))
+	m.sqlMock.
+		ExpectExec(regexp.QuoteMeta(updateTable)).
+		WithArgs(5, 7, tbl.ID, tbl.Version).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectAudit(
+		m, auditDef.ActionGuestCheckedIn, "test", 1,
+		`{"guest":{"name":"test","table":1,"accompanying_guests":1,"companions":["ana"],"rsvp":"accepted",`+
+			`"checked_out":false,"walk_in":false},"table":{"id":1,"capacity":6,"empty_seats":10}}`,
+		`{"guest":{"name":"test","table":1,"accompanying_guests":2,"companions":["ana","ben"],"rsvp":"accepted",`+
+			`"time_arrived":"2026-10-19T20:30:00Z","checked_out":false,"walk_in":false},`+
+			`"table":{"id":1,"capacity":5,"empty_seats":7}}`,
+	)
+	expectTrack(m, attendanceDef.TypeGuestCheckedIn, "test", 1, 3)
+	m.sqlMock.ExpectCommit()
+
+	//	method call
+	err := repo.CheckIn(checkInReq, g, tbl, now)
+
+	//	assert
+	assert.NoError(t, err)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestRepository_CheckInWithoutReservation(t *testing.T) {
+	//	setup
+	repo, m := setupIntegrationRepo(t)
+
+	// test data
+	checkInReq := guestsDef.CheckInRequest{Name: "test", Accompanying: 2}
+	g := guestsDef.Guest{Name: "test", TableID: 1, Accompanying: 1, RSVP: guestsDef.RSVPTentative}
+	tbl := tablesDef.Table{ID: 1, Capacity: 6, EmptySeats: 10}
+
+	//	mocks
+	updateGuest := "UPDATE `guests` SET `accompanying`=?,`time_arrived`=?,`version`=version + 1 WHERE event_id = ? AND name = ? " +
+		"AND version = ?"
+	updateTable := "UPDATE `tables` SET `capacity`=?,`empty_seats`=?,`version`=version + 1 WHERE id = ? AND version = ?"
+	m.sqlMock.ExpectBegin()
+	expectAdmit(m, 0, 0, 0)
+	m.sqlMock.
+		ExpectExec(regexp.QuoteMeta(updateGuest)).
+		WithArgs(checkInReq.Accompanying, now, 1, checkInReq.Name, g.Version).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	// no seat was reserved, the whole party takes seats from the capacity
+	m.sqlMock.
+		ExpectExec(regexp.QuoteMeta(updateTable)).
+		WithArgs(3, 7, tbl.ID, tbl.Version).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectAudit(m, auditDef.ActionGuestCheckedIn, "test", 1, sqlmock.AnyArg(), sqlmock.AnyArg())
+	expectTrack(m, attendanceDef.TypeGuestCheckedIn, "test", 1, 3)
+	m.sqlMock.ExpectCommit()
+
+	//	method call
+	err := repo.CheckIn(checkInReq, g, tbl, now)
+
+	//	assert
+	assert.NoError(t, err)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestRepository_Respond(t *testing.T) {
+	getGuest := "SELECT * FROM `guests` WHERE event_id = ? AND name = ? ORDER BY `guests`.`name` LIMIT 1"
+	getTable := "SELECT * FROM `tables` WHERE `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1"
+	updateGuest := "UPDATE `guests` SET `accompanying`=?,`rsvp`=?,`version`=version + 1 WHERE event_id = ? AND name = ? " +
+		"AND version = ?"
+	updateTable := "UPDATE `tables` SET `capacity`=?,`version`=version + 1 WHERE id = ? AND version = ?"
+	g := guestsDef.Guest{Name: "test", TableID: 1, Accompanying: 0, RSVP: guestsDef.RSVPAccepted, Version: 2}
+	left := tablesDef.Table{ID: 1, Capacity: 4, EmptySeats: 8, Version: 3}
+	// the guest and the table are read as they were before the answer for the audit log
+	expectBefore := func(m repoMocks) {
+		m.sqlMock.ExpectQuery(regexp.QuoteMeta(getGuest)).
+			WithArgs(1, g.Name).
+			WillReturnRows(
+				sqlmock.NewRows([]string{"name", "table_id", "accompanying", "rsvp"}).
+					AddRow(g.Name, 1, 2, guestsDef.RSVPInvited),
+			)
+		expectCompanions(m, sqlmock.NewRows(cColumns), g.Name)
+		m.sqlMock.ExpectQuery(regexp.QuoteMeta(getTable)).
+			WithArgs(g.TableID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "capacity", "empty_seats"}).AddRow(1, 5, 8))
+	}
+
+	t.Run(
+		"error update guest", func(t *testing.T) {
+			//	setup
+			repo, m := setupIntegrationRepo(t)
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			expectBefore(m)
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(updateGuest)).
+				WithArgs(g.Accompanying, g.RSVP, 1, g.Name, g.Version).
+				WillReturnError(errors.New("error update guest"))
+			m.sqlMock.ExpectRollback()
+
+			//	method call
+			err := repo.Respond(g, left, now)
+
+			//	assert
+			assert.Error(t, err)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"guest changed concurrently", func(t *testing.T) {
+			//	setup
+			repo, m := setupIntegrationRepo(t)
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			expectBefore(m)
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(updateGuest)).
+				WithArgs(g.Accompanying, g.RSVP, 1, g.Name, g.Version).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			m.sqlMock.ExpectRollback()
+
+			//	method call
+			err := repo.Respond(g, left, now)
+
+			//	assert
+			assert.ErrorIs(t, err, etag.ErrStale)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			//	setup
+			repo, m := setupIntegrationRepo(t)
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(updateGuest)).
+				WithArgs(g.Accompanying, g.RSVP, 1, g.Name, g.Version).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			expectBefore(m)
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(updateTable)).
+				WithArgs(left.Capacity, left.ID, left.Version).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			expectAudit(
+				m, auditDef.ActionGuestResponded, "test", 1,
+				`{"guest":{"name":"test","table":1,"accompanying_guests":2,"rsvp":"invited","checked_out":false,`+
+					`"walk_in":false},"table":{"id":1,"capacity":5,"empty_seats":8}}`,
+				`{"guest":{"name":"test","table":1,"accompanying_guests":0,"rsvp":"accepted","checked_out":false,`+
+					`"walk_in":false},"table":{"id":1,"capacity":4,"empty_seats":8}}`,
+			)
+			expectTrack(m, attendanceDef.TypeGuestResponded, "test", 1, 1)
+			m.sqlMock.ExpectCommit()
+
+			//	method call
+			err := repo.Respond(g, left, now)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
+
+func TestRepository_EditCompanions(t *testing.T) {
+	getGuest := "SELECT * FROM `guests` WHERE event_id = ? AND name = ? ORDER BY `guests`.`name` LIMIT 1"
+	getTable := "SELECT * FROM `tables` WHERE `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1"
+	updateGuest := "UPDATE `guests` SET `accompanying`=?,`version`=version + 1 WHERE event_id = ? AND name = ? " +
+		"AND version = ?"
+	deleteCompanions := "DELETE FROM `companions` WHERE event_id = ? AND guest_name = ?"
+	createCompanions := "INSERT INTO `companions` (`event_id`,`guest_name`,`name`,`dietary`,`dietary_notes`) " +
+		"VALUES (?,?,?,?,?),(?,?,?,?,?)"
+	updateTable := "UPDATE `tables` SET `capacity`=?,`version`=version + 1 WHERE id = ? AND version = ?"
+	g := guestsDef.Guest{
+		Name: "test", TableID: 1, Accompanying: 2, RSVP: guestsDef.RSVPAccepted, Version: 2,
+		Companions: []guestsDef.Companion{{Name: "ana", Dietary: "vegan"}, {Name: "ben"}},
+	}
+	left := tablesDef.Table{ID: 1, Capacity: 3, EmptySeats: 8, Version: 3}
+	// the guest, its companions and the table are read as they were before the change for the audit log
+	expectBefore := func(m repoMocks) {
+		m.sqlMock.ExpectQuery(regexp.QuoteMeta(getGuest)).
+			WithArgs(1, g.Name).
+			WillReturnRows(
+				sqlmock.NewRows([]string{"name", "table_id", "accompanying", "rsvp"}).
+					AddRow(g.Name, 1, 1, guestsDef.RSVPAccepted),
+			)
+		expectCompanions(m, sqlmock.NewRows(cColumns).AddRow(3, 1, g.Name, "ana", ""), g.Name)
+		m.sqlMock.ExpectQuery(regexp.QuoteMeta(getTable)).
+			WithArgs(g.TableID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "capacity", "empty_seats"}).AddRow(1, 4, 8))
+	}
+
+	t.Run(
+		"guest changed concurrently", func(t *testing.T) {
+			//	setup
+			repo, m := setupIntegrationRepo(t)
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			expectBefore(m)
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(updateGuest)).
+				WithArgs(g.Accompanying, 1, g.Name, g.Version).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			m.sqlMock.ExpectRollback()
+
+			//	method call
+			err := repo.EditCompanions(g, left, now)
+
+			//	assert
+			assert.ErrorIs(t, err, etag.ErrStale)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			//	setup
+			repo, m := setupIntegrationRepo(t)
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			expectBefore(m)
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(updateGuest)).
+				WithArgs(g.Accompanying, 1, g.Name, g.Version).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(deleteCompanions)).
+				WithArgs(1, g.Name).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(createCompanions)).
+				WithArgs(1, g.Name, "ana", "vegan", "", 1, g.Name, "ben", "", "").
+				WillReturnResult(sqlmock.NewResult(4, 2))
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(updateTable)).
+				WithArgs(left.Capacity, left.ID, left.Version).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			expectAudit(
+				m, auditDef.ActionGuestEdited, "test", 1,
+				`{"guest":{"name":"test","table":1,"accompanying_guests":1,"companions":["ana"],"rsvp":"accepted",`+
+					`"checked_out":false,"walk_in":false},"table":{"id":1,"capacity":4,"empty_seats":8}}`,
+				`{"guest":{"name":"test","table":1,"accompanying_guests":2,"companions":["ana","ben"],`+
+					`"rsvp":"accepted","checked_out":false,"walk_in":false},"table":{"id":1,"capacity":3,"empty_seats":8}}`,
+			)
+			expectTrack(m, attendanceDef.TypeGuestResized, "test", 1, 3)
+			m.sqlMock.ExpectCommit()
+
+			//	method call
+			err := repo.EditCompanions(g, left, now)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
+
+func TestRepository_Delete(t *testing.T) {
+	deleteInvitations := "DELETE FROM `invitations` WHERE event_id = ? AND guest_name = ?"
+	deleteCompanions := "DELETE FROM `companions` WHERE event_id = ? AND guest_name = ?"
+	deleteGuest := "DELETE FROM `guests` WHERE event_id = ? AND name = ? AND version = ?"
+	updateTable := "UPDATE `tables` SET `capacity`=?,`version`=version + 1 WHERE id = ? AND version = ?"
+	g := guestsDef.Guest{Name: "test", TableID: 1, Accompanying: 1, RSVP: guestsDef.RSVPAccepted, Version: 2}
+	left := tablesDef.Table{ID: 1, Capacity: 6, EmptySeats: 8, Version: 3}
+	// the table is read as it was before the guest left the guest list for the audit log
+	expectTable := func(m repoMocks) {
+		q := "SELECT * FROM `tables` WHERE `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1"
+		m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+			WithArgs(g.TableID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "capacity", "empty_seats"}).AddRow(1, 4, 8))
+	}
+
+	t.Run(
+		"error delete guest", func(t *testing.T) {
+			//	setup
+			repo, m := setupIntegrationRepo(t)
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			expectTable(m)
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(deleteInvitations)).
+				WithArgs(1, g.Name).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(deleteCompanions)).
+				WithArgs(1, g.Name).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(deleteGuest)).
+				WithArgs(1, g.Name, g.Version).
+				WillReturnError(errors.New("error delete guest"))
+			m.sqlMock.ExpectRollback()
+
+			//	method call
+			err := repo.Delete(g, left, now)
+
+			//	assert
+			assert.Error(t, err)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			//	setup
+			repo, m := setupIntegrationRepo(t)
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			expectTable(m)
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(deleteInvitations)).
+				WithArgs(1, g.Name).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(deleteCompanions)).
+				WithArgs(1, g.Name).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(deleteGuest)).
+				WithArgs(1, g.Name, g.Version).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(updateTable)).
+				WithArgs(left.Capacity, left.ID, left.Version).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			expectAudit(
+				m, auditDef.ActionGuestDeleted, "test", 1,
+				`{"guest":{"name":"test","table":1,"accompanying_guests":1,"rsvp":"accepted","checked_out":false,`+
+					`"walk_in":false},"table":{"id":1,"capacity":4,"empty_seats":8}}`,
+				`{"table":{"id":1,"capacity":6,"empty_seats":8}}`,
+			)
+			expectTrack(m, attendanceDef.TypeGuestUninvited, "test", 1, 2)
+			m.sqlMock.ExpectCommit()
+
+			//	method call
+			err := repo.Delete(g, left, now)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
+
+func TestRepository_CountRSVP(t *testing.T) {
+	columns := []string{"table_id", "rsvp", "guests", "people"}
+
+	t.Run(
+		"every table", func(t *testing.T) {
+			//	setup
+			repo, m := setupIntegrationRepo(t)
+
+			//	mocks
+			q := "SELECT table_id, rsvp, COUNT(*) AS guests, SUM(accompanying + 1) AS people FROM `guests` " +
+				"WHERE event_id = ? GROUP BY table_id, rsvp ORDER BY table_id"
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+				WillReturnRows(
+					sqlmock.NewRows(columns).
+						AddRow(1, guestsDef.RSVPAccepted, 2, 5).
+						AddRow(2, guestsDef.RSVPDeclined, 1, 1),
+				)
+
+			//	method call
+			counts, err := repo.CountRSVP(0)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(
+				t, []guestsDef.RSVPCount{
+					{TableID: 1, RSVP: guestsDef.RSVPAccepted, Guests: 2, People: 5},
+					{TableID: 2, RSVP: guestsDef.RSVPDeclined, Guests: 1, People: 1},
+				}, counts,
+			)
+		},
+	)
+
+	t.Run(
+		"single table", func(t *testing.T) {
+			//	setup
+			repo, m := setupIntegrationRepo(t)
+
+			//	mocks
+			q := "SELECT table_id, rsvp, COUNT(*) AS guests, SUM(accompanying + 1) AS people FROM `guests` " +
+				"WHERE event_id = ? AND table_id = ? GROUP BY table_id, rsvp ORDER BY table_id"
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+				WithArgs(1, 2).
+				WillReturnError(errors.New("internal error"))
+
+			//	method call
+			counts, err := repo.CountRSVP(2)
+
+			//	assert
+			assert.Error(t, err)
+			assert.Empty(t, counts)
+		},
+	)
+}
+
+func TestRepository_EditProfile(t *testing.T) {
+	updateGuest := "UPDATE `guests` SET `accessibility`=?,`department`=?,`dietary`=?,`dietary_notes`=?,`email`=?," +
+		"`phone`=?,`version`=version + 1 WHERE event_id = ? AND name = ? AND version = ?"
+	g := guestsDef.Guest{
+		Name: "test", TableID: 1, Accompanying: 1, RSVP: guestsDef.RSVPAccepted, Version: 2,
+		Profile: guestsDef.Profile{
+			Email: "test@example.com", Department: "sales", Dietary: guestsDef.DietVegan, Accessibility: "wheelchair",
+		},
+	}
+
+	t.Run(
+		"guest changed concurrently", func(t *testing.T) {
+			//	setup
+			repo, m := setupIntegrationRepo(t)
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(updateGuest)).
+				WithArgs("wheelchair", "sales", guestsDef.DietVegan, "", "test@example.com", "", 1, g.Name, g.Version).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			m.sqlMock.ExpectRollback()
+
+			//	method call
+			err := repo.EditProfile(g, now)
+
+			//	assert
+			assert.ErrorIs(t, err, etag.ErrStale)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			//	setup
+			repo, m := setupIntegrationRepo(t)
+
+			//	mocks
+			// the profile is personal data, the audit entry only records that the guest was edited
+			state := `{"guest":{"name":"test","table":1,"accompanying_guests":1,"rsvp":"accepted","checked_out":false,` +
+				`"walk_in":false}}`
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(updateGuest)).
+				WithArgs("wheelchair", "sales", guestsDef.DietVegan, "", "test@example.com", "", 1, g.Name, g.Version).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			expectAudit(m, auditDef.ActionGuestEdited, "test", 1, state, state)
+			m.sqlMock.ExpectCommit()
+
+			//	method call
+			err := repo.EditProfile(g, now)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
+
+func TestRepository_CountDiets(t *testing.T) {
+	columns := []string{"table_id", "dietary", "invited", "arrived"}
+	listed := "SELECT table_id, dietary, COUNT(*) AS invited, SUM(time_arrived IS NOT NULL) AS arrived FROM `guests` " +
+		"WHERE event_id = ? AND rsvp <> ?"
+	named := "SELECT guests.table_id, companions.dietary, COUNT(*) AS invited, SUM(guests.time_arrived IS NOT NULL) " +
+		"AS arrived FROM `companions` JOIN guests ON guests.event_id = companions.event_id AND guests.name = " +
+		"companions.guest_name WHERE companions.event_id = ? AND guests.rsvp <> ?"
+	unnamed := "SELECT table_id, ? AS dietary, SUM(accompanying) AS invited, SUM(CASE WHEN time_arrived IS NULL THEN 0 " +
+		"ELSE accompanying END) AS arrived FROM `guests` WHERE event_id = ? AND rsvp <> ? AND accompanying > 0 AND " +
+		"(NOT EXISTS (SELECT 1 FROM companions WHERE companions.event_id = guests.event_id AND companions.guest_name = " +
+		"guests.name))"
+
+	t.Run(
+		"every table", func(t *testing.T) {
+			//	setup
+			repo, m := setupIntegrationRepo(t)
+
+			//	mocks
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(listed+" GROUP BY table_id, dietary")).
+				WithArgs(1, guestsDef.RSVPDeclined).
+				WillReturnRows(
+					sqlmock.NewRows(columns).
+						AddRow(1, "", 2, 1).
+						AddRow(1, guestsDef.DietVegan, 1, 0),
+				)
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(named+" GROUP BY guests.table_id, companions.dietary")).
+				WithArgs(1, guestsDef.RSVPDeclined).
+				WillReturnRows(sqlmock.NewRows(columns).AddRow(1, guestsDef.DietVegan, 2, 2))
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(unnamed+" GROUP BY `table_id`")).
+				WithArgs(guestsDef.DietUnknown, 1, guestsDef.RSVPDeclined).
+				WillReturnRows(sqlmock.NewRows(columns).AddRow(2, guestsDef.DietUnknown, 3, 0))
+
+			//	method call
+			counts, err := repo.CountDiets(0)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(
+				t, []guestsDef.DietCount{
+					{TableID: 1, Invited: 2, Arrived: 1},
+					{TableID: 1, Dietary: guestsDef.DietVegan, Invited: 1},
+					{TableID: 1, Dietary: guestsDef.DietVegan, Invited: 2, Arrived: 2},
+					{TableID: 2, Dietary: guestsDef.DietUnknown, Invited: 3},
+				}, counts,
+			)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"single table", func(t *testing.T) {
+			//	setup
+			repo, m := setupIntegrationRepo(t)
+
+			//	mocks
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(listed+" AND table_id = ? GROUP BY table_id, dietary")).
+				WithArgs(1, guestsDef.RSVPDeclined, 2).
+				WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "", 1, 0))
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(named+" AND guests.table_id = ? GROUP BY")).
+				WithArgs(1, guestsDef.RSVPDeclined, 2).
+				WillReturnError(errors.New("internal error"))
+
+			//	method call
+			counts, err := repo.CountDiets(2)
+
+			//	assert
+			assert.Error(t, err)
+			assert.Empty(t, counts)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
+
+func TestRepository_WalkIn(t *testing.T) {
+	countGuest := "SELECT count(*) FROM `guests` WHERE event_id = ? AND name = ?"
+	findTable := "SELECT * FROM `tables` WHERE event_id = ? AND empty_seats >= ? ORDER BY empty_seats,id LIMIT 1 " +
+		"FOR UPDATE"
+	createGuest := "INSERT INTO `guests` (`name`,`table_id`,`accompanying`,`time_arrived`,`checked_out`,`rsvp`," +
+		"`walk_in`,`event_id`,`version`,`email`,`phone`,`department`,`dietary`,`dietary_notes`," +
+		"`accessibility`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)"
+	updateTable := "UPDATE `tables` SET `capacity`=?,`empty_seats`=?,`version`=version + 1 WHERE id = ? AND version = ?"
+	tColumns := []string{"id", "capacity", "empty_seats", "version"}
+	req := guestsDef.WalkInRequest{Name: "test", Accompanying: 2}
+
+	t.Run(
+		"already listed", func(t *testing.T) {
+			//	setup
+			repo, m := setupIntegrationRepo(t)
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(countGuest)).
+				WithArgs(1, req.Name).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			m.sqlMock.ExpectRollback()
+
+			//	method call
+			g, tbl, err := repo.WalkIn(req, now)
+
+			//	assert
+			assert.ErrorIs(t, err, guestsDef.ErrAlreadyListed)
+			assert.Empty(t, g)
+			assert.Empty(t, tbl)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"no empty seats at the table", func(t *testing.T) {
+			//	setup
+			repo, m := setupIntegrationRepo(t)
+			req := guestsDef.WalkInRequest{Name: "test", Table: 2, Accompanying: 2}
+
+			//	mocks
+			q := "SELECT * FROM `tables` WHERE event_id = ? AND empty_seats >= ? AND id = ? ORDER BY empty_seats,id LIMIT 1 FOR UPDATE"
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(countGuest)).
+				WithArgs(1, req.Name).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			expectAdmit(m, 0, 0, 0)
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).WithArgs(1, 3, 2).WillReturnRows(sqlmock.NewRows(tColumns))
+			m.sqlMock.ExpectRollback()
+
+			//	method call
+			_, _, err := repo.WalkIn(req, now)
+
+			//	assert
+			assert.ErrorIs(t, err, guestsDef.ErrNoEmptySeats)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"venue full", func(t *testing.T) {
+			//	setup
+			repo, m := setupIntegrationRepo(t)
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(countGuest)).
+				WithArgs(1, req.Name).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			expectAdmit(m, 100, 10, 88)
+			m.sqlMock.ExpectRollback()
+
+			//	method call
+			_, _, err := repo.WalkIn(req, now)
+
+			//	assert
+			assert.ErrorIs(t, err, venueDef.ErrFull)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			//	setup
+			repo, m := setupIntegrationRepo(t)
+
+			//	mocks
+			// the table has a single seat left to reserve, the walk-in takes the seats of guests yet to come
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(countGuest)).
+				WithArgs(1, req.Name).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			expectAdmit(m, 0, 0, 0)
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(findTable)).
+				WithArgs(1, 3).
+				WillReturnRows(sqlmock.NewRows(tColumns).AddRow(4, 1, 5, 2))
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(createGuest)).
+				WithArgs(
+					req.Name, 4, req.Accompanying, now, 0, guestsDef.RSVPAccepted, true, 1, 1, "", "", "", "", "", "",
+				).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(updateTable)).
+				WithArgs(0, 2, 4, 2).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			expectAudit(
+				m, auditDef.ActionGuestWalkedIn, "test", 4, `{"table":{"id":4,"capacity":1,"empty_seats":5}}`,
+				sqlmock.AnyArg(),
+			)
+			expectTrack(m, attendanceDef.TypeGuestCheckedIn, "test", 4, 3)
+			m.sqlMock.ExpectCommit()
+
+			//	method call
+			g, tbl, err := repo.WalkIn(req, now)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, "test", g.Name)
+			assert.Equal(t, uint(4), g.TableID)
+			assert.True(t, g.WalkIn)
+			assert.Equal(t, &now, g.TimeArrived)
+			assert.Equal(t, tablesDef.Table{ID: 4, Capacity: 0, EmptySeats: 2, Version: 3}, tbl)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
+
 func TestRepository_CheckOut(t *testing.T) {
 	t.Run(
 		"guest not found", func(t *testing.T) {
@@ -474,14 +1478,14 @@ func TestRepository_CheckOut(t *testing.T) {
 			name := "test"
 
 			//	mocks
-			q := "SELECT * FROM `guests` WHERE name = ? AND checked_out = 0 AND time_arrived IS NOT NULL ORDER BY `guests`.`name` LIMIT 1"
+			q := "SELECT * FROM `guests` WHERE event_id = ? AND name = ? AND checked_out = 0 AND time_arrived IS NOT NULL ORDER BY `guests`.`name` LIMIT 1"
 			m.sqlMock.
 				ExpectQuery(regexp.QuoteMeta(q)).
-				WithArgs(name).
+				WithArgs(1, name).
 				WillReturnError(errors.New("guest not found"))
 
 			//	method call
-			err := repo.CheckOut(name)
+			_, err := repo.CheckOut(name, now)
 
 			//	assert
 			assert.Error(t, err)
@@ -504,11 +1508,11 @@ func TestRepository_CheckOut(t *testing.T) {
 			}
 
 			//	mocks
-			guestQuery := "SELECT * FROM `guests` WHERE name = ? AND checked_out = 0 AND time_arrived IS NOT NULL ORDER BY `guests`.`name` LIMIT 1"
+			guestQuery := "SELECT * FROM `guests` WHERE event_id = ? AND name = ? AND checked_out = 0 AND time_arrived IS NOT NULL ORDER BY `guests`.`name` LIMIT 1"
 			tableQuery := "SELECT * FROM `tables` WHERE `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1"
 			m.sqlMock.
 				ExpectQuery(regexp.QuoteMeta(guestQuery)).
-				WithArgs(name).
+				WithArgs(1, name).
 				WillReturnRows(
 					sqlmock.NewRows(
 						[]string{
@@ -517,13 +1521,14 @@ func TestRepository_CheckOut(t *testing.T) {
 						},
 					).AddRow(g.Name, g.TableID, g.Accompanying, g.TimeArrived),
 				)
+			expectCompanions(m, sqlmock.NewRows(cColumns), name)
 			m.sqlMock.
 				ExpectQuery(regexp.QuoteMeta(tableQuery)).
 				WithArgs(g.TableID).
 				WillReturnError(errors.New("table not found"))
 
 			//	method call
-			err := repo.CheckOut(name)
+			_, err := repo.CheckOut(name, now)
 
 			//	assert
 			assert.Error(t, err)
@@ -551,12 +1556,12 @@ func TestRepository_CheckOut(t *testing.T) {
 			}
 
 			//	mocks
-			guestQuery := "SELECT * FROM `guests` WHERE name = ? AND checked_out = 0 AND time_arrived IS NOT NULL ORDER BY `guests`.`name` LIMIT 1"
+			guestQuery := "SELECT * FROM `guests` WHERE event_id = ? AND name = ? AND checked_out = 0 AND time_arrived IS NOT NULL ORDER BY `guests`.`name` LIMIT 1"
 			tableQuery := "SELECT * FROM `tables` WHERE `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1"
-			updateGuest := "UPDATE `guests` SET `checked_out`=? WHERE `guests`.`name` = ?"
+			updateGuest := "UPDATE `guests` SET `checked_out`=?,`version`=version + 1 WHERE event_id = ? AND name = ? AND version = ?"
 			m.sqlMock.
 				ExpectQuery(regexp.QuoteMeta(guestQuery)).
-				WithArgs(name).
+				WithArgs(1, name).
 				WillReturnRows(
 					sqlmock.NewRows(
 						[]string{
@@ -565,6 +1570,7 @@ func TestRepository_CheckOut(t *testing.T) {
 						},
 					).AddRow(g.Name, g.TableID, g.Accompanying, g.TimeArrived),
 				)
+			expectCompanions(m, sqlmock.NewRows(cColumns), name)
 			m.sqlMock.
 				ExpectQuery(regexp.QuoteMeta(tableQuery)).
 				WithArgs(g.TableID).
@@ -576,13 +1582,15 @@ func TestRepository_CheckOut(t *testing.T) {
 				)
 			m.sqlMock.ExpectBegin()
 			m.sqlMock.ExpectExec(regexp.QuoteMeta(updateGuest)).WithArgs(
+				1,
 				1,
 				name,
+				g.Version,
 			).WillReturnError(errors.New("error updating guest"))
 			m.sqlMock.ExpectRollback()
 
 			//	method call
-			err := repo.CheckOut(name)
+			_, err := repo.CheckOut(name, now)
 
 			//	assert
 			assert.Error(t, err)
@@ -610,13 +1618,13 @@ func TestRepository_CheckOut(t *testing.T) {
 			}
 
 			//	mocks
-			guestQuery := "SELECT * FROM `guests` WHERE name = ? AND checked_out = 0 AND time_arrived IS NOT NULL ORDER BY `guests`.`name` LIMIT 1"
+			guestQuery := "SELECT * FROM `guests` WHERE event_id = ? AND name = ? AND checked_out = 0 AND time_arrived IS NOT NULL ORDER BY `guests`.`name` LIMIT 1"
 			tableQuery := "SELECT * FROM `tables` WHERE `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1"
-			updateGuest := "UPDATE `guests` SET `checked_out`=? WHERE `guests`.`name` = ?"
-			updateTable := " UPDATE `tables` SET `empty_seats`=? WHERE `tables`.`id` = ?"
+			updateGuest := "UPDATE `guests` SET `checked_out`=?,`version`=version + 1 WHERE event_id = ? AND name = ? AND version = ?"
+			updateTable := "UPDATE `tables` SET `empty_seats`=?,`version`=version + 1 WHERE id = ? AND version = ?"
 			m.sqlMock.
 				ExpectQuery(regexp.QuoteMeta(guestQuery)).
-				WithArgs(name).
+				WithArgs(1, name).
 				WillReturnRows(
 					sqlmock.NewRows(
 						[]string{
@@ -625,6 +1633,7 @@ func TestRepository_CheckOut(t *testing.T) {
 						},
 					).AddRow(g.Name, g.TableID, g.Accompanying, g.TimeArrived),
 				)
+			expectCompanions(m, sqlmock.NewRows(cColumns), name)
 			m.sqlMock.
 				ExpectQuery(regexp.QuoteMeta(tableQuery)).
 				WithArgs(g.TableID).
@@ -636,17 +1645,20 @@ func TestRepository_CheckOut(t *testing.T) {
 				)
 			m.sqlMock.ExpectBegin()
 			m.sqlMock.ExpectExec(regexp.QuoteMeta(updateGuest)).WithArgs(
+				1,
 				1,
 				name,
+				g.Version,
 			).WillReturnResult(sqlmock.NewResult(1, 1))
 			m.sqlMock.ExpectExec(regexp.QuoteMeta(updateTable)).WithArgs(
 				tbl.EmptySeats+g.Accompanying+1,
 				tbl.ID,
+				tbl.Version,
 			).WillReturnError(errors.New("error updating table"))
 			m.sqlMock.ExpectRollback()
 
 			//	method call
-			err := repo.CheckOut(name)
+			_, err := repo.CheckOut(name, now)
 
 			//	assert
 			assert.Error(t, err)
@@ -674,13 +1686,13 @@ func TestRepository_CheckOut(t *testing.T) {
 			}
 
 			//	mocks
-			guestQuery := "SELECT * FROM `guests` WHERE name = ? AND checked_out = 0 AND time_arrived IS NOT NULL ORDER BY `guests`.`name` LIMIT 1"
+			guestQuery := "SELECT * FROM `guests` WHERE event_id = ? AND name = ? AND checked_out = 0 AND time_arrived IS NOT NULL ORDER BY `guests`.`name` LIMIT 1"
 			tableQuery := "SELECT * FROM `tables` WHERE `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1"
-			updateGuest := "UPDATE `guests` SET `checked_out`=? WHERE `guests`.`name` = ?"
-			updateTable := " UPDATE `tables` SET `empty_seats`=? WHERE `tables`.`id` = ?"
+			updateGuest := "UPDATE `guests` SET `checked_out`=?,`version`=version + 1 WHERE event_id = ? AND name = ? AND version = ?"
+			updateTable := "UPDATE `tables` SET `empty_seats`=?,`version`=version + 1 WHERE id = ? AND version = ?"
 			m.sqlMock.
 				ExpectQuery(regexp.QuoteMeta(guestQuery)).
-				WithArgs(name).
+				WithArgs(1, name).
 				WillReturnRows(
 					sqlmock.NewRows(
 						[]string{
@@ -689,6 +1701,7 @@ func TestRepository_CheckOut(t *testing.T) {
 						},
 					).AddRow(g.Name, g.TableID, g.Accompanying, g.TimeArrived),
 				)
+			expectCompanions(m, sqlmock.NewRows(cColumns), name)
 			m.sqlMock.
 				ExpectQuery(regexp.QuoteMeta(tableQuery)).
 				WithArgs(g.TableID).
@@ -700,24 +1713,33 @@ func TestRepository_CheckOut(t *testing.T) {
 				)
 			m.sqlMock.ExpectBegin()
 			m.sqlMock.ExpectExec(regexp.QuoteMeta(updateGuest)).WithArgs(
+				1,
 				1,
 				name,
+				g.Version,
 			).WillReturnResult(sqlmock.NewResult(1, 1))
 			m.sqlMock.ExpectExec(regexp.QuoteMeta(updateGuest)).WithArgs(
+				1,
 				1,
 				name,
+				g.Version,
 			).WillReturnResult(sqlmock.NewResult(1, 1))
 			m.sqlMock.ExpectExec(regexp.QuoteMeta(updateTable)).WithArgs(
 				tbl.EmptySeats+g.Accompanying+1,
 				tbl.ID,
+				tbl.Version,
 			).WillReturnResult(sqlmock.NewResult(1, 1))
+			expectAudit(m, auditDef.ActionGuestCheckedOut, "test", 1, sqlmock.AnyArg(), sqlmock.AnyArg())
+			expectTrack(m, attendanceDef.TypeGuestCheckedOut, "test", 1, g.Accompanying+1)
 			m.sqlMock.ExpectCommit()
 
 			//	method call
-			err := repo.CheckOut(name)
+			res, err := repo.CheckOut(name, now)
 
 			//	assert
 			assert.NoError(t, err)
+			assert.Equal(t, name, res.Name)
+			assert.Equal(t, 1, res.CheckedOut)
 		},
 	)
 }
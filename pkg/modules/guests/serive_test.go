@@ -2,12 +2,25 @@ package guests_test
 
 import (
 	"errors"
+	auditDef "github.com/getground/tech-tasks/backend/definitions/audit"
+	"github.com/getground/tech-tasks/backend/definitions/clock"
+	"github.com/getground/tech-tasks/backend/definitions/etag"
+	eventsDef "github.com/getground/tech-tasks/backend/definitions/events"
 	guestsDef "github.com/getground/tech-tasks/backend/definitions/guests"
+	invitationsDef "github.com/getground/tech-tasks/backend/definitions/invitations"
+	notificationsDef "github.com/getground/tech-tasks/backend/definitions/notifications"
 	tablesDef "github.com/getground/tech-tasks/backend/definitions/tables"
+	venueDef "github.com/getground/tech-tasks/backend/definitions/venue"
+	eventsMocks "github.com/getground/tech-tasks/backend/mocks/definitions/events"
 	guestsMocks "github.com/getground/tech-tasks/backend/mocks/definitions/guests"
+	invitationsMocks "github.com/getground/tech-tasks/backend/mocks/definitions/invitations"
+	notificationsMocks "github.com/getground/tech-tasks/backend/mocks/definitions/notifications"
 	tableMocks "github.com/getground/tech-tasks/backend/mocks/definitions/tables"
+	venueMocks "github.com/getground/tech-tasks/backend/mocks/definitions/venue"
+	waitlistMocks "github.com/getground/tech-tasks/backend/mocks/definitions/waitlist"
 	"github.com/getground/tech-tasks/backend/pkg/modules/guests"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"testing"
 	"time"
 )
@@ -15,16 +28,52 @@ import (
 type serviceMocks struct {
 	repo         *guestsMocks.Repository
 	tableService *tableMocks.Service
+	invitations  *invitationsMocks.Service
+	publisher    *notificationsMocks.Publisher
+	index        *guestsMocks.Index
+	waitlist     *waitlistMocks.Service
+	events       *eventsMocks.Service
+	venue        *venueMocks.Service
 }
 
+// now is the time of the clock of the service, the time the guests arrive at.
+var now = time.Date(2026, 10, 19, 20, 30, 0, 0, time.UTC)
+
 func setupService() (guests.Service, serviceMocks) {
 	repo := new(guestsMocks.Repository)
 	tblService := new(tableMocks.Service)
-	service := guests.NewService(repo, tblService)
-	mocks := serviceMocks{repo, tblService}
+	invitations := new(invitationsMocks.Service)
+	publisher := new(notificationsMocks.Publisher)
+	index := new(guestsMocks.Index)
+	waitlist := new(waitlistMocks.Service)
+	events := new(eventsMocks.Service)
+	venue := new(venueMocks.Service)
+	// the guests of the zero event can always be changed, the tests of the gate scope the service to another event
+	events.On("Allow", uint(0), mock.Anything).Return(nil).Maybe()
+	// the guests of an invitation token are looked up in the event of the invitation
+	repo.On("ForEvent", mock.Anything).Return(repo).Maybe()
+	tblService.On("ForEvent", mock.Anything).Return(tblService).Maybe()
+	invitations.On("ForEvent", mock.Anything).Return(invitations).Maybe()
+	venue.On("ForEvent", mock.Anything).Return(venue).Maybe()
+	venue.On("Track", mock.Anything).Maybe()
+	indexes := new(guestsMocks.Indexes)
+	indexes.On("ForEvent", mock.Anything).Return(index)
+	service := guests.NewService(repo, tblService, invitations, publisher, indexes, waitlist, events, venue).
+		WithClock(clock.Fixed(now))
+	mocks := serviceMocks{repo, tblService, invitations, publisher, index, waitlist, events, venue}
 	return service, mocks
 }
 
+// notification matches a published notification ignoring the time it occurred at.
+func notification(expected notificationsDef.Notification) interface{} {
+	return mock.MatchedBy(
+		func(n notificationsDef.Notification) bool {
+			n.OccurredAt = expected.OccurredAt
+			return n == expected
+		},
+	)
+}
+
 func TestService_Create(t *testing.T) {
 	// setup
 	service, m := setupService()
@@ -47,6 +96,23 @@ func TestService_Create(t *testing.T) {
 		},
 	)
 
+	t.Run(
+		"companions don't match", func(t *testing.T) {
+			// test data
+			req := guestsDef.CreateRequest{
+				Name: "test", Table: 1, Accompanying: 2, Companions: []guestsDef.CompanionDTO{{Name: "ana"}},
+			}
+
+			//	method call
+			res, err := service.Create(req)
+
+			//	assert
+			assert.ErrorIs(t, err, guestsDef.ErrCompanionCount)
+			assert.Empty(t, res)
+			m.repo.AssertExpectations(t)
+		},
+	)
+
 	t.Run(
 		"no capacity", func(t *testing.T) {
 			// test data
@@ -83,7 +149,7 @@ func TestService_Create(t *testing.T) {
 
 			//	mocks
 			m.tableService.On("GetByID", req.Table).Return(tbl, nil).Once()
-			m.repo.On("Create", req, tbl.Capacity-req.Accompanying-1).Return(
+			m.repo.On("Create", req, now).Return(
 				errors.New(
 					"error adding guest to guest list",
 				),
@@ -100,6 +166,40 @@ func TestService_Create(t *testing.T) {
 		},
 	)
 
+	t.Run(
+		"invitation error", func(t *testing.T) {
+			// test data
+			req := guestsDef.CreateRequest{Name: "test", Table: 1, Accompanying: 1}
+			tbl := tablesDef.Table{
+				ID:         1,
+				Capacity:   5,
+				EmptySeats: 5,
+			}
+
+			//	mocks
+			m.tableService.On("GetByID", req.Table).Return(tbl, nil).Once()
+			m.repo.On("Create", req, now).Return(nil).Once()
+			m.index.On(
+				"Put",
+				guestsDef.Guest{
+					Name: req.Name, TableID: req.Table, Accompanying: req.Accompanying, RSVP: guestsDef.RSVPInvited,
+				},
+			).Once()
+			m.invitations.On("Issue", req.Name).Return("", errors.New("internal error")).Once()
+			m.publisher.On("Publish", mock.Anything).Once()
+
+			//	method call
+			res, err := service.Create(req)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, guestsDef.CreateResponse{Name: req.Name}, res)
+			m.repo.AssertExpectations(t)
+			m.invitations.AssertExpectations(t)
+			m.publisher.AssertExpectations(t)
+		},
+	)
+
 	t.Run(
 		"success", func(t *testing.T) {
 			// test data
@@ -112,16 +212,38 @@ func TestService_Create(t *testing.T) {
 
 			//	mocks
 			m.tableService.On("GetByID", req.Table).Return(tbl, nil).Once()
-			m.repo.On("Create", req, tbl.Capacity-req.Accompanying-1).Return(nil).Once()
+			m.repo.On("Create", req, now).Return(nil).Once()
+			m.index.On(
+				"Put",
+				guestsDef.Guest{
+					Name: req.Name, TableID: req.Table, Accompanying: req.Accompanying, RSVP: guestsDef.RSVPInvited,
+				},
+			).Once()
+			m.invitations.On("Issue", req.Name).Return("id.signature", nil).Once()
+			m.publisher.On(
+				"Publish", notification(
+					notificationsDef.Notification{
+						Type:         notificationsDef.GuestInvited,
+						Guest:        req.Name,
+						Accompanying: req.Accompanying,
+						TableID:      tbl.ID,
+						Capacity:     tbl.Capacity,
+						EmptySeats:   tbl.EmptySeats,
+					},
+				),
+			).Once()
 
 			//	method call
 			res, err := service.Create(req)
 
 			//	assert
 			assert.NoError(t, err)
-			assert.NotEmpty(t, res)
+			assert.Equal(t, guestsDef.CreateResponse{Name: req.Name, Invitation: "id.signature"}, res)
 			m.tableService.AssertExpectations(t)
 			m.repo.AssertExpectations(t)
+			m.invitations.AssertExpectations(t)
+			m.publisher.AssertExpectations(t)
+			m.index.AssertExpectations(t)
 		},
 	)
 }
@@ -132,10 +254,10 @@ func TestService_GetGuestList(t *testing.T) {
 	t.Run(
 		"repo error", func(t *testing.T) {
 			//	mocks
-			m.repo.On("GetGuestList", false).Return([]guestsDef.Guest{}, errors.New("error retrieving")).Once()
+			m.repo.On("ListPage", guestsDef.ListRequest{}).Return(guestsDef.Page{}, errors.New("error retrieving")).Once()
 
 			//	method call
-			res, err := service.GetGuestList()
+			res, err := service.GetGuestList(guestsDef.ListRequest{})
 
 			//	assert
 			assert.Error(t, err)
@@ -152,22 +274,31 @@ func TestService_GetGuestList(t *testing.T) {
 					Name:         "test",
 					TableID:      1,
 					Accompanying: 10,
+					Profile: guestsDef.Profile{
+						Email: "test@example.com", Phone: "+447700900123", Dietary: guestsDef.DietVegan,
+					},
 				},
 			}
+			// the contact details are left out of the listing
 			listDto := guestsDef.ListDTO{
 				Guests: []guestsDef.GuestListDTO{
 					{
 						Name:         "test",
 						Table:        1,
 						Accompanying: 10,
+						ProfileDTO:   guestsDef.ProfileDTO{Dietary: guestsDef.DietVegan},
 					},
 				},
+				Total:      2,
+				NextCursor: "next",
 			}
+			req := guestsDef.ListRequest{Filter: guestsDef.Filter{NamePrefix: "te"}, Sort: guestsDef.SortName}
+			req.Limit = 1
 			//	mocks
-			m.repo.On("GetGuestList", false).Return(gs, nil).Once()
+			m.repo.On("ListPage", req).Return(guestsDef.Page{Guests: gs, Total: 2, NextCursor: "next"}, nil).Once()
 
 			//	method call
-			res, err := service.GetGuestList()
+			res, err := service.GetGuestList(req)
 
 			//	assert
 			assert.NoError(t, err)
@@ -177,16 +308,24 @@ func TestService_GetGuestList(t *testing.T) {
 	)
 }
 
+func arrivedRequest(req guestsDef.ListRequest) guestsDef.ListRequest {
+	arrived := true
+	req.Arrived = &arrived
+	return req
+}
+
 func TestService_GetGuests(t *testing.T) {
 	// setup
 	service, m := setupService()
 	t.Run(
 		"repo error", func(t *testing.T) {
 			//	mocks
-			m.repo.On("GetGuestList", true).Return([]guestsDef.Guest{}, errors.New("error retrieving")).Once()
+			m.repo.On("ListPage", arrivedRequest(guestsDef.ListRequest{})).
+				Return(guestsDef.Page{}, errors.New("error retrieving")).
+				Once()
 
 			//	method call
-			res, err := service.GetGuests()
+			res, err := service.GetGuests(guestsDef.ListRequest{})
 
 			//	assert
 			assert.Error(t, err)
@@ -198,7 +337,7 @@ func TestService_GetGuests(t *testing.T) {
 	t.Run(
 		"success", func(t *testing.T) {
 			// test data
-			timeArrived := time.Now()
+			timeArrived := now.In(time.FixedZone("BST", 3600))
 			gs := []guestsDef.Guest{
 				{
 					Name:         "test",
@@ -213,15 +352,19 @@ func TestService_GetGuests(t *testing.T) {
 					{
 						Name:         "test",
 						Accompanying: 10,
-						TimeArrived:  timeArrived.String(),
+						TimeArrived:  "2026-10-19T20:30:00Z",
 					},
 				},
+				Total: 1,
 			}
+			// the filter can't ask for guests that didn't arrive
+			notArrived := false
+			req := guestsDef.ListRequest{Filter: guestsDef.Filter{Arrived: &notArrived}}
 			//	mocks
-			m.repo.On("GetGuestList", true).Return(gs, nil).Once()
+			m.repo.On("ListPage", arrivedRequest(guestsDef.ListRequest{})).Return(guestsDef.Page{Guests: gs, Total: 1}, nil).Once()
 
 			//	method call
-			res, err := service.GetGuests()
+			res, err := service.GetGuests(req)
 
 			//	assert
 			assert.NoError(t, err)
@@ -229,178 +372,1365 @@ func TestService_GetGuests(t *testing.T) {
 			m.repo.AssertExpectations(t)
 		},
 	)
+
+	t.Run(
+		"venue timezone", func(t *testing.T) {
+			// test data
+			gs := []guestsDef.Guest{{Name: "test", TableID: 1, TimeArrived: &now}}
+
+			//	mocks
+			m.repo.On("ListPage", arrivedRequest(guestsDef.ListRequest{})).
+				Return(guestsDef.Page{Guests: gs, Total: 1}, nil).
+				Once()
+
+			//	method call
+			res, err := service.WithLocation(time.FixedZone("BST", 3600)).GetGuests(guestsDef.ListRequest{})
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, "2026-10-19T21:30:00+01:00", res.Guests[0].TimeArrived)
+			m.repo.AssertExpectations(t)
+		},
+	)
 }
 
-func TestService_CheckIn(t *testing.T) {
+func TestService_List(t *testing.T) {
 	// setup
 	service, m := setupService()
 	t.Run(
-		"guest not found", func(t *testing.T) {
-			//	test data
-			req := guestsDef.CheckInRequest{
-				Name:         "test",
-				Accompanying: 10,
-			}
+		"repo error", func(t *testing.T) {
+			// test data
+			filter := guestsDef.Filter{Status: guestsDef.StatusLeft}
 
 			//	mocks
-			m.repo.On("GetByName", req.Name).Return(guestsDef.Guest{}, errors.New("guest not invited")).Once()
+			m.repo.On("List", filter).Return(nil, errors.New("error retrieving")).Once()
 
 			//	method call
-			res, err := service.CheckIn(req)
+			res, err := service.List(filter)
 
 			//	assert
 			assert.Error(t, err)
 			assert.Empty(t, res)
+			m.repo.AssertExpectations(t)
 		},
 	)
 
 	t.Run(
-		"table not found", func(t *testing.T) {
-			//	test data
-			req := guestsDef.CheckInRequest{
-				Name:         "test",
-				Accompanying: 10,
-			}
-			g := guestsDef.Guest{
-				Name:         "test",
-				TableID:      1,
-				Accompanying: 10,
-				TimeArrived:  nil,
-			}
-			tbl := tablesDef.Table{}
+		"success", func(t *testing.T) {
+			// test data
+			filter := guestsDef.Filter{Table: 1}
+			gs := []guestsDef.Guest{{Name: "test", TableID: 1, Accompanying: 2}}
 
 			//	mocks
-			m.repo.On("GetByName", req.Name).Return(g, nil).Once()
-			m.tableService.On("GetByID", g.TableID).Return(tbl, errors.New("table not found")).Once()
+			m.repo.On("List", filter).Return(gs, nil).Once()
 
 			//	method call
-			res, err := service.CheckIn(req)
+			res, err := service.List(filter)
 
 			//	assert
-			assert.Error(t, err)
+			assert.NoError(t, err)
+			assert.Equal(t, gs, res)
+			m.repo.AssertExpectations(t)
+		},
+	)
+}
+
+func TestService_Get(t *testing.T) {
+	// setup
+	service, m := setupService()
+	t.Run(
+		"guest not invited", func(t *testing.T) {
+			//	mocks
+			m.repo.On("List", guestsDef.Filter{Name: "test"}).Return([]guestsDef.Guest{}, nil).Once()
+
+			//	method call
+			res, err := service.Get("test")
+
+			//	assert
+			assert.ErrorIs(t, err, guestsDef.ErrNotInvited)
 			assert.Empty(t, res)
+			m.repo.AssertExpectations(t)
 		},
 	)
 
 	t.Run(
-		"extra accompanying than expected", func(t *testing.T) {
-			//	test data
-			req := guestsDef.CheckInRequest{
-				Name:         "test",
-				Accompanying: 10,
-			}
-			g := guestsDef.Guest{
-				Name:         "test",
-				TableID:      1,
-				Accompanying: 4,
-				TimeArrived:  nil,
-			}
-			tbl := tablesDef.Table{
-				ID:         1,
-				Capacity:   5,
-				EmptySeats: 5,
-			}
+		"success", func(t *testing.T) {
+			// test data
+			arrived := time.Now()
+			gs := []guestsDef.Guest{{Name: "test", TableID: 1, Accompanying: 2, TimeArrived: &arrived, Version: 3}}
 
 			//	mocks
-			m.repo.On("GetByName", req.Name).Return(g, nil).Once()
-			m.tableService.On("GetByID", g.TableID).Return(tbl, nil).Once()
+			m.repo.On("List", guestsDef.Filter{Name: "test"}).Return(gs, nil).Once()
 
 			//	method call
-			res, err := service.CheckIn(req)
+			res, err := service.Get("test")
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, guestsDef.GuestListDTO{Name: "test", Table: 1, Accompanying: 2, Version: 3}, res)
+			m.repo.AssertExpectations(t)
+		},
+	)
+}
+
+// seed runs the load the service gives the index, as the index does on the first search.
+func seed(load func() ([]guestsDef.Guest, error)) error {
+	_, err := load()
+	return err
+}
+
+func TestService_Search(t *testing.T) {
+	// setup
+	service, m := setupService()
+	t.Run(
+		"seed error", func(t *testing.T) {
+			//	mocks
+			m.index.On("Seed", mock.Anything).Return(seed).Once()
+			m.repo.On("List", guestsDef.Filter{}).Return(nil, errors.New("error retrieving")).Once()
+
+			//	method call
+			res, err := service.Search(guestsDef.SearchRequest{Query: "sam"})
 
 			//	assert
 			assert.Error(t, err)
 			assert.Empty(t, res)
+			m.repo.AssertExpectations(t)
+			m.index.AssertExpectations(t)
 		},
 	)
 
 	t.Run(
-		"repo error", func(t *testing.T) {
-			//	test data
-			req := guestsDef.CheckInRequest{
-				Name:         "test",
-				Accompanying: 4,
-			}
-			g := guestsDef.Guest{
-				Name:         "test",
-				TableID:      1,
-				Accompanying: 4,
-				TimeArrived:  nil,
-			}
-			tbl := tablesDef.Table{
-				ID:         1,
-				Capacity:   5,
-				EmptySeats: 5,
+		"seeds the index on the first search", func(t *testing.T) {
+			// test data
+			gs := []guestsDef.Guest{{Name: "Sam Smith", TableID: 1, Accompanying: 2}}
+
+			//	mocks
+			m.index.On("Seed", mock.Anything).Return(seed).Once()
+			m.repo.On("List", guestsDef.Filter{}).Return(gs, nil).Once()
+			m.index.On("Search", "smith sam", 5).Return([]guestsDef.Match{{Guest: gs[0], Score: 1}}).Once()
+
+			//	method call
+			res, err := service.Search(guestsDef.SearchRequest{Query: "smith sam", Limit: 5})
+
+			//	assert
+			expected := guestsDef.SearchDTO{
+				Guests: []guestsDef.MatchDTO{
+					{Name: "Sam Smith", Table: 1, Accompanying: 2, Status: guestsDef.StatusNotArrived, Score: 1},
+				},
 			}
+			assert.NoError(t, err)
+			assert.Equal(t, expected, res)
+			m.repo.AssertExpectations(t)
+			m.index.AssertExpectations(t)
+		},
+	)
 
+	t.Run(
+		"seeded", func(t *testing.T) {
 			//	mocks
-			m.repo.On("GetByName", req.Name).Return(g, nil).Once()
-			m.tableService.On("GetByID", g.TableID).Return(tbl, nil).Once()
-			m.repo.On("CheckIn", req, g, tbl).Return(errors.New("error checking user in")).Once()
+			m.index.On("Seed", mock.Anything).Return(nil).Once()
+			m.index.On("Search", "nobody", 0).Return(nil).Once()
 
 			//	method call
-			res, err := service.CheckIn(req)
+			res, err := service.Search(guestsDef.SearchRequest{Query: "nobody"})
 
 			//	assert
-			assert.Error(t, err)
+			assert.NoError(t, err)
+			assert.Equal(t, guestsDef.SearchDTO{Guests: []guestsDef.MatchDTO{}}, res)
+			m.index.AssertExpectations(t)
+		},
+	)
+}
+
+func TestService_Respond(t *testing.T) {
+	tbl := tablesDef.Table{ID: 1, Capacity: 4, EmptySeats: 10}
+	inv := invitationsDef.Invitation{ID: "id", GuestName: "test"}
+
+	t.Run(
+		"revoked invitation", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+			req := guestsDef.RSVPRequest{Token: "id.signature", Response: guestsDef.RSVPAccepted}
+
+			//	mocks
+			m.invitations.On("Verify", req.Token).Return(invitationsDef.Invitation{}, invitationsDef.ErrRevoked).Once()
+
+			//	method call
+			res, err := service.Respond(req)
+
+			//	assert
+			assert.ErrorIs(t, err, invitationsDef.ErrRevoked)
 			assert.Empty(t, res)
+			m.repo.AssertExpectations(t)
 		},
 	)
 
 	t.Run(
-		"success", func(t *testing.T) {
-			//	test data
-			req := guestsDef.CheckInRequest{
-				Name:         "test",
-				Accompanying: 4,
-			}
-			checkInRes := guestsDef.CheckInResponse{
-				Name: "test",
-			}
-			g := guestsDef.Guest{
-				Name:         "test",
-				TableID:      1,
-				Accompanying: 4,
-				TimeArrived:  nil,
-			}
-			tbl := tablesDef.Table{
-				ID:         1,
-				Capacity:   5,
-				EmptySeats: 5,
-			}
+		"arrived already", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+			req := guestsDef.RSVPRequest{Token: "id.signature", Response: guestsDef.RSVPDeclined}
 
 			//	mocks
-			m.repo.On("GetByName", req.Name).Return(g, nil).Once()
+			m.invitations.On("Verify", req.Token).Return(inv, nil).Once()
+			m.repo.On("GetByName", inv.GuestName).Return(guestsDef.Guest{}, errors.New("record not found")).Once()
+
+			//	method call
+			res, err := service.Respond(req)
+
+			//	assert
+			assert.ErrorIs(t, err, guestsDef.ErrNotInvited)
+			assert.Empty(t, res)
+		},
+	)
+
+	t.Run(
+		"no capacity", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+			req := guestsDef.RSVPRequest{Token: "id.signature", Response: guestsDef.RSVPAccepted, Accompanying: 4}
+			g := guestsDef.Guest{Name: "test", TableID: 1, Accompanying: 1, RSVP: guestsDef.RSVPInvited}
+
+			//	mocks
+			m.invitations.On("Verify", req.Token).Return(inv, nil).Once()
+			m.repo.On("GetByName", inv.GuestName).Return(g, nil).Once()
 			m.tableService.On("GetByID", g.TableID).Return(tbl, nil).Once()
-			m.repo.On("CheckIn", req, g, tbl).Return(nil).Once()
 
 			//	method call
-			res, err := service.CheckIn(req)
+			res, err := service.Respond(req)
 
 			//	assert
-			assert.NoError(t, err)
-			assert.Equal(t, checkInRes, res)
+			assert.ErrorIs(t, err, guestsDef.ErrNoCapacity)
+			assert.Empty(t, res)
+			m.repo.AssertExpectations(t)
 		},
 	)
+
+	responses := []struct {
+		name     string
+		guest    guestsDef.Guest
+		req      guestsDef.RSVPRequest
+		answered guestsDef.Guest
+		capacity int64
+		promotes bool
+	}{
+		{
+			name:     "accept reserves the seats",
+			guest:    guestsDef.Guest{Name: "test", TableID: 1, Accompanying: 1, RSVP: guestsDef.RSVPInvited},
+			req:      guestsDef.RSVPRequest{Response: guestsDef.RSVPAccepted, Accompanying: 2},
+			answered: guestsDef.Guest{Name: "test", TableID: 1, Accompanying: 2, RSVP: guestsDef.RSVPAccepted},
+			capacity: 1,
+		},
+		{
+			name:  "accept names the companions",
+			guest: guestsDef.Guest{Name: "test", TableID: 1, Accompanying: 1, RSVP: guestsDef.RSVPInvited},
+			req: guestsDef.RSVPRequest{
+				Response: guestsDef.RSVPAccepted, Accompanying: 1, Companions: []guestsDef.CompanionDTO{{Name: "ana"}},
+			},
+			answered: guestsDef.Guest{
+				Name: "test", TableID: 1, Accompanying: 1, RSVP: guestsDef.RSVPAccepted,
+				Companions: []guestsDef.Companion{{Name: "ana"}},
+			},
+			capacity: 2,
+		},
+		{
+			name:     "accept again with a bigger party",
+			guest:    guestsDef.Guest{Name: "test", TableID: 1, Accompanying: 1, RSVP: guestsDef.RSVPAccepted},
+			req:      guestsDef.RSVPRequest{Response: guestsDef.RSVPAccepted, Accompanying: 5},
+			answered: guestsDef.Guest{Name: "test", TableID: 1, Accompanying: 5, RSVP: guestsDef.RSVPAccepted},
+			capacity: 0,
+		},
+		{
+			name:     "decline gives the seats back",
+			guest:    guestsDef.Guest{Name: "test", TableID: 1, Accompanying: 2, RSVP: guestsDef.RSVPAccepted},
+			req:      guestsDef.RSVPRequest{Response: guestsDef.RSVPDeclined},
+			answered: guestsDef.Guest{Name: "test", TableID: 1, Accompanying: 2, RSVP: guestsDef.RSVPDeclined},
+			capacity: 7,
+			promotes: true,
+		},
+		{
+			name:     "tentative doesn't reserve",
+			guest:    guestsDef.Guest{Name: "test", TableID: 1, Accompanying: 1, RSVP: guestsDef.RSVPInvited},
+			req:      guestsDef.RSVPRequest{Response: guestsDef.RSVPTentative, Accompanying: 3},
+			answered: guestsDef.Guest{Name: "test", TableID: 1, Accompanying: 3, RSVP: guestsDef.RSVPTentative},
+			capacity: 4,
+		},
+		{
+			name:     "tentative after accepting gives the seats back",
+			guest:    guestsDef.Guest{Name: "test", TableID: 1, Accompanying: 1, RSVP: guestsDef.RSVPAccepted},
+			req:      guestsDef.RSVPRequest{Response: guestsDef.RSVPTentative, Accompanying: 1},
+			answered: guestsDef.Guest{Name: "test", TableID: 1, Accompanying: 1, RSVP: guestsDef.RSVPTentative},
+			capacity: 6,
+			promotes: true,
+		},
+	}
+	for _, r := range responses {
+		r := r
+		t.Run(
+			r.name, func(t *testing.T) {
+				// setup
+				service, m := setupService()
+				r.req.Token = "id.signature"
+
+				//	mocks
+				m.invitations.On("Verify", r.req.Token).Return(inv, nil).Once()
+				m.repo.On("GetByName", inv.GuestName).Return(r.guest, nil).Once()
+				m.tableService.On("GetByID", r.guest.TableID).Return(tbl, nil).Once()
+				left := tbl
+				left.Capacity = r.capacity
+				m.repo.On("Respond", r.answered, left, now).Return(nil).Once()
+				m.index.On("Put", r.answered).Once()
+				m.publisher.On(
+					"Publish", notification(
+						notificationsDef.Notification{
+							Type:         notificationsDef.GuestResponded,
+							Guest:        r.guest.Name,
+							Accompanying: r.answered.Accompanying,
+							TableID:      tbl.ID,
+							Capacity:     r.capacity,
+							EmptySeats:   tbl.EmptySeats,
+						},
+					),
+				).Once()
+				if r.promotes {
+					m.waitlist.On("Promote", uint(0), auditDef.SystemActor, tbl.ID).Once()
+				}
+
+				//	method call
+				res, err := service.Respond(r.req)
+
+				//	assert
+				assert.NoError(t, err)
+				assert.Equal(
+					t, guestsDef.RSVPResponse{
+						Name: r.guest.Name, Response: r.answered.RSVP, Accompanying: r.answered.Accompanying,
+						Companions: r.req.Companions,
+					}, res,
+				)
+				m.repo.AssertExpectations(t)
+				m.index.AssertExpectations(t)
+				m.publisher.AssertExpectations(t)
+				m.waitlist.AssertExpectations(t)
+			},
+		)
+	}
 }
 
-func TestService_CheckOut(t *testing.T) {
-	// setup
-	service, m := setupService()
+func TestService_EditCompanions(t *testing.T) {
+	tbl := tablesDef.Table{ID: 1, Capacity: 2, EmptySeats: 10, Version: 4}
+	g := guestsDef.Guest{
+		Name: "test", TableID: 1, Accompanying: 2, RSVP: guestsDef.RSVPAccepted, Version: 3,
+		Companions: []guestsDef.Companion{{Name: "ana"}, {Name: "ben"}},
+	}
+
 	t.Run(
-		"success", func(t *testing.T) {
-			// test data
-			name := "test"
+		"guest not invited", func(t *testing.T) {
+			// setup
+			service, m := setupService()
 
 			//	mocks
-			m.repo.On("CheckOut", name).Return(nil).Once()
+			m.repo.On("GetByName", "test").Return(guestsDef.Guest{}, errors.New("record not found")).Once()
 
 			//	method call
-			err := service.CheckOut(name)
+			_, err := service.EditCompanions(guestsDef.CompanionsRequest{Name: "test"})
 
 			//	assert
-			assert.NoError(t, err)
-			m.repo.AssertExpectations(t)
+			assert.ErrorIs(t, err, guestsDef.ErrNotInvited)
+		},
+	)
+
+	t.Run(
+		"stale version", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+
+			//	mocks
+			m.repo.On("GetByName", "test").Return(g, nil).Once()
+
+			//	method call
+			_, err := service.EditCompanions(guestsDef.CompanionsRequest{Name: "test", Version: 2})
+
+			//	assert
+			assert.ErrorIs(t, err, etag.ErrStale)
+			m.tableService.AssertNotCalled(t, "GetByID", mock.Anything)
+		},
+	)
+
+	t.Run(
+		"no capacity", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+			req := guestsDef.CompanionsRequest{
+				Name:       "test",
+				Companions: []guestsDef.CompanionDTO{{Name: "ana"}, {Name: "ben"}, {Name: "cy"}, {Name: "dee"}, {Name: "eve"}},
+			}
+
+			//	mocks
+			m.repo.On("GetByName", "test").Return(g, nil).Once()
+			m.tableService.On("GetByID", g.TableID).Return(tbl, nil).Once()
+
+			//	method call
+			_, err := service.EditCompanions(req)
+
+			//	assert
+			assert.ErrorIs(t, err, guestsDef.ErrNoCapacity)
+			m.repo.AssertNotCalled(t, "EditCompanions", mock.Anything, mock.Anything, mock.Anything)
+		},
+	)
+
+	t.Run(
+		"smaller party gives the seats to the waitlist", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+			req := guestsDef.CompanionsRequest{
+				Name: "test", Companions: []guestsDef.CompanionDTO{{Name: "ana", Dietary: "vegan"}}, Version: 3,
+			}
+
+			//	mocks
+			m.repo.On("GetByName", "test").Return(g, nil).Once()
+			m.tableService.On("GetByID", g.TableID).Return(tbl, nil).Once()
+			edited := g
+			edited.Accompanying = 1
+			edited.Companions = []guestsDef.Companion{{Name: "ana", Dietary: "vegan"}}
+			left := tbl
+			left.Capacity = 3
+			m.repo.On("EditCompanions", edited, left, now).Return(nil).Once()
+			m.index.On("Put", edited).Once()
+			m.waitlist.On("Promote", uint(0), auditDef.SystemActor, tbl.ID).Once()
+
+			//	method call
+			res, err := service.EditCompanions(req)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(
+				t, guestsDef.GuestListDTO{
+					Name:         "test",
+					Table:        1,
+					Accompanying: 1,
+					Companions:   []guestsDef.CompanionDTO{{Name: "ana", Dietary: "vegan"}},
+					RSVP:         guestsDef.RSVPAccepted,
+					Version:      4,
+				}, res,
+			)
+			m.repo.AssertExpectations(t)
+			m.index.AssertExpectations(t)
+			m.waitlist.AssertExpectations(t)
+		},
+	)
+}
+
+func TestService_RSVPCounts(t *testing.T) {
+	t.Run(
+		"repo error", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+
+			//	mocks
+			m.repo.On("CountRSVP", uint(0)).Return(nil, errors.New("internal error")).Once()
+
+			//	method call
+			res, err := service.RSVPCounts(guestsDef.RSVPCountsRequest{})
+
+			//	assert
+			assert.Error(t, err)
+			assert.Empty(t, res)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+
+			//	mocks
+			m.repo.On("CountRSVP", uint(0)).Return(
+				[]guestsDef.RSVPCount{
+					{TableID: 1, RSVP: guestsDef.RSVPAccepted, Guests: 2, People: 5},
+					{TableID: 1, RSVP: guestsDef.RSVPInvited, Guests: 1, People: 2},
+					{TableID: 2, RSVP: guestsDef.RSVPDeclined, Guests: 1, People: 1},
+					{TableID: 2, RSVP: guestsDef.RSVPTentative, Guests: 3, People: 4},
+				}, nil,
+			).Once()
+
+			//	method call
+			res, err := service.RSVPCounts(guestsDef.RSVPCountsRequest{})
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(
+				t, guestsDef.RSVPCountsDTO{
+					Tables: []guestsDef.TableRSVPDTO{
+						{Table: 1, Invited: 1, Accepted: 2, SeatsReserved: 5},
+						{Table: 2, Declined: 1, Tentative: 3},
+					},
+				}, res,
+			)
+		},
+	)
+}
+
+func TestService_EditProfile(t *testing.T) {
+	arrived := time.Date(2026, 10, 19, 20, 30, 0, 0, time.UTC)
+	g := guestsDef.Guest{
+		Name: "test", TableID: 1, Accompanying: 1, TimeArrived: &arrived, RSVP: guestsDef.RSVPAccepted, Version: 3,
+		Profile: guestsDef.Profile{Email: "old@example.com", Dietary: guestsDef.DietVegan},
+	}
+	profile := guestsDef.ProfileDTO{
+		Email: "test@example.com", Phone: "+447700900123", Dietary: guestsDef.DietOther, DietaryNotes: "no shellfish",
+		Accessibility: "step free access",
+	}
+
+	t.Run(
+		"guest not invited", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+
+			//	mocks
+			m.repo.On("List", guestsDef.Filter{Name: "test"}).Return(nil, nil).Once()
+
+			//	method call
+			_, err := service.EditProfile(guestsDef.ProfileRequest{Name: "test", ProfileDTO: profile})
+
+			//	assert
+			assert.ErrorIs(t, err, guestsDef.ErrNotInvited)
+		},
+	)
+
+	t.Run(
+		"stale version", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+
+			//	mocks
+			m.repo.On("List", guestsDef.Filter{Name: "test"}).Return([]guestsDef.Guest{g}, nil).Once()
+
+			//	method call
+			_, err := service.EditProfile(guestsDef.ProfileRequest{Name: "test", ProfileDTO: profile, Version: 2})
+
+			//	assert
+			assert.ErrorIs(t, err, etag.ErrStale)
+			m.repo.AssertNotCalled(t, "EditProfile", mock.Anything, mock.Anything)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+
+			//	mocks
+			// the profile of a guest that arrived is edited too, the whole profile is replaced
+			m.repo.On("List", guestsDef.Filter{Name: "test"}).Return([]guestsDef.Guest{g}, nil).Once()
+			edited := g
+			edited.Profile = guestsDef.Profile{
+				Email: "test@example.com", Phone: "+447700900123", Dietary: guestsDef.DietOther,
+				DietaryNotes: "no shellfish", Accessibility: "step free access",
+			}
+			m.repo.On("EditProfile", edited, now).Return(nil).Once()
+			m.index.On("Put", edited).Once()
+
+			//	method call
+			res, err := service.EditProfile(guestsDef.ProfileRequest{Name: "test", ProfileDTO: profile, Version: 3})
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(
+				t, guestsDef.GuestListDTO{
+					Name:         "test",
+					Table:        1,
+					Accompanying: 1,
+					RSVP:         guestsDef.RSVPAccepted,
+					Version:      4,
+					ProfileDTO:   profile,
+				}, res,
+			)
+			m.repo.AssertExpectations(t)
+			m.index.AssertExpectations(t)
+		},
+	)
+}
+
+func TestService_Catering(t *testing.T) {
+	t.Run(
+		"repo error", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+
+			//	mocks
+			m.repo.On("CountDiets", uint(2)).Return(nil, errors.New("internal error")).Once()
+
+			//	method call
+			res, err := service.Catering(guestsDef.CateringRequest{Table: 2})
+
+			//	assert
+			assert.Error(t, err)
+			assert.Empty(t, res)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+
+			//	mocks
+			// the guests and their companions are counted apart, the tables come in any order
+			m.repo.On("CountDiets", uint(0)).Return(
+				[]guestsDef.DietCount{
+					{TableID: 2, Invited: 1},
+					{TableID: 1, Invited: 2, Arrived: 1},
+					{TableID: 1, Dietary: guestsDef.DietVegan, Invited: 1},
+					{TableID: 1, Dietary: guestsDef.DietVegan, Invited: 2, Arrived: 2},
+					{TableID: 2, Dietary: guestsDef.DietUnknown, Invited: 3},
+				}, nil,
+			).Once()
+
+			//	method call
+			res, err := service.Catering(guestsDef.CateringRequest{})
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(
+				t, guestsDef.CateringDTO{
+					Tables: []guestsDef.TableCateringDTO{
+						{
+							Table:   1,
+							Invited: map[guestsDef.Diet]int64{guestsDef.DietNone: 2, guestsDef.DietVegan: 3},
+							Arrived: map[guestsDef.Diet]int64{guestsDef.DietNone: 1, guestsDef.DietVegan: 2},
+						},
+						{
+							Table:   2,
+							Invited: map[guestsDef.Diet]int64{guestsDef.DietNone: 1, guestsDef.DietUnknown: 3},
+							Arrived: map[guestsDef.Diet]int64{},
+						},
+					},
+				}, res,
+			)
+		},
+	)
+}
+
+func TestService_CheckIn(t *testing.T) {
+	// setup
+	service, m := setupService()
+	t.Run(
+		"doors not open", func(t *testing.T) {
+			//	mocks
+			m.events.On("Allow", uint(2), eventsDef.OpCheckIn).Return(eventsDef.ErrNotAllowed).Once()
+
+			//	method call
+			res, err := service.ForEvent(2).CheckIn(guestsDef.CheckInRequest{Name: "test"})
+
+			//	assert
+			assert.ErrorIs(t, err, eventsDef.ErrNotAllowed)
+			assert.Empty(t, res)
+			m.events.AssertExpectations(t)
+			m.repo.AssertNotCalled(t, "GetByName", mock.Anything)
+		},
+	)
+
+	t.Run(
+		"guest not found", func(t *testing.T) {
+			//	test data
+			req := guestsDef.CheckInRequest{
+				Name:         "test",
+				Accompanying: 10,
+			}
+
+			//	mocks
+			m.repo.On("GetByName", req.Name).Return(guestsDef.Guest{}, errors.New("guest not invited")).Once()
+
+			//	method call
+			res, err := service.CheckIn(req)
+
+			//	assert
+			assert.Error(t, err)
+			assert.Empty(t, res)
+		},
+	)
+
+	t.Run(
+		"stale version", func(t *testing.T) {
+			//	test data
+			req := guestsDef.CheckInRequest{Name: "test", Accompanying: 1, Version: 2}
+			g := guestsDef.Guest{Name: "test", TableID: 1, RSVP: guestsDef.RSVPAccepted, Version: 3}
+
+			//	mocks
+			m.repo.On("GetByName", req.Name).Return(g, nil).Once()
+
+			//	method call
+			res, err := service.CheckIn(req)
+
+			//	assert
+			assert.ErrorIs(t, err, etag.ErrStale)
+			assert.Empty(t, res)
+			m.tableService.AssertNotCalled(t, "GetByID", mock.Anything)
+		},
+	)
+
+	t.Run(
+		"companions named before don't match", func(t *testing.T) {
+			//	test data
+			req := guestsDef.CheckInRequest{Name: "test", Accompanying: 2}
+			g := guestsDef.Guest{
+				Name: "test", TableID: 1, Accompanying: 1, RSVP: guestsDef.RSVPAccepted,
+				Companions: []guestsDef.Companion{{Name: "ana"}},
+			}
+
+			//	mocks
+			m.repo.On("GetByName", req.Name).Return(g, nil).Once()
+
+			//	method call
+			res, err := service.CheckIn(req)
+
+			//	assert
+			assert.ErrorIs(t, err, guestsDef.ErrCompanionCount)
+			assert.Empty(t, res)
+		},
+	)
+
+	t.Run(
+		"table not found", func(t *testing.T) {
+			//	test data
+			req := guestsDef.CheckInRequest{
+				Name:         "test",
+				Accompanying: 10,
+			}
+			g := guestsDef.Guest{
+				Name:         "test",
+				TableID:      1,
+				Accompanying: 10,
+				TimeArrived:  nil,
+				RSVP:         guestsDef.RSVPAccepted,
+			}
+			tbl := tablesDef.Table{}
+
+			//	mocks
+			m.repo.On("GetByName", req.Name).Return(g, nil).Once()
+			m.tableService.On("GetByID", g.TableID).Return(tbl, errors.New("table not found")).Once()
+
+			//	method call
+			res, err := service.CheckIn(req)
+
+			//	assert
+			assert.Error(t, err)
+			assert.Empty(t, res)
+		},
+	)
+
+	t.Run(
+		"extra accompanying than expected", func(t *testing.T) {
+			//	test data
+			req := guestsDef.CheckInRequest{
+				Name:         "test",
+				Accompanying: 10,
+			}
+			g := guestsDef.Guest{
+				Name:         "test",
+				TableID:      1,
+				Accompanying: 4,
+				TimeArrived:  nil,
+				RSVP:         guestsDef.RSVPAccepted,
+			}
+			tbl := tablesDef.Table{
+				ID:         1,
+				Capacity:   5,
+				EmptySeats: 5,
+			}
+
+			//	mocks
+			m.repo.On("GetByName", req.Name).Return(g, nil).Once()
+			m.tableService.On("GetByID", g.TableID).Return(tbl, nil).Once()
+
+			//	method call
+			res, err := service.CheckIn(req)
+
+			//	assert
+			assert.Error(t, err)
+			assert.Empty(t, res)
+		},
+	)
+
+	t.Run(
+		"venue full", func(t *testing.T) {
+			//	test data
+			req := guestsDef.CheckInRequest{
+				Name:         "test",
+				Accompanying: 10,
+			}
+			g := guestsDef.Guest{
+				Name:         "test",
+				TableID:      1,
+				Accompanying: 10,
+				RSVP:         guestsDef.RSVPAccepted,
+			}
+			tbl := tablesDef.Table{
+				ID:         1,
+				Capacity:   0,
+				EmptySeats: 0,
+			}
+
+			//	mocks
+			m.repo.On("GetByName", req.Name).Return(g, nil).Once()
+			m.tableService.On("GetByID", g.TableID).Return(tbl, nil).Once()
+			m.repo.On("CheckIn", req, g, tbl, now).Return(venueDef.ErrFull).Once()
+
+			//	method call
+			res, err := service.CheckIn(req)
+
+			//	assert
+			assert.ErrorIs(t, err, venueDef.ErrFull)
+			assert.Empty(t, res)
+			m.venue.AssertNotCalled(t, "Track", int64(11))
+		},
+	)
+
+	t.Run(
+		"repo error", func(t *testing.T) {
+			//	test data
+			req := guestsDef.CheckInRequest{
+				Name:         "test",
+				Accompanying: 4,
+			}
+			g := guestsDef.Guest{
+				Name:         "test",
+				TableID:      1,
+				Accompanying: 4,
+				TimeArrived:  nil,
+				RSVP:         guestsDef.RSVPAccepted,
+			}
+			tbl := tablesDef.Table{
+				ID:         1,
+				Capacity:   5,
+				EmptySeats: 5,
+			}
+
+			//	mocks
+			m.repo.On("GetByName", req.Name).Return(g, nil).Once()
+			m.tableService.On("GetByID", g.TableID).Return(tbl, nil).Once()
+			m.repo.On("CheckIn", req, g, tbl, now).Return(errors.New("error checking user in")).Once()
+
+			//	method call
+			res, err := service.CheckIn(req)
+
+			//	assert
+			assert.Error(t, err)
+			assert.Empty(t, res)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			//	test data
+			req := guestsDef.CheckInRequest{
+				Name:         "test",
+				Accompanying: 4,
+			}
+			checkInRes := guestsDef.CheckInResponse{
+				Name: "test",
+			}
+			g := guestsDef.Guest{
+				Name:         "test",
+				TableID:      1,
+				Accompanying: 3,
+				TimeArrived:  nil,
+				RSVP:         guestsDef.RSVPAccepted,
+			}
+			tbl := tablesDef.Table{
+				ID:         1,
+				Capacity:   5,
+				EmptySeats: 5,
+			}
+
+			//	mocks
+			m.repo.On("GetByName", req.Name).Return(g, nil).Once()
+			m.tableService.On("GetByID", g.TableID).Return(tbl, nil).Once()
+			m.repo.On("CheckIn", req, g, tbl, now).Return(nil).Once()
+			m.index.On(
+				"Put", mock.MatchedBy(
+					func(indexed guestsDef.Guest) bool {
+						return indexed.Name == g.Name && indexed.Accompanying == req.Accompanying &&
+							indexed.TimeArrived.Equal(now)
+					},
+				),
+			).Once()
+			m.publisher.On(
+				"Publish", notification(
+					notificationsDef.Notification{
+						Type:         notificationsDef.GuestCheckedIn,
+						Guest:        req.Name,
+						Accompanying: req.Accompanying,
+						TableID:      tbl.ID,
+						Capacity:     tbl.Capacity - 1,
+						EmptySeats:   tbl.EmptySeats - req.Accompanying - 1,
+					},
+				),
+			).Once()
+
+			//	method call
+			res, err := service.CheckIn(req)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, checkInRes, res)
+			m.publisher.AssertExpectations(t)
+			m.index.AssertExpectations(t)
+			m.venue.AssertCalled(t, "Track", int64(5))
+		},
+	)
+
+	t.Run(
+		"companions named at the door", func(t *testing.T) {
+			//	test data
+			req := guestsDef.CheckInRequest{
+				Name:         "named",
+				Accompanying: 2,
+				Companions:   []guestsDef.CompanionDTO{{Name: "ana"}, {Name: "ben", Dietary: guestsDef.DietNutAllergy}},
+			}
+			g := guestsDef.Guest{
+				Name: "named", TableID: 1, Accompanying: 1, RSVP: guestsDef.RSVPAccepted,
+				Companions: []guestsDef.Companion{{Name: "ana"}},
+			}
+			tbl := tablesDef.Table{ID: 1, Capacity: 5, EmptySeats: 5}
+
+			//	mocks
+			m.repo.On("GetByName", req.Name).Return(g, nil).Once()
+			m.tableService.On("GetByID", g.TableID).Return(tbl, nil).Once()
+			m.repo.On("CheckIn", req, g, tbl, now).Return(nil).Once()
+			m.index.On(
+				"Put", mock.MatchedBy(
+					func(indexed guestsDef.Guest) bool {
+						return indexed.Name == g.Name && indexed.Accompanying == 2 && len(indexed.Companions) == 2 &&
+							indexed.Companions[1].Dietary == guestsDef.DietNutAllergy
+					},
+				),
+			).Once()
+			m.publisher.On("Publish", mock.Anything).Once()
+
+			//	method call
+			res, err := service.CheckIn(req)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, guestsDef.CheckInResponse{Name: "named"}, res)
+			m.repo.AssertExpectations(t)
+			m.index.AssertExpectations(t)
+		},
+	)
+}
+
+func TestService_Scan(t *testing.T) {
+	t.Run(
+		"invalid token", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+			req := guestsDef.ScanRequest{Token: "id.forged", Accompanying: 1}
+
+			//	mocks
+			m.invitations.On("Verify", req.Token).Return(invitationsDef.Invitation{}, invitationsDef.ErrInvalidToken).Once()
+
+			//	method call
+			res, err := service.Scan(req)
+
+			//	assert
+			assert.ErrorIs(t, err, invitationsDef.ErrInvalidToken)
+			assert.Empty(t, res)
+			m.invitations.AssertExpectations(t)
+			m.repo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"check in error", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+			req := guestsDef.ScanRequest{Token: "id.signature", Accompanying: 1}
+
+			//	mocks
+			m.invitations.On("Verify", req.Token).Return(invitationsDef.Invitation{ID: "id", GuestName: "test"}, nil).Once()
+			m.repo.On("GetByName", "test").Return(guestsDef.Guest{}, errors.New("record not found")).Once()
+
+			//	method call
+			res, err := service.Scan(req)
+
+			//	assert
+			assert.ErrorIs(t, err, guestsDef.ErrNotInvited)
+			assert.Empty(t, res)
+			m.invitations.AssertExpectations(t)
+			m.repo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+			req := guestsDef.ScanRequest{Token: "id.signature", Accompanying: 2}
+			g := guestsDef.Guest{Name: "test", TableID: 1, Accompanying: 1}
+			tbl := tablesDef.Table{ID: 1, Capacity: 3, EmptySeats: 5}
+
+			//	mocks
+			m.invitations.On("Verify", req.Token).
+				Return(invitationsDef.Invitation{ID: "id", EventID: 2, GuestName: g.Name}, nil).
+				Once()
+			m.events.On("Allow", uint(2), eventsDef.OpCheckIn).Return(nil).Once()
+			m.repo.On("GetByName", g.Name).Return(g, nil).Once()
+			m.tableService.On("GetByID", g.TableID).Return(tbl, nil).Once()
+			m.repo.On("CheckIn", guestsDef.CheckInRequest{Name: g.Name, Accompanying: 2}, g, tbl, now).Return(nil).Once()
+			m.index.On("Put", mock.Anything).Once()
+			m.publisher.On("Publish", mock.Anything).Once()
+			// the guest is in even if the token couldn't be marked
+			m.invitations.On("Use", "id").Return(errors.New("internal error")).Once()
+
+			//	method call
+			res, err := service.Scan(req)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, guestsDef.CheckInResponse{Name: g.Name}, res)
+			// the guest is checked in at the event of the invitation
+			m.repo.AssertCalled(t, "ForEvent", uint(2))
+			m.tableService.AssertCalled(t, "ForEvent", uint(2))
+			m.events.AssertExpectations(t)
+			m.invitations.AssertExpectations(t)
+			m.repo.AssertExpectations(t)
+		},
+	)
+}
+
+func TestService_WalkIn(t *testing.T) {
+	req := guestsDef.WalkInRequest{Name: "test", Accompanying: 2}
+
+	t.Run(
+		"no empty seats", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+
+			//	mocks
+			m.repo.On("WalkIn", req, now).Return(guestsDef.Guest{}, tablesDef.Table{}, guestsDef.ErrNoEmptySeats).Once()
+
+			//	method call
+			res, err := service.WalkIn(req)
+
+			//	assert
+			assert.ErrorIs(t, err, guestsDef.ErrNoEmptySeats)
+			assert.Empty(t, res)
+			m.index.AssertExpectations(t)
+			m.publisher.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"venue full", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+			party := guestsDef.WalkInRequest{Name: "test", Accompanying: 9}
+
+			//	mocks
+			m.repo.On("WalkIn", party, now).Return(guestsDef.Guest{}, tablesDef.Table{}, venueDef.ErrFull).Once()
+
+			//	method call
+			res, err := service.WalkIn(party)
+
+			//	assert
+			assert.ErrorIs(t, err, venueDef.ErrFull)
+			assert.Empty(t, res)
+			m.venue.AssertNotCalled(t, "Track", mock.Anything)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+			arrived := time.Now()
+			g := guestsDef.Guest{
+				Name: "test", TableID: 4, Accompanying: 2, TimeArrived: &arrived, RSVP: guestsDef.RSVPAccepted,
+				WalkIn: true,
+			}
+			tbl := tablesDef.Table{ID: 4, Capacity: 0, EmptySeats: 2}
+
+			//	mocks
+			m.repo.On("WalkIn", req, now).Return(g, tbl, nil).Once()
+			m.index.On("Put", g).Once()
+			m.publisher.On(
+				"Publish", notification(
+					notificationsDef.Notification{
+						Type:         notificationsDef.GuestCheckedIn,
+						Guest:        "test",
+						Accompanying: 2,
+						TableID:      4,
+						Capacity:     0,
+						EmptySeats:   2,
+					},
+				),
+			).Once()
+
+			//	method call
+			res, err := service.WalkIn(req)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, guestsDef.WalkInResponse{Name: "test", Table: 4}, res)
+			m.venue.AssertCalled(t, "Track", int64(3))
+			m.repo.AssertExpectations(t)
+			m.index.AssertExpectations(t)
+			m.publisher.AssertExpectations(t)
+		},
+	)
+}
+
+func TestService_Reinvite(t *testing.T) {
+	t.Run(
+		"guest not invited", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+
+			//	mocks
+			m.repo.On("GetByName", "test").Return(guestsDef.Guest{}, errors.New("record not found")).Once()
+
+			//	method call
+			res, err := service.Reinvite("test")
+
+			//	assert
+			assert.ErrorIs(t, err, guestsDef.ErrNotInvited)
+			assert.Empty(t, res)
+			m.repo.AssertExpectations(t)
+			m.invitations.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"revoke error", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+
+			//	mocks
+			m.repo.On("GetByName", "test").Return(guestsDef.Guest{Name: "test"}, nil).Once()
+			m.invitations.On("Revoke", "test").Return(errors.New("internal error")).Once()
+
+			//	method call
+			res, err := service.Reinvite("test")
+
+			//	assert
+			assert.Error(t, err)
+			assert.Empty(t, res)
+			m.invitations.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"success without previous invitation", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+
+			//	mocks
+			m.repo.On("GetByName", "test").Return(guestsDef.Guest{Name: "test"}, nil).Once()
+			m.invitations.On("Revoke", "test").Return(invitationsDef.ErrNotFound).Once()
+			m.invitations.On("Issue", "test").Return("id.signature", nil).Once()
+
+			//	method call
+			res, err := service.Reinvite("test")
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, invitationsDef.InvitationResponse{Token: "id.signature"}, res)
+			m.invitations.AssertExpectations(t)
+		},
+	)
+}
+
+func TestService_Uninvite(t *testing.T) {
+	tbl := tablesDef.Table{ID: 1, Capacity: 2, EmptySeats: 10}
+
+	t.Run(
+		"guest not invited", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+
+			//	mocks
+			m.repo.On("GetByName", "test").Return(guestsDef.Guest{}, errors.New("record not found")).Once()
+
+			//	method call
+			err := service.Uninvite("test")
+
+			//	assert
+			assert.ErrorIs(t, err, guestsDef.ErrNotInvited)
+			m.repo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"repo error", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+			g := guestsDef.Guest{Name: "test", TableID: 1, Accompanying: 2, RSVP: guestsDef.RSVPAccepted}
+
+			//	mocks
+			m.repo.On("GetByName", "test").Return(g, nil).Once()
+			m.tableService.On("GetByID", g.TableID).Return(tbl, nil).Once()
+			left := tbl
+			left.Capacity = 5
+			m.repo.On("Delete", g, left, now).Return(errors.New("internal error")).Once()
+
+			//	method call
+			err := service.Uninvite("test")
+
+			//	assert
+			assert.Error(t, err)
+			m.repo.AssertExpectations(t)
+			m.index.AssertExpectations(t)
+			m.waitlist.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"accepted guest gives the seats to the waitlist", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+			g := guestsDef.Guest{Name: "test", TableID: 1, Accompanying: 2, RSVP: guestsDef.RSVPAccepted}
+
+			//	mocks
+			m.repo.On("GetByName", "test").Return(g, nil).Once()
+			m.tableService.On("GetByID", g.TableID).Return(tbl, nil).Once()
+			left := tbl
+			left.Capacity = 5
+			m.repo.On("Delete", g, left, now).Return(nil).Once()
+			m.index.On("Remove", "test").Once()
+			m.publisher.On(
+				"Publish", notification(
+					notificationsDef.Notification{
+						Type:         notificationsDef.GuestUninvited,
+						Guest:        "test",
+						Accompanying: 2,
+						TableID:      tbl.ID,
+						Capacity:     5,
+						EmptySeats:   tbl.EmptySeats,
+					},
+				),
+			).Once()
+			m.waitlist.On("Promote", uint(0), auditDef.SystemActor, tbl.ID).Once()
+
+			//	method call
+			err := service.Uninvite("test")
+
+			//	assert
+			assert.NoError(t, err)
+			m.repo.AssertExpectations(t)
+			m.index.AssertExpectations(t)
+			m.publisher.AssertExpectations(t)
+			m.waitlist.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"invited guest had no seats", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+			g := guestsDef.Guest{Name: "test", TableID: 1, Accompanying: 2, RSVP: guestsDef.RSVPInvited}
+
+			//	mocks
+			m.repo.On("GetByName", "test").Return(g, nil).Once()
+			m.tableService.On("GetByID", g.TableID).Return(tbl, nil).Once()
+			m.repo.On("Delete", g, tbl, now).Return(nil).Once()
+			m.index.On("Remove", "test").Once()
+			m.publisher.On("Publish", mock.Anything).Once()
+
+			//	method call
+			err := service.Uninvite("test")
+
+			//	assert
+			assert.NoError(t, err)
+			m.repo.AssertExpectations(t)
+			m.waitlist.AssertNotCalled(t, "Promote", mock.Anything, mock.Anything, mock.Anything)
+		},
+	)
+}
+
+func TestService_CheckOut(t *testing.T) {
+	// setup
+	service, m := setupService()
+	t.Run(
+		"repo error", func(t *testing.T) {
+			// test data
+			name := "test"
+
+			//	mocks
+			m.repo.On("CheckOut", name, now).Return(guestsDef.Guest{}, errors.New("guest not checked in")).Once()
+
+			//	method call
+			err := service.CheckOut(name)
+
+			//	assert
+			assert.Error(t, err)
+			m.repo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"table reload error", func(t *testing.T) {
+			// test data
+			name := "test"
+			g := guestsDef.Guest{Name: name, TableID: 1, Accompanying: 2, CheckedOut: 1}
+
+			//	mocks
+			m.repo.On("CheckOut", name, now).Return(g, nil).Once()
+			m.index.On("Put", g).Once()
+			m.tableService.On("GetByID", g.TableID).Return(tablesDef.Table{}, errors.New("table not found")).Once()
+			m.publisher.On(
+				"Publish", notification(
+					notificationsDef.Notification{
+						Type:         notificationsDef.GuestCheckedOut,
+						Guest:        name,
+						Accompanying: g.Accompanying,
+						TableID:      g.TableID,
+					},
+				),
+			).Once()
+
+			//	method call
+			err := service.CheckOut(name)
+
+			//	assert
+			assert.NoError(t, err)
+			m.repo.AssertExpectations(t)
+			m.publisher.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// test data
+			name := "test"
+			g := guestsDef.Guest{Name: name, TableID: 1, Accompanying: 2, CheckedOut: 1}
+			tbl := tablesDef.Table{ID: 1, Capacity: 5, EmptySeats: 8}
+
+			//	mocks
+			m.repo.On("CheckOut", name, now).Return(g, nil).Once()
+			m.index.On("Put", g).Once()
+			m.tableService.On("GetByID", g.TableID).Return(tbl, nil).Once()
+			m.publisher.On(
+				"Publish", notification(
+					notificationsDef.Notification{
+						Type:         notificationsDef.GuestCheckedOut,
+						Guest:        name,
+						Accompanying: g.Accompanying,
+						TableID:      tbl.ID,
+						Capacity:     tbl.Capacity,
+						EmptySeats:   tbl.EmptySeats,
+					},
+				),
+			).Once()
+
+			//	method call
+			err := service.CheckOut(name)
+
+			//	assert
+			assert.NoError(t, err)
+			m.repo.AssertExpectations(t)
+			m.tableService.AssertExpectations(t)
+			m.publisher.AssertExpectations(t)
 		},
 	)
 }
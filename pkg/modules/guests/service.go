@@ -2,62 +2,263 @@ package guests
 
 import (
 	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/audit"
+	"github.com/getground/tech-tasks/backend/definitions/clock"
+	"github.com/getground/tech-tasks/backend/definitions/etag"
+	"github.com/getground/tech-tasks/backend/definitions/events"
 	"github.com/getground/tech-tasks/backend/definitions/guests"
+	"github.com/getground/tech-tasks/backend/definitions/invitations"
+	"github.com/getground/tech-tasks/backend/definitions/notifications"
 	"github.com/getground/tech-tasks/backend/definitions/tables"
+	"github.com/getground/tech-tasks/backend/definitions/venue"
+	"github.com/getground/tech-tasks/backend/definitions/waitlist"
+	log "github.com/sirupsen/logrus"
+	"time"
 )
 
 type Service struct {
-	repository guests.Repository
-	tableSvc   tables.Service
+	repository    guests.Repository
+	tableSvc      tables.Service
+	invitationSvc invitations.Service
+	publisher     notifications.Publisher
+	indexes       guests.Indexes
+	index         guests.Index
+	promoter      waitlist.Promoter
+	gate          events.Gate
+	venueSvc      venue.Service
+	clock         clock.Clock
+	loc           *time.Location
+	event         uint
+	actor         string
 }
 
-func NewService(repository guests.Repository, tableSvc tables.Service) Service {
-	return Service{repository: repository, tableSvc: tableSvc}
+func NewService(
+	repository guests.Repository, tableSvc tables.Service, invitationSvc invitations.Service,
+	publisher notifications.Publisher, indexes guests.Indexes, promoter waitlist.Promoter, gate events.Gate,
+	venueSvc venue.Service,
+) Service {
+	return Service{
+		repository:    repository,
+		tableSvc:      tableSvc,
+		invitationSvc: invitationSvc,
+		publisher:     publisher,
+		indexes:       indexes,
+		index:         indexes.ForEvent(0),
+		promoter:      promoter,
+		gate:          gate,
+		venueSvc:      venueSvc,
+		clock:         clock.UTC{},
+		loc:           time.UTC,
+		actor:         audit.SystemActor,
+	}
+}
+
+// WithClock returns the service telling the time with c, the arrival times included.
+func (s Service) WithClock(c clock.Clock) Service {
+	s.clock = c
+	return s
+}
+
+// WithLocation returns the service answering the arrival times in the timezone of the venue.
+func (s Service) WithLocation(loc *time.Location) Service {
+	s.loc = loc
+	return s
+}
+
+func (s Service) ForEvent(event uint) guests.Service {
+	return s.forEvent(event)
+}
+
+func (s Service) ForActor(actor string) guests.Service {
+	s.repository = s.repository.ForActor(actor)
+	s.actor = actor
+	return s
 }
 
+// forEvent scopes the service and the services it calls to the event.
+func (s Service) forEvent(event uint) Service {
+	s.repository = s.repository.ForEvent(event)
+	s.tableSvc = s.tableSvc.ForEvent(event)
+	s.invitationSvc = s.invitationSvc.ForEvent(event)
+	s.venueSvc = s.venueSvc.ForEvent(event)
+	s.index = s.indexes.ForEvent(event)
+	s.event = event
+	return s
+}
+
+// Create invites a guest, the guest list is only edited while the event is planned.
 func (s Service) Create(req guests.CreateRequest) (res guests.CreateResponse, err error) {
+	if err = s.gate.Allow(s.event, events.OpEditGuestList); err != nil {
+		return
+	}
+	_, err = guests.Guest{}.WithCompanions(req.Accompanying, mapCompanionsFromDTO(req.Companions))
+	if err != nil {
+		return
+	}
 	t, err := s.tableSvc.GetByID(req.Table)
 	if err != nil {
 		return
 	}
 
 	// validate capacity, can be moved to validator
-	newTableCapacity := t.Capacity - req.Accompanying - 1
-	if newTableCapacity < 0 {
-		err = errors.New("table have no capacity for accompanying")
+	// the seats are reserved once the guest accepts, a party that doesn't fit the table isn't invited
+	if t.Capacity-req.Accompanying-1 < 0 {
+		err = guests.ErrNoCapacity
 		return
 	}
 
-	err = s.repository.Create(req, newTableCapacity)
+	err = s.repository.Create(req, s.clock.Now())
 	if err != nil {
 		return
 	}
 	res.Name = req.Name
+	s.index.Put(
+		guests.Guest{
+			Name:         req.Name,
+			TableID:      req.Table,
+			Accompanying: req.Accompanying,
+			RSVP:         guests.RSVPInvited,
+			EventID:      s.event,
+		},
+	)
+
+	// the guest is on the list already, a missing invitation can be issued again with Reinvite
+	res.Invitation, err = s.invitationSvc.Issue(req.Name)
+	if err != nil {
+		log.Error(err)
+		err = nil
+	}
+
+	s.publish(notifications.GuestInvited, req.Name, req.Accompanying, t)
 	return
 }
 
-func (s Service) GetGuestList() (list guests.ListDTO, err error) {
-	res, err := s.repository.GetGuestList(false)
+func (s Service) GetGuestList(req guests.ListRequest) (list guests.ListDTO, err error) {
+	page, err := s.repository.ListPage(req)
 	if err != nil {
 		return
 	}
-	list = mapGuestsListToDTO(res)
+	list = mapGuestsListToDTO(page)
 	return
 }
 
-func (s Service) GetGuests() (list guests.DTO, err error) {
-	res, err := s.repository.GetGuestList(true)
+// GetGuests lists the guests that arrived, including the ones that left already unless the request filters them out.
+func (s Service) GetGuests(req guests.ListRequest) (list guests.DTO, err error) {
+	arrived := true
+	req.Arrived = &arrived
+	page, err := s.repository.ListPage(req)
 	if err != nil {
 		return
 	}
-	list = mapGuestsToDTO(res)
+	list = mapGuestsToDTO(page, s.loc)
 	return
 }
 
-func (s Service) CheckIn(req guests.CheckInRequest) (res guests.CheckInResponse, err error) {
+// Get returns the guest whether it arrived or not, unlike GetByName.
+func (s Service) Get(name string) (res guests.GuestListDTO, err error) {
+	list, err := s.repository.List(guests.Filter{Name: name})
+	if err != nil {
+		return
+	}
+	if len(list) == 0 {
+		err = guests.ErrNotInvited
+		return
+	}
+	res = mapGuestListToDTO(list[0])
+	return
+}
+
+func (s Service) List(filter guests.Filter) ([]guests.Guest, error) {
+	return s.repository.List(filter)
+}
+
+// Search seeds the index from the repository on the first search, the writes of the service keep it current after.
+func (s Service) Search(req guests.SearchRequest) (res guests.SearchDTO, err error) {
+	err = s.index.Seed(
+		func() ([]guests.Guest, error) {
+			return s.repository.List(guests.Filter{})
+		},
+	)
+	if err != nil {
+		return
+	}
+	res = mapMatchesToDTO(s.index.Search(req.Query, req.Limit))
+	return
+}
+
+// Respond saves the answer of a guest that didn't arrive yet, accepting reserves the seats of the party at the table,
+// declining or turning tentative gives back the seats reserved before. The guest is looked up in the event of the
+// invitation.
+func (s Service) Respond(req guests.RSVPRequest) (res guests.RSVPResponse, err error) {
+	inv, err := s.invitationSvc.Verify(req.Token)
+	if err != nil {
+		return
+	}
+	s = s.forEvent(inv.EventID)
+	if err = s.gate.Allow(s.event, events.OpRSVP); err != nil {
+		return
+	}
+
+	g, err := s.repository.GetByName(inv.GuestName)
+	if err != nil {
+		err = guests.ErrNotInvited
+		return
+	}
+
+	t, err := s.tableSvc.GetByID(g.TableID)
+	if err != nil {
+		return
+	}
+
+	answered := g
+	answered.RSVP = req.Response
+	if req.Response != guests.RSVPDeclined {
+		answered, err = answered.WithCompanions(req.Accompanying, mapCompanionsFromDTO(req.Companions))
+		if err != nil {
+			return
+		}
+	}
+	capacity := t.Capacity - (answered.ReservedSeats() - g.ReservedSeats())
+	if capacity < 0 {
+		err = guests.ErrNoCapacity
+		return
+	}
+
+	left := t
+	left.Capacity = capacity
+	err = s.repository.Respond(answered, left, s.clock.Now())
+	if err != nil {
+		return
+	}
+	s.index.Put(answered)
+
+	res = guests.RSVPResponse{
+		Name:         g.Name,
+		Response:     answered.RSVP,
+		Accompanying: answered.Accompanying,
+		Companions:   mapCompanionsToDTO(answered.Companions),
+	}
+	t.Capacity = capacity
+	s.publish(notifications.GuestResponded, g.Name, answered.Accompanying, t)
+	if answered.ReservedSeats() < g.ReservedSeats() {
+		s.promoter.Promote(s.event, s.actor, t.ID)
+	}
+	return
+}
+
+// EditCompanions replaces the companions of a guest that didn't arrive yet, the party becomes as large as the
+// companions named. The seats reserved by an accepted guest follow the party, the seats given back go to the waitlist.
+func (s Service) EditCompanions(req guests.CompanionsRequest) (res guests.GuestListDTO, err error) {
+	if err = s.gate.Allow(s.event, events.OpEditGuestList); err != nil {
+		return
+	}
 	g, err := s.repository.GetByName(req.Name)
 	if err != nil {
-		err = errors.New("guest not invited or already checked in")
+		err = guests.ErrNotInvited
+		return
+	}
+	if req.Version != 0 && req.Version != g.Version {
+		err = etag.ErrStale
 		return
 	}
 
@@ -66,20 +267,250 @@ func (s Service) CheckIn(req guests.CheckInRequest) (res guests.CheckInResponse,
 		return
 	}
 
-	if req.Accompanying-g.Accompanying-t.Capacity > 0 {
-		err = errors.New("extra accompanying than expected")
+	edited := g
+	edited.Accompanying = int64(len(req.Companions))
+	edited.Companions = mapCompanionsFromDTO(req.Companions)
+	capacity := t.Capacity - (edited.ReservedSeats() - g.ReservedSeats())
+	if capacity < 0 {
+		err = guests.ErrNoCapacity
 		return
 	}
 
-	err = s.repository.CheckIn(req, g, t)
+	left := t
+	left.Capacity = capacity
+	err = s.repository.EditCompanions(edited, left, s.clock.Now())
 	if err != nil {
 		return
 	}
+	s.index.Put(edited)
+
+	edited.Version++
+	res = mapGuestListToDTO(edited)
+	if edited.ReservedSeats() < g.ReservedSeats() {
+		s.promoter.Promote(s.event, s.actor, t.ID)
+	}
+	return
+}
+
+// EditProfile replaces the contact details and the needs of a guest whether it arrived or not, the catering and the
+// facilities keep them current during the event too.
+func (s Service) EditProfile(req guests.ProfileRequest) (res guests.GuestListDTO, err error) {
+	list, err := s.repository.List(guests.Filter{Name: req.Name})
+	if err != nil {
+		return
+	}
+	if len(list) == 0 {
+		err = guests.ErrNotInvited
+		return
+	}
+	g := list[0]
+	if req.Version != 0 && req.Version != g.Version {
+		err = etag.ErrStale
+		return
+	}
+
+	g.Profile = mapProfileFromDTO(req.ProfileDTO)
+	err = s.repository.EditProfile(g, s.clock.Now())
+	if err != nil {
+		return
+	}
+	s.index.Put(g)
+
+	g.Version++
+	res = mapGuestListToDTO(g)
+	return
+}
+
+func (s Service) RSVPCounts(req guests.RSVPCountsRequest) (res guests.RSVPCountsDTO, err error) {
+	counts, err := s.repository.CountRSVP(req.Table)
+	if err != nil {
+		return
+	}
+	res = mapRSVPCountsToDTO(counts)
+	return
+}
+
+func (s Service) Catering(req guests.CateringRequest) (res guests.CateringDTO, err error) {
+	counts, err := s.repository.CountDiets(req.Table)
+	if err != nil {
+		return
+	}
+	res = mapCateringToDTO(counts)
+	return
+}
+
+// CheckIn lets an invited guest in once the doors of the event are open.
+func (s Service) CheckIn(req guests.CheckInRequest) (res guests.CheckInResponse, err error) {
+	if err = s.gate.Allow(s.event, events.OpCheckIn); err != nil {
+		return
+	}
+	g, err := s.repository.GetByName(req.Name)
+	if err != nil {
+		err = guests.ErrNotInvited
+		return
+	}
+	// the check in is conditioned on the version the client read with If-Match
+	if req.Version != 0 && req.Version != g.Version {
+		err = etag.ErrStale
+		return
+	}
+	// a party arriving with another size than the companions it named is named again at the door
+	checkedIn, err := g.WithCompanions(req.Accompanying, mapCompanionsFromDTO(req.Companions))
+	if err != nil {
+		return
+	}
+
+	t, err := s.tableSvc.GetByID(g.TableID)
+	if err != nil {
+		return
+	}
+
+	// the seats reserved when the guest accepted are free for the party
+	if req.Accompanying+1-g.ReservedSeats() > t.Capacity {
+		err = guests.ErrExtraAccompanying
+		return
+	}
+	// the whole party counts towards the headcount of the venue on top of the seats of the table, the repository
+	// enforces the venue limit
+	arrived := s.clock.Now()
+	err = s.repository.CheckIn(req, g, t, arrived)
+	if err != nil {
+		return
+	}
+	s.venueSvc.Track(req.Accompanying + 1)
 
 	res.Name = req.Name
+	checkedIn.TimeArrived = &arrived
+	s.index.Put(checkedIn)
+
+	t.Capacity -= req.Accompanying + 1 - g.ReservedSeats()
+	t.EmptySeats -= req.Accompanying + 1
+	s.publish(notifications.GuestCheckedIn, req.Name, req.Accompanying, t)
+	return
+}
+
+// Scan checks in the guest of an invitation token in the event of the invitation, the token can't be scanned again
+// once the guest is in.
+func (s Service) Scan(req guests.ScanRequest) (res guests.CheckInResponse, err error) {
+	inv, err := s.invitationSvc.Verify(req.Token)
+	if err != nil {
+		return
+	}
+
+	res, err = s.forEvent(inv.EventID).CheckIn(
+		guests.CheckInRequest{Name: inv.GuestName, Accompanying: req.Accompanying, Companions: req.Companions},
+	)
+	if err != nil {
+		return
+	}
+
+	// the guest can't check in twice anyway, failing to mark the token doesn't turn the guest away
+	if useErr := s.invitationSvc.Use(inv.ID); useErr != nil {
+		log.Error(useErr)
+	}
+	return
+}
+
+// WalkIn checks in a guest that isn't on the guest list at a table with enough empty seats.
+func (s Service) WalkIn(req guests.WalkInRequest) (res guests.WalkInResponse, err error) {
+	if err = s.gate.Allow(s.event, events.OpCheckIn); err != nil {
+		return
+	}
+	_, err = guests.Guest{}.WithCompanions(req.Accompanying, mapCompanionsFromDTO(req.Companions))
+	if err != nil {
+		return
+	}
+	g, t, err := s.repository.WalkIn(req, s.clock.Now())
+	if err != nil {
+		return
+	}
+	s.venueSvc.Track(req.Accompanying + 1)
+	s.index.Put(g)
+
+	res = guests.WalkInResponse{Name: g.Name, Table: t.ID}
+	s.publish(notifications.GuestCheckedIn, g.Name, g.Accompanying, t)
+	return
+}
+
+// Reinvite revokes the invitations of a guest that didn't arrive yet and issues a new one.
+func (s Service) Reinvite(name string) (res invitations.InvitationResponse, err error) {
+	if err = s.gate.Allow(s.event, events.OpEditGuestList); err != nil {
+		return
+	}
+	_, err = s.repository.GetByName(name)
+	if err != nil {
+		err = guests.ErrNotInvited
+		return
+	}
+
+	err = s.invitationSvc.Revoke(name)
+	if err != nil && !errors.Is(err, invitations.ErrNotFound) {
+		return
+	}
+	res.Token, err = s.invitationSvc.Issue(name)
+	return
+}
+
+// Uninvite removes a guest that didn't arrive yet from the guest list, the seats it reserved go to the waitlist.
+func (s Service) Uninvite(name string) (err error) {
+	if err = s.gate.Allow(s.event, events.OpEditGuestList); err != nil {
+		return
+	}
+	g, err := s.repository.GetByName(name)
+	if err != nil {
+		return guests.ErrNotInvited
+	}
+
+	t, err := s.tableSvc.GetByID(g.TableID)
+	if err != nil {
+		return
+	}
+
+	t.Capacity += g.ReservedSeats()
+	err = s.repository.Delete(g, t, s.clock.Now())
+	if err != nil {
+		return
+	}
+	s.index.Remove(name)
+
+	s.publish(notifications.GuestUninvited, g.Name, g.Accompanying, t)
+	if g.ReservedSeats() > 0 {
+		s.promoter.Promote(s.event, s.actor, t.ID)
+	}
 	return
 }
 
 func (s Service) CheckOut(name string) (err error) {
-	return s.repository.CheckOut(name)
+	if err = s.gate.Allow(s.event, events.OpCheckOut); err != nil {
+		return
+	}
+	g, err := s.repository.CheckOut(name, s.clock.Now())
+	if err != nil {
+		return
+	}
+	s.index.Put(g)
+
+	// the repository released the seats already, reload the table to publish its current state
+	t, err := s.tableSvc.GetByID(g.TableID)
+	if err != nil {
+		log.Error(err)
+		t = tables.Table{ID: g.TableID}
+	}
+	s.publish(notifications.GuestCheckedOut, g.Name, g.Accompanying, t)
+	return nil
+}
+
+func (s Service) publish(typ notifications.Type, name string, accompanying int64, t tables.Table) {
+	s.publisher.Publish(
+		notifications.Notification{
+			Type:         typ,
+			EventID:      s.event,
+			OccurredAt:   s.clock.Now(),
+			Guest:        name,
+			Accompanying: accompanying,
+			TableID:      t.ID,
+			Capacity:     t.Capacity,
+			EmptySeats:   t.EmptySeats,
+		},
+	)
 }
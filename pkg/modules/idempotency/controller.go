@@ -0,0 +1,147 @@
+package idempotency
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/idempotency"
+	"github.com/getground/tech-tasks/backend/definitions/ratelimit"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+)
+
+type Controller struct {
+	service idempotency.Service
+}
+
+func NewController(service idempotency.Service) Controller {
+	return Controller{
+		service: service,
+	}
+}
+
+// Keys replays the response of the first POST, PUT or DELETE request made with an Idempotency-Key header to the
+// retries of the request, the requests without the header run as usual. A key is scoped to the client and the route
+// of the request. The responses with a 5xx status aren't stored so the retries run the request again.
+func (ctrl Controller) Keys(c *gin.Context) {
+	key := c.GetHeader(idempotency.Header)
+	if key == "" || !mutating(c.Request.Method) {
+		c.Next()
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		log.Error(err)
+		c.AbortWithStatusJSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	scope := scope(c)
+	replay, err := ctrl.service.Begin(scope, key, fingerprint(c.Request, body))
+	if err != nil {
+		log.Error(err)
+		c.AbortWithStatusJSON(
+			errorStatus(err), gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+	if replay != nil {
+		for name, values := range replay.Header {
+			for _, v := range values {
+				c.Writer.Header().Add(name, v)
+			}
+		}
+		c.Header(idempotency.ReplayedHeader, "true")
+		c.Data(replay.Status, replay.Header.Get("Content-Type"), replay.Body)
+		c.Abort()
+		return
+	}
+
+	rec := &recorder{ResponseWriter: c.Writer}
+	c.Writer = rec
+	completed := false
+	// a panic of the handler releases the key too
+	defer func() {
+		if !completed {
+			if err := ctrl.service.Release(scope, key); err != nil {
+				log.Error(err)
+			}
+		}
+	}()
+
+	c.Next()
+
+	if rec.Status() >= http.StatusInternalServerError {
+		return
+	}
+	res := idempotency.Response{Status: rec.Status(), Header: http.Header{}, Body: rec.body.Bytes()}
+	for _, name := range idempotency.StoredHeaders {
+		for _, v := range rec.Header().Values(name) {
+			res.Header.Add(name, v)
+		}
+	}
+	err = ctrl.service.Complete(scope, key, res)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	completed = true
+}
+
+func mutating(method string) bool {
+	return method == http.MethodPost || method == http.MethodPut || method == http.MethodDelete
+}
+
+// scope tells the client and the route of the request apart, e.g. "key:organiser POST /events/:event/tables".
+func scope(c *gin.Context) string {
+	client := c.GetString(ratelimit.ContextKey)
+	if client == "" {
+		client = "ip:" + c.ClientIP()
+	}
+	return client + " " + c.Request.Method + " " + c.FullPath()
+}
+
+// fingerprint identifies the request, a key sent again with another method, url or body is a reused key.
+func fingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// recorder keeps a copy of the response body written to the client.
+type recorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *recorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, idempotency.ErrInvalidKey):
+		return http.StatusBadRequest
+	case errors.Is(err, idempotency.ErrInProgress), errors.Is(err, idempotency.ErrKeyReused):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
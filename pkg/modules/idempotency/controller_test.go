@@ -0,0 +1,221 @@
+package idempotency_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	idempotencyDef "github.com/getground/tech-tasks/backend/definitions/idempotency"
+	ratelimitDef "github.com/getground/tech-tasks/backend/definitions/ratelimit"
+	idempotencyMocks "github.com/getground/tech-tasks/backend/mocks/definitions/idempotency"
+	"github.com/getground/tech-tasks/backend/pkg/modules/idempotency"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// setupController serves a route creating a table behind the middleware, calls counts the requests reaching it.
+func setupController(calls *int) (*gin.Engine, *idempotencyMocks.Service) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(gin.Recovery())
+
+	service := new(idempotencyMocks.Service)
+	r.Use(
+		func(c *gin.Context) {
+			c.Set(ratelimitDef.ContextKey, "key:organiser")
+		},
+		idempotency.NewController(service).Keys,
+	)
+	r.POST(
+		"/tables", func(c *gin.Context) {
+			*calls++
+			body, _ := io.ReadAll(c.Request.Body)
+			c.Header("Location", "/tables/1")
+			c.Header("X-Request-Id", "abc")
+			switch string(body) {
+			case "fail":
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+			case "panic":
+				panic("handler panicked")
+			default:
+				c.JSON(http.StatusCreated, gin.H{"id": 1})
+			}
+		},
+	)
+	r.GET(
+		"/tables", func(c *gin.Context) {
+			*calls++
+			c.JSON(http.StatusOK, gin.H{"tables": []int{}})
+		},
+	)
+	return r, service
+}
+
+// scope is the scope of the keys sent to POST /tables by the client of the tests.
+const scope = "key:organiser POST /tables"
+
+func fingerprint(method, url, body string) string {
+	h := sha256.Sum256([]byte(method + " " + url + "\n" + body))
+	return hex.EncodeToString(h[:])
+}
+
+func request(method, url, key, body string) *http.Request {
+	req, _ := http.NewRequest(method, url, strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(idempotencyDef.Header, key)
+	}
+	return req
+}
+
+func TestController_Keys(t *testing.T) {
+	t.Run(
+		"without key", func(t *testing.T) {
+			// setup
+			var calls int
+			r, service := setupController(&calls)
+			w := httptest.NewRecorder()
+
+			//	method call
+			r.ServeHTTP(w, request(http.MethodPost, "/tables", "", `{"capacity":10}`))
+
+			//	assert
+			assert.Equal(t, http.StatusCreated, w.Code)
+			assert.Equal(t, 1, calls)
+			service.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"reads are not keyed", func(t *testing.T) {
+			// setup
+			var calls int
+			r, service := setupController(&calls)
+			w := httptest.NewRecorder()
+
+			//	method call
+			r.ServeHTTP(w, request(http.MethodGet, "/tables", "key", ""))
+
+			//	assert
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, 1, calls)
+			service.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"first request", func(t *testing.T) {
+			// setup
+			var calls int
+			r, service := setupController(&calls)
+			w := httptest.NewRecorder()
+
+			//	mocks
+			service.On("Begin", scope, "key", fingerprint(http.MethodPost, "/tables", "{}")).Return(nil, nil).Once()
+			// the headers not replayed aren't stored
+			res := idempotencyDef.Response{
+				Status: http.StatusCreated,
+				Header: http.Header{"Content-Type": {"application/json; charset=utf-8"}, "Location": {"/tables/1"}},
+				Body:   []byte(`{"id":1}`),
+			}
+			service.On("Complete", scope, "key", res).Return(nil).Once()
+
+			//	method call
+			r.ServeHTTP(w, request(http.MethodPost, "/tables", "key", "{}"))
+
+			//	assert
+			assert.Equal(t, http.StatusCreated, w.Code)
+			assert.Equal(t, `{"id":1}`, w.Body.String())
+			assert.Empty(t, w.Header().Get(idempotencyDef.ReplayedHeader))
+			assert.Equal(t, 1, calls)
+			service.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"retry replays the response", func(t *testing.T) {
+			// setup
+			var calls int
+			r, service := setupController(&calls)
+			w := httptest.NewRecorder()
+			res := &idempotencyDef.Response{
+				Status: http.StatusCreated,
+				Header: http.Header{"Content-Type": {"application/json"}, "Location": {"/tables/1"}},
+				Body:   []byte(`{"id":1}`),
+			}
+
+			//	mocks
+			service.On("Begin", scope, "key", fingerprint(http.MethodPost, "/tables", "{}")).Return(res, nil).Once()
+
+			//	method call
+			r.ServeHTTP(w, request(http.MethodPost, "/tables", "key", "{}"))
+
+			//	assert
+			assert.Equal(t, http.StatusCreated, w.Code)
+			assert.Equal(t, `{"id":1}`, w.Body.String())
+			assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+			assert.Equal(t, "/tables/1", w.Header().Get("Location"))
+			assert.Equal(t, "true", w.Header().Get(idempotencyDef.ReplayedHeader))
+			assert.Equal(t, 0, calls)
+			service.AssertExpectations(t)
+		},
+	)
+
+	cases := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{name: "invalid key", err: idempotencyDef.ErrInvalidKey, code: http.StatusBadRequest},
+		{name: "in progress", err: idempotencyDef.ErrInProgress, code: http.StatusConflict},
+		{name: "other request", err: idempotencyDef.ErrKeyReused, code: http.StatusConflict},
+	}
+	for _, tc := range cases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				// setup
+				var calls int
+				r, service := setupController(&calls)
+				w := httptest.NewRecorder()
+
+				//	mocks
+				service.On("Begin", scope, "key", mock.Anything).Return(nil, tc.err).Once()
+
+				//	method call
+				r.ServeHTTP(w, request(http.MethodPost, "/tables", "key", "{}"))
+
+				//	assert
+				assert.Equal(t, tc.code, w.Code)
+				assert.JSONEq(t, `{"error":"`+tc.err.Error()+`"}`, w.Body.String())
+				assert.Equal(t, 0, calls)
+				service.AssertExpectations(t)
+			},
+		)
+	}
+
+	for _, body := range []string{"fail", "panic"} {
+		t.Run(
+			body+" releases the key", func(t *testing.T) {
+				// setup
+				var calls int
+				r, service := setupController(&calls)
+				w := httptest.NewRecorder()
+
+				//	mocks
+				service.On("Begin", scope, "key", mock.Anything).Return(nil, nil).Once()
+				service.On("Release", scope, "key").Return(nil).Once()
+
+				//	method call
+				r.ServeHTTP(w, request(http.MethodPost, "/tables", "key", body))
+
+				//	assert
+				assert.Equal(t, http.StatusInternalServerError, w.Code)
+				assert.Equal(t, 1, calls)
+				service.AssertExpectations(t)
+			},
+		)
+	}
+}
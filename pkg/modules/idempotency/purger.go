@@ -0,0 +1,51 @@
+package idempotency
+
+import (
+	"context"
+	"github.com/getground/tech-tasks/backend/definitions/clock"
+	"github.com/getground/tech-tasks/backend/definitions/idempotency"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+// Purger removes the expired keys every interval, the keys never sent again would stay in the table otherwise.
+type Purger struct {
+	repository idempotency.Repository
+	interval   time.Duration
+	clock      clock.Clock
+}
+
+func NewPurger(repository idempotency.Repository, interval time.Duration) Purger {
+	return Purger{
+		repository: repository,
+		interval:   interval,
+		clock:      clock.UTC{},
+	}
+}
+
+func (p Purger) WithClock(c clock.Clock) Purger {
+	p.clock = c
+	return p
+}
+
+// Run purges the expired keys every interval until ctx is done.
+func (p Purger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.Purge(); err != nil {
+			log.Error(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Purge removes the keys expired by now and returns how many were removed.
+func (p Purger) Purge() (int64, error) {
+	return p.repository.Purge(p.clock.Now())
+}
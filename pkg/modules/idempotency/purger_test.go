@@ -0,0 +1,27 @@
+package idempotency_test
+
+import (
+	"github.com/getground/tech-tasks/backend/definitions/clock"
+	idempotencyMocks "github.com/getground/tech-tasks/backend/mocks/definitions/idempotency"
+	"github.com/getground/tech-tasks/backend/pkg/modules/idempotency"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestPurger_Purge(t *testing.T) {
+	// setup
+	repo := idempotencyMocks.NewRepository(t)
+	now := time.Date(2023, 6, 21, 18, 0, 0, 0, time.UTC)
+	purger := idempotency.NewPurger(repo, time.Hour).WithClock(clock.Fixed(now))
+
+	//	mocks
+	repo.On("Purge", now).Return(int64(3), nil).Once()
+
+	//	method call
+	n, err := purger.Purge()
+
+	//	assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+}
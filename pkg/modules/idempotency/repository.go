@@ -0,0 +1,64 @@
+package idempotency
+
+import (
+	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/idempotency"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// Repository stores the keys of every event, the url of a request tells its event.
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return Repository{
+		db: db,
+	}
+}
+
+func (r Repository) Get(key string) (rec idempotency.Record, err error) {
+	err = r.db.Where("idempotency_key = ?", key).First(&rec).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = idempotency.ErrNotFound
+	}
+	return
+}
+
+func (r Repository) Reserve(rec idempotency.Record, now time.Time) error {
+	return r.db.Transaction(
+		func(tx *gorm.DB) error {
+			err := tx.Where("idempotency_key = ? AND expires_at <= ?", rec.Key, now).Delete(&idempotency.Record{}).Error
+			if err != nil {
+				return err
+			}
+			res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rec)
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return idempotency.ErrInProgress
+			}
+			return nil
+		},
+	)
+}
+
+func (r Repository) Complete(rec idempotency.Record) error {
+	return r.db.Model(&idempotency.Record{}).
+		Where("idempotency_key = ?", rec.Key).
+		Select("status", "headers", "body").
+		Updates(idempotency.Record{Status: rec.Status, Headers: rec.Headers, Body: rec.Body}).
+		Error
+}
+
+func (r Repository) Release(key string) error {
+	return r.db.Where("idempotency_key = ?", key).Delete(&idempotency.Record{}).Error
+}
+
+func (r Repository) Purge(now time.Time) (int64, error) {
+	res := r.db.Where("expires_at <= ?", now).Delete(&idempotency.Record{})
+	return res.RowsAffected, res.Error
+}
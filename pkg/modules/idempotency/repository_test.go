@@ -0,0 +1,201 @@
+package idempotency_test
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	idempotencyDef "github.com/getground/tech-tasks/backend/definitions/idempotency"
+	"github.com/getground/tech-tasks/backend/pkg/database"
+	"github.com/getground/tech-tasks/backend/pkg/modules/idempotency"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type repoMocks struct {
+	db      *sql.DB
+	sqlMock sqlmock.Sqlmock
+}
+
+func setupIntegrationRepo(t *testing.T) (idempotencyDef.Repository, repoMocks) {
+	db, m, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	msc := mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true})
+	gDB, err := database.NewDatabaseForTests(msc)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating grom database connection", err)
+	}
+	r := idempotency.NewRepository(gDB)
+	return r, repoMocks{
+		db:      db,
+		sqlMock: m,
+	}
+}
+
+func TestRepository_Get(t *testing.T) {
+	q := "SELECT * FROM `idempotency_keys` WHERE idempotency_key = ? ORDER BY `idempotency_keys`.`idempotency_key` " +
+		"LIMIT 1"
+
+	t.Run(
+		"not found", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+				WithArgs("key").
+				WillReturnRows(sqlmock.NewRows([]string{"idempotency_key"}))
+
+			//	method call
+			_, err := repo.Get("key")
+
+			//	assert
+			assert.ErrorIs(t, err, idempotencyDef.ErrNotFound)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+			expires := time.Date(2023, 6, 21, 18, 0, 0, 0, time.UTC)
+
+			//	mocks
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+				WithArgs("key").
+				WillReturnRows(
+					sqlmock.NewRows([]string{"idempotency_key", "fingerprint", "status", "body", "expires_at"}).
+						AddRow("key", "abc", 200, `{"id":1}`, expires),
+				)
+
+			//	method call
+			res, err := repo.Get("key")
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(
+				t, idempotencyDef.Record{Key: "key", Fingerprint: "abc", Status: 200, Body: `{"id":1}`, ExpiresAt: expires},
+				res,
+			)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
+
+func TestRepository_Reserve(t *testing.T) {
+	// only the key reserved is removed when it expired, the others are left to the purger
+	expired := "DELETE FROM `idempotency_keys` WHERE idempotency_key = ? AND expires_at <= ?"
+	reserve := "INSERT INTO `idempotency_keys` (`idempotency_key`,`fingerprint`,`status`,`headers`,`body`," +
+		"`created_at`,`expires_at`) VALUES (?,?,?,?,?,?,?) ON DUPLICATE KEY UPDATE `idempotency_key`=`idempotency_key`"
+	now := time.Date(2023, 6, 21, 18, 0, 0, 0, time.UTC)
+	rec := idempotencyDef.Record{Key: "key", Fingerprint: "abc", CreatedAt: now, ExpiresAt: now.Add(time.Hour)}
+
+	cases := []struct {
+		name     string
+		affected int64
+		err      error
+	}{
+		{name: "already reserved", affected: 0, err: idempotencyDef.ErrInProgress},
+		{name: "success", affected: 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				// setup
+				repo, m := setupIntegrationRepo(t)
+				defer m.db.Close()
+
+				//	mocks
+				m.sqlMock.ExpectBegin()
+				m.sqlMock.ExpectExec(regexp.QuoteMeta(expired)).
+					WithArgs("key", now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.sqlMock.ExpectExec(regexp.QuoteMeta(reserve)).
+					WithArgs("key", "abc", 0, "", "", now, now.Add(time.Hour)).
+					WillReturnResult(sqlmock.NewResult(0, tc.affected))
+				if tc.err != nil {
+					m.sqlMock.ExpectRollback()
+				} else {
+					m.sqlMock.ExpectCommit()
+				}
+
+				//	method call
+				err := repo.Reserve(rec, now)
+
+				//	assert
+				assert.Equal(t, tc.err, err)
+				assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+			},
+		)
+	}
+}
+
+func TestRepository_Complete(t *testing.T) {
+	// setup
+	repo, m := setupIntegrationRepo(t)
+	defer m.db.Close()
+
+	//	mocks
+	q := "UPDATE `idempotency_keys` SET `status`=?,`headers`=?,`body`=? WHERE idempotency_key = ?"
+	headers := `{"Content-Type":["application/json"]}`
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).
+		WithArgs(201, headers, "sealed", "key").
+		WillReturnError(errors.New("internal error"))
+	m.sqlMock.ExpectRollback()
+
+	//	method call
+	err := repo.Complete(idempotencyDef.Record{Key: "key", Status: 201, Headers: headers, Body: "sealed"})
+
+	//	assert
+	assert.Error(t, err)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestRepository_Release(t *testing.T) {
+	// setup
+	repo, m := setupIntegrationRepo(t)
+	defer m.db.Close()
+
+	//	mocks
+	q := "DELETE FROM `idempotency_keys` WHERE idempotency_key = ?"
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).WithArgs("key").WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectCommit()
+
+	//	method call
+	err := repo.Release("key")
+
+	//	assert
+	assert.NoError(t, err)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestRepository_Purge(t *testing.T) {
+	// setup
+	repo, m := setupIntegrationRepo(t)
+	defer m.db.Close()
+	now := time.Date(2023, 6, 21, 18, 0, 0, 0, time.UTC)
+
+	//	mocks
+	q := "DELETE FROM `idempotency_keys` WHERE expires_at <= ?"
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).WithArgs(now).WillReturnResult(sqlmock.NewResult(0, 2))
+	m.sqlMock.ExpectCommit()
+
+	//	method call
+	n, err := repo.Purge(now)
+
+	//	assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
@@ -0,0 +1,154 @@
+package idempotency
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/clock"
+	"github.com/getground/tech-tasks/backend/definitions/idempotency"
+	"net/http"
+	"time"
+)
+
+type Service struct {
+	repository idempotency.Repository
+	ttl        time.Duration
+	clock      clock.Clock
+}
+
+// NewService keeps the responses for ttl, a retry coming later runs the request again.
+func NewService(repository idempotency.Repository, ttl time.Duration) Service {
+	return Service{
+		repository: repository,
+		ttl:        ttl,
+		clock:      clock.UTC{},
+	}
+}
+
+// WithClock returns the service telling the time with c.
+func (s Service) WithClock(c clock.Clock) Service {
+	s.clock = c
+	return s
+}
+
+func (s Service) Begin(scope, key, fingerprint string) (*idempotency.Response, error) {
+	if key == "" || len(key) > idempotency.MaxKeyLength {
+		return nil, idempotency.ErrInvalidKey
+	}
+
+	now := s.clock.Now()
+	id := digest(scope, key)
+	rec, err := s.repository.Get(id)
+	switch {
+	case errors.Is(err, idempotency.ErrNotFound):
+	case err != nil:
+		return nil, err
+	case rec.ExpiresAt.After(now):
+		if rec.Fingerprint != fingerprint {
+			return nil, idempotency.ErrKeyReused
+		}
+		if !rec.Completed() {
+			return nil, idempotency.ErrInProgress
+		}
+		return mapRecordToResponse(rec, sealing(scope, key))
+	}
+
+	err = s.repository.Reserve(
+		idempotency.Record{Key: id, Fingerprint: fingerprint, CreatedAt: now, ExpiresAt: now.Add(s.ttl)}, now,
+	)
+	return nil, err
+}
+
+func (s Service) Complete(scope, key string, res idempotency.Response) error {
+	rec, err := mapResponseToRecord(res, sealing(scope, key))
+	if err != nil {
+		return err
+	}
+	rec.Key = digest(scope, key)
+	return s.repository.Complete(rec)
+}
+
+func (s Service) Release(scope, key string) error {
+	return s.repository.Release(digest(scope, key))
+}
+
+// digest identifies the key of the client on the route, the key can't be told from it.
+func digest(scope, key string) string {
+	sum := sha256.Sum256([]byte("key\n" + scope + "\n" + key))
+	return hex.EncodeToString(sum[:])
+}
+
+// sealing returns the AES key sealing the responses of the key of the client on the route, it can't be derived from
+// the digest so only the client holding the key can have the response opened.
+func sealing(scope, key string) []byte {
+	sum := sha256.Sum256([]byte("body\n" + scope + "\n" + key))
+	return sum[:]
+}
+
+func mapResponseToRecord(res idempotency.Response, secret []byte) (idempotency.Record, error) {
+	headers, err := json.Marshal(res.Header)
+	if err != nil {
+		return idempotency.Record{}, err
+	}
+	body, err := seal(secret, res.Body)
+	if err != nil {
+		return idempotency.Record{}, err
+	}
+	return idempotency.Record{Status: res.Status, Headers: string(headers), Body: body}, nil
+}
+
+func mapRecordToResponse(rec idempotency.Record, secret []byte) (*idempotency.Response, error) {
+	res := &idempotency.Response{Status: rec.Status, Header: http.Header{}}
+	if rec.Headers != "" {
+		if err := json.Unmarshal([]byte(rec.Headers), &res.Header); err != nil {
+			return nil, err
+		}
+	}
+	body, err := open(secret, rec.Body)
+	if err != nil {
+		return nil, err
+	}
+	res.Body = body
+	return res, nil
+}
+
+// seal encrypts the body with AES-GCM, the nonce comes first.
+func seal(secret, body []byte) (string, error) {
+	gcm, err := newGCM(secret)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, body, nil)), nil
+}
+
+func open(secret []byte, sealed string) ([]byte, error) {
+	b, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(secret)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) < gcm.NonceSize() {
+		return nil, errors.New("sealed idempotent response too short")
+	}
+	return gcm.Open(nil, b[:gcm.NonceSize()], b[gcm.NonceSize():], nil)
+}
+
+func newGCM(secret []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(secret)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,146 @@
+package idempotency_test
+
+import (
+	"errors"
+	idempotencyDef "github.com/getground/tech-tasks/backend/definitions/idempotency"
+	idempotencyMocks "github.com/getground/tech-tasks/backend/mocks/definitions/idempotency"
+	"github.com/getground/tech-tasks/backend/pkg/modules/idempotency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// stored completes the request of the key and returns the record the repository was given.
+func stored(t *testing.T, scope, key string, res idempotencyDef.Response) (rec idempotencyDef.Record) {
+	repo := idempotencyMocks.NewRepository(t)
+	repo.On("Complete", mock.Anything).Run(
+		func(args mock.Arguments) {
+			rec = args.Get(0).(idempotencyDef.Record)
+		},
+	).Return(nil).Once()
+	if err := idempotency.NewService(repo, time.Hour).Complete(scope, key, res); err != nil {
+		t.Fatalf("an error '%s' was not expected when completing the request", err)
+	}
+	return
+}
+
+func TestService_Begin(t *testing.T) {
+	later := time.Now().Add(time.Hour)
+	res := idempotencyDef.Response{
+		Status: 201,
+		Header: http.Header{"Content-Type": {"application/json"}, "Location": {"/tables/1"}},
+		Body:   []byte(`{"id":1,"secret":"s3cr3t"}`),
+	}
+	completed := stored(t, "scope", "key", res)
+	completed.Fingerprint, completed.ExpiresAt = "abc", later
+	running := idempotencyDef.Record{Key: completed.Key, Fingerprint: "abc", ExpiresAt: later}
+	expired := idempotencyDef.Record{
+		Key: completed.Key, Fingerprint: "other", Status: 201, ExpiresAt: time.Now().Add(-time.Hour),
+	}
+
+	cases := []struct {
+		name        string
+		key         string
+		record      idempotencyDef.Record
+		getErr      error
+		reserve     bool
+		reserveErr  error
+		expected    *idempotencyDef.Response
+		expectedErr error
+	}{
+		{name: "missing key", expectedErr: idempotencyDef.ErrInvalidKey},
+		{name: "key too long", key: strings.Repeat("k", 256), expectedErr: idempotencyDef.ErrInvalidKey},
+		{name: "error get", key: "key", getErr: errors.New("internal error"), expectedErr: errors.New("internal error")},
+		{name: "new key", key: "key", getErr: idempotencyDef.ErrNotFound, reserve: true},
+		{
+			name:        "reserved meanwhile",
+			key:         "key",
+			getErr:      idempotencyDef.ErrNotFound,
+			reserve:     true,
+			reserveErr:  idempotencyDef.ErrInProgress,
+			expectedErr: idempotencyDef.ErrInProgress,
+		},
+		{name: "expired key", key: "key", record: expired, reserve: true},
+		{name: "in progress", key: "key", record: running, expectedErr: idempotencyDef.ErrInProgress},
+		{
+			name:        "other request",
+			key:         "key",
+			record:      idempotencyDef.Record{Key: completed.Key, Fingerprint: "other", Status: 201, ExpiresAt: later},
+			expectedErr: idempotencyDef.ErrKeyReused,
+		},
+		{name: "replay", key: "key", record: completed, expected: &res},
+	}
+
+	for _, tc := range cases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				// setup
+				repo := idempotencyMocks.NewRepository(t)
+				srv := idempotency.NewService(repo, time.Hour)
+
+				//	mocks
+				if tc.key != "" && len(tc.key) <= idempotencyDef.MaxKeyLength {
+					repo.On("Get", completed.Key).Return(tc.record, tc.getErr).Once()
+				}
+				if tc.reserve {
+					repo.On(
+						"Reserve", mock.MatchedBy(
+							func(r idempotencyDef.Record) bool {
+								return r.Key == completed.Key && r.Fingerprint == "abc" && r.Status == 0 &&
+									r.ExpiresAt.Sub(r.CreatedAt) == time.Hour
+							},
+						), mock.Anything,
+					).Return(tc.reserveErr).Once()
+				}
+
+				//	method call
+				res, err := srv.Begin("scope", tc.key, "abc")
+
+				//	assert
+				assert.Equal(t, tc.expectedErr, err)
+				assert.Equal(t, tc.expected, res)
+			},
+		)
+	}
+}
+
+func TestService_Complete(t *testing.T) {
+	// setup
+	res := idempotencyDef.Response{
+		Status: 201,
+		Header: http.Header{"Content-Type": {"application/json"}},
+		Body:   []byte(`{"id":1,"secret":"s3cr3t"}`),
+	}
+
+	//	method call
+	rec := stored(t, "scope", "key", res)
+
+	//	assert
+	assert.Len(t, rec.Key, 64)
+	assert.NotContains(t, rec.Key, "key")
+	assert.Equal(t, 201, rec.Status)
+	assert.JSONEq(t, `{"Content-Type":["application/json"]}`, rec.Headers)
+	// the body is sealed with the key
+	assert.NotContains(t, rec.Body, "s3cr3t")
+	// the same key of another client or route is another key
+	assert.NotEqual(t, rec.Key, stored(t, "other scope", "key", res).Key)
+}
+
+func TestService_Release(t *testing.T) {
+	// setup
+	repo := idempotencyMocks.NewRepository(t)
+	srv := idempotency.NewService(repo, time.Hour)
+	key := stored(t, "scope", "key", idempotencyDef.Response{Status: 201}).Key
+
+	//	mocks
+	repo.On("Release", key).Return(nil).Once()
+
+	//	method call
+	err := srv.Release("scope", "key")
+
+	//	assert
+	assert.NoError(t, err)
+}
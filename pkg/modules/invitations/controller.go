@@ -0,0 +1,97 @@
+package invitations
+
+import (
+	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/events"
+	"github.com/getground/tech-tasks/backend/definitions/invitations"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+var contentTypes = map[invitations.Format]string{
+	invitations.FormatPNG: "image/png",
+	invitations.FormatSVG: "image/svg+xml",
+}
+
+type Controller struct {
+	handler Handler
+	service invitations.Service
+}
+
+func NewController(handler Handler, service invitations.Service) Controller {
+	return Controller{
+		handler: handler,
+		service: service,
+	}
+}
+
+func (ctrl Controller) QR(c *gin.Context) {
+	req, err := ctrl.handler.QR(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.service.QR(req)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			errorStatus(err), gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.Data(http.StatusOK, contentTypes[req.Format], res)
+}
+
+func (ctrl Controller) Revoke(c *gin.Context) {
+	name, err := ctrl.handler.Revoke(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	err = ctrl.scoped(c).Revoke(name)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			errorStatus(err), gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, http.NoBody)
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, invitations.ErrInvalidToken), errors.Is(err, invitations.ErrRevoked),
+		errors.Is(err, invitations.ErrUsed):
+		return http.StatusForbidden
+	case errors.Is(err, invitations.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, events.ErrNotAllowed):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// scoped returns the service of the event the request is scoped to.
+func (ctrl Controller) scoped(c *gin.Context) invitations.Service {
+	return ctrl.service.ForEvent(c.GetUint(events.ContextKey))
+}
@@ -0,0 +1,175 @@
+package invitations_test
+
+import (
+	"errors"
+	eventsDef "github.com/getground/tech-tasks/backend/definitions/events"
+	invitationsDef "github.com/getground/tech-tasks/backend/definitions/invitations"
+	invitationsMocks "github.com/getground/tech-tasks/backend/mocks/definitions/invitations"
+	"github.com/getground/tech-tasks/backend/pkg/modules/invitations"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func setupController() (*gin.Engine, *invitationsMocks.Service) {
+	r := gin.Default()
+	gin.SetMode(gin.TestMode)
+
+	service := new(invitationsMocks.Service)
+	// the requests are not nested under an event, they are scoped to the zero event
+	service.On("ForEvent", uint(0)).Return(service).Maybe()
+	ctrl := invitations.NewController(invitations.NewHandler(), service)
+	r.GET("/invitations/:token/qr", ctrl.QR)
+	r.DELETE("/guest_list/:name/invitation", ctrl.Revoke)
+
+	return r, service
+}
+
+func TestController_QR(t *testing.T) {
+	//	setup
+	r, service := setupController()
+
+	cases := []struct {
+		name        string
+		url         string
+		mock        func()
+		code        int
+		contentType string
+	}{
+		{
+			name: "invalid format",
+			url:  "/invitations/id.signature/qr?format=gif",
+			mock: func() {},
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "revoked",
+			url:  "/invitations/id.signature/qr",
+			mock: func() {
+				service.On("QR", invitationsDef.QRRequest{Token: "id.signature", Format: invitationsDef.FormatPNG}).
+					Return(nil, invitationsDef.ErrRevoked).
+					Once()
+			},
+			code: http.StatusForbidden,
+		},
+		{
+			name: "service error",
+			url:  "/invitations/id.signature/qr",
+			mock: func() {
+				service.On("QR", invitationsDef.QRRequest{Token: "id.signature", Format: invitationsDef.FormatPNG}).
+					Return(nil, errors.New("internal error")).
+					Once()
+			},
+			code: http.StatusInternalServerError,
+		},
+		{
+			name: "png",
+			url:  "/invitations/id.signature/qr?size=128",
+			mock: func() {
+				service.On(
+					"QR", invitationsDef.QRRequest{Token: "id.signature", Format: invitationsDef.FormatPNG, Size: 128},
+				).Return([]byte("png"), nil).Once()
+			},
+			code:        http.StatusOK,
+			contentType: "image/png",
+		},
+		{
+			name: "svg",
+			url:  "/invitations/id.signature/qr?format=svg",
+			mock: func() {
+				service.On("QR", invitationsDef.QRRequest{Token: "id.signature", Format: invitationsDef.FormatSVG}).
+					Return([]byte("<svg/>"), nil).
+					Once()
+			},
+			code:        http.StatusOK,
+			contentType: "image/svg+xml",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				// mocks
+				tc.mock()
+
+				//	request
+				req, err := http.NewRequest(http.MethodGet, tc.url, http.NoBody)
+				if err != nil {
+					t.Errorf("Error requesting test controller: %v\n", err)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, req)
+
+				// assert
+				assert.Equal(t, tc.code, rr.Code)
+				if tc.contentType != "" {
+					assert.Equal(t, tc.contentType, rr.Header().Get("Content-Type"))
+				}
+				service.AssertExpectations(t)
+			},
+		)
+	}
+}
+
+func TestController_Revoke(t *testing.T) {
+	//	setup
+	r, service := setupController()
+
+	t.Run(
+		"nothing to revoke", func(t *testing.T) {
+			// mocks
+			service.On("Revoke", "test").Return(invitationsDef.ErrNotFound).Once()
+
+			//	request
+			req, err := http.NewRequest(http.MethodDelete, "/guest_list/test/invitation", http.NoBody)
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			// assert
+			assert.Equal(t, http.StatusNotFound, rr.Code)
+			service.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"guest list closed", func(t *testing.T) {
+			// mocks
+			service.On("Revoke", "test").Return(eventsDef.ErrNotAllowed).Once()
+
+			//	request
+			req, err := http.NewRequest(http.MethodDelete, "/guest_list/test/invitation", http.NoBody)
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			// assert
+			assert.Equal(t, http.StatusConflict, rr.Code)
+			service.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// mocks
+			service.On("Revoke", "test").Return(nil).Once()
+
+			//	request
+			req, err := http.NewRequest(http.MethodDelete, "/guest_list/test/invitation", http.NoBody)
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			// assert
+			assert.Equal(t, http.StatusNoContent, rr.Code)
+			service.AssertExpectations(t)
+		},
+	)
+}
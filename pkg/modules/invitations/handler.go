@@ -0,0 +1,33 @@
+package invitations
+
+import (
+	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/invitations"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct{}
+
+func NewHandler() Handler {
+	return Handler{}
+}
+
+func (h Handler) QR(c *gin.Context) (req invitations.QRRequest, err error) {
+	err = c.ShouldBindUri(&req)
+	if err != nil {
+		return
+	}
+	err = c.ShouldBindQuery(&req)
+	if req.Format == "" {
+		req.Format = invitations.FormatPNG
+	}
+	return
+}
+
+func (h Handler) Revoke(c *gin.Context) (name string, err error) {
+	name = c.Param("name")
+	if name == "" {
+		err = errors.New("name is required")
+	}
+	return
+}
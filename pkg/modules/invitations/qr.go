@@ -0,0 +1,45 @@
+package invitations
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/getground/tech-tasks/backend/definitions/invitations"
+	"github.com/skip2/go-qrcode"
+)
+
+const defaultQRSize = 256
+
+// renderQR encodes content as a size x size PNG, or as an SVG of the same size.
+func renderQR(content string, format invitations.Format, size int) ([]byte, error) {
+	if size == 0 {
+		size = defaultQRSize
+	}
+	q, err := qrcode.New(content, qrcode.Medium)
+	if err != nil {
+		return nil, err
+	}
+	if format == invitations.FormatSVG {
+		return svg(q.Bitmap(), size), nil
+	}
+	return q.PNG(size)
+}
+
+// svg draws every dark module of the bitmap as a unit square of a single path, the bitmap includes the quiet zone.
+func svg(bitmap [][]bool, size int) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(
+		&b,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		size, size, len(bitmap), len(bitmap),
+	)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#fff"/><path fill="#000" d="`, len(bitmap), len(bitmap))
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&b, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+	b.WriteString(`"/></svg>`)
+	return b.Bytes()
+}
@@ -0,0 +1,63 @@
+package invitations
+
+import (
+	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/invitations"
+	"gorm.io/gorm"
+	"time"
+)
+
+type Repository struct {
+	db    *gorm.DB
+	event uint
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return Repository{
+		db: db,
+	}
+}
+
+func (r Repository) ForEvent(event uint) invitations.Repository {
+	r.event = event
+	return r
+}
+
+func (r Repository) Create(inv invitations.Invitation) error {
+	inv.EventID = r.event
+	return r.db.Create(&inv).Error
+}
+
+func (r Repository) GetByID(id string) (inv invitations.Invitation, err error) {
+	err = r.db.Where("id = ?", id).First(&inv).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = invitations.ErrNotFound
+	}
+	return
+}
+
+// Revoke revokes every invitation of the guest that wasn't revoked yet at the time and returns how many were.
+func (r Repository) Revoke(guestName string, at time.Time) (int64, error) {
+	res := r.db.Model(&invitations.Invitation{}).
+		Where("event_id = ?", r.event).
+		Where("guest_name = ?", guestName).
+		Where("revoked_at IS NULL").
+		Update("revoked_at", at)
+	return res.RowsAffected, res.Error
+}
+
+// Use marks the invitation used, the condition makes sure two scans of the same token can't both use it.
+func (r Repository) Use(id string, at time.Time) error {
+	res := r.db.Model(&invitations.Invitation{}).
+		Where("id = ?", id).
+		Where("used_at IS NULL").
+		Where("revoked_at IS NULL").
+		Update("used_at", at)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return invitations.ErrUsed
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package invitations_test
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	invitationsDef "github.com/getground/tech-tasks/backend/definitions/invitations"
+	"github.com/getground/tech-tasks/backend/pkg/database"
+	"github.com/getground/tech-tasks/backend/pkg/modules/invitations"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type repoMocks struct {
+	db      *sql.DB
+	sqlMock sqlmock.Sqlmock
+}
+
+func setupIntegrationRepo(t *testing.T) (invitationsDef.Repository, repoMocks) {
+	db, m, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	msc := mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true})
+	gDB, err := database.NewDatabaseForTests(msc)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating grom database connection", err)
+	}
+	r := invitations.NewRepository(gDB).ForEvent(1)
+	return r, repoMocks{
+		db:      db,
+		sqlMock: m,
+	}
+}
+
+func TestRepository_Create(t *testing.T) {
+	// setup
+	repo, m := setupIntegrationRepo(t)
+	defer m.db.Close()
+
+	// test data
+	inv := invitationsDef.Invitation{ID: "id", GuestName: "test", CreatedAt: time.Now()}
+
+	// mocks
+	q := "INSERT INTO `invitations` (`id`,`event_id`,`guest_name`,`created_at`,`revoked_at`,`used_at`) " +
+		"VALUES (?,?,?,?,?,?)"
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).
+		WithArgs(inv.ID, 1, inv.GuestName, inv.CreatedAt, nil, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	m.sqlMock.ExpectCommit()
+
+	// method call
+	err := repo.Create(inv)
+
+	//	assert
+	assert.NoError(t, err)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestRepository_GetByID(t *testing.T) {
+	q := "SELECT * FROM `invitations` WHERE id = ? ORDER BY `invitations`.`id` LIMIT 1"
+	t.Run(
+		"not found", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			// mocks
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+				WithArgs("id").
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			// method call
+			inv, err := repo.GetByID("id")
+
+			//	assert
+			assert.ErrorIs(t, err, invitationsDef.ErrNotFound)
+			assert.Empty(t, inv)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+			created := time.Now()
+
+			// mocks
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+				WithArgs("id").
+				WillReturnRows(
+					sqlmock.NewRows([]string{"id", "guest_name", "created_at", "revoked_at", "used_at"}).
+						AddRow("id", "test", created, nil, nil),
+				)
+
+			// method call
+			inv, err := repo.GetByID("id")
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, invitationsDef.Invitation{ID: "id", GuestName: "test", CreatedAt: created}, inv)
+		},
+	)
+}
+
+func TestRepository_Revoke(t *testing.T) {
+	// setup
+	repo, m := setupIntegrationRepo(t)
+	defer m.db.Close()
+
+	// mocks
+	q := "UPDATE `invitations` SET `revoked_at`=? WHERE event_id = ? AND guest_name = ? AND revoked_at IS NULL"
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).
+		WithArgs(now, 1, "test").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	m.sqlMock.ExpectCommit()
+
+	// method call
+	n, err := repo.Revoke("test", now)
+
+	//	assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestRepository_Use(t *testing.T) {
+	q := "UPDATE `invitations` SET `used_at`=? WHERE id = ? AND used_at IS NULL AND revoked_at IS NULL"
+	t.Run(
+		"error", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			// mocks
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).
+				WithArgs(now, "id").
+				WillReturnError(errors.New("internal error"))
+			m.sqlMock.ExpectRollback()
+
+			// method call
+			err := repo.Use("id", now)
+
+			//	assert
+			assert.Error(t, err)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"used already", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			// mocks
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).
+				WithArgs(now, "id").
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			m.sqlMock.ExpectCommit()
+
+			// method call
+			err := repo.Use("id", now)
+
+			//	assert
+			assert.ErrorIs(t, err, invitationsDef.ErrUsed)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			// mocks
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).
+				WithArgs(now, "id").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			m.sqlMock.ExpectCommit()
+
+			// method call
+			err := repo.Use("id", now)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
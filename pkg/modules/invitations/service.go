@@ -0,0 +1,127 @@
+package invitations
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"github.com/getground/tech-tasks/backend/definitions/clock"
+	"github.com/getground/tech-tasks/backend/definitions/events"
+	"github.com/getground/tech-tasks/backend/definitions/invitations"
+	"io"
+	"strings"
+)
+
+// idBytes makes the invitation ids unguessable, the signature only spares a database lookup for forged tokens.
+const idBytes = 16
+
+var encoding = base64.RawURLEncoding
+
+type Service struct {
+	repository invitations.Repository
+	secret     []byte
+	gate       events.Gate
+	clock      clock.Clock
+	event      uint
+}
+
+func NewService(repository invitations.Repository, secret []byte, gate events.Gate) Service {
+	return Service{repository: repository, secret: secret, gate: gate, clock: clock.UTC{}}
+}
+
+// WithClock returns the service telling the time with c.
+func (s Service) WithClock(c clock.Clock) Service {
+	s.clock = c
+	return s
+}
+
+func (s Service) ForEvent(event uint) invitations.Service {
+	s.repository = s.repository.ForEvent(event)
+	s.event = event
+	return s
+}
+
+// Issue stores a new invitation of the guest and returns its token, "<id>.<signature>".
+func (s Service) Issue(guestName string) (token string, err error) {
+	b := make([]byte, idBytes)
+	_, err = io.ReadFull(rand.Reader, b)
+	if err != nil {
+		return
+	}
+	id := encoding.EncodeToString(b)
+
+	err = s.repository.Create(invitations.Invitation{ID: id, GuestName: guestName, CreatedAt: s.clock.Now()})
+	if err != nil {
+		return
+	}
+	token = id + "." + s.sign(id)
+	return
+}
+
+// Verify checks the signature of the token and returns its invitation when it can still be used.
+func (s Service) Verify(token string) (inv invitations.Invitation, err error) {
+	id, err := s.id(token)
+	if err != nil {
+		return
+	}
+
+	inv, err = s.repository.GetByID(id)
+	if err != nil {
+		return
+	}
+	switch {
+	case inv.RevokedAt != nil:
+		err = invitations.ErrRevoked
+	case inv.UsedAt != nil:
+		err = invitations.ErrUsed
+	}
+	return
+}
+
+func (s Service) Use(id string) error {
+	return s.repository.Use(id, s.clock.Now())
+}
+
+// Revoke revokes the invitations of the guest while the guest list can be edited, their tokens can't be scanned or
+// rendered anymore.
+func (s Service) Revoke(guestName string) error {
+	if err := s.gate.Allow(s.event, events.OpEditGuestList); err != nil {
+		return err
+	}
+	n, err := s.repository.Revoke(guestName, s.clock.Now())
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return invitations.ErrNotFound
+	}
+	return nil
+}
+
+// QR renders the token of a valid invitation as a QR code.
+func (s Service) QR(req invitations.QRRequest) ([]byte, error) {
+	_, err := s.Verify(req.Token)
+	if err != nil {
+		return nil, err
+	}
+	return renderQR(req.Token, req.Format, req.Size)
+}
+
+// id returns the invitation id of a token signed by the service.
+func (s Service) id(token string) (string, error) {
+	i := strings.IndexByte(token, '.')
+	if i < 0 {
+		return "", invitations.ErrInvalidToken
+	}
+	id, sig := token[:i], token[i+1:]
+	if !hmac.Equal([]byte(sig), []byte(s.sign(id))) {
+		return "", invitations.ErrInvalidToken
+	}
+	return id, nil
+}
+
+func (s Service) sign(id string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(id))
+	return encoding.EncodeToString(mac.Sum(nil))
+}
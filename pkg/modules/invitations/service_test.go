@@ -0,0 +1,263 @@
+package invitations_test
+
+import (
+	"bytes"
+	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/clock"
+	eventsDef "github.com/getground/tech-tasks/backend/definitions/events"
+	invitationsDef "github.com/getground/tech-tasks/backend/definitions/invitations"
+	eventsMocks "github.com/getground/tech-tasks/backend/mocks/definitions/events"
+	invitationsMocks "github.com/getground/tech-tasks/backend/mocks/definitions/invitations"
+	"github.com/getground/tech-tasks/backend/pkg/modules/invitations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+)
+
+// now is the time of the clock of the service, the time the invitations are used and revoked at.
+var now = time.Date(2026, 10, 19, 20, 30, 0, 0, time.UTC)
+
+func setupService() (invitations.Service, *invitationsMocks.Repository) {
+	repo := new(invitationsMocks.Repository)
+	// the zero event allows every change, the tests of the gate scope the service to another event
+	gate := new(eventsMocks.Service)
+	gate.On("Allow", uint(0), mock.Anything).Return(nil).Maybe()
+	return invitations.NewService(repo, []byte("secret"), gate).WithClock(clock.Fixed(now)), repo
+}
+
+// issue issues a token for the guest and returns it with its stored invitation.
+func issue(t *testing.T, service invitations.Service, repo *invitationsMocks.Repository, name string) (
+	string, invitationsDef.Invitation,
+) {
+	var stored invitationsDef.Invitation
+	repo.On(
+		"Create", mock.MatchedBy(
+			func(inv invitationsDef.Invitation) bool {
+				stored = inv
+				return inv.GuestName == name
+			},
+		),
+	).Return(nil).Once()
+
+	token, err := service.Issue(name)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when issuing a token", err)
+	}
+	return token, stored
+}
+
+func TestService_Issue(t *testing.T) {
+	t.Run(
+		"repo error", func(t *testing.T) {
+			// setup
+			service, repo := setupService()
+
+			//	mocks
+			repo.On("Create", mock.Anything).Return(errors.New("internal error")).Once()
+
+			//	method call
+			token, err := service.Issue("test")
+
+			//	assert
+			assert.Error(t, err)
+			assert.Empty(t, token)
+			repo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			service, repo := setupService()
+
+			//	method call
+			first, inv := issue(t, service, repo, "test")
+			second, _ := issue(t, service, repo, "test")
+
+			//	assert
+			assert.True(t, strings.HasPrefix(first, inv.ID+"."))
+			assert.Len(t, inv.ID, 22)
+			assert.NotEqual(t, first, second)
+			repo.AssertExpectations(t)
+		},
+	)
+}
+
+func TestService_Verify(t *testing.T) {
+	// setup
+	service, repo := setupService()
+	token, inv := issue(t, service, repo, "test")
+	now := time.Now()
+
+	t.Run(
+		"malformed", func(t *testing.T) {
+			_, err := service.Verify("token")
+
+			assert.ErrorIs(t, err, invitationsDef.ErrInvalidToken)
+		},
+	)
+
+	t.Run(
+		"forged signature", func(t *testing.T) {
+			// the signature of another secret
+			other := invitations.NewService(repo, []byte("other"), nil)
+			repo.On("Create", mock.Anything).Return(nil).Once()
+			forged, err := other.Issue("test")
+			assert.NoError(t, err)
+
+			_, err = service.Verify(forged)
+
+			assert.ErrorIs(t, err, invitationsDef.ErrInvalidToken)
+		},
+	)
+
+	t.Run(
+		"not found", func(t *testing.T) {
+			repo.On("GetByID", inv.ID).Return(invitationsDef.Invitation{}, invitationsDef.ErrNotFound).Once()
+
+			_, err := service.Verify(token)
+
+			assert.ErrorIs(t, err, invitationsDef.ErrNotFound)
+		},
+	)
+
+	t.Run(
+		"revoked", func(t *testing.T) {
+			revoked := inv
+			revoked.RevokedAt = &now
+			repo.On("GetByID", inv.ID).Return(revoked, nil).Once()
+
+			_, err := service.Verify(token)
+
+			assert.ErrorIs(t, err, invitationsDef.ErrRevoked)
+		},
+	)
+
+	t.Run(
+		"used", func(t *testing.T) {
+			used := inv
+			used.UsedAt = &now
+			repo.On("GetByID", inv.ID).Return(used, nil).Once()
+
+			_, err := service.Verify(token)
+
+			assert.ErrorIs(t, err, invitationsDef.ErrUsed)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			repo.On("GetByID", inv.ID).Return(inv, nil).Once()
+
+			res, err := service.Verify(token)
+
+			assert.NoError(t, err)
+			assert.Equal(t, inv, res)
+			repo.AssertExpectations(t)
+		},
+	)
+}
+
+func TestService_Revoke(t *testing.T) {
+	// setup
+	service, repo := setupService()
+
+	t.Run(
+		"guest list closed", func(t *testing.T) {
+			gate := new(eventsMocks.Service)
+			gate.On("Allow", uint(2), eventsDef.OpEditGuestList).Return(eventsDef.ErrNotAllowed).Once()
+			repo.On("ForEvent", uint(2)).Return(repo).Once()
+
+			err := invitations.NewService(repo, []byte("secret"), gate).ForEvent(2).Revoke("test")
+
+			assert.ErrorIs(t, err, eventsDef.ErrNotAllowed)
+			repo.AssertNotCalled(t, "Revoke", "test", mock.Anything)
+			gate.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"repo error", func(t *testing.T) {
+			repo.On("Revoke", "test", now).Return(int64(0), errors.New("internal error")).Once()
+
+			assert.Error(t, service.Revoke("test"))
+		},
+	)
+
+	t.Run(
+		"nothing to revoke", func(t *testing.T) {
+			repo.On("Revoke", "test", now).Return(int64(0), nil).Once()
+
+			assert.ErrorIs(t, service.Revoke("test"), invitationsDef.ErrNotFound)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			repo.On("Revoke", "test", now).Return(int64(1), nil).Once()
+
+			assert.NoError(t, service.Revoke("test"))
+			repo.AssertExpectations(t)
+		},
+	)
+}
+
+func TestService_Use(t *testing.T) {
+	// setup
+	service, repo := setupService()
+
+	//	mocks
+	repo.On("Use", "id", now).Return(invitationsDef.ErrUsed).Once()
+
+	//	method call
+	err := service.Use("id")
+
+	//	assert
+	assert.ErrorIs(t, err, invitationsDef.ErrUsed)
+	repo.AssertExpectations(t)
+}
+
+func TestService_QR(t *testing.T) {
+	// setup
+	service, repo := setupService()
+	token, inv := issue(t, service, repo, "test")
+
+	t.Run(
+		"invalid token", func(t *testing.T) {
+			res, err := service.QR(invitationsDef.QRRequest{Token: inv.ID + ".forged"})
+
+			assert.ErrorIs(t, err, invitationsDef.ErrInvalidToken)
+			assert.Nil(t, res)
+		},
+	)
+
+	t.Run(
+		"png", func(t *testing.T) {
+			repo.On("GetByID", inv.ID).Return(inv, nil).Once()
+
+			res, err := service.QR(invitationsDef.QRRequest{Token: token, Format: invitationsDef.FormatPNG, Size: 128})
+
+			assert.NoError(t, err)
+			img, err := png.Decode(bytes.NewReader(res))
+			if assert.NoError(t, err) {
+				assert.Equal(t, 128, img.Bounds().Dx())
+			}
+		},
+	)
+
+	t.Run(
+		"svg", func(t *testing.T) {
+			repo.On("GetByID", inv.ID).Return(inv, nil).Once()
+
+			res, err := service.QR(invitationsDef.QRRequest{Token: token, Format: invitationsDef.FormatSVG})
+
+			assert.NoError(t, err)
+			assert.True(t, bytes.HasPrefix(res, []byte(`<svg xmlns="http://www.w3.org/2000/svg" width="256" height="256"`)))
+			assert.Contains(t, string(res), "h1v1h-1z")
+			repo.AssertExpectations(t)
+		},
+	)
+}
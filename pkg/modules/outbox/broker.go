@@ -0,0 +1,42 @@
+package outbox
+
+import (
+	"context"
+	"github.com/getground/tech-tasks/backend/definitions/outbox"
+	"sync"
+)
+
+// MemoryBroker is an in process Broker keeping the messages of every topic, it drops the ids it already received so
+// a message sent again by the relay is kept once.
+type MemoryBroker struct {
+	mu       sync.RWMutex
+	received map[string]bool
+	topics   map[string][]outbox.Message
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		received: map[string]bool{},
+		topics:   map[string][]outbox.Message{},
+	}
+}
+
+func (b *MemoryBroker) Publish(_ context.Context, topic string, m outbox.Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.received[m.ID] {
+		return nil
+	}
+	b.received[m.ID] = true
+	b.topics[topic] = append(b.topics[topic], m)
+	return nil
+}
+
+// Messages returns the messages published on the topic in the order they were received.
+func (b *MemoryBroker) Messages(topic string) []outbox.Message {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return append([]outbox.Message(nil), b.topics[topic]...)
+}
@@ -0,0 +1,143 @@
+package outbox
+
+import (
+	"context"
+	"github.com/getground/tech-tasks/backend/definitions/clock"
+	"github.com/getground/tech-tasks/backend/definitions/outbox"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+// Relay dispatches the messages of the outbox to the sinks. A message is sent again until every sink received it or
+// ran out of attempts, so the sinks receive every message at least once and drop the ids they already handled.
+type Relay struct {
+	repository outbox.Repository
+	sinks      []outbox.Sink
+	interval   time.Duration
+	batch      int
+	retry      outbox.Retry
+	clock      clock.Clock
+}
+
+func NewRelay(
+	repository outbox.Repository, interval time.Duration, batch int, retry outbox.Retry, sinks ...outbox.Sink,
+) Relay {
+	return Relay{
+		repository: repository,
+		sinks:      sinks,
+		interval:   interval,
+		batch:      batch,
+		retry:      retry,
+		clock:      clock.UTC{},
+	}
+}
+
+func (r Relay) WithClock(c clock.Clock) Relay {
+	r.clock = c
+	return r
+}
+
+// Run flushes the outbox every interval until ctx is done.
+func (r Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.Flush(ctx); err != nil {
+			log.Error(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Flush dispatches the pending messages oldest first and returns how many were dispatched. Every sink makes its own
+// progress: a sink that fails a message, or waits to retry it, receives none of the next ones until the next flush
+// so it receives them in order, while the other sinks go on.
+func (r Relay) Flush(ctx context.Context) (int, error) {
+	list, err := r.repository.Pending(r.batch)
+	if err != nil {
+		return 0, err
+	}
+	held := make(map[string]bool, len(r.sinks))
+	n := 0
+	for _, m := range list {
+		dispatched, err := r.dispatch(ctx, m, held)
+		if err != nil {
+			return n, err
+		}
+		if dispatched {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// dispatch sends the message to the sinks that didn't receive it yet and aren't held, and reports whether every sink
+// is done with it. The progress of a sink is saved after every send so a failure of the next sink doesn't send it
+// again to the previous ones.
+func (r Relay) dispatch(ctx context.Context, m outbox.Message, held map[string]bool) (bool, error) {
+	list, err := r.repository.Sinks(m.ID)
+	if err != nil {
+		return false, err
+	}
+	progress := make(map[string]outbox.Sent, len(list))
+	for _, s := range list {
+		progress[s.Sink] = s
+	}
+
+	done := true
+	for _, sink := range r.sinks {
+		s, ok := progress[sink.Name()]
+		if !ok {
+			s = outbox.Sent{MessageID: m.ID, Sink: sink.Name()}
+		}
+		if s.Done() {
+			continue
+		}
+		if held[s.Sink] || s.RetryAt != nil && r.clock.Now().Before(*s.RetryAt) {
+			held[s.Sink] = true
+			done = false
+			continue
+		}
+
+		s = r.send(ctx, sink, m, s)
+		if err = r.repository.Save(s); err != nil {
+			return false, err
+		}
+		if !s.Done() {
+			held[s.Sink] = true
+			done = false
+		}
+	}
+	if !done {
+		return false, nil
+	}
+	return true, r.repository.Dispatched(m.ID, r.clock.Now())
+}
+
+// send returns the progress of the sink after sending it the message, the sink skips the message once the attempts
+// run out.
+func (r Relay) send(ctx context.Context, sink outbox.Sink, m outbox.Message, s outbox.Sent) outbox.Sent {
+	err := sink.Send(ctx, m)
+	now := r.clock.Now()
+	if err == nil {
+		s.SentAt = &now
+		return s
+	}
+
+	s.Attempts++
+	s.Error = err.Error()
+	if s.Attempts >= r.retry.MaxAttempts {
+		log.Errorf("outbox message %s to %s is skipped after %d attempts: %s", m.ID, s.Sink, s.Attempts, err)
+		s.SkippedAt = &now
+		return s
+	}
+	log.Warnf("outbox message %s to %s, attempt %d: %s", m.ID, s.Sink, s.Attempts, err)
+	retryAt := now.Add(r.retry.Backoff << (s.Attempts - 1))
+	s.RetryAt = &retryAt
+	return s
+}
@@ -0,0 +1,177 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/clock"
+	outboxDef "github.com/getground/tech-tasks/backend/definitions/outbox"
+	outboxMocks "github.com/getground/tech-tasks/backend/mocks/definitions/outbox"
+	"github.com/getground/tech-tasks/backend/pkg/modules/outbox"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2023, 6, 21, 18, 0, 0, 0, time.UTC)
+
+func setupRelay(t *testing.T) (outbox.Relay, *outboxMocks.Repository, *outboxMocks.Sink, *outboxMocks.Sink) {
+	repo := outboxMocks.NewRepository(t)
+	first, second := outboxMocks.NewSink(t), outboxMocks.NewSink(t)
+	first.On("Name").Return("first").Maybe()
+	second.On("Name").Return("second").Maybe()
+	retry := outboxDef.Retry{MaxAttempts: 3, Backoff: time.Second}
+	return outbox.NewRelay(repo, 0, 10, retry, first, second).WithClock(clock.Fixed(now)), repo, first, second
+}
+
+func TestRelay_Flush(t *testing.T) {
+	ctx := context.Background()
+	a := outboxDef.Message{ID: "a", Type: "guest.checked_in"}
+	b := outboxDef.Message{ID: "b", Type: "guest.checked_out"}
+	later := now.Add(time.Second)
+
+	t.Run(
+		"error pending", func(t *testing.T) {
+			// setup
+			relay, repo, _, _ := setupRelay(t)
+
+			//	mocks
+			repo.On("Pending", 10).Return(nil, errors.New("internal error")).Once()
+
+			//	method call
+			n, err := relay.Flush(ctx)
+
+			//	assert
+			assert.Error(t, err)
+			assert.Equal(t, 0, n)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			relay, repo, first, second := setupRelay(t)
+
+			//	mocks
+			repo.On("Pending", 10).Return([]outboxDef.Message{a, b}, nil).Once()
+			// a reached the first sink before a crash, only the second sink receives it again
+			repo.On("Sinks", "a").Return([]outboxDef.Sent{{MessageID: "a", Sink: "first", SentAt: &now}}, nil).Once()
+			second.On("Send", ctx, a).Return(nil).Once()
+			repo.On("Save", outboxDef.Sent{MessageID: "a", Sink: "second", SentAt: &now}).Return(nil).Once()
+			repo.On("Dispatched", "a", now).Return(nil).Once()
+			repo.On("Sinks", "b").Return(nil, nil).Once()
+			first.On("Send", ctx, b).Return(nil).Once()
+			repo.On("Save", outboxDef.Sent{MessageID: "b", Sink: "first", SentAt: &now}).Return(nil).Once()
+			second.On("Send", ctx, b).Return(nil).Once()
+			repo.On("Save", outboxDef.Sent{MessageID: "b", Sink: "second", SentAt: &now}).Return(nil).Once()
+			repo.On("Dispatched", "b", now).Return(nil).Once()
+
+			//	method call
+			n, err := relay.Flush(ctx)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, 2, n)
+		},
+	)
+
+	t.Run(
+		"sink failure holds back the sink only", func(t *testing.T) {
+			// setup
+			relay, repo, first, second := setupRelay(t)
+
+			//	mocks
+			repo.On("Pending", 10).Return([]outboxDef.Message{a, b}, nil).Once()
+			repo.On("Sinks", "a").Return(nil, nil).Once()
+			first.On("Send", ctx, a).Return(errors.New("unavailable")).Once()
+			failed := outboxDef.Sent{MessageID: "a", Sink: "first", Attempts: 1, Error: "unavailable", RetryAt: &later}
+			repo.On("Save", failed).Return(nil).Once()
+			second.On("Send", ctx, a).Return(nil).Once()
+			repo.On("Save", outboxDef.Sent{MessageID: "a", Sink: "second", SentAt: &now}).Return(nil).Once()
+			repo.On("Sinks", "b").Return(nil, nil).Once()
+			second.On("Send", ctx, b).Return(nil).Once()
+			repo.On("Save", outboxDef.Sent{MessageID: "b", Sink: "second", SentAt: &now}).Return(nil).Once()
+
+			//	method call
+			n, err := relay.Flush(ctx)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, 0, n)
+			first.AssertNotCalled(t, "Send", ctx, b)
+		},
+	)
+
+	t.Run(
+		"retry not due", func(t *testing.T) {
+			// setup
+			relay, repo, _, _ := setupRelay(t)
+
+			//	mocks
+			repo.On("Pending", 10).Return([]outboxDef.Message{a}, nil).Once()
+			repo.On("Sinks", "a").Return(
+				[]outboxDef.Sent{
+					{MessageID: "a", Sink: "first", Attempts: 1, RetryAt: &later},
+					{MessageID: "a", Sink: "second", SentAt: &now},
+				}, nil,
+			).Once()
+
+			//	method call
+			n, err := relay.Flush(ctx)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, 0, n)
+		},
+	)
+
+	t.Run(
+		"skipped once the attempts run out", func(t *testing.T) {
+			// setup
+			relay, repo, first, _ := setupRelay(t)
+
+			//	mocks
+			repo.On("Pending", 10).Return([]outboxDef.Message{a}, nil).Once()
+			repo.On("Sinks", "a").Return(
+				[]outboxDef.Sent{
+					{MessageID: "a", Sink: "first", Attempts: 2, RetryAt: &now},
+					{MessageID: "a", Sink: "second", SentAt: &now},
+				}, nil,
+			).Once()
+			first.On("Send", ctx, a).Return(errors.New("unavailable")).Once()
+			repo.On(
+				"Save", outboxDef.Sent{
+					MessageID: "a", Sink: "first", Attempts: 3, Error: "unavailable", RetryAt: &now, SkippedAt: &now,
+				},
+			).Return(nil).Once()
+			repo.On("Dispatched", "a", now).Return(nil).Once()
+
+			//	method call
+			n, err := relay.Flush(ctx)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, 1, n)
+		},
+	)
+
+	t.Run(
+		"error saving the sink", func(t *testing.T) {
+			// setup
+			relay, repo, first, _ := setupRelay(t)
+
+			//	mocks
+			repo.On("Pending", 10).Return([]outboxDef.Message{a}, nil).Once()
+			repo.On("Sinks", "a").Return(nil, nil).Once()
+			first.On("Send", ctx, a).Return(nil).Once()
+			repo.On("Save", outboxDef.Sent{MessageID: "a", Sink: "first", SentAt: &now}).
+				Return(errors.New("internal error")).Once()
+
+			//	method call
+			n, err := relay.Flush(ctx)
+
+			//	assert
+			assert.Error(t, err)
+			assert.Equal(t, 0, n)
+		},
+	)
+}
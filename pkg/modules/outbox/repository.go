@@ -0,0 +1,38 @@
+package outbox
+
+import (
+	"github.com/getground/tech-tasks/backend/definitions/outbox"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// Repository reads the outbox of every event, the relay dispatches the messages whatever their event.
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return Repository{
+		db: db,
+	}
+}
+
+func (r Repository) Pending(limit int) (list []outbox.Message, err error) {
+	err = r.db.Where("dispatched_at IS NULL").Order("created_at, id").Limit(limit).Find(&list).Error
+	return
+}
+
+func (r Repository) Sinks(id string) (list []outbox.Sent, err error) {
+	err = r.db.Where("message_id = ?", id).Find(&list).Error
+	return
+}
+
+// Save replaces the progress already recorded, the relay saves the sink after every send.
+func (r Repository) Save(s outbox.Sent) error {
+	return r.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&s).Error
+}
+
+func (r Repository) Dispatched(id string, at time.Time) error {
+	return r.db.Model(&outbox.Message{}).Where("id = ?", id).Update("dispatched_at", at).Error
+}
@@ -0,0 +1,163 @@
+package outbox_test
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	outboxDef "github.com/getground/tech-tasks/backend/definitions/outbox"
+	"github.com/getground/tech-tasks/backend/pkg/database"
+	"github.com/getground/tech-tasks/backend/pkg/modules/outbox"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type repoMocks struct {
+	db      *sql.DB
+	sqlMock sqlmock.Sqlmock
+}
+
+func setupIntegrationRepo(t *testing.T) (outboxDef.Repository, repoMocks) {
+	db, m, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	msc := mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true})
+	gDB, err := database.NewDatabaseForTests(msc)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating grom database connection", err)
+	}
+	r := outbox.NewRepository(gDB)
+	return r, repoMocks{
+		db:      db,
+		sqlMock: m,
+	}
+}
+
+func TestRepository_Pending(t *testing.T) {
+	q := "SELECT * FROM `outbox` WHERE dispatched_at IS NULL ORDER BY created_at, id LIMIT 10"
+
+	t.Run(
+		"error", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).WillReturnError(errors.New("internal error"))
+
+			//	method call
+			_, err := repo.Pending(10)
+
+			//	assert
+			assert.Error(t, err)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+			created := time.Date(2023, 6, 21, 18, 0, 0, 0, time.UTC)
+
+			//	mocks
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+				WillReturnRows(
+					sqlmock.NewRows([]string{"id", "event_id", "type", "payload", "created_at", "dispatched_at"}).
+						AddRow("a1", 1, "guest.checked_in", `{"id":"a1"}`, created, nil),
+				)
+
+			//	method call
+			res, err := repo.Pending(10)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(
+				t, []outboxDef.Message{
+					{ID: "a1", EventID: 1, Type: "guest.checked_in", Payload: `{"id":"a1"}`, CreatedAt: created},
+				}, res,
+			)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
+
+func TestRepository_Sinks(t *testing.T) {
+	// setup
+	repo, m := setupIntegrationRepo(t)
+	defer m.db.Close()
+
+	//	mocks
+	sent := time.Date(2023, 6, 21, 18, 0, 0, 0, time.UTC)
+	q := "SELECT * FROM `outbox_sent` WHERE message_id = ?"
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+		WithArgs("a1").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"message_id", "sink", "attempts", "error", "retry_at", "sent_at", "skipped_at"}).
+				AddRow("a1", "log", 0, "", nil, sent, nil).
+				AddRow("a1", "http", 2, "unavailable", sent, nil, nil),
+		)
+
+	//	method call
+	res, err := repo.Sinks("a1")
+
+	//	assert
+	assert.NoError(t, err)
+	assert.Equal(
+		t, []outboxDef.Sent{
+			{MessageID: "a1", Sink: "log", SentAt: &sent},
+			{MessageID: "a1", Sink: "http", Attempts: 2, Error: "unavailable", RetryAt: &sent},
+		}, res,
+	)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestRepository_Save(t *testing.T) {
+	// setup
+	repo, m := setupIntegrationRepo(t)
+	defer m.db.Close()
+	sent := time.Date(2023, 6, 21, 18, 0, 0, 0, time.UTC)
+
+	//	mocks
+	q := "INSERT INTO `outbox_sent` (`message_id`,`sink`,`attempts`,`error`,`retry_at`,`sent_at`,`skipped_at`) " +
+		"VALUES (?,?,?,?,?,?,?) ON DUPLICATE KEY UPDATE `attempts`=VALUES(`attempts`),`error`=VALUES(`error`)," +
+		"`retry_at`=VALUES(`retry_at`),`sent_at`=VALUES(`sent_at`),`skipped_at`=VALUES(`skipped_at`)"
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).
+		WithArgs("a1", "log", 1, "unavailable", nil, sent, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectCommit()
+
+	//	method call
+	err := repo.Save(outboxDef.Sent{MessageID: "a1", Sink: "log", Attempts: 1, Error: "unavailable", SentAt: &sent})
+
+	//	assert
+	assert.NoError(t, err)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestRepository_Dispatched(t *testing.T) {
+	// setup
+	repo, m := setupIntegrationRepo(t)
+	defer m.db.Close()
+	at := time.Date(2023, 6, 21, 18, 0, 0, 0, time.UTC)
+
+	//	mocks
+	q := "UPDATE `outbox` SET `dispatched_at`=? WHERE id = ?"
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).
+		WithArgs(at, "a1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectCommit()
+
+	//	method call
+	err := repo.Dispatched("a1", at)
+
+	//	assert
+	assert.NoError(t, err)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
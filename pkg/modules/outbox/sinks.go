@@ -0,0 +1,75 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/getground/tech-tasks/backend/definitions/outbox"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+)
+
+// LogSink writes the messages to the log.
+type LogSink struct{}
+
+func (LogSink) Name() string {
+	return "log"
+}
+
+func (LogSink) Send(_ context.Context, m outbox.Message) error {
+	log.WithFields(log.Fields{"id": m.ID, "type": m.Type, "event": m.EventID}).Info(m.Payload)
+	return nil
+}
+
+// HTTPSink posts the payload of the messages to an endpoint, any answer but 2xx is a failure.
+type HTTPSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewHTTPSink(url string, client *http.Client) HTTPSink {
+	return HTTPSink{url: url, client: client}
+}
+
+func (HTTPSink) Name() string {
+	return "http"
+}
+
+func (s HTTPSink) Send(ctx context.Context, m outbox.Message) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader([]byte(m.Payload)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(outbox.IDHeader, m.ID)
+	req.Header.Set(outbox.TypeHeader, m.Type)
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+	_ = res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", res.StatusCode)
+	}
+	return nil
+}
+
+// BrokerSink publishes the messages to a broker.
+type BrokerSink struct {
+	broker outbox.Broker
+}
+
+func NewBrokerSink(broker outbox.Broker) BrokerSink {
+	return BrokerSink{broker: broker}
+}
+
+func (BrokerSink) Name() string {
+	return "broker"
+}
+
+func (s BrokerSink) Send(ctx context.Context, m outbox.Message) error {
+	return s.broker.Publish(ctx, m.Type, m)
+}
@@ -0,0 +1,87 @@
+package outbox_test
+
+import (
+	"context"
+	outboxDef "github.com/getground/tech-tasks/backend/definitions/outbox"
+	"github.com/getground/tech-tasks/backend/pkg/modules/outbox"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLogSink_Send(t *testing.T) {
+	err := outbox.LogSink{}.Send(context.Background(), outboxDef.Message{ID: "a", Payload: `{"id":"a"}`})
+
+	assert.NoError(t, err)
+}
+
+func TestHTTPSink_Send(t *testing.T) {
+	m := outboxDef.Message{ID: "a", Type: "guest.checked_in", Payload: `{"id":"a"}`}
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			var got *http.Request
+			var body []byte
+			server := httptest.NewServer(
+				http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						got = r
+						body, _ = io.ReadAll(r.Body)
+						w.WriteHeader(http.StatusAccepted)
+					},
+				),
+			)
+			defer server.Close()
+
+			//	method call
+			err := outbox.NewHTTPSink(server.URL, server.Client()).Send(context.Background(), m)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, http.MethodPost, got.Method)
+			assert.Equal(t, "a", got.Header.Get(outboxDef.IDHeader))
+			assert.Equal(t, "guest.checked_in", got.Header.Get(outboxDef.TypeHeader))
+			assert.JSONEq(t, m.Payload, string(body))
+		},
+	)
+
+	t.Run(
+		"rejected", func(t *testing.T) {
+			// setup
+			server := httptest.NewServer(
+				http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						w.WriteHeader(http.StatusServiceUnavailable)
+					},
+				),
+			)
+			defer server.Close()
+
+			//	method call
+			err := outbox.NewHTTPSink(server.URL, server.Client()).Send(context.Background(), m)
+
+			//	assert
+			assert.EqualError(t, err, "unexpected status 503")
+		},
+	)
+}
+
+func TestBrokerSink_Send(t *testing.T) {
+	// setup
+	broker := outbox.NewMemoryBroker()
+	sink := outbox.NewBrokerSink(broker)
+	a := outboxDef.Message{ID: "a", Type: "guest.checked_in"}
+	b := outboxDef.Message{ID: "b", Type: "guest.checked_in"}
+
+	//	method call
+	for _, m := range []outboxDef.Message{a, b, a} {
+		assert.NoError(t, sink.Send(context.Background(), m))
+	}
+
+	//	assert
+	assert.Equal(t, []outboxDef.Message{a, b}, broker.Messages("guest.checked_in"))
+	assert.Empty(t, broker.Messages("guest.checked_out"))
+}
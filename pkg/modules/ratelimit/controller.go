@@ -0,0 +1,91 @@
+package ratelimit
+
+import (
+	"github.com/getground/tech-tasks/backend/definitions/ratelimit"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// eventPrefix is stripped from the routes nested under an event, so they share the limits of the routes of the
+// default event.
+const eventPrefix = "/events/:event"
+
+type Controller struct {
+	service ratelimit.Service
+}
+
+func NewController(service ratelimit.Service) Controller {
+	return Controller{
+		service: service,
+	}
+}
+
+// Limit answers 429 with a Retry-After header to the clients making more requests than their limit allows, the
+// clients are told apart by their API key or their IP and the client is kept in the gin context for the next
+// handlers. An API key the service doesn't know is answered with 401, so a client can't get new buckets by making
+// keys up. The RateLimit headers are set on every limited route. The requests are let through when the store fails,
+// so an outage of a shared store doesn't take the API down.
+func (ctrl Controller) Limit(c *gin.Context) {
+	id, known := ctrl.client(c)
+	if !known {
+		c.AbortWithStatusJSON(
+			http.StatusUnauthorized, gin.H{
+				"error": ratelimit.ErrUnknownKey.Error(),
+			},
+		)
+		return
+	}
+	c.Set(ratelimit.ContextKey, id)
+	res, err := ctrl.service.Allow(id, route(c))
+	if err != nil {
+		log.Error(err)
+		c.Next()
+		return
+	}
+	if res.Limit == 0 {
+		c.Next()
+		return
+	}
+
+	c.Header(ratelimit.LimitHeader, strconv.Itoa(res.Limit))
+	c.Header(ratelimit.RemainingHeader, strconv.Itoa(res.Remaining))
+	c.Header(ratelimit.ResetHeader, strconv.Itoa(ceilSeconds(res.Reset)))
+	if !res.Allowed {
+		c.Header(ratelimit.RetryAfterHeader, strconv.Itoa(ceilSeconds(res.RetryAfter)))
+		c.AbortWithStatusJSON(
+			http.StatusTooManyRequests, gin.H{
+				"error": ratelimit.ErrLimited.Error(),
+			},
+		)
+		return
+	}
+	c.Next()
+}
+
+// client returns the client of the request and whether the service knows its API key, the clients sending none are
+// told apart by their IP.
+func (ctrl Controller) client(c *gin.Context) (string, bool) {
+	key := c.GetHeader(ratelimit.KeyHeader)
+	if key == "" {
+		return "ip:" + c.ClientIP(), true
+	}
+	return "key:" + key, ctrl.service.Known(key)
+}
+
+// route is the method and the path of the route matched, e.g. "POST /guest_list/:name".
+func route(c *gin.Context) string {
+	path := c.FullPath()
+	if strings.HasPrefix(path, eventPrefix+"/") {
+		path = strings.TrimPrefix(path, eventPrefix)
+	}
+	return c.Request.Method + " " + path
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
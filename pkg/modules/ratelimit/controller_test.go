@@ -0,0 +1,149 @@
+package ratelimit_test
+
+import (
+	"errors"
+	ratelimitDef "github.com/getground/tech-tasks/backend/definitions/ratelimit"
+	ratelimitMocks "github.com/getground/tech-tasks/backend/mocks/definitions/ratelimit"
+	"github.com/getground/tech-tasks/backend/pkg/modules/ratelimit"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// setupController serves a guest list route of the default event and of an event behind the middleware.
+func setupController(t *testing.T) (*gin.Engine, *ratelimitMocks.Service) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	service := ratelimitMocks.NewService(t)
+	r.Use(ratelimit.NewController(service).Limit)
+	handler := func(c *gin.Context) {
+		c.JSON(http.StatusCreated, gin.H{"name": c.Param("name")})
+	}
+	r.POST("/guest_list/:name", handler)
+	r.POST("/events/:event/guest_list/:name", handler)
+	return r, service
+}
+
+func TestController_Limit(t *testing.T) {
+	cases := []struct {
+		name            string
+		url             string
+		apiKey          string
+		unknown         bool
+		client          string
+		result          ratelimitDef.Result
+		err             error
+		expectedCode    int
+		expectedHeaders map[string]string
+	}{
+		{
+			name:         "allowed",
+			url:          "/guest_list/john",
+			client:       "ip:192.0.2.1",
+			result:       ratelimitDef.Result{Allowed: true, Limit: 5, Remaining: 4, Reset: 900 * time.Millisecond},
+			expectedCode: http.StatusCreated,
+			expectedHeaders: map[string]string{
+				ratelimitDef.LimitHeader:      "5",
+				ratelimitDef.RemainingHeader:  "4",
+				ratelimitDef.ResetHeader:      "1",
+				ratelimitDef.RetryAfterHeader: "",
+			},
+		},
+		{
+			name:         "limited",
+			url:          "/guest_list/john",
+			apiKey:       "organiser",
+			client:       "key:organiser",
+			result:       ratelimitDef.Result{Limit: 5, Reset: 5 * time.Second, RetryAfter: 1200 * time.Millisecond},
+			expectedCode: http.StatusTooManyRequests,
+			expectedHeaders: map[string]string{
+				ratelimitDef.LimitHeader:      "5",
+				ratelimitDef.RemainingHeader:  "0",
+				ratelimitDef.ResetHeader:      "5",
+				ratelimitDef.RetryAfterHeader: "2",
+			},
+		},
+		{
+			name:         "unknown key",
+			url:          "/guest_list/john",
+			apiKey:       "made-up",
+			unknown:      true,
+			expectedCode: http.StatusUnauthorized,
+			expectedHeaders: map[string]string{
+				ratelimitDef.LimitHeader: "",
+			},
+		},
+		{
+			name:         "event route",
+			url:          "/events/2/guest_list/john",
+			client:       "ip:192.0.2.1",
+			result:       ratelimitDef.Result{Limit: 5, RetryAfter: time.Second},
+			expectedCode: http.StatusTooManyRequests,
+			expectedHeaders: map[string]string{
+				ratelimitDef.RetryAfterHeader: "1",
+			},
+		},
+		{
+			name:         "unlimited",
+			url:          "/guest_list/john",
+			client:       "ip:192.0.2.1",
+			result:       ratelimitDef.Result{Allowed: true},
+			expectedCode: http.StatusCreated,
+			expectedHeaders: map[string]string{
+				ratelimitDef.LimitHeader: "",
+			},
+		},
+		{
+			name:         "error store",
+			url:          "/guest_list/john",
+			client:       "ip:192.0.2.1",
+			err:          errors.New("internal error"),
+			expectedCode: http.StatusCreated,
+			expectedHeaders: map[string]string{
+				ratelimitDef.LimitHeader: "",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				// setup
+				r, service := setupController(t)
+				w := httptest.NewRecorder()
+				req, _ := http.NewRequest(http.MethodPost, tc.url, nil)
+				req.RemoteAddr = "192.0.2.1:1234"
+				if tc.apiKey != "" {
+					req.Header.Set(ratelimitDef.KeyHeader, tc.apiKey)
+				}
+
+				//	mocks
+				if tc.apiKey != "" {
+					service.On("Known", tc.apiKey).Return(!tc.unknown)
+				}
+				if !tc.unknown {
+					service.On("Allow", tc.client, "POST /guest_list/:name").Return(tc.result, tc.err)
+				}
+
+				//	method call
+				r.ServeHTTP(w, req)
+
+				//	assert
+				assert.Equal(t, tc.expectedCode, w.Code)
+				for header, value := range tc.expectedHeaders {
+					assert.Equal(t, value, w.Header().Get(header), header)
+				}
+				if tc.expectedCode == http.StatusTooManyRequests {
+					assert.Equal(t, `{"error":"too many requests"}`, w.Body.String())
+				}
+				if tc.unknown {
+					assert.Equal(t, `{"error":"unknown API key"}`, w.Body.String())
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,77 @@
+package ratelimit
+
+import (
+	"github.com/getground/tech-tasks/backend/definitions/ratelimit"
+	"sync"
+	"time"
+)
+
+type Service struct {
+	store  ratelimit.Store
+	limits *limits
+	now    func() time.Time
+}
+
+// limits are shared by the copies of the service, so a change reaches them all.
+type limits struct {
+	mu     sync.RWMutex
+	limit  ratelimit.Limit
+	routes map[string]ratelimit.Limit
+	keys   map[string]bool
+}
+
+// NewService limits every client to limit, the routes, e.g. "POST /guest_list/:name", listed in routes get their own
+// bucket and limit instead. The clients are given the API keys listed in keys.
+func NewService(
+	store ratelimit.Store, limit ratelimit.Limit, routes map[string]ratelimit.Limit, keys []string,
+) Service {
+	return Service{
+		store:  store,
+		limits: &limits{limit: limit, routes: routes, keys: keySet(keys)},
+		now:    time.Now,
+	}
+}
+
+func (s Service) Allow(client, route string) (ratelimit.Result, error) {
+	s.limits.mu.RLock()
+	key, limit := client, s.limits.limit
+	if l, ok := s.limits.routes[route]; ok {
+		key, limit = client+" "+route, l
+	}
+	s.limits.mu.RUnlock()
+
+	if limit.Unlimited() {
+		return ratelimit.Result{Allowed: true}, nil
+	}
+	return s.store.Take(key, limit, s.now())
+}
+
+func (s Service) SetLimits(limit ratelimit.Limit, routes map[string]ratelimit.Limit) {
+	s.limits.mu.Lock()
+	defer s.limits.mu.Unlock()
+
+	s.limits.limit = limit
+	s.limits.routes = routes
+}
+
+func (s Service) Known(key string) bool {
+	s.limits.mu.RLock()
+	defer s.limits.mu.RUnlock()
+
+	return s.limits.keys[key]
+}
+
+func (s Service) SetKeys(keys []string) {
+	s.limits.mu.Lock()
+	defer s.limits.mu.Unlock()
+
+	s.limits.keys = keySet(keys)
+}
+
+func keySet(keys []string) map[string]bool {
+	set := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		set[k] = true
+	}
+	return set
+}
@@ -0,0 +1,110 @@
+package ratelimit_test
+
+import (
+	"errors"
+	ratelimitDef "github.com/getground/tech-tasks/backend/definitions/ratelimit"
+	ratelimitMocks "github.com/getground/tech-tasks/backend/mocks/definitions/ratelimit"
+	"github.com/getground/tech-tasks/backend/pkg/modules/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestService_Allow(t *testing.T) {
+	limit := ratelimitDef.Limit{Rate: 10, Burst: 20}
+	routes := map[string]ratelimitDef.Limit{
+		"POST /guest_list/:name": {Rate: 1, Burst: 5},
+		"GET /guest_list":        {},
+	}
+	allowed := ratelimitDef.Result{Allowed: true, Limit: 20, Remaining: 19}
+
+	cases := []struct {
+		name        string
+		route       string
+		key         string
+		limit       ratelimitDef.Limit
+		take        bool
+		takeErr     error
+		expected    ratelimitDef.Result
+		expectedErr error
+	}{
+		{name: "default limit", route: "GET /tables", key: "ip:1", limit: limit, take: true, expected: allowed},
+		{
+			name:     "route limit",
+			route:    "POST /guest_list/:name",
+			key:      "ip:1 POST /guest_list/:name",
+			limit:    routes["POST /guest_list/:name"],
+			take:     true,
+			expected: allowed,
+		},
+		{name: "unlimited route", route: "GET /guest_list", expected: ratelimitDef.Result{Allowed: true}},
+		{
+			name:        "error store",
+			route:       "GET /tables",
+			key:         "ip:1",
+			limit:       limit,
+			take:        true,
+			takeErr:     errors.New("internal error"),
+			expectedErr: errors.New("internal error"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				// setup
+				store := ratelimitMocks.NewStore(t)
+				srv := ratelimit.NewService(store, limit, routes, nil)
+
+				//	mocks
+				if tc.take {
+					store.On("Take", tc.key, tc.limit, mock.Anything).Return(tc.expected, tc.takeErr)
+				}
+
+				//	method call
+				res, err := srv.Allow("ip:1", tc.route)
+
+				//	assert
+				assert.Equal(t, tc.expected, res)
+				assert.Equal(t, tc.expectedErr, err)
+			},
+		)
+	}
+}
+
+func TestService_SetLimits(t *testing.T) {
+	// setup
+	store := ratelimitMocks.NewStore(t)
+	srv := ratelimit.NewService(store, ratelimitDef.Limit{}, nil, nil)
+	limit := ratelimitDef.Limit{Rate: 1, Burst: 5}
+	allowed := ratelimitDef.Result{Allowed: true, Limit: 5, Remaining: 4}
+
+	//	mocks
+	store.On("Take", "ip:1 GET /guest_list", limit, mock.Anything).Return(allowed, nil)
+
+	//	method call
+	before, _ := srv.Allow("ip:1", "GET /guest_list")
+	srv.SetLimits(ratelimitDef.Limit{}, map[string]ratelimitDef.Limit{"GET /guest_list": limit})
+	after, err := srv.Allow("ip:1", "GET /guest_list")
+
+	//	assert
+	assert.NoError(t, err)
+	assert.Equal(t, ratelimitDef.Result{Allowed: true}, before)
+	assert.Equal(t, allowed, after)
+}
+
+func TestService_Known(t *testing.T) {
+	// setup
+	srv := ratelimit.NewService(ratelimitMocks.NewStore(t), ratelimitDef.Limit{}, nil, []string{"organiser"})
+
+	//	method call
+	known := srv.Known("organiser")
+	srv.SetKeys([]string{"caterer"})
+	revoked := srv.Known("organiser")
+
+	//	assert
+	assert.True(t, known)
+	assert.False(t, revoked)
+	assert.True(t, srv.Known("caterer"))
+	assert.False(t, srv.Known(""))
+}
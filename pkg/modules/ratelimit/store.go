@@ -0,0 +1,74 @@
+package ratelimit
+
+import (
+	"context"
+	"github.com/getground/tech-tasks/backend/definitions/ratelimit"
+	"sync"
+	"time"
+)
+
+// sweepInterval is the wait between two removals of the idle buckets.
+const sweepInterval = time.Minute
+
+// MemoryStore keeps the buckets in the process, every instance of the API limits the clients on its own. Run removes
+// the idle buckets every minute so the memory doesn't grow with the clients seen.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]bucket
+}
+
+// bucket remembers the limit it was filled with, to tell when it is full again.
+type bucket struct {
+	ratelimit.Bucket
+	limit ratelimit.Limit
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: map[string]bucket{},
+	}
+}
+
+func (s *MemoryStore) Take(key string, limit ratelimit.Limit, now time.Time) (ratelimit.Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, res := s.buckets[key].Take(limit, now)
+	s.buckets[key] = bucket{Bucket: b, limit: limit}
+	return res, nil
+}
+
+// Run sweeps the idle buckets every minute until ctx is done, whether the clients make requests or not.
+func (s *MemoryStore) Run(ctx context.Context) {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.Sweep(now)
+		}
+	}
+}
+
+// Sweep removes the buckets idle long enough to be full at now, a client coming back gets a full bucket anyway.
+func (s *MemoryStore) Sweep(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, b := range s.buckets {
+		if b.Tokens+now.Sub(b.Updated).Seconds()*b.limit.Rate >= float64(b.limit.Burst) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// Len returns the number of buckets kept.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.buckets)
+}
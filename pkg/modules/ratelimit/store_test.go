@@ -0,0 +1,84 @@
+package ratelimit_test
+
+import (
+	ratelimitDef "github.com/getground/tech-tasks/backend/definitions/ratelimit"
+	"github.com/getground/tech-tasks/backend/pkg/modules/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore_Take(t *testing.T) {
+	limit := ratelimitDef.Limit{Rate: 1, Burst: 2}
+	now := time.Now()
+
+	t.Run(
+		"burst then limited", func(t *testing.T) {
+			// setup
+			store := ratelimit.NewMemoryStore()
+
+			//	method call
+			first, _ := store.Take("ip:1", limit, now)
+			second, _ := store.Take("ip:1", limit, now)
+			third, err := store.Take("ip:1", limit, now)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, ratelimitDef.Result{Allowed: true, Limit: 2, Remaining: 1, Reset: time.Second}, first)
+			assert.Equal(t, ratelimitDef.Result{Allowed: true, Limit: 2, Remaining: 0, Reset: 2 * time.Second}, second)
+			assert.Equal(
+				t, ratelimitDef.Result{Limit: 2, Remaining: 0, Reset: 2 * time.Second, RetryAfter: time.Second}, third,
+			)
+		},
+	)
+
+	t.Run(
+		"refilled", func(t *testing.T) {
+			// setup
+			store := ratelimit.NewMemoryStore()
+			_, _ = store.Take("ip:1", limit, now)
+			_, _ = store.Take("ip:1", limit, now)
+
+			//	method call
+			res, err := store.Take("ip:1", limit, now.Add(1500*time.Millisecond))
+
+			//	assert
+			assert.NoError(t, err)
+			assert.True(t, res.Allowed)
+			assert.Equal(t, 0, res.Remaining)
+			assert.Equal(t, 1500*time.Millisecond, res.Reset)
+		},
+	)
+
+	t.Run(
+		"clients apart", func(t *testing.T) {
+			// setup
+			store := ratelimit.NewMemoryStore()
+			_, _ = store.Take("ip:1", limit, now)
+			_, _ = store.Take("ip:1", limit, now)
+
+			//	method call
+			res, err := store.Take("ip:2", limit, now)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.True(t, res.Allowed)
+			assert.Equal(t, 1, res.Remaining)
+		},
+	)
+
+	t.Run(
+		"idle buckets removed", func(t *testing.T) {
+			// setup
+			store := ratelimit.NewMemoryStore()
+			_, _ = store.Take("ip:1", limit, now)
+			_, _ = store.Take("ip:2", ratelimitDef.Limit{Rate: 0.001, Burst: 2}, now)
+
+			//	method call
+			store.Sweep(now.Add(2 * time.Minute))
+
+			//	assert
+			assert.Equal(t, 1, store.Len())
+		},
+	)
+}
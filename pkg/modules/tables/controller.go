@@ -1,6 +1,11 @@
 package tables
 
 import (
+	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/audit"
+	"github.com/getground/tech-tasks/backend/definitions/etag"
+	"github.com/getground/tech-tasks/backend/definitions/events"
+	"github.com/getground/tech-tasks/backend/definitions/pagination"
 	"github.com/getground/tech-tasks/backend/definitions/tables"
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
@@ -27,21 +32,129 @@ func (ctrl Controller) Create(c *gin.Context) {
 		return
 	}
 
-	res, err := ctrl.service.Create(req)
+	res, err := ctrl.scoped(c).Create(req)
 	if err != nil {
 		log.Error(err)
-		c.JSON(http.StatusInternalServerError, err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, events.ErrNotAllowed) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, res)
 }
 
+func (ctrl Controller) List(c *gin.Context) {
+	req, err := ctrl.handler.List(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.scoped(c).GetTables(req)
+	if err != nil {
+		log.Error(err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, pagination.ErrInvalidCursor) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(
+			status, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// Get answers the table with its version in the ETag header, the version can be sent back in If-Match to resize it.
+func (ctrl Controller) Get(c *gin.Context) {
+	id, err := ctrl.handler.Get(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.scoped(c).Get(id)
+	if err != nil {
+		log.Error(err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, tables.ErrNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(
+			status, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.Header(etag.Header, etag.Format(res.Version))
+	c.JSON(http.StatusOK, res)
+}
+
+// Resize answers a stale If-Match header with 412.
+func (ctrl Controller) Resize(c *gin.Context) {
+	req, err := ctrl.handler.Resize(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.scoped(c).Resize(req)
+	if err != nil {
+		log.Error(err)
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, tables.ErrNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, tables.ErrSeatsTaken), errors.Is(err, events.ErrNotAllowed):
+			status = http.StatusConflict
+		case errors.Is(err, etag.ErrStale):
+			status = http.StatusPreconditionFailed
+		}
+		c.JSON(
+			status, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.Header(etag.Header, etag.Format(res.Version))
+	c.JSON(http.StatusOK, res)
+}
+
 func (ctrl Controller) CountEmptySeats(c *gin.Context) {
-	seatsEmpty := ctrl.service.CountEmptySeats()
+	seatsEmpty := ctrl.scoped(c).CountEmptySeats()
 	c.JSON(
 		http.StatusOK, gin.H{
 			"seats_empty": seatsEmpty,
 		},
 	)
 }
+
+// scoped returns the service of the event the request is scoped to, acting on behalf of the actor of the request.
+func (ctrl Controller) scoped(c *gin.Context) tables.Service {
+	return ctrl.service.ForEvent(c.GetUint(events.ContextKey)).ForActor(c.GetString(audit.ContextKey))
+}
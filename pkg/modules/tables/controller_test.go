@@ -3,6 +3,9 @@ package tables_test
 import (
 	"encoding/json"
 	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/etag"
+	eventsDef "github.com/getground/tech-tasks/backend/definitions/events"
+	"github.com/getground/tech-tasks/backend/definitions/pagination"
 	tableDef "github.com/getground/tech-tasks/backend/definitions/tables"
 	tableMocks "github.com/getground/tech-tasks/backend/mocks/definitions/tables"
 	"github.com/getground/tech-tasks/backend/pkg/modules/tables"
@@ -25,6 +28,10 @@ func setupController() (*gin.Engine, tables.Controller, ctrlMocks) {
 
 	handler := tables.NewHandler()
 	service := new(tableMocks.Service)
+	// the requests are not nested under an event, they are scoped to the zero event
+	service.On("ForEvent", uint(0)).Return(service).Maybe()
+	// the requests have no actor without the audit middleware
+	service.On("ForActor", "").Return(service).Maybe()
 	ctrl := tables.NewController(handler, service)
 	mocks := ctrlMocks{handler, service}
 
@@ -93,6 +100,35 @@ func TestController_Create(t *testing.T) {
 		},
 	)
 
+	t.Run(
+		"doors open", func(t *testing.T) {
+			//	test data
+			createReq := tableDef.CreateRequest{
+				Capacity: 10,
+			}
+
+			// mocks
+			m.service.On("Create", createReq).Return(tableDef.CreateResponse{}, eventsDef.ErrNotAllowed).Once()
+
+			//	request
+			body, err := json.Marshal(&createReq)
+			if err != nil {
+				t.Errorf("Error converting struct to json - test controller: %v\n", err)
+			}
+			req, err := http.NewRequest(http.MethodPost, "/tables", strings.NewReader(string(body)))
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			// assert
+			assert.Equal(t, http.StatusConflict, rr.Code)
+			m.service.AssertExpectations(t)
+		},
+	)
+
 	t.Run(
 		"success", func(t *testing.T) {
 			//	test data
@@ -132,6 +168,221 @@ func TestController_Create(t *testing.T) {
 	)
 }
 
+func TestController_List(t *testing.T) {
+	//	setup
+	r, ctrl, m := setupController()
+	r.GET("/tables", ctrl.List)
+	t.Run(
+		"invalid query", func(t *testing.T) {
+			//	request
+			req, err := http.NewRequest(http.MethodGet, "/tables?limit=0x", http.NoBody)
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			// assert
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+		},
+	)
+
+	t.Run(
+		"invalid cursor", func(t *testing.T) {
+			//	mocks
+			listReq := tableDef.ListRequest{}
+			listReq.Cursor = "abc"
+			listReq.Limit = pagination.DefaultLimit
+			m.service.On("GetTables", listReq).Return(tableDef.ListDTO{}, pagination.ErrInvalidCursor).Once()
+
+			//	request
+			req, err := http.NewRequest(http.MethodGet, "/tables?cursor=abc", http.NoBody)
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			// assert
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+		},
+	)
+
+	t.Run(
+		"service error", func(t *testing.T) {
+			//	mocks
+			// every table is listed when neither a limit nor a cursor is sent
+			listReq := tableDef.ListRequest{}
+			m.service.On("GetTables", listReq).Return(tableDef.ListDTO{}, errors.New("internal error")).Once()
+
+			//	request
+			req, err := http.NewRequest(http.MethodGet, "/tables", http.NoBody)
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			// assert
+			assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			//	test data
+			res := tableDef.ListDTO{
+				Tables:     []tableDef.TableDTO{{ID: 1, Capacity: 4, EmptySeats: 6}},
+				Total:      2,
+				NextCursor: "next",
+			}
+			listReq := tableDef.ListRequest{MinEmptySeats: 2}
+			listReq.Limit = 1
+
+			//	mocks
+			m.service.On("GetTables", listReq).Return(res, nil).Once()
+
+			//	request
+			req, err := http.NewRequest(http.MethodGet, "/tables?min_empty_seats=2&limit=1", http.NoBody)
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			expected := `{"tables":[{"id":1,"capacity":4,"empty_seats":6,"version":0}],"total":2,"next_cursor":"next"}`
+
+			// assert
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, expected, rr.Body.String())
+		},
+	)
+	m.service.AssertExpectations(t)
+}
+
+func TestController_Get(t *testing.T) {
+	//	setup
+	r, ctrl, m := setupController()
+	r.GET("/tables/:id", ctrl.Get)
+
+	cases := []struct {
+		name       string
+		path       string
+		serviceErr error
+		status     int
+		expected   string
+	}{
+		{name: "invalid id", path: "/tables/one", status: http.StatusBadRequest},
+		{name: "table not found", path: "/tables/1", serviceErr: tableDef.ErrNotFound, status: http.StatusNotFound},
+		{
+			name: "success", path: "/tables/1", status: http.StatusOK,
+			expected: `{"id":1,"capacity":4,"empty_seats":8,"version":2}`,
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				// mocks
+				if c.status != http.StatusBadRequest {
+					m.service.On("Get", uint(1)).
+						Return(tableDef.TableDTO{ID: 1, Capacity: 4, EmptySeats: 8, Version: 2}, c.serviceErr).
+						Once()
+				}
+
+				//	request
+				req, err := http.NewRequest(http.MethodGet, c.path, http.NoBody)
+				if err != nil {
+					t.Errorf("Error requesting test controller: %v\n", err)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, req)
+
+				// assert
+				assert.Equal(t, c.status, rr.Code)
+				if c.expected != "" {
+					assert.Equal(t, c.expected, rr.Body.String())
+					assert.Equal(t, `"2"`, rr.Header().Get(etag.Header))
+				}
+				m.service.AssertExpectations(t)
+			},
+		)
+	}
+}
+
+func TestController_Resize(t *testing.T) {
+	//	setup
+	r, ctrl, m := setupController()
+	r.PUT("/tables/:id", ctrl.Resize)
+
+	cases := []struct {
+		name       string
+		path       string
+		ifMatch    string
+		version    int64
+		body       string
+		serviceErr error
+		status     int
+		expected   string
+	}{
+		{name: "invalid id", path: "/tables/one", body: `{"capacity":8}`, status: http.StatusBadRequest},
+		{name: "missing capacity", path: "/tables/1", body: `{}`, status: http.StatusBadRequest},
+		{name: "invalid If-Match", path: "/tables/1", ifMatch: "2", body: `{"capacity":8}`, status: http.StatusBadRequest},
+		{
+			name: "stale version", path: "/tables/1", ifMatch: `"2"`, version: 2, body: `{"capacity":8}`,
+			serviceErr: etag.ErrStale, status: http.StatusPreconditionFailed,
+		},
+		{
+			name: "table not found", path: "/tables/1", body: `{"capacity":8}`, serviceErr: tableDef.ErrNotFound,
+			status: http.StatusNotFound,
+		},
+		{
+			name: "seats taken", path: "/tables/1", body: `{"capacity":8}`, serviceErr: tableDef.ErrSeatsTaken,
+			status: http.StatusConflict,
+		},
+		{
+			name: "service error", path: "/tables/1", body: `{"capacity":8}`, serviceErr: errors.New("internal error"),
+			status: http.StatusInternalServerError,
+		},
+		{
+			name: "success", path: "/tables/1", ifMatch: `"2"`, version: 2, body: `{"capacity":8}`,
+			status: http.StatusOK, expected: `{"id":1,"capacity":4,"empty_seats":8,"version":3}`,
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				// mocks
+				if c.status != http.StatusBadRequest {
+					m.service.On("Resize", tableDef.ResizeRequest{ID: 1, Capacity: 8, Version: c.version}).
+						Return(tableDef.TableDTO{ID: 1, Capacity: 4, EmptySeats: 8, Version: 3}, c.serviceErr).
+						Once()
+				}
+
+				//	request
+				req, err := http.NewRequest(http.MethodPut, c.path, strings.NewReader(c.body))
+				if err != nil {
+					t.Errorf("Error requesting test controller: %v\n", err)
+				}
+				if c.ifMatch != "" {
+					req.Header.Set(etag.IfMatchHeader, c.ifMatch)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, req)
+
+				// assert
+				assert.Equal(t, c.status, rr.Code)
+				if c.expected != "" {
+					assert.Equal(t, c.expected, rr.Body.String())
+					assert.Equal(t, `"3"`, rr.Header().Get(etag.Header))
+				}
+				m.service.AssertExpectations(t)
+			},
+		)
+	}
+}
+
 func TestController_CountEmptySeats(t *testing.T) {
 	//	setup
 	r, ctrl, m := setupController()
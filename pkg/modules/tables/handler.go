@@ -1,8 +1,12 @@
 package tables
 
 import (
+	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/etag"
+	"github.com/getground/tech-tasks/backend/definitions/pagination"
 	"github.com/getground/tech-tasks/backend/definitions/tables"
 	"github.com/gin-gonic/gin"
+	"strconv"
 )
 
 type Handler struct{}
@@ -15,3 +19,38 @@ func (h Handler) Create(c *gin.Context) (req tables.CreateRequest, err error) {
 	err = c.ShouldBindJSON(&req)
 	return
 }
+
+func (h Handler) List(c *gin.Context) (req tables.ListRequest, err error) {
+	err = c.ShouldBindQuery(&req)
+	// a request without a limit or a cursor gets every row as it did before the listing was paginated
+	if req.Limit == 0 && req.Cursor != "" {
+		req.Limit = pagination.DefaultLimit
+	}
+	return
+}
+
+func (h Handler) Get(c *gin.Context) (id uint, err error) {
+	return tableID(c)
+}
+
+// Resize takes the version the table must still be at from the If-Match header.
+func (h Handler) Resize(c *gin.Context) (req tables.ResizeRequest, err error) {
+	req.ID, err = tableID(c)
+	if err != nil {
+		return
+	}
+	req.Version, err = etag.Parse(c.GetHeader(etag.IfMatchHeader))
+	if err != nil {
+		return
+	}
+	err = c.ShouldBindJSON(&req)
+	return
+}
+
+func tableID(c *gin.Context) (uint, error) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		return 0, errors.New("id must be a table id")
+	}
+	return uint(id), nil
+}
@@ -0,0 +1,22 @@
+package tables
+
+import (
+	"github.com/getground/tech-tasks/backend/definitions/tables"
+)
+
+func mapTablesToDTO(page tables.Page) tables.ListDTO {
+	list := make([]tables.TableDTO, 0, len(page.Tables))
+	for _, t := range page.Tables {
+		list = append(list, mapTableToDTO(t))
+	}
+	return tables.ListDTO{Tables: list, Total: page.Total, NextCursor: page.NextCursor}
+}
+
+func mapTableToDTO(t tables.Table) tables.TableDTO {
+	return tables.TableDTO{
+		ID:         t.ID,
+		Capacity:   t.Capacity,
+		EmptySeats: t.EmptySeats,
+		Version:    t.Version,
+	}
+}
@@ -1,24 +1,57 @@
 package tables
 
 import (
+	"github.com/getground/tech-tasks/backend/definitions/attendance"
+	"github.com/getground/tech-tasks/backend/definitions/audit"
+	"github.com/getground/tech-tasks/backend/definitions/etag"
+	"github.com/getground/tech-tasks/backend/definitions/pagination"
 	"github.com/getground/tech-tasks/backend/definitions/tables"
+	"github.com/getground/tech-tasks/backend/pkg/journal"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
 )
 
 type repository struct {
-	db *gorm.DB
+	db    *gorm.DB
+	event uint
+	actor string
 }
 
 func NewRepository(db *gorm.DB) repository {
-	return repository{db: db}
+	return repository{db: db, actor: audit.SystemActor}
 }
 
-func (r repository) Create(req tables.CreateRequest) (tables.Table, error) {
+func (r repository) ForEvent(event uint) tables.Repository {
+	r.event = event
+	return r
+}
+
+func (r repository) ForActor(actor string) tables.Repository {
+	r.actor = actor
+	return r
+}
+
+func (r repository) Create(req tables.CreateRequest, at time.Time) (tables.Table, error) {
 	t := tables.Table{
+		EventID:    r.event,
 		Capacity:   req.Capacity,
 		EmptySeats: req.Capacity,
+		Version:    1,
 	}
-	err := r.db.Create(&t).Error
+	err := r.db.Transaction(
+		func(tx *gorm.DB) error {
+			err := tx.Create(&t).Error
+			if err != nil {
+				return err
+			}
+			err = r.record(tx, audit.ActionTableCreated, nil, &t, at)
+			if err != nil {
+				return err
+			}
+			return r.track(tx, attendance.TypeTableCreated, t.ID, req.Capacity, at)
+		},
+	)
 	if err != nil {
 		return tables.Table{}, err
 	}
@@ -26,11 +59,144 @@ func (r repository) Create(req tables.CreateRequest) (tables.Table, error) {
 }
 
 func (r repository) GetByID(id uint) (t tables.Table, err error) {
-	err = r.db.Where(tables.Table{ID: id}).First(&t).Error
+	err = r.scoped(r.db).Where(tables.Table{ID: id}).First(&t).Error
+	return
+}
+
+func (r repository) List() (list []tables.Table, err error) {
+	err = r.scoped(r.db).Order("id").Find(&list).Error
+	return
+}
+
+func (r repository) ListPage(req tables.ListRequest) (page tables.Page, err error) {
+	err = r.filter(req).Count(&page.Total).Error
+	if err != nil {
+		return
+	}
+
+	q := r.filter(req)
+	if req.Cursor != "" {
+		var c cursor
+		err = pagination.DecodeCursor(req.Cursor, &c)
+		if err != nil {
+			return
+		}
+		q = q.Where("id > ?", c.ID)
+	}
+	if req.Limit > 0 {
+		// one more row tells if there is a next page
+		q = q.Limit(req.Limit + 1)
+	}
+	err = q.Order("id").Find(&page.Tables).Error
+	if err != nil {
+		return
+	}
+
+	if req.Limit > 0 && len(page.Tables) > req.Limit {
+		page.Tables = page.Tables[:req.Limit]
+		page.NextCursor = pagination.EncodeCursor(cursor{ID: page.Tables[req.Limit-1].ID})
+	}
+	return
+}
+
+// Resize sets the number of seats of the table, the seats are the empty seats and the seats of the guests sitting at
+// the table. The difference is added to the capacity and the empty seats, the table can't shrink below the seats
+// reserved or taken. A version other than zero must be the version of the table.
+func (r repository) Resize(id uint, seats int64, version int64, at time.Time) (t tables.Table, err error) {
+	err = r.db.Transaction(
+		func(tx *gorm.DB) error {
+			err := r.scoped(tx).Clauses(clause.Locking{Strength: "UPDATE"}).Where(tables.Table{ID: id}).First(&t).Error
+			if err != nil {
+				return err
+			}
+			if version != 0 && t.Version != version {
+				return etag.ErrStale
+			}
+
+			var seated int64
+			err = tx.Table("guests").
+				Select("COALESCE(SUM(accompanying + 1), 0)").
+				Where("table_id = ? AND time_arrived IS NOT NULL AND checked_out = 0", id).
+				Scan(&seated).
+				Error
+			if err != nil {
+				return err
+			}
+
+			delta := seats - (t.EmptySeats + seated)
+			if t.Capacity+delta < 0 || t.EmptySeats+delta < 0 {
+				return tables.ErrSeatsTaken
+			}
+			before := t
+			t.Capacity += delta
+			t.EmptySeats += delta
+			t.Version++
+			err = update(tx, before, map[string]interface{}{"capacity": t.Capacity, "empty_seats": t.EmptySeats})
+			if err != nil {
+				return err
+			}
+			err = r.record(tx, audit.ActionTableResized, &before, &t, at)
+			if err != nil {
+				return err
+			}
+			return r.track(tx, attendance.TypeTableResized, id, seats, at)
+		},
+	)
+	if err != nil {
+		t = tables.Table{}
+	}
 	return
 }
 
 func (r repository) CountEmptySeats() (count int) {
-	r.db.Model(&tables.Table{}).Select("SUM(empty_seats)").Scan(&count)
+	r.scoped(r.db.Model(&tables.Table{})).Select("SUM(empty_seats)").Scan(&count)
 	return
 }
+
+func (r repository) filter(req tables.ListRequest) *gorm.DB {
+	q := r.scoped(r.db.Model(&tables.Table{}))
+	if req.MinEmptySeats > 0 {
+		q = q.Where("empty_seats >= ?", req.MinEmptySeats)
+	}
+	return q
+}
+
+// record appends the change of the table to the audit log and its message to the outbox in the transaction of the
+// change, a nil before is a table created.
+func (r repository) record(tx *gorm.DB, action audit.Action, before, after *tables.Table, at time.Time) error {
+	var from audit.State
+	if before != nil {
+		from = audit.Snapshot(nil, before)
+	}
+	return journal.Record(tx, r.event, r.actor, action, from, audit.Snapshot(nil, after), at)
+}
+
+// track appends the seats of the table to the attendance stream in the transaction of the change.
+func (r repository) track(tx *gorm.DB, typ attendance.Type, id uint, seats int64, at time.Time) error {
+	rec := attendance.Record{EventID: r.event, Type: typ, TableID: id, People: seats, OccurredAt: at}
+	return tx.Create(&rec).Error
+}
+
+// update sets the columns of the table if it is still at the version it was read at and increases its version, it
+// returns etag.ErrStale when the table changed since.
+func update(tx *gorm.DB, t tables.Table, columns map[string]interface{}) error {
+	columns["version"] = gorm.Expr("version + 1")
+	res := tx.Model(&tables.Table{}).Where("id = ? AND version = ?", t.ID, t.Version).Updates(columns)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return etag.ErrStale
+	}
+	return nil
+}
+
+// scoped narrows down q to the tables of the event of the repository.
+func (r repository) scoped(q *gorm.DB) *gorm.DB {
+	return q.Where("event_id = ?", r.event)
+}
+
+// cursor holds the id of the last table of a page.
+type cursor struct {
+	ID uint `json:"id"`
+}
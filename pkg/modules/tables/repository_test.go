@@ -5,6 +5,10 @@ import (
 	"database/sql/driver"
 	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
+	attendanceDef "github.com/getground/tech-tasks/backend/definitions/attendance"
+	auditDef "github.com/getground/tech-tasks/backend/definitions/audit"
+	"github.com/getground/tech-tasks/backend/definitions/etag"
+	"github.com/getground/tech-tasks/backend/definitions/pagination"
 	tablesDef "github.com/getground/tech-tasks/backend/definitions/tables"
 	"github.com/getground/tech-tasks/backend/pkg/database"
 	"github.com/getground/tech-tasks/backend/pkg/modules/tables"
@@ -30,13 +34,40 @@ func setupIntegrationRepo(t *testing.T) (tablesDef.Repository, repoMocks) {
 		t.Fatalf("an error '%s' was not expected when creating grom database connection", err)
 	}
 	m.MatchExpectationsInOrder(false)
-	r := tables.NewRepository(gDB)
+	r := tables.NewRepository(gDB).ForEvent(1)
 	return r, repoMocks{
 		db:      db,
 		sqlMock: m,
 	}
 }
 
+// expectAudit expects the audit entry of a change to a table and its outbox message.
+func expectAudit(m repoMocks, actor string, action auditDef.Action, table uint, before, after string) {
+	q := "INSERT INTO `audit_log` (`event_id`,`actor`,`action`,`guest`,`table_id`,`before`,`after`,`created_at`) " +
+		"VALUES (?,?,?,?,?,?,?,?)"
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).
+		WithArgs(1, actor, action, "", table, before, after, now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectOutbox(m, action)
+}
+
+// expectOutbox expects the message of the change in the outbox.
+func expectOutbox(m repoMocks, action auditDef.Action) {
+	q := "INSERT INTO `outbox` (`id`,`event_id`,`type`,`payload`,`created_at`,`dispatched_at`) VALUES (?,?,?,?,?,?)"
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).
+		WithArgs(sqlmock.AnyArg(), 1, action, sqlmock.AnyArg(), now, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+// expectTrack expects the record of the seats of a table in the attendance stream.
+func expectTrack(m repoMocks, typ attendanceDef.Type, table uint, seats int64) {
+	q := "INSERT INTO `attendance` (`event_id`,`type`,`guest`,`table_id`,`people`,`rsvp`,`occurred_at`) " +
+		"VALUES (?,?,?,?,?,?,?)"
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).
+		WithArgs(1, typ, "", table, seats, "", now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
 func TestRepository_Create(t *testing.T) {
 	t.Run(
 		"error", func(t *testing.T) {
@@ -47,14 +78,16 @@ func TestRepository_Create(t *testing.T) {
 			// test data
 			req := tablesDef.CreateRequest{Capacity: 10}
 			// mocks
-			q := "INSERT INTO `tables` (`capacity`,`empty_seats`) VALUES (?,?)"
+			q := "INSERT INTO `tables` (`event_id`,`capacity`,`empty_seats`,`version`) VALUES (?,?,?,?)"
+			m.sqlMock.ExpectBegin()
 			m.sqlMock.
 				ExpectExec(regexp.QuoteMeta(q)).
-				WithArgs(req.Capacity, req.Capacity).
+				WithArgs(1, req.Capacity, req.Capacity, 1).
 				WillReturnError(errors.New("table not found"))
+			m.sqlMock.ExpectRollback()
 
 			// method call
-			tbl, err := repo.Create(req)
+			tbl, err := repo.Create(req, now)
 
 			//	assert
 			assert.Error(t, err)
@@ -72,21 +105,27 @@ func TestRepository_Create(t *testing.T) {
 			req := tablesDef.CreateRequest{Capacity: 10}
 			// mocks
 			m.sqlMock.ExpectBegin()
-			q := "INSERT INTO `tables` (`capacity`,`empty_seats`) VALUES (?,?)"
+			q := "INSERT INTO `tables` (`event_id`,`capacity`,`empty_seats`,`version`) VALUES (?,?,?,?)"
 			m.sqlMock.
 				ExpectExec(regexp.QuoteMeta(q)).
-				WithArgs(int(req.Capacity), int(req.Capacity)).
+				WithArgs(1, int(req.Capacity), int(req.Capacity), 1).
 				WillReturnResult(sqlmock.NewResult(1, 1))
+			expectAudit(
+				m, "alice", auditDef.ActionTableCreated, 1, "", `{"table":{"id":1,"capacity":10,"empty_seats":10}}`,
+			)
+			expectTrack(m, attendanceDef.TypeTableCreated, 1, 10)
 			m.sqlMock.ExpectCommit()
 
 			// method call
-			res, err := repo.Create(req)
+			res, err := repo.ForActor("alice").Create(req, now)
 
 			// expectations
 			expecteTable := tablesDef.Table{
 				ID:         1,
+				EventID:    1,
 				Capacity:   10,
 				EmptySeats: 10,
+				Version:    1,
 			}
 
 			//	assert
@@ -107,10 +146,10 @@ func TestRepository_GetByID(t *testing.T) {
 			id := uint(1)
 
 			//	mocks
-			q := "SELECT * FROM `tables` WHERE `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1"
+			q := "SELECT * FROM `tables` WHERE event_id = ? AND `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1"
 			m.sqlMock.
 				ExpectQuery(regexp.QuoteMeta(q)).
-				WithArgs(id).
+				WithArgs(1, id).
 				WillReturnError(errors.New("table not found"))
 
 			//	method call
@@ -135,15 +174,15 @@ func TestRepository_GetByID(t *testing.T) {
 			id := uint(1)
 
 			// columns
-			tColumns := []string{"id", "capacity", "empty_seats"}
+			tColumns := []string{"id", "capacity", "empty_seats", "version"}
 
 			// test data
-			tValues := []driver.Value{1, 10, 10}
+			tValues := []driver.Value{1, 10, 10, 1}
 			//	mocks
-			q := "SELECT * FROM `tables` WHERE `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1"
+			q := "SELECT * FROM `tables` WHERE event_id = ? AND `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1"
 			m.sqlMock.
 				ExpectQuery(regexp.QuoteMeta(q)).
-				WithArgs(id).
+				WithArgs(1, id).
 				WillReturnRows(sqlmock.NewRows(tColumns).AddRow(tValues...))
 
 			//	method call
@@ -154,6 +193,7 @@ func TestRepository_GetByID(t *testing.T) {
 				ID:         1,
 				Capacity:   10,
 				EmptySeats: 10,
+				Version:    1,
 			}
 
 			//	assert
@@ -163,6 +203,194 @@ func TestRepository_GetByID(t *testing.T) {
 	)
 }
 
+func TestRepository_List(t *testing.T) {
+	t.Run(
+		"error", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			q := "SELECT * FROM `tables` WHERE event_id = ? ORDER BY id"
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(q)).
+				WillReturnError(errors.New("error listing tables"))
+
+			//	method call
+			res, err := repo.List()
+
+			//	assert
+			assert.Error(t, err)
+			assert.Empty(t, res)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			// columns
+			tColumns := []string{"id", "capacity", "empty_seats"}
+
+			//	mocks
+			q := "SELECT * FROM `tables` WHERE event_id = ? ORDER BY id"
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(q)).
+				WillReturnRows(sqlmock.NewRows(tColumns).AddRow(1, 10, 10).AddRow(2, 4, 2))
+
+			//	method call
+			res, err := repo.List()
+
+			// expectation
+			expected := []tablesDef.Table{
+				{ID: 1, Capacity: 10, EmptySeats: 10},
+				{ID: 2, Capacity: 4, EmptySeats: 2},
+			}
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, expected, res)
+		},
+	)
+}
+
+func TestRepository_ListPage(t *testing.T) {
+	tColumns := []string{"id", "capacity", "empty_seats"}
+
+	t.Run(
+		"error count", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			q := "SELECT count(*) FROM `tables` WHERE event_id = ?"
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(q)).
+				WillReturnError(errors.New("error counting tables"))
+
+			//	method call
+			res, err := repo.ListPage(tablesDef.ListRequest{})
+
+			//	assert
+			assert.Error(t, err)
+			assert.Empty(t, res)
+		},
+	)
+
+	t.Run(
+		"invalid cursor", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			q := "SELECT count(*) FROM `tables` WHERE event_id = ?"
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(q)).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+			//	method call
+			req := tablesDef.ListRequest{}
+			req.Cursor = "not a cursor"
+			_, err := repo.ListPage(req)
+
+			//	assert
+			assert.ErrorIs(t, err, pagination.ErrInvalidCursor)
+		},
+	)
+
+	t.Run(
+		"error find", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			count := "SELECT count(*) FROM `tables` WHERE event_id = ?"
+			q := "SELECT * FROM `tables` WHERE event_id = ? ORDER BY id"
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(count)).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(q)).
+				WillReturnError(errors.New("error listing tables"))
+
+			//	method call
+			res, err := repo.ListPage(tablesDef.ListRequest{})
+
+			//	assert
+			assert.Error(t, err)
+			assert.Empty(t, res.Tables)
+		},
+	)
+
+	t.Run(
+		"first page", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			count := "SELECT count(*) FROM `tables` WHERE event_id = ? AND empty_seats >= ?"
+			q := "SELECT * FROM `tables` WHERE event_id = ? AND empty_seats >= ? ORDER BY id LIMIT 2"
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(count)).
+				WithArgs(1, 2).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(q)).
+				WithArgs(1, 2).
+				WillReturnRows(sqlmock.NewRows(tColumns).AddRow(1, 10, 10).AddRow(3, 4, 2))
+
+			//	method call
+			req := tablesDef.ListRequest{MinEmptySeats: 2}
+			req.Limit = 1
+			res, err := repo.ListPage(req)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, []tablesDef.Table{{ID: 1, Capacity: 10, EmptySeats: 10}}, res.Tables)
+			assert.Equal(t, int64(3), res.Total)
+			assert.Equal(t, pagination.EncodeCursor(map[string]uint{"id": 1}), res.NextCursor)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"last page", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			count := "SELECT count(*) FROM `tables` WHERE event_id = ?"
+			q := "SELECT * FROM `tables` WHERE event_id = ? AND id > ? ORDER BY id LIMIT 2"
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(count)).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(q)).
+				WithArgs(1, 1).
+				WillReturnRows(sqlmock.NewRows(tColumns).AddRow(2, 4, 2))
+
+			//	method call
+			req := tablesDef.ListRequest{}
+			req.Cursor = pagination.EncodeCursor(map[string]uint{"id": 1})
+			req.Limit = 1
+			res, err := repo.ListPage(req)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, []tablesDef.Table{{ID: 2, Capacity: 4, EmptySeats: 2}}, res.Tables)
+			assert.Empty(t, res.NextCursor)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
+
 func TestRepository_CountEmptySeats(t *testing.T) {
 	t.Run(
 		"success", func(t *testing.T) {
@@ -173,7 +401,7 @@ func TestRepository_CountEmptySeats(t *testing.T) {
 			// test data
 			sum := int(10)
 			//	mocks
-			q := "SELECT SUM(empty_seats) FROM `tables`"
+			q := "SELECT SUM(empty_seats) FROM `tables` WHERE event_id = ?"
 			m.sqlMock.
 				ExpectQuery(regexp.QuoteMeta(q)).
 				WillReturnRows(sqlmock.NewRows([]string{"sum(empty_seats)"}).AddRow(sum))
@@ -186,3 +414,154 @@ func TestRepository_CountEmptySeats(t *testing.T) {
 		},
 	)
 }
+
+func TestRepository_Resize(t *testing.T) {
+	selectTable := "SELECT * FROM `tables` WHERE event_id = ? AND `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1 FOR UPDATE"
+	sumSeated := "SELECT COALESCE(SUM(accompanying + 1), 0) FROM `guests` " +
+		"WHERE table_id = ? AND time_arrived IS NOT NULL AND checked_out = 0"
+	update := "UPDATE `tables` SET `capacity`=?,`empty_seats`=?,`version`=version + 1 WHERE id = ? AND version = ?"
+	tColumns := []string{"id", "capacity", "empty_seats", "version"}
+
+	t.Run(
+		"table not found", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(selectTable)).WithArgs(1, 1).WillReturnRows(sqlmock.NewRows(tColumns))
+			m.sqlMock.ExpectRollback()
+
+			//	method call
+			res, err := repo.Resize(1, 8, 0, now)
+
+			//	assert
+			assert.Error(t, err)
+			assert.Empty(t, res)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"stale version", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(selectTable)).
+				WithArgs(1, 1).
+				WillReturnRows(sqlmock.NewRows(tColumns).AddRow(1, 3, 7, 2))
+			m.sqlMock.ExpectRollback()
+
+			//	method call
+			res, err := repo.Resize(1, 12, 1, now)
+
+			//	assert
+			assert.ErrorIs(t, err, etag.ErrStale)
+			assert.Empty(t, res)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"changed concurrently", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(selectTable)).
+				WithArgs(1, 1).
+				WillReturnRows(sqlmock.NewRows(tColumns).AddRow(1, 3, 7, 2))
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(sumSeated)).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"seated"}).AddRow(3))
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(update)).
+				WithArgs(5, 9, 1, 2).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			m.sqlMock.ExpectRollback()
+
+			//	method call
+			res, err := repo.Resize(1, 12, 0, now)
+
+			//	assert
+			assert.ErrorIs(t, err, etag.ErrStale)
+			assert.Empty(t, res)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"seats taken", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			// ten seats, three taken by a party sitting and four more reserved
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(selectTable)).
+				WithArgs(1, 1).
+				WillReturnRows(sqlmock.NewRows(tColumns).AddRow(1, 3, 7, 2))
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(sumSeated)).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"seated"}).AddRow(3))
+			m.sqlMock.ExpectRollback()
+
+			//	method call
+			res, err := repo.Resize(1, 6, 0, now)
+
+			//	assert
+			assert.ErrorIs(t, err, tablesDef.ErrSeatsTaken)
+			assert.Empty(t, res)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(selectTable)).
+				WithArgs(1, 1).
+				WillReturnRows(sqlmock.NewRows(tColumns).AddRow(1, 3, 7, 2))
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(sumSeated)).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"seated"}).AddRow(3))
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(update)).
+				WithArgs(5, 9, 1, 2).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			expectAudit(
+				m, auditDef.SystemActor, auditDef.ActionTableResized, 1,
+				`{"table":{"id":1,"capacity":3,"empty_seats":7}}`, `{"table":{"id":1,"capacity":5,"empty_seats":9}}`,
+			)
+			expectTrack(m, attendanceDef.TypeTableResized, 1, 12)
+			m.sqlMock.ExpectCommit()
+
+			//	method call
+			res, err := repo.Resize(1, 12, 2, now)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, tablesDef.Table{ID: 1, Capacity: 5, EmptySeats: 9, Version: 3}, res)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
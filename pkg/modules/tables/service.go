@@ -2,19 +2,62 @@ package tables
 
 import (
 	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/audit"
+	"github.com/getground/tech-tasks/backend/definitions/clock"
+	"github.com/getground/tech-tasks/backend/definitions/events"
+	"github.com/getground/tech-tasks/backend/definitions/notifications"
 	"github.com/getground/tech-tasks/backend/definitions/tables"
+	"github.com/getground/tech-tasks/backend/definitions/waitlist"
+	"gorm.io/gorm"
 )
 
 type Service struct {
 	repository tables.Repository
+	publisher  notifications.Publisher
+	promoter   waitlist.Promoter
+	gate       events.Gate
+	clock      clock.Clock
+	event      uint
+	actor      string
 }
 
-func NewService(repository tables.Repository) Service {
-	return Service{repository: repository}
+func NewService(
+	repository tables.Repository, publisher notifications.Publisher, promoter waitlist.Promoter, gate events.Gate,
+) Service {
+	return Service{
+		repository: repository,
+		publisher:  publisher,
+		promoter:   promoter,
+		gate:       gate,
+		clock:      clock.UTC{},
+		actor:      audit.SystemActor,
+	}
+}
+
+// WithClock returns the service telling the time with c.
+func (s Service) WithClock(c clock.Clock) Service {
+	s.clock = c
+	return s
+}
+
+func (s Service) ForEvent(event uint) tables.Service {
+	s.repository = s.repository.ForEvent(event)
+	s.event = event
+	return s
 }
 
+func (s Service) ForActor(actor string) tables.Service {
+	s.repository = s.repository.ForActor(actor)
+	s.actor = actor
+	return s
+}
+
+// Create adds a table, the tables are only added while the event is planned.
 func (s Service) Create(req tables.CreateRequest) (res tables.CreateResponse, err error) {
-	t, err := s.repository.Create(req)
+	if err = s.gate.Allow(s.event, events.OpEditTables); err != nil {
+		return
+	}
+	t, err := s.repository.Create(req, s.clock.Now())
 	if err != nil {
 		return
 	}
@@ -22,17 +65,78 @@ func (s Service) Create(req tables.CreateRequest) (res tables.CreateResponse, er
 		ID:       t.ID,
 		Capacity: t.Capacity,
 	}
+	s.publish(notifications.TableCreated, t)
+	s.promoter.Promote(s.event, s.actor, t.ID)
+	return
+}
+
+// Resize sets the number of seats of a table while the event is planned, the parties waiting for the seats freed are
+// promoted.
+func (s Service) Resize(req tables.ResizeRequest) (res tables.TableDTO, err error) {
+	if err = s.gate.Allow(s.event, events.OpEditTables); err != nil {
+		return
+	}
+	t, err := s.repository.Resize(req.ID, req.Capacity, req.Version, s.clock.Now())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = tables.ErrNotFound
+	}
+	if err != nil {
+		return
+	}
+	s.publish(notifications.TableResized, t)
+	s.promoter.Promote(s.event, s.actor, t.ID)
+
+	// the promotions took some of the seats, reload the table to answer its current state
+	if t, err = s.repository.GetByID(t.ID); err != nil {
+		return
+	}
+	res = mapTableToDTO(t)
+	return
+}
+
+func (s Service) Get(id uint) (res tables.TableDTO, err error) {
+	t, err := s.GetByID(id)
+	if err != nil {
+		return
+	}
+	res = mapTableToDTO(t)
 	return
 }
 
 func (s Service) GetByID(id uint) (t tables.Table, err error) {
 	t, err = s.repository.GetByID(id)
 	if err != nil {
-		err = errors.New("table not found")
+		err = tables.ErrNotFound
+	}
+	return
+}
+
+func (s Service) List() ([]tables.Table, error) {
+	return s.repository.List()
+}
+
+func (s Service) GetTables(req tables.ListRequest) (list tables.ListDTO, err error) {
+	page, err := s.repository.ListPage(req)
+	if err != nil {
+		return
 	}
+	list = mapTablesToDTO(page)
 	return
 }
 
 func (s Service) CountEmptySeats() (count int) {
 	return s.repository.CountEmptySeats()
 }
+
+func (s Service) publish(typ notifications.Type, t tables.Table) {
+	s.publisher.Publish(
+		notifications.Notification{
+			Type:       typ,
+			EventID:    s.event,
+			OccurredAt: s.clock.Now(),
+			TableID:    t.ID,
+			Capacity:   t.Capacity,
+			EmptySeats: t.EmptySeats,
+		},
+	)
+}
@@ -2,33 +2,71 @@ package tables_test
 
 import (
 	"errors"
+	auditDef "github.com/getground/tech-tasks/backend/definitions/audit"
+	"github.com/getground/tech-tasks/backend/definitions/clock"
+	eventsDef "github.com/getground/tech-tasks/backend/definitions/events"
+	notificationsDef "github.com/getground/tech-tasks/backend/definitions/notifications"
 	tablesDef "github.com/getground/tech-tasks/backend/definitions/tables"
+	eventsMocks "github.com/getground/tech-tasks/backend/mocks/definitions/events"
+	notificationsMocks "github.com/getground/tech-tasks/backend/mocks/definitions/notifications"
 	tableMocks "github.com/getground/tech-tasks/backend/mocks/definitions/tables"
+	waitlistMocks "github.com/getground/tech-tasks/backend/mocks/definitions/waitlist"
 	"github.com/getground/tech-tasks/backend/pkg/modules/tables"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 	"testing"
+	"time"
 )
 
 type serviceMocks struct {
-	repo *tableMocks.Repository
+	repo      *tableMocks.Repository
+	publisher *notificationsMocks.Publisher
+	waitlist  *waitlistMocks.Service
+	events    *eventsMocks.Service
 }
 
+// now is the time of the clock of the service, the time the tables are created and resized at.
+var now = time.Date(2026, 10, 19, 20, 30, 0, 0, time.UTC)
+
 func setupService() (tables.Service, serviceMocks) {
 	repo := new(tableMocks.Repository)
-	service := tables.NewService(repo)
-	mocks := serviceMocks{repo}
+	publisher := new(notificationsMocks.Publisher)
+	waitlist := new(waitlistMocks.Service)
+	events := new(eventsMocks.Service)
+	// the tables of the zero event can always be changed, the tests of the gate scope the service to another event
+	events.On("Allow", uint(0), mock.Anything).Return(nil).Maybe()
+	repo.On("ForEvent", mock.Anything).Return(repo).Maybe()
+	service := tables.NewService(repo, publisher, waitlist, events).WithClock(clock.Fixed(now))
+	mocks := serviceMocks{repo, publisher, waitlist, events}
 	return service, mocks
 }
 
 func TestService_Create(t *testing.T) {
 	// setup
 	service, m := setupService()
+	t.Run(
+		"doors open", func(t *testing.T) {
+			//	mocks
+			m.events.On("Allow", uint(2), eventsDef.OpEditTables).Return(eventsDef.ErrNotAllowed).Once()
+
+			//	method call
+			res, err := service.ForEvent(2).Create(tablesDef.CreateRequest{Capacity: 10})
+
+			//	assert
+			assert.ErrorIs(t, err, eventsDef.ErrNotAllowed)
+			assert.Empty(t, res)
+			m.events.AssertExpectations(t)
+			m.repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		},
+	)
+
 	t.Run(
 		"repository error", func(t *testing.T) {
 			//	test date
 			createReq := tablesDef.CreateRequest{Capacity: 10}
 			//	mocks
-			m.repo.On("Create", createReq).Return(tablesDef.Table{}, errors.New("error creating table")).Once()
+			m.repo.On("Create", createReq, now).Return(tablesDef.Table{}, errors.New("error creating table")).Once()
 
 			//	method call
 			res, err := service.Create(createReq)
@@ -44,10 +82,19 @@ func TestService_Create(t *testing.T) {
 		"success", func(t *testing.T) {
 			//	test date
 			createReq := tablesDef.CreateRequest{Capacity: 10}
-			tbl := tablesDef.Table{ID: 1, Capacity: 10}
+			tbl := tablesDef.Table{ID: 1, Capacity: 10, EmptySeats: 10}
 			createRes := tablesDef.CreateResponse{ID: 1, Capacity: 10}
 			//	mocks
-			m.repo.On("Create", createReq).Return(tbl, nil).Once()
+			m.repo.On("Create", createReq, now).Return(tbl, nil).Once()
+			m.publisher.On(
+				"Publish", mock.MatchedBy(
+					func(n notificationsDef.Notification) bool {
+						return n.Type == notificationsDef.TableCreated && n.TableID == 1 && n.Capacity == 10 &&
+							n.EmptySeats == 10
+					},
+				),
+			).Once()
+			m.waitlist.On("Promote", uint(0), auditDef.SystemActor, tbl.ID).Once()
 
 			//	method call
 			res, err := service.Create(createReq)
@@ -56,6 +103,8 @@ func TestService_Create(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Equal(t, createRes, res)
 			m.repo.AssertExpectations(t)
+			m.publisher.AssertExpectations(t)
+			m.waitlist.AssertExpectations(t)
 		},
 	)
 }
@@ -99,6 +148,194 @@ func TestService_GetByID(t *testing.T) {
 	)
 }
 
+func TestService_Get(t *testing.T) {
+	// setup
+	service, m := setupService()
+	t.Run(
+		"table not found", func(t *testing.T) {
+			//	mocks
+			m.repo.On("GetByID", uint(1)).Return(tablesDef.Table{}, errors.New("record not found")).Once()
+
+			//	method call
+			res, err := service.Get(1)
+
+			//	assert
+			assert.ErrorIs(t, err, tablesDef.ErrNotFound)
+			assert.Empty(t, res)
+			m.repo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			//	mocks
+			m.repo.On("GetByID", uint(1)).Return(tablesDef.Table{ID: 1, Capacity: 10, Version: 4}, nil).Once()
+
+			//	method call
+			res, err := service.Get(1)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, tablesDef.TableDTO{ID: 1, Capacity: 10, Version: 4}, res)
+			m.repo.AssertExpectations(t)
+		},
+	)
+}
+
+func TestService_List(t *testing.T) {
+	// setup
+	service, m := setupService()
+	t.Run(
+		"repo error", func(t *testing.T) {
+			//	mocks
+			m.repo.On("List").Return(nil, errors.New("error listing tables")).Once()
+
+			//	method call
+			res, err := service.List()
+
+			//	assert
+			assert.Error(t, err)
+			assert.Empty(t, res)
+			m.repo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// test data
+			list := []tablesDef.Table{{ID: 1, Capacity: 10, EmptySeats: 10}}
+			//	mocks
+			m.repo.On("List").Return(list, nil).Once()
+
+			//	method call
+			res, err := service.List()
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, list, res)
+			m.repo.AssertExpectations(t)
+		},
+	)
+}
+
+func TestService_GetTables(t *testing.T) {
+	// setup
+	service, m := setupService()
+	t.Run(
+		"repo error", func(t *testing.T) {
+			//	mocks
+			m.repo.On("ListPage", tablesDef.ListRequest{}).Return(tablesDef.Page{}, errors.New("error listing tables")).Once()
+
+			//	method call
+			res, err := service.GetTables(tablesDef.ListRequest{})
+
+			//	assert
+			assert.Error(t, err)
+			assert.Empty(t, res)
+			m.repo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// test data
+			req := tablesDef.ListRequest{MinEmptySeats: 2}
+			req.Limit = 1
+			page := tablesDef.Page{Tables: []tablesDef.Table{{ID: 1, Capacity: 4, EmptySeats: 6}}, Total: 2, NextCursor: "next"}
+			dto := tablesDef.ListDTO{
+				Tables:     []tablesDef.TableDTO{{ID: 1, Capacity: 4, EmptySeats: 6}},
+				Total:      2,
+				NextCursor: "next",
+			}
+			//	mocks
+			m.repo.On("ListPage", req).Return(page, nil).Once()
+
+			//	method call
+			res, err := service.GetTables(req)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, dto, res)
+			m.repo.AssertExpectations(t)
+		},
+	)
+}
+
+func TestService_Resize(t *testing.T) {
+	req := tablesDef.ResizeRequest{ID: 1, Capacity: 8}
+
+	t.Run(
+		"table not found", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+
+			//	mocks
+			m.repo.On("Resize", req.ID, req.Capacity, req.Version, now).
+				Return(tablesDef.Table{}, gorm.ErrRecordNotFound).
+				Once()
+
+			//	method call
+			res, err := service.Resize(req)
+
+			//	assert
+			assert.ErrorIs(t, err, tablesDef.ErrNotFound)
+			assert.Empty(t, res)
+			m.waitlist.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"seats taken", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+
+			//	mocks
+			m.repo.On("Resize", req.ID, req.Capacity, req.Version, now).
+				Return(tablesDef.Table{}, tablesDef.ErrSeatsTaken).
+				Once()
+
+			//	method call
+			res, err := service.Resize(req)
+
+			//	assert
+			assert.ErrorIs(t, err, tablesDef.ErrSeatsTaken)
+			assert.Empty(t, res)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+			resized := tablesDef.Table{ID: 1, Capacity: 6, EmptySeats: 8}
+
+			//	mocks
+			m.repo.On("Resize", req.ID, req.Capacity, req.Version, now).Return(resized, nil).Once()
+			m.publisher.On(
+				"Publish", mock.MatchedBy(
+					func(n notificationsDef.Notification) bool {
+						return n.Type == notificationsDef.TableResized && n.TableID == 1 && n.Capacity == 6 &&
+							n.EmptySeats == 8
+					},
+				),
+			).Once()
+			m.waitlist.On("Promote", uint(0), auditDef.SystemActor, resized.ID).Once()
+			// a party of three took seats from the waitlist
+			m.repo.On("GetByID", resized.ID).Return(tablesDef.Table{ID: 1, Capacity: 3, EmptySeats: 8}, nil).Once()
+
+			//	method call
+			res, err := service.Resize(req)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, tablesDef.TableDTO{ID: 1, Capacity: 3, EmptySeats: 8}, res)
+			m.repo.AssertExpectations(t)
+			m.publisher.AssertExpectations(t)
+			m.waitlist.AssertExpectations(t)
+		},
+	)
+}
+
 func TestService_CountEmptySeats(t *testing.T) {
 	// setup
 	service, m := setupService()
@@ -0,0 +1,39 @@
+package venue
+
+import (
+	"github.com/getground/tech-tasks/backend/definitions/events"
+	"github.com/getground/tech-tasks/backend/definitions/venue"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type Controller struct {
+	service venue.Service
+}
+
+func NewController(service venue.Service) Controller {
+	return Controller{
+		service: service,
+	}
+}
+
+func (ctrl Controller) Headcount(c *gin.Context) {
+	res, err := ctrl.scoped(c).Headcount()
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusInternalServerError, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// scoped returns the service of the event the request is scoped to.
+func (ctrl Controller) scoped(c *gin.Context) venue.Service {
+	return ctrl.service.ForEvent(c.GetUint(events.ContextKey))
+}
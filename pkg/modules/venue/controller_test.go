@@ -0,0 +1,62 @@
+package venue_test
+
+import (
+	"errors"
+	venueDef "github.com/getground/tech-tasks/backend/definitions/venue"
+	venueMocks "github.com/getground/tech-tasks/backend/mocks/definitions/venue"
+	"github.com/getground/tech-tasks/backend/pkg/modules/venue"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestController_Headcount(t *testing.T) {
+	//	setup
+	r := gin.Default()
+	gin.SetMode(gin.TestMode)
+	service := new(venueMocks.Service)
+	service.On("ForEvent", uint(0)).Return(service)
+	r.GET("/venue/headcount", venue.NewController(service).Headcount)
+
+	cases := []struct {
+		name     string
+		res      venueDef.HeadcountDTO
+		err      error
+		code     int
+		expected string
+	}{
+		{name: "service error", err: errors.New("internal error"), code: http.StatusInternalServerError},
+		{
+			name:     "success",
+			res:      venueDef.HeadcountDTO{OnSite: 50, Guests: 40, Staff: 10, Peak: 75, Limit: 100},
+			code:     http.StatusOK,
+			expected: `{"on_site":50,"guests":40,"staff":10,"peak":75,"limit":100}`,
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				//	mocks
+				service.On("Headcount").Return(c.res, c.err).Once()
+
+				//	request
+				req, err := http.NewRequest(http.MethodGet, "/venue/headcount", nil)
+				if err != nil {
+					t.Errorf("Error requesting test controller: %v\n", err)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, req)
+
+				//	assert
+				assert.Equal(t, c.code, rr.Code)
+				if c.expected != "" {
+					assert.Equal(t, c.expected, rr.Body.String())
+				}
+				service.AssertExpectations(t)
+			},
+		)
+	}
+}
@@ -0,0 +1,59 @@
+package venue
+
+import (
+	"github.com/getground/tech-tasks/backend/definitions/events"
+	"github.com/getground/tech-tasks/backend/definitions/guests"
+	"github.com/getground/tech-tasks/backend/definitions/venue"
+	"gorm.io/gorm"
+)
+
+type Repository struct {
+	db    *gorm.DB
+	event uint
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return Repository{
+		db: db,
+	}
+}
+
+func (r Repository) ForEvent(event uint) venue.Repository {
+	r.event = event
+	return r
+}
+
+func (r Repository) Guests() (people int64, err error) {
+	err = r.db.Model(&guests.Guest{}).
+		Select("COALESCE(SUM(accompanying + 1), 0)").
+		Where("event_id = ?", r.event).
+		Where("time_arrived IS NOT NULL").
+		Where("checked_out = 0").
+		Scan(&people).
+		Error
+	return
+}
+
+func (r Repository) Limits() (l venue.Limits, err error) {
+	err = r.db.Model(&events.Event{}).
+		Select("venue_limit AS max, staff").
+		Where("id = ?", r.event).
+		Scan(&l).
+		Error
+	return
+}
+
+// Peak returns the peak headcount saved on the event.
+func (r Repository) Peak() (peak int64, err error) {
+	err = r.db.Model(&events.Event{}).Select("peak_headcount").Where("id = ?", r.event).Scan(&peak).Error
+	return
+}
+
+// RecordPeak saves the headcount on the event, the condition keeps the highest of two check ins at the same time.
+func (r Repository) RecordPeak(headcount int64) error {
+	return r.db.Model(&events.Event{}).
+		Where("id = ?", r.event).
+		Where("peak_headcount < ?", headcount).
+		Update("peak_headcount", headcount).
+		Error
+}
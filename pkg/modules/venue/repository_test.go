@@ -0,0 +1,139 @@
+package venue_test
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	venueDef "github.com/getground/tech-tasks/backend/definitions/venue"
+	"github.com/getground/tech-tasks/backend/pkg/database"
+	"github.com/getground/tech-tasks/backend/pkg/modules/venue"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"regexp"
+	"testing"
+)
+
+type repoMocks struct {
+	db      *sql.DB
+	sqlMock sqlmock.Sqlmock
+}
+
+func setupIntegrationRepo(t *testing.T) (venueDef.Repository, repoMocks) {
+	db, m, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	msc := mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true})
+	gDB, err := database.NewDatabaseForTests(msc)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating grom database connection", err)
+	}
+	r := venue.NewRepository(gDB).ForEvent(2)
+	return r, repoMocks{
+		db:      db,
+		sqlMock: m,
+	}
+}
+
+func TestRepository_Guests(t *testing.T) {
+	q := "SELECT COALESCE(SUM(accompanying + 1), 0) FROM `guests` WHERE event_id = ? AND time_arrived IS NOT NULL " +
+		"AND checked_out = 0"
+
+	t.Run(
+		"error", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).WithArgs(2).WillReturnError(errors.New("internal error"))
+
+			//	method call
+			res, err := repo.Guests()
+
+			//	assert
+			assert.Error(t, err)
+			assert.Zero(t, res)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+				WithArgs(2).
+				WillReturnRows(sqlmock.NewRows([]string{"people"}).AddRow(42))
+
+			//	method call
+			res, err := repo.Guests()
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, int64(42), res)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
+
+func TestRepository_Limits(t *testing.T) {
+	// setup
+	repo, m := setupIntegrationRepo(t)
+	defer m.db.Close()
+
+	//	mocks
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT venue_limit AS max, staff FROM `events` WHERE id = ?")).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"max", "staff"}).AddRow(150, 5))
+
+	//	method call
+	res, err := repo.Limits()
+
+	//	assert
+	assert.NoError(t, err)
+	assert.Equal(t, venueDef.Limits{Max: 150, Staff: 5}, res)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestRepository_Peak(t *testing.T) {
+	// setup
+	repo, m := setupIntegrationRepo(t)
+	defer m.db.Close()
+
+	//	mocks
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT peak_headcount FROM `events` WHERE id = ?")).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"peak_headcount"}).AddRow(120))
+
+	//	method call
+	res, err := repo.Peak()
+
+	//	assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(120), res)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestRepository_RecordPeak(t *testing.T) {
+	// setup
+	repo, m := setupIntegrationRepo(t)
+	defer m.db.Close()
+
+	//	mocks
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `events` SET `peak_headcount`=? WHERE id = ? AND peak_headcount < ?")).
+		WithArgs(120, 2, 120).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectCommit()
+
+	//	method call
+	err := repo.RecordPeak(120)
+
+	//	assert
+	assert.NoError(t, err)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
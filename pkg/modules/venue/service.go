@@ -0,0 +1,119 @@
+package venue
+
+import (
+	"github.com/getground/tech-tasks/backend/definitions/clock"
+	"github.com/getground/tech-tasks/backend/definitions/notifications"
+	"github.com/getground/tech-tasks/backend/definitions/venue"
+	log "github.com/sirupsen/logrus"
+	"sync"
+)
+
+type Service struct {
+	repository venue.Repository
+	publisher  notifications.Publisher
+	warning    *warning
+	clock      clock.Clock
+	event      uint
+}
+
+// warning is shared by the services of every event, so a change reaches them all.
+type warning struct {
+	mu    sync.RWMutex
+	share float64
+}
+
+// NewService returns the service warning when the headcount reaches the share of the limit of the venue.
+func NewService(repository venue.Repository, publisher notifications.Publisher, share float64) Service {
+	return Service{repository: repository, publisher: publisher, warning: &warning{share: share}, clock: clock.UTC{}}
+}
+
+// WithClock returns the service telling the time with c.
+func (s Service) WithClock(c clock.Clock) Service {
+	s.clock = c
+	return s
+}
+
+func (s Service) ForEvent(event uint) venue.Service {
+	s.repository = s.repository.ForEvent(event)
+	s.event = event
+	return s
+}
+
+func (s Service) SetWarning(share float64) {
+	s.warning.mu.Lock()
+	defer s.warning.mu.Unlock()
+
+	s.warning.share = share
+}
+
+func (s Service) warningShare() float64 {
+	s.warning.mu.RLock()
+	defer s.warning.mu.RUnlock()
+
+	return s.warning.share
+}
+
+// Headcount counts the people on site, the staff is always on site so the peak is never below it.
+func (s Service) Headcount() (res venue.HeadcountDTO, err error) {
+	l, err := s.repository.Limits()
+	if err != nil {
+		return
+	}
+	guests, err := s.repository.Guests()
+	if err != nil {
+		return
+	}
+	peak, err := s.repository.Peak()
+	if err != nil {
+		return
+	}
+
+	res = venue.HeadcountDTO{
+		OnSite: guests + l.Staff,
+		Guests: guests,
+		Staff:  l.Staff,
+		Peak:   peak,
+		Limit:  l.Max,
+	}
+	if res.Peak < res.OnSite {
+		res.Peak = res.OnSite
+	}
+	return
+}
+
+// Track is called once the people are in, the warning is only given when their arrival reaches the warning share of
+// the limit so the venue doesn't warn on every check in after. The limit itself is enforced by the check in.
+func (s Service) Track(people int64) {
+	l, err := s.repository.Limits()
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	guests, err := s.repository.Guests()
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	headcount := guests + l.Staff
+	if err = s.repository.RecordPeak(headcount); err != nil {
+		log.Error(err)
+	}
+
+	if l.Max == 0 {
+		return
+	}
+	warning := s.warningShare() * float64(l.Max)
+	if float64(headcount) < warning || float64(headcount-people) >= warning {
+		return
+	}
+	log.Warnf("event %d venue nearly full: %d people on site, the limit is %d", s.event, headcount, l.Max)
+	s.publisher.Publish(
+		notifications.Notification{
+			Type:       notifications.VenueNearlyFull,
+			EventID:    s.event,
+			OccurredAt: s.clock.Now(),
+			Headcount:  headcount,
+			VenueLimit: l.Max,
+		},
+	)
+}
@@ -0,0 +1,181 @@
+package venue_test
+
+import (
+	"errors"
+	notificationsDef "github.com/getground/tech-tasks/backend/definitions/notifications"
+	venueDef "github.com/getground/tech-tasks/backend/definitions/venue"
+	notificationsMocks "github.com/getground/tech-tasks/backend/mocks/definitions/notifications"
+	venueMocks "github.com/getground/tech-tasks/backend/mocks/definitions/venue"
+	"github.com/getground/tech-tasks/backend/pkg/modules/venue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+var limits = venueDef.Limits{Max: 100, Staff: 10}
+
+func setupService(limits venueDef.Limits) (venueDef.Service, *venueMocks.Repository, *notificationsMocks.Publisher) {
+	repo := new(venueMocks.Repository)
+	publisher := new(notificationsMocks.Publisher)
+	repo.On("ForEvent", uint(2)).Return(repo)
+	repo.On("Limits").Return(limits, nil).Maybe()
+	return venue.NewService(repo, publisher, 0.9).ForEvent(2), repo, publisher
+}
+
+func TestService_Headcount(t *testing.T) {
+	t.Run(
+		"repository error", func(t *testing.T) {
+			// setup
+			service, repo, _ := setupService(limits)
+
+			//	mocks
+			repo.On("Guests").Return(int64(0), errors.New("internal error")).Once()
+
+			//	method call
+			res, err := service.Headcount()
+
+			//	assert
+			assert.Error(t, err)
+			assert.Empty(t, res)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			service, repo, _ := setupService(limits)
+
+			//	mocks
+			repo.On("Guests").Return(int64(40), nil).Once()
+			repo.On("Peak").Return(int64(75), nil).Once()
+
+			//	method call
+			res, err := service.Headcount()
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, venueDef.HeadcountDTO{OnSite: 50, Guests: 40, Staff: 10, Peak: 75, Limit: 100}, res)
+			repo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"peak not saved yet", func(t *testing.T) {
+			// setup
+			service, repo, _ := setupService(limits)
+
+			//	mocks
+			repo.On("Guests").Return(int64(0), nil).Once()
+			repo.On("Peak").Return(int64(0), nil).Once()
+
+			//	method call
+			res, err := service.Headcount()
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, venueDef.HeadcountDTO{OnSite: 10, Staff: 10, Peak: 10, Limit: 100}, res)
+		},
+	)
+}
+
+func TestService_SetWarning(t *testing.T) {
+	// setup
+	repo := new(venueMocks.Repository)
+	publisher := new(notificationsMocks.Publisher)
+	repo.On("ForEvent", uint(2)).Return(repo)
+	srv := venue.NewService(repo, publisher, 0.9)
+	service := srv.ForEvent(2)
+
+	//	mocks
+	repo.On("Limits").Return(limits, nil)
+	repo.On("Guests").Return(int64(40), nil)
+	repo.On("RecordPeak", int64(50)).Return(nil)
+	publisher.On("Publish", mock.Anything).Once()
+
+	//	method call
+	srv.SetWarning(0.5)
+	service.Track(2)
+
+	//	assert
+	publisher.AssertExpectations(t)
+}
+
+func TestService_Track(t *testing.T) {
+	t.Run(
+		"below the warning", func(t *testing.T) {
+			// setup
+			service, repo, publisher := setupService(limits)
+
+			//	mocks
+			repo.On("Guests").Return(int64(79), nil).Once()
+			repo.On("RecordPeak", int64(89)).Return(nil).Once()
+
+			//	method call
+			service.Track(2)
+
+			//	assert
+			repo.AssertExpectations(t)
+			publisher.AssertNotCalled(t, "Publish", mock.Anything)
+		},
+	)
+
+	t.Run(
+		"reaches the warning", func(t *testing.T) {
+			// setup
+			service, repo, publisher := setupService(limits)
+
+			//	mocks
+			repo.On("Guests").Return(int64(81), nil).Once()
+			repo.On("RecordPeak", int64(91)).Return(errors.New("internal error")).Once()
+			publisher.On(
+				"Publish", mock.MatchedBy(
+					func(n notificationsDef.Notification) bool {
+						return n.Type == notificationsDef.VenueNearlyFull && n.Headcount == 91 && n.VenueLimit == 100
+					},
+				),
+			).Once()
+
+			//	method call
+			service.Track(3)
+
+			//	assert
+			repo.AssertExpectations(t)
+			publisher.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"no limit", func(t *testing.T) {
+			// setup
+			service, repo, publisher := setupService(venueDef.Limits{Staff: 10})
+
+			//	mocks
+			repo.On("Guests").Return(int64(1000), nil).Once()
+			repo.On("RecordPeak", int64(1010)).Return(nil).Once()
+
+			//	method call
+			service.Track(1000)
+
+			//	assert
+			repo.AssertExpectations(t)
+			publisher.AssertNotCalled(t, "Publish", mock.Anything)
+		},
+	)
+
+	t.Run(
+		"already over the warning", func(t *testing.T) {
+			// setup
+			service, repo, publisher := setupService(limits)
+
+			//	mocks
+			repo.On("Guests").Return(int64(85), nil).Once()
+			repo.On("RecordPeak", int64(95)).Return(nil).Once()
+
+			//	method call
+			service.Track(2)
+
+			//	assert
+			publisher.AssertNotCalled(t, "Publish", mock.Anything)
+		},
+	)
+}
@@ -0,0 +1,117 @@
+package waitlist
+
+import (
+	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/audit"
+	"github.com/getground/tech-tasks/backend/definitions/events"
+	"github.com/getground/tech-tasks/backend/definitions/tables"
+	"github.com/getground/tech-tasks/backend/definitions/waitlist"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type Controller struct {
+	handler Handler
+	service waitlist.Service
+}
+
+func NewController(handler Handler, service waitlist.Service) Controller {
+	return Controller{
+		handler: handler,
+		service: service,
+	}
+}
+
+func (ctrl Controller) Create(c *gin.Context) {
+	req, err := ctrl.handler.Create(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.scoped(c).Create(req)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			errorStatus(err), gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (ctrl Controller) List(c *gin.Context) {
+	req, err := ctrl.handler.List(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.scoped(c).List(req)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusInternalServerError, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (ctrl Controller) Delete(c *gin.Context) {
+	id, err := ctrl.handler.Delete(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	err = ctrl.scoped(c).Delete(id)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			errorStatus(err), gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, http.NoBody)
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, waitlist.ErrAlreadyListed), errors.Is(err, events.ErrNotAllowed):
+		return http.StatusConflict
+	case errors.Is(err, waitlist.ErrNotFound), errors.Is(err, tables.ErrNotFound):
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+// scoped returns the service of the event the request is scoped to, acting on behalf of the actor of the request.
+func (ctrl Controller) scoped(c *gin.Context) waitlist.Service {
+	return ctrl.service.ForEvent(c.GetUint(events.ContextKey)).ForActor(c.GetString(audit.ContextKey))
+}
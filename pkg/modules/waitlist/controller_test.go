@@ -0,0 +1,199 @@
+package waitlist_test
+
+import (
+	"errors"
+	tablesDef "github.com/getground/tech-tasks/backend/definitions/tables"
+	waitlistDef "github.com/getground/tech-tasks/backend/definitions/waitlist"
+	waitlistMocks "github.com/getground/tech-tasks/backend/mocks/definitions/waitlist"
+	"github.com/getground/tech-tasks/backend/pkg/modules/waitlist"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func setupController() (*gin.Engine, *waitlistMocks.Service) {
+	r := gin.Default()
+	gin.SetMode(gin.TestMode)
+
+	service := new(waitlistMocks.Service)
+	// the requests are not nested under an event, they are scoped to the zero event
+	service.On("ForEvent", uint(0)).Return(service).Maybe()
+	service.On("ForActor", "").Return(service).Maybe()
+	ctrl := waitlist.NewController(waitlist.NewHandler(), service)
+	r.POST("/waitlist", ctrl.Create)
+	r.GET("/waitlist", ctrl.List)
+	r.DELETE("/waitlist/:id", ctrl.Delete)
+
+	return r, service
+}
+
+func TestController_Create(t *testing.T) {
+	//	setup
+	r, service := setupController()
+	req := waitlistDef.CreateRequest{Name: "test", Table: 1, Accompanying: 2, Priority: 5}
+	created := time.Date(2022, 11, 5, 18, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		body     string
+		mock     func()
+		code     int
+		expected string
+	}{
+		{
+			name: "missing name",
+			body: `{"table":1}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "already listed",
+			body: `{"name":"test","table":1,"accompanying_guests":2,"priority":5}`,
+			mock: func() {
+				service.On("Create", req).Return(waitlistDef.EntryDTO{}, waitlistDef.ErrAlreadyListed).Once()
+			},
+			code: http.StatusConflict,
+		},
+		{
+			name: "table not found",
+			body: `{"name":"test","table":1,"accompanying_guests":2,"priority":5}`,
+			mock: func() {
+				service.On("Create", req).Return(waitlistDef.EntryDTO{}, tablesDef.ErrNotFound).Once()
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name: "service error",
+			body: `{"name":"test","table":1,"accompanying_guests":2,"priority":5}`,
+			mock: func() {
+				service.On("Create", req).Return(waitlistDef.EntryDTO{}, errors.New("internal error")).Once()
+			},
+			code: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			body: `{"name":"test","table":1,"accompanying_guests":2,"priority":5}`,
+			mock: func() {
+				service.On("Create", req).Return(
+					waitlistDef.EntryDTO{ID: 3, Name: "test", Table: 1, Accompanying: 2, Priority: 5, CreatedAt: created},
+					nil,
+				).Once()
+			},
+			code: http.StatusOK,
+			expected: `{"id":3,"name":"test","table":1,"accompanying_guests":2,"priority":5,` +
+				`"created_at":"2022-11-05T18:00:00Z"}`,
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				//	mocks
+				if c.mock != nil {
+					c.mock()
+				}
+
+				//	request
+				httpReq, err := http.NewRequest(http.MethodPost, "/waitlist", strings.NewReader(c.body))
+				if err != nil {
+					t.Errorf("Error requesting test controller: %v\n", err)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, httpReq)
+
+				//	assert
+				assert.Equal(t, c.code, rr.Code)
+				if c.expected != "" {
+					assert.Equal(t, c.expected, rr.Body.String())
+				}
+				service.AssertExpectations(t)
+			},
+		)
+	}
+}
+
+func TestController_List(t *testing.T) {
+	//	setup
+	r, service := setupController()
+	promoted := true
+
+	t.Run(
+		"invalid filter", func(t *testing.T) {
+			//	request
+			req, err := http.NewRequest(http.MethodGet, "/waitlist?promoted=maybe", http.NoBody)
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			//	assert
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			service.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			//	mocks
+			service.On("List", waitlistDef.ListRequest{Table: 2, Promoted: &promoted}).
+				Return(waitlistDef.ListDTO{Entries: []waitlistDef.EntryDTO{}}, nil).
+				Once()
+
+			//	request
+			req, err := http.NewRequest(http.MethodGet, "/waitlist?table=2&promoted=true", http.NoBody)
+			if err != nil {
+				t.Errorf("Error requesting test controller: %v\n", err)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			//	assert
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, `{"entries":[]}`, rr.Body.String())
+			service.AssertExpectations(t)
+		},
+	)
+}
+
+func TestController_Delete(t *testing.T) {
+	//	setup
+	r, service := setupController()
+
+	cases := []struct {
+		name string
+		path string
+		err  error
+		code int
+	}{
+		{name: "invalid id", path: "/waitlist/one", code: http.StatusBadRequest},
+		{name: "not waiting", path: "/waitlist/1", err: waitlistDef.ErrNotFound, code: http.StatusNotFound},
+		{name: "success", path: "/waitlist/1", code: http.StatusNoContent},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				//	mocks
+				if c.code != http.StatusBadRequest {
+					service.On("Delete", uint(1)).Return(c.err).Once()
+				}
+
+				//	request
+				req, err := http.NewRequest(http.MethodDelete, c.path, http.NoBody)
+				if err != nil {
+					t.Errorf("Error requesting test controller: %v\n", err)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, req)
+
+				//	assert
+				assert.Equal(t, c.code, rr.Code)
+				service.AssertExpectations(t)
+			},
+		)
+	}
+}
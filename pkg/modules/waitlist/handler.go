@@ -0,0 +1,34 @@
+package waitlist
+
+import (
+	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/waitlist"
+	"github.com/gin-gonic/gin"
+	"strconv"
+)
+
+type Handler struct{}
+
+func NewHandler() Handler {
+	return Handler{}
+}
+
+func (h Handler) Create(c *gin.Context) (req waitlist.CreateRequest, err error) {
+	err = c.ShouldBindJSON(&req)
+	return
+}
+
+func (h Handler) List(c *gin.Context) (req waitlist.ListRequest, err error) {
+	err = c.ShouldBindQuery(&req)
+	return
+}
+
+func (h Handler) Delete(c *gin.Context) (id uint, err error) {
+	n, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || n == 0 {
+		err = errors.New("id must be a waitlist entry id")
+		return
+	}
+	id = uint(n)
+	return
+}
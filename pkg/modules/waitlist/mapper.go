@@ -0,0 +1,33 @@
+package waitlist
+
+import (
+	"github.com/getground/tech-tasks/backend/definitions/waitlist"
+	"time"
+)
+
+func mapEntriesToDTO(list []waitlist.Entry, loc *time.Location) waitlist.ListDTO {
+	entries := make([]waitlist.EntryDTO, 0, len(list))
+	for _, e := range list {
+		entries = append(entries, mapEntryToDTO(e, loc))
+	}
+	return waitlist.ListDTO{Entries: entries}
+}
+
+// mapEntryToDTO maps the entry with its times in loc, an entry waiting still has no promotion time.
+func mapEntryToDTO(e waitlist.Entry, loc *time.Location) waitlist.EntryDTO {
+	var promoted *time.Time
+	if e.PromotedAt != nil {
+		at := e.PromotedAt.In(loc)
+		promoted = &at
+	}
+	return waitlist.EntryDTO{
+		ID:           e.ID,
+		Name:         e.Name,
+		Table:        e.TableID,
+		Accompanying: e.Accompanying,
+		Priority:     e.Priority,
+		CreatedAt:    e.CreatedAt.In(loc),
+		PromotedAt:   promoted,
+		PromotedTo:   e.PromotedTo,
+	}
+}
@@ -0,0 +1,160 @@
+package waitlist
+
+import (
+	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/attendance"
+	"github.com/getground/tech-tasks/backend/definitions/audit"
+	"github.com/getground/tech-tasks/backend/definitions/etag"
+	"github.com/getground/tech-tasks/backend/definitions/guests"
+	"github.com/getground/tech-tasks/backend/definitions/tables"
+	"github.com/getground/tech-tasks/backend/definitions/waitlist"
+	"github.com/getground/tech-tasks/backend/pkg/journal"
+	"gorm.io/gorm"
+	"time"
+)
+
+type Repository struct {
+	db    *gorm.DB
+	event uint
+	actor string
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return Repository{
+		db:    db,
+		actor: audit.SystemActor,
+	}
+}
+
+func (r Repository) ForEvent(event uint) waitlist.Repository {
+	r.event = event
+	return r
+}
+
+func (r Repository) ForActor(actor string) waitlist.Repository {
+	r.actor = actor
+	return r
+}
+
+func (r Repository) Create(e waitlist.Entry) (waitlist.Entry, error) {
+	e.EventID = r.event
+	err := r.db.Create(&e).Error
+	if err != nil {
+		return waitlist.Entry{}, err
+	}
+	return e, nil
+}
+
+func (r Repository) GetByID(id uint) (e waitlist.Entry, err error) {
+	err = r.scoped(r.db).Where("id = ?", id).First(&e).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = waitlist.ErrNotFound
+	}
+	return
+}
+
+// Delete takes a party that is still waiting off the waitlist, the promotions are kept as their record.
+func (r Repository) Delete(id uint) error {
+	res := r.scoped(r.db).Where("id = ?", id).Where("promoted_at IS NULL").Delete(&waitlist.Entry{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return waitlist.ErrNotFound
+	}
+	return nil
+}
+
+func (r Repository) List(req waitlist.ListRequest) (list []waitlist.Entry, err error) {
+	q := r.scoped(r.db.Model(&waitlist.Entry{}))
+	if req.Table != 0 {
+		q = q.Where("table_id = ?", req.Table)
+	}
+	if req.Promoted != nil {
+		if *req.Promoted {
+			q = q.Where("promoted_at IS NOT NULL")
+		} else {
+			q = q.Where("promoted_at IS NULL")
+		}
+	}
+	err = q.Order("priority DESC").Order("id").Find(&list).Error
+	return
+}
+
+func (r Repository) Listed(name string) (bool, error) {
+	var n int64
+	err := r.scoped(r.db.Model(&guests.Guest{})).Where("name = ?", name).Count(&n).Error
+	if err != nil || n > 0 {
+		return n > 0, err
+	}
+	err = r.scoped(r.db.Model(&waitlist.Entry{})).Where("name = ?", name).Where("promoted_at IS NULL").Count(&n).Error
+	return n > 0, err
+}
+
+// Waiting returns the parties waiting for the table or for any table, the highest priority first and the earliest
+// queued first among equal priorities.
+func (r Repository) Waiting(tableID uint) (list []waitlist.Entry, err error) {
+	err = r.scoped(r.db).
+		Where("promoted_at IS NULL").
+		Where("table_id = ? OR table_id = 0", tableID).
+		Order("priority DESC").
+		Order("id").
+		Find(&list).Error
+	return
+}
+
+// Promote adds the party to the guest list with its seats reserved, saves the capacity left at the table and records
+// the promotion on the entry at the time given, the table must still be at the version the service read it at. The
+// audit log records the promotion as done by the actor whose change freed the seats.
+func (r Repository) Promote(e waitlist.Entry, g guests.Guest, left tables.Table, at time.Time) error {
+	return r.db.Transaction(
+		func(tx *gorm.DB) error {
+			var t tables.Table
+			err := tx.Where(&tables.Table{ID: g.TableID}).First(&t).Error
+			if err != nil {
+				return err
+			}
+
+			g.EventID = r.event
+			err = tx.Create(&g).Error
+			if err != nil {
+				return err
+			}
+
+			res := tx.Model(&tables.Table{}).
+				Where("id = ? AND version = ?", left.ID, left.Version).
+				Updates(map[string]interface{}{"capacity": left.Capacity, "version": gorm.Expr("version + 1")})
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return etag.ErrStale
+			}
+
+			resized := t
+			resized.Capacity = left.Capacity
+			err = journal.Record(
+				tx, r.event, r.actor, audit.ActionGuestPromoted, audit.Snapshot(nil, &t), audit.Snapshot(&g, &resized),
+				at,
+			)
+			if err != nil {
+				return err
+			}
+			err = journal.Track(tx, r.event, attendance.TypeGuestInvited, g, at)
+			if err != nil {
+				return err
+			}
+
+			return tx.Where(&waitlist.Entry{ID: e.ID}).
+				Select("promoted_at", "promoted_to").
+				Updates(waitlist.Entry{PromotedAt: &at, PromotedTo: g.TableID}).
+				Error
+		},
+	)
+}
+
+// scoped narrows down q to the rows of the event of the repository, the waitlist and the guests have the same event_id
+// column.
+func (r Repository) scoped(q *gorm.DB) *gorm.DB {
+	return q.Where("event_id = ?", r.event)
+}
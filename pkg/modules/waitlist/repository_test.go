@@ -0,0 +1,447 @@
+package waitlist_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	attendanceDef "github.com/getground/tech-tasks/backend/definitions/attendance"
+	auditDef "github.com/getground/tech-tasks/backend/definitions/audit"
+	"github.com/getground/tech-tasks/backend/definitions/etag"
+	guestsDef "github.com/getground/tech-tasks/backend/definitions/guests"
+	tablesDef "github.com/getground/tech-tasks/backend/definitions/tables"
+	waitlistDef "github.com/getground/tech-tasks/backend/definitions/waitlist"
+	"github.com/getground/tech-tasks/backend/pkg/database"
+	"github.com/getground/tech-tasks/backend/pkg/modules/waitlist"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type repoMocks struct {
+	db      *sql.DB
+	sqlMock sqlmock.Sqlmock
+}
+
+var columns = []string{"id", "name", "table_id", "accompanying", "priority", "created_at", "promoted_at", "promoted_to"}
+
+func setupIntegrationRepo(t *testing.T) (waitlistDef.Repository, repoMocks) {
+	db, m, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	msc := mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true})
+	gDB, err := database.NewDatabaseForTests(msc)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating grom database connection", err)
+	}
+	r := waitlist.NewRepository(gDB).ForEvent(1)
+	return r, repoMocks{
+		db:      db,
+		sqlMock: m,
+	}
+}
+
+func TestRepository_Create(t *testing.T) {
+	q := "INSERT INTO `waitlist` (`event_id`,`name`,`table_id`,`accompanying`,`priority`,`created_at`,`promoted_at`," +
+		"`promoted_to`) VALUES (?,?,?,?,?,?,?,?)"
+	e := waitlistDef.Entry{Name: "test", TableID: 1, Accompanying: 2, Priority: 5}
+
+	t.Run(
+		"error", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(q)).
+				WithArgs(1, e.Name, e.TableID, e.Accompanying, e.Priority, sqlmock.AnyArg(), nil, 0).
+				WillReturnError(errors.New("error creating entry"))
+			m.sqlMock.ExpectRollback()
+
+			//	method call
+			res, err := repo.Create(e)
+
+			//	assert
+			assert.Error(t, err)
+			assert.Empty(t, res)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(q)).
+				WithArgs(1, e.Name, e.TableID, e.Accompanying, e.Priority, sqlmock.AnyArg(), nil, 0).
+				WillReturnResult(sqlmock.NewResult(3, 1))
+			m.sqlMock.ExpectCommit()
+
+			//	method call
+			res, err := repo.Create(e)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, uint(3), res.ID)
+			assert.False(t, res.CreatedAt.IsZero())
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
+
+func TestRepository_GetByID(t *testing.T) {
+	q := "SELECT * FROM `waitlist` WHERE event_id = ? AND id = ? ORDER BY `waitlist`.`id` LIMIT 1"
+
+	t.Run(
+		"not found", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).WithArgs(1, 1).WillReturnRows(sqlmock.NewRows(columns))
+
+			//	method call
+			_, err := repo.GetByID(1)
+
+			//	assert
+			assert.ErrorIs(t, err, waitlistDef.ErrNotFound)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+			created := time.Date(2022, 11, 5, 18, 0, 0, 0, time.UTC)
+
+			//	mocks
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(q)).
+				WithArgs(1, 1).
+				WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "test", 0, 2, 5, created, nil, 0))
+
+			//	method call
+			res, err := repo.GetByID(1)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(
+				t, waitlistDef.Entry{ID: 1, Name: "test", Accompanying: 2, Priority: 5, CreatedAt: created}, res,
+			)
+		},
+	)
+}
+
+func TestRepository_Delete(t *testing.T) {
+	q := "DELETE FROM `waitlist` WHERE event_id = ? AND id = ? AND promoted_at IS NULL"
+
+	t.Run(
+		"not waiting", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+			m.sqlMock.ExpectCommit()
+
+			//	method call
+			err := repo.Delete(1)
+
+			//	assert
+			assert.ErrorIs(t, err, waitlistDef.ErrNotFound)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+			m.sqlMock.ExpectCommit()
+
+			//	method call
+			err := repo.Delete(1)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
+
+func TestRepository_List(t *testing.T) {
+	waiting := false
+	cases := []struct {
+		name string
+		req  waitlistDef.ListRequest
+		q    string
+		args []driver.Value
+	}{
+		{
+			name: "every entry",
+			q:    "SELECT * FROM `waitlist` WHERE event_id = ? ORDER BY priority DESC,id",
+			args: []driver.Value{1},
+		},
+		{
+			name: "waiting for a table",
+			req:  waitlistDef.ListRequest{Table: 2, Promoted: &waiting},
+			q: "SELECT * FROM `waitlist` WHERE event_id = ? AND table_id = ? AND promoted_at IS NULL " +
+				"ORDER BY priority DESC,id",
+			args: []driver.Value{1, 2},
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				// setup
+				repo, m := setupIntegrationRepo(t)
+				defer m.db.Close()
+
+				//	mocks
+				m.sqlMock.
+					ExpectQuery(regexp.QuoteMeta(c.q)).
+					WithArgs(c.args...).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "test", 2, 0, 0, time.Now(), nil, 0))
+
+				//	method call
+				res, err := repo.List(c.req)
+
+				//	assert
+				assert.NoError(t, err)
+				assert.Len(t, res, 1)
+				assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+			},
+		)
+	}
+}
+
+func TestRepository_Listed(t *testing.T) {
+	guests := "SELECT count(*) FROM `guests` WHERE event_id = ? AND name = ?"
+	waiting := "SELECT count(*) FROM `waitlist` WHERE event_id = ? AND name = ? AND promoted_at IS NULL"
+
+	t.Run(
+		"on the guest list", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(guests)).
+				WithArgs(1, "test").
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+			//	method call
+			listed, err := repo.Listed("test")
+
+			//	assert
+			assert.NoError(t, err)
+			assert.True(t, listed)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"waiting", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(guests)).
+				WithArgs(1, "test").
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			m.sqlMock.
+				ExpectQuery(regexp.QuoteMeta(waiting)).
+				WithArgs(1, "test").
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+			//	method call
+			listed, err := repo.Listed("test")
+
+			//	assert
+			assert.NoError(t, err)
+			assert.True(t, listed)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
+
+func TestRepository_Waiting(t *testing.T) {
+	// setup
+	repo, m := setupIntegrationRepo(t)
+	defer m.db.Close()
+	created := time.Date(2022, 11, 5, 18, 0, 0, 0, time.UTC)
+
+	//	mocks
+	q := "SELECT * FROM `waitlist` WHERE event_id = ? AND promoted_at IS NULL AND (table_id = ? OR table_id = 0) " +
+		"ORDER BY priority DESC,id"
+	m.sqlMock.
+		ExpectQuery(regexp.QuoteMeta(q)).
+		WithArgs(1, 2).
+		WillReturnRows(
+			sqlmock.NewRows(columns).
+				AddRow(3, "vip", 0, 1, 10, created, nil, 0).
+				AddRow(1, "test", 2, 0, 0, created, nil, 0),
+		)
+
+	//	method call
+	res, err := repo.Waiting(2)
+
+	//	assert
+	assert.NoError(t, err)
+	assert.Equal(
+		t, []waitlistDef.Entry{
+			{ID: 3, Name: "vip", Accompanying: 1, Priority: 10, CreatedAt: created},
+			{ID: 1, Name: "test", TableID: 2, CreatedAt: created},
+		}, res,
+	)
+}
+
+func TestRepository_Promote(t *testing.T) {
+	insertGuest := "INSERT INTO `guests` (`name`,`table_id`,`accompanying`,`time_arrived`,`checked_out`,`rsvp`," +
+		"`walk_in`,`event_id`,`version`,`email`,`phone`,`department`,`dietary`,`dietary_notes`," +
+		"`accessibility`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)"
+	updateTable := "UPDATE `tables` SET `capacity`=?,`version`=version + 1 WHERE id = ? AND version = ?"
+	updateEntry := "UPDATE `waitlist` SET `promoted_at`=?,`promoted_to`=? WHERE `waitlist`.`id` = ?"
+	insertAudit := "INSERT INTO `audit_log` (`event_id`,`actor`,`action`,`guest`,`table_id`,`before`,`after`," +
+		"`created_at`) VALUES (?,?,?,?,?,?,?,?)"
+	insertMessage := "INSERT INTO `outbox` (`id`,`event_id`,`type`,`payload`,`created_at`,`dispatched_at`) " +
+		"VALUES (?,?,?,?,?,?)"
+	insertRecord := "INSERT INTO `attendance` (`event_id`,`type`,`guest`,`table_id`,`people`,`rsvp`,`occurred_at`) " +
+		"VALUES (?,?,?,?,?,?,?)"
+	e := waitlistDef.Entry{ID: 3, Name: "test", Accompanying: 1}
+	g := guestsDef.Guest{Name: "test", TableID: 2, Accompanying: 1, RSVP: guestsDef.RSVPAccepted, Version: 1}
+	left := tablesDef.Table{ID: 2, Capacity: 2, EmptySeats: 6, Version: 5}
+	at := time.Date(2024, 6, 1, 18, 30, 0, 0, time.UTC)
+	// the table is read as it was before the promotion for the audit log
+	expectTable := func(m repoMocks) {
+		q := "SELECT * FROM `tables` WHERE `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1"
+		m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+			WithArgs(g.TableID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "capacity", "empty_seats"}).AddRow(2, 4, 6))
+	}
+
+	t.Run(
+		"error insert guest", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			expectTable(m)
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(insertGuest)).
+				WithArgs(g.Name, g.TableID, g.Accompanying, nil, 0, g.RSVP, false, 1, g.Version, "", "", "", "", "", "").
+				WillReturnError(errors.New("duplicate entry"))
+			m.sqlMock.ExpectRollback()
+
+			//	method call
+			err := repo.Promote(e, g, left, at)
+
+			//	assert
+			assert.Error(t, err)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"table changed concurrently", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			expectTable(m)
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(insertGuest)).
+				WithArgs(g.Name, g.TableID, g.Accompanying, nil, 0, g.RSVP, false, 1, g.Version, "", "", "", "", "", "").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(updateTable)).
+				WithArgs(left.Capacity, left.ID, left.Version).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			m.sqlMock.ExpectRollback()
+
+			//	method call
+			err := repo.Promote(e, g, left, at)
+
+			//	assert
+			assert.ErrorIs(t, err, etag.ErrStale)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+			// the promotion is recorded as made by the actor whose change freed the seats
+			repo = repo.ForActor("host")
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			expectTable(m)
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(insertGuest)).
+				WithArgs(g.Name, g.TableID, g.Accompanying, nil, 0, g.RSVP, false, 1, g.Version, "", "", "", "", "", "").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(updateTable)).
+				WithArgs(left.Capacity, left.ID, left.Version).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(insertAudit)).
+				WithArgs(
+					1, "host", auditDef.ActionGuestPromoted, g.Name, g.TableID,
+					`{"table":{"id":2,"capacity":4,"empty_seats":6}}`,
+					`{"guest":{"name":"test","table":2,"accompanying_guests":1,"rsvp":"accepted","checked_out":false,`+
+						`"walk_in":false},"table":{"id":2,"capacity":2,"empty_seats":6}}`,
+					sqlmock.AnyArg(),
+				).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(insertMessage)).
+				WithArgs(sqlmock.AnyArg(), 1, auditDef.ActionGuestPromoted, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(insertRecord)).
+				WithArgs(1, attendanceDef.TypeGuestInvited, g.Name, g.TableID, 2, guestsDef.RSVPAccepted, at).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			m.sqlMock.
+				ExpectExec(regexp.QuoteMeta(updateEntry)).
+				WithArgs(at, g.TableID, e.ID).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			m.sqlMock.ExpectCommit()
+
+			//	method call
+			err := repo.Promote(e, g, left, at)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
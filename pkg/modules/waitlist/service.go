@@ -0,0 +1,222 @@
+package waitlist
+
+import (
+	"github.com/getground/tech-tasks/backend/definitions/audit"
+	"github.com/getground/tech-tasks/backend/definitions/clock"
+	"github.com/getground/tech-tasks/backend/definitions/events"
+	"github.com/getground/tech-tasks/backend/definitions/guests"
+	"github.com/getground/tech-tasks/backend/definitions/invitations"
+	"github.com/getground/tech-tasks/backend/definitions/notifications"
+	"github.com/getground/tech-tasks/backend/definitions/tables"
+	"github.com/getground/tech-tasks/backend/definitions/waitlist"
+	log "github.com/sirupsen/logrus"
+	"sync"
+	"time"
+)
+
+// Service keeps the waitlist, it reads the tables from their repository since the tables service promotes through it.
+type Service struct {
+	repository    waitlist.Repository
+	tablesRepo    tables.Repository
+	invitationSvc invitations.Service
+	publisher     notifications.Publisher
+	indexes       guests.Indexes
+	index         guests.Index
+	gate          events.Gate
+	clock         clock.Clock
+	loc           *time.Location
+	event         uint
+	actor         string
+	// mu serialises the promotions, so two of them can't hand out the same seats
+	mu *sync.Mutex
+}
+
+func NewService(
+	repository waitlist.Repository, tablesRepo tables.Repository, invitationSvc invitations.Service,
+	publisher notifications.Publisher, indexes guests.Indexes, gate events.Gate,
+) Service {
+	return Service{
+		repository:    repository,
+		tablesRepo:    tablesRepo,
+		invitationSvc: invitationSvc,
+		publisher:     publisher,
+		indexes:       indexes,
+		index:         indexes.ForEvent(0),
+		gate:          gate,
+		clock:         clock.UTC{},
+		loc:           time.UTC,
+		actor:         audit.SystemActor,
+		mu:            &sync.Mutex{},
+	}
+}
+
+// WithClock returns the service telling the time with c.
+func (s Service) WithClock(c clock.Clock) Service {
+	s.clock = c
+	return s
+}
+
+// WithLocation returns the service answering the times of the entries in the timezone of the venue.
+func (s Service) WithLocation(loc *time.Location) Service {
+	s.loc = loc
+	return s
+}
+
+func (s Service) ForEvent(event uint) waitlist.Service {
+	return s.forEvent(event)
+}
+
+func (s Service) ForActor(actor string) waitlist.Service {
+	return s.forActor(actor)
+}
+
+// forActor makes the changes of the service on behalf of the actor, the promotions it makes included.
+func (s Service) forActor(actor string) Service {
+	s.repository = s.repository.ForActor(actor)
+	s.actor = actor
+	return s
+}
+
+// forEvent scopes the service and the repositories it reads to the event, the promotions of every event share the lock.
+func (s Service) forEvent(event uint) Service {
+	s.repository = s.repository.ForEvent(event)
+	s.tablesRepo = s.tablesRepo.ForEvent(event)
+	s.invitationSvc = s.invitationSvc.ForEvent(event)
+	s.index = s.indexes.ForEvent(event)
+	s.event = event
+	return s
+}
+
+// Create queues a party for a table or for any table while the guest list can be edited, the party is promoted right
+// away when it fits.
+func (s Service) Create(req waitlist.CreateRequest) (res waitlist.EntryDTO, err error) {
+	if err = s.gate.Allow(s.event, events.OpEditGuestList); err != nil {
+		return
+	}
+	listed, err := s.repository.Listed(req.Name)
+	if err != nil {
+		return
+	}
+	if listed {
+		err = waitlist.ErrAlreadyListed
+		return
+	}
+
+	var ts []tables.Table
+	if req.Table != 0 {
+		var t tables.Table
+		t, err = s.tablesRepo.GetByID(req.Table)
+		if err != nil {
+			err = tables.ErrNotFound
+			return
+		}
+		ts = append(ts, t)
+	} else {
+		ts, err = s.tablesRepo.List()
+		if err != nil {
+			return
+		}
+	}
+
+	e, err := s.repository.Create(
+		waitlist.Entry{Name: req.Name, TableID: req.Table, Accompanying: req.Accompanying, Priority: req.Priority},
+	)
+	if err != nil {
+		return
+	}
+	for _, t := range ts {
+		s.Promote(s.event, s.actor, t.ID)
+	}
+
+	// the entry records its promotion when there were seats for the party already
+	e, err = s.repository.GetByID(e.ID)
+	if err != nil {
+		return
+	}
+	res = mapEntryToDTO(e, s.loc)
+	return
+}
+
+// Delete takes the party off the waitlist while the guest list is edited, the waitlist is frozen along with it.
+func (s Service) Delete(id uint) error {
+	if err := s.gate.Allow(s.event, events.OpEditGuestList); err != nil {
+		return err
+	}
+	return s.repository.Delete(id)
+}
+
+func (s Service) List(req waitlist.ListRequest) (res waitlist.ListDTO, err error) {
+	list, err := s.repository.List(req)
+	if err != nil {
+		return
+	}
+	res = mapEntriesToDTO(list, s.loc)
+	return
+}
+
+// Promote adds the waiting parties that fit the seats left at the table to the guest list in waitlist order, a party
+// too big for the seats left is skipped for the next ones. The failures are logged, the seats released stay released.
+// The parties keep waiting once the status of the event doesn't allow editing the guest list anymore.
+func (s Service) Promote(event uint, actor string, tableID uint) {
+	s = s.forEvent(event).forActor(actor)
+	if err := s.gate.Allow(s.event, events.OpEditGuestList); err != nil {
+		log.Debug(err)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, err := s.tablesRepo.GetByID(tableID)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	entries, err := s.repository.Waiting(tableID)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	for _, e := range entries {
+		if e.Seats() > t.Capacity {
+			continue
+		}
+
+		g := guests.Guest{
+			Name:         e.Name,
+			TableID:      t.ID,
+			Accompanying: e.Accompanying,
+			RSVP:         guests.RSVPAccepted,
+			EventID:      s.event,
+			Version:      1,
+		}
+		left := t
+		left.Capacity -= e.Seats()
+		err = s.repository.Promote(e, g, left, s.clock.Now())
+		if err != nil {
+			log.Error(err)
+			continue
+		}
+		t.Capacity = left.Capacity
+		t.Version++
+		s.index.Put(g)
+
+		// the guest is on the list already, a missing invitation can be issued again with Reinvite
+		if _, err = s.invitationSvc.Issue(g.Name); err != nil {
+			log.Error(err)
+		}
+
+		s.publisher.Publish(
+			notifications.Notification{
+				Type:         notifications.GuestPromoted,
+				EventID:      s.event,
+				OccurredAt:   s.clock.Now(),
+				Guest:        g.Name,
+				Accompanying: g.Accompanying,
+				TableID:      t.ID,
+				Capacity:     t.Capacity,
+				EmptySeats:   t.EmptySeats,
+			},
+		)
+	}
+}
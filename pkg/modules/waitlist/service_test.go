@@ -0,0 +1,358 @@
+package waitlist_test
+
+import (
+	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/clock"
+	eventsDef "github.com/getground/tech-tasks/backend/definitions/events"
+	guestsDef "github.com/getground/tech-tasks/backend/definitions/guests"
+	notificationsDef "github.com/getground/tech-tasks/backend/definitions/notifications"
+	tablesDef "github.com/getground/tech-tasks/backend/definitions/tables"
+	waitlistDef "github.com/getground/tech-tasks/backend/definitions/waitlist"
+	eventsMocks "github.com/getground/tech-tasks/backend/mocks/definitions/events"
+	guestsMocks "github.com/getground/tech-tasks/backend/mocks/definitions/guests"
+	invitationsMocks "github.com/getground/tech-tasks/backend/mocks/definitions/invitations"
+	notificationsMocks "github.com/getground/tech-tasks/backend/mocks/definitions/notifications"
+	tableMocks "github.com/getground/tech-tasks/backend/mocks/definitions/tables"
+	waitlistMocks "github.com/getground/tech-tasks/backend/mocks/definitions/waitlist"
+	"github.com/getground/tech-tasks/backend/pkg/modules/waitlist"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+type serviceMocks struct {
+	repo        *waitlistMocks.Repository
+	tablesRepo  *tableMocks.Repository
+	invitations *invitationsMocks.Service
+	publisher   *notificationsMocks.Publisher
+	index       *guestsMocks.Index
+	events      *eventsMocks.Service
+}
+
+// now is the time the service under test tells, the promotions are stamped with it.
+var now = time.Date(2024, 6, 1, 18, 30, 0, 0, time.UTC)
+
+func setupService() (waitlist.Service, serviceMocks) {
+	repo := new(waitlistMocks.Repository)
+	tablesRepo := new(tableMocks.Repository)
+	invitations := new(invitationsMocks.Service)
+	publisher := new(notificationsMocks.Publisher)
+	index := new(guestsMocks.Index)
+	// the promotions are scoped to the event of the table
+	repo.On("ForEvent", mock.Anything).Return(repo).Maybe()
+	repo.On("ForActor", mock.Anything).Return(repo).Maybe()
+	tablesRepo.On("ForEvent", mock.Anything).Return(tablesRepo).Maybe()
+	invitations.On("ForEvent", mock.Anything).Return(invitations).Maybe()
+	indexes := new(guestsMocks.Indexes)
+	indexes.On("ForEvent", mock.Anything).Return(index)
+	// the zero event allows every change, the tests of the gate scope the service to another event
+	events := new(eventsMocks.Service)
+	events.On("Allow", uint(0), mock.Anything).Return(nil).Maybe()
+	service := waitlist.NewService(repo, tablesRepo, invitations, publisher, indexes, events).
+		WithClock(clock.Fixed(now))
+	mocks := serviceMocks{repo, tablesRepo, invitations, publisher, index, events}
+	return service, mocks
+}
+
+// promotion matches the notification of a promotion ignoring the time it occurred at.
+func promotion(name string, accompanying int64, t tablesDef.Table) interface{} {
+	return mock.MatchedBy(
+		func(n notificationsDef.Notification) bool {
+			return n.Type == notificationsDef.GuestPromoted && n.Guest == name && n.Accompanying == accompanying &&
+				n.TableID == t.ID && n.Capacity == t.Capacity && n.EmptySeats == t.EmptySeats
+		},
+	)
+}
+
+func TestService_Create(t *testing.T) {
+	t.Run(
+		"guest list closed", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+
+			//	mocks
+			m.events.On("Allow", uint(2), eventsDef.OpEditGuestList).Return(eventsDef.ErrNotAllowed).Once()
+
+			//	method call
+			res, err := service.ForEvent(2).Create(waitlistDef.CreateRequest{Name: "test", Table: 1})
+
+			//	assert
+			assert.ErrorIs(t, err, eventsDef.ErrNotAllowed)
+			assert.Empty(t, res)
+			m.repo.AssertNotCalled(t, "Create", mock.Anything)
+			m.events.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"already listed", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+
+			//	mocks
+			m.repo.On("Listed", "test").Return(true, nil).Once()
+
+			//	method call
+			res, err := service.Create(waitlistDef.CreateRequest{Name: "test", Table: 1})
+
+			//	assert
+			assert.ErrorIs(t, err, waitlistDef.ErrAlreadyListed)
+			assert.Empty(t, res)
+			m.repo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"table not found", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+
+			//	mocks
+			m.repo.On("Listed", "test").Return(false, nil).Once()
+			m.tablesRepo.On("GetByID", uint(1)).Return(tablesDef.Table{}, errors.New("record not found")).Once()
+
+			//	method call
+			res, err := service.Create(waitlistDef.CreateRequest{Name: "test", Table: 1})
+
+			//	assert
+			assert.ErrorIs(t, err, tablesDef.ErrNotFound)
+			assert.Empty(t, res)
+			m.repo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"promoted right away", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+			req := waitlistDef.CreateRequest{Name: "test", Table: 1, Accompanying: 1, Priority: 2}
+			tbl := tablesDef.Table{ID: 1, Capacity: 4, EmptySeats: 10, Version: 4}
+			entry := waitlistDef.Entry{ID: 3, Name: "test", TableID: 1, Accompanying: 1, Priority: 2}
+			g := guestsDef.Guest{Name: "test", TableID: 1, Accompanying: 1, RSVP: guestsDef.RSVPAccepted, Version: 1}
+			promotedAt := time.Now()
+			promoted := entry
+			promoted.PromotedAt, promoted.PromotedTo = &promotedAt, 1
+
+			//	mocks
+			m.repo.On("Listed", "test").Return(false, nil).Once()
+			m.tablesRepo.On("GetByID", uint(1)).Return(tbl, nil).Twice()
+			m.repo.On("Create", waitlistDef.Entry{Name: "test", TableID: 1, Accompanying: 1, Priority: 2}).
+				Return(entry, nil).
+				Once()
+			m.repo.On("Waiting", uint(1)).Return([]waitlistDef.Entry{entry}, nil).Once()
+			m.repo.On("Promote", entry, g, tablesDef.Table{ID: 1, Capacity: 2, EmptySeats: 10, Version: 4}, now).
+				Return(nil).
+				Once()
+			m.index.On("Put", g).Once()
+			m.invitations.On("Issue", "test").Return("id.signature", nil).Once()
+			m.publisher.On("Publish", promotion("test", 1, tablesDef.Table{ID: 1, Capacity: 2, EmptySeats: 10})).Once()
+			m.repo.On("GetByID", entry.ID).Return(promoted, nil).Once()
+
+			//	method call
+			res, err := service.Create(req)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, uint(1), res.PromotedTo)
+			assert.NotNil(t, res.PromotedAt)
+			m.repo.AssertExpectations(t)
+			m.tablesRepo.AssertExpectations(t)
+			m.index.AssertExpectations(t)
+			m.invitations.AssertExpectations(t)
+			m.publisher.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"waiting for any table", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+			req := waitlistDef.CreateRequest{Name: "test", Accompanying: 6}
+			entry := waitlistDef.Entry{ID: 3, Name: "test", Accompanying: 6}
+			tbls := []tablesDef.Table{{ID: 1, Capacity: 2, EmptySeats: 2}, {ID: 2, Capacity: 4, EmptySeats: 4}}
+
+			//	mocks
+			m.repo.On("Listed", "test").Return(false, nil).Once()
+			m.tablesRepo.On("List").Return(tbls, nil).Once()
+			m.repo.On("Create", waitlistDef.Entry{Name: "test", Accompanying: 6}).Return(entry, nil).Once()
+			for _, tbl := range tbls {
+				m.tablesRepo.On("GetByID", tbl.ID).Return(tbl, nil).Once()
+				m.repo.On("Waiting", tbl.ID).Return([]waitlistDef.Entry{entry}, nil).Once()
+			}
+			m.repo.On("GetByID", entry.ID).Return(entry, nil).Once()
+
+			//	method call
+			res, err := service.Create(req)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, waitlistDef.EntryDTO{ID: 3, Name: "test", Accompanying: 6}, res)
+			assert.Nil(t, res.PromotedAt)
+			m.repo.AssertExpectations(t)
+			m.tablesRepo.AssertExpectations(t)
+			m.publisher.AssertNotCalled(t, "Publish", mock.Anything)
+		},
+	)
+}
+
+func TestService_Promote(t *testing.T) {
+	t.Run(
+		"guest list closed", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+
+			//	mocks
+			m.events.On("Allow", uint(1), eventsDef.OpEditGuestList).Return(eventsDef.ErrNotAllowed).Once()
+
+			//	method call
+			service.Promote(1, "host", 1)
+
+			//	assert
+			m.events.AssertExpectations(t)
+			m.tablesRepo.AssertNotCalled(t, "GetByID", mock.Anything)
+			m.repo.AssertNotCalled(t, "Waiting", mock.Anything)
+		},
+	)
+
+	t.Run(
+		"table error", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+
+			//	mocks
+			m.events.On("Allow", uint(1), eventsDef.OpEditGuestList).Return(nil).Once()
+			m.tablesRepo.On("GetByID", uint(1)).Return(tablesDef.Table{}, errors.New("record not found")).Once()
+
+			//	method call
+			service.Promote(1, "host", 1)
+
+			//	assert
+			m.tablesRepo.AssertExpectations(t)
+			m.repo.AssertNotCalled(t, "Waiting", mock.Anything)
+		},
+	)
+
+	t.Run(
+		"first parties that fit", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+			tbl := tablesDef.Table{ID: 1, Capacity: 5, EmptySeats: 8, Version: 7}
+			big := waitlistDef.Entry{ID: 1, Name: "big", TableID: 1, Accompanying: 5, Priority: 9}
+			failing := waitlistDef.Entry{ID: 2, Name: "failing", Accompanying: 0, Priority: 8}
+			first := waitlistDef.Entry{ID: 3, Name: "first", TableID: 1, Accompanying: 2, Priority: 5}
+			second := waitlistDef.Entry{ID: 4, Name: "second", Accompanying: 2}
+			last := waitlistDef.Entry{ID: 5, Name: "last", TableID: 1, Accompanying: 0}
+			guest := func(e waitlistDef.Entry) guestsDef.Guest {
+				return guestsDef.Guest{
+					Name:         e.Name,
+					TableID:      1,
+					Accompanying: e.Accompanying,
+					RSVP:         guestsDef.RSVPAccepted,
+					EventID:      2,
+					Version:      1,
+				}
+			}
+			// every promotion is conditioned on the version the previous one left the table at
+			left := func(capacity, version int64) tablesDef.Table {
+				return tablesDef.Table{ID: 1, Capacity: capacity, EmptySeats: 8, Version: version}
+			}
+
+			//	mocks
+			m.events.On("Allow", uint(2), eventsDef.OpEditGuestList).Return(nil).Once()
+			m.tablesRepo.On("GetByID", uint(1)).Return(tbl, nil).Once()
+			m.repo.On("Waiting", uint(1)).Return([]waitlistDef.Entry{big, failing, first, second, last}, nil).Once()
+			m.repo.On("Promote", failing, guest(failing), left(4, 7), now).Return(errors.New("duplicate entry")).Once()
+			m.repo.On("Promote", first, guest(first), left(2, 7), now).Return(nil).Once()
+			m.repo.On("Promote", last, guest(last), left(1, 8), now).Return(nil).Once()
+			m.index.On("Put", guest(first)).Once()
+			m.index.On("Put", guest(last)).Once()
+			m.invitations.On("Issue", "first").Return("id.signature", nil).Once()
+			m.invitations.On("Issue", "last").Return("", errors.New("internal error")).Once()
+			m.publisher.On("Publish", promotion("first", 2, tablesDef.Table{ID: 1, Capacity: 2, EmptySeats: 8})).Once()
+			m.publisher.On("Publish", promotion("last", 0, tablesDef.Table{ID: 1, Capacity: 1, EmptySeats: 8})).Once()
+
+			//	method call
+			service.Promote(2, "host", 1)
+
+			//	assert
+			m.repo.AssertCalled(t, "ForEvent", uint(2))
+			m.repo.AssertCalled(t, "ForActor", "host")
+			m.tablesRepo.AssertCalled(t, "ForEvent", uint(2))
+			m.repo.AssertExpectations(t)
+			m.index.AssertExpectations(t)
+			m.invitations.AssertExpectations(t)
+			m.publisher.AssertExpectations(t)
+		},
+	)
+}
+
+func TestService_List(t *testing.T) {
+	// setup
+	service, m := setupService()
+	waiting := false
+	req := waitlistDef.ListRequest{Promoted: &waiting}
+	created := time.Date(2022, 11, 5, 18, 0, 0, 0, time.UTC)
+	promoted := created.Add(time.Hour)
+	// the times are answered in the timezone of the venue
+	loc := time.FixedZone("BST", 3600)
+
+	//	mocks
+	m.repo.On("List", req).Return(
+		[]waitlistDef.Entry{
+			{ID: 1, Name: "test", TableID: 2, Accompanying: 1, Priority: 3, CreatedAt: created},
+			{ID: 2, Name: "sam", TableID: 2, CreatedAt: created, PromotedAt: &promoted, PromotedTo: 2},
+		}, nil,
+	).Once()
+
+	//	method call
+	res, err := service.WithLocation(loc).List(req)
+
+	//	assert
+	assert.NoError(t, err)
+	promotedAt := promoted.In(loc)
+	assert.Equal(
+		t, waitlistDef.ListDTO{
+			Entries: []waitlistDef.EntryDTO{
+				{ID: 1, Name: "test", Table: 2, Accompanying: 1, Priority: 3, CreatedAt: created.In(loc)},
+				{ID: 2, Name: "sam", Table: 2, CreatedAt: created.In(loc), PromotedAt: &promotedAt, PromotedTo: 2},
+			},
+		}, res,
+	)
+}
+
+func TestService_Delete(t *testing.T) {
+	t.Run(
+		"guest list closed", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+
+			//	mocks
+			m.events.On("Allow", uint(2), eventsDef.OpEditGuestList).Return(eventsDef.ErrNotAllowed).Once()
+
+			//	method call
+			err := service.ForEvent(2).Delete(1)
+
+			//	assert
+			assert.ErrorIs(t, err, eventsDef.ErrNotAllowed)
+			m.repo.AssertNotCalled(t, "Delete", mock.Anything)
+			m.events.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"not found", func(t *testing.T) {
+			// setup
+			service, m := setupService()
+
+			//	mocks
+			m.repo.On("Delete", uint(1)).Return(waitlistDef.ErrNotFound).Once()
+
+			//	method call
+			err := service.Delete(1)
+
+			//	assert
+			assert.ErrorIs(t, err, waitlistDef.ErrNotFound)
+		},
+	)
+}
@@ -0,0 +1,182 @@
+package webhooks
+
+import (
+	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/events"
+	"github.com/getground/tech-tasks/backend/definitions/webhooks"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type Controller struct {
+	handler Handler
+	service webhooks.Service
+}
+
+func NewController(handler Handler, service webhooks.Service) Controller {
+	return Controller{
+		handler: handler,
+		service: service,
+	}
+}
+
+func (ctrl Controller) Create(c *gin.Context) {
+	req, err := ctrl.handler.Create(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.scoped(c).Create(req)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			errorStatus(err), gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (ctrl Controller) List(c *gin.Context) {
+	res, err := ctrl.scoped(c).List()
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusInternalServerError, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (ctrl Controller) Get(c *gin.Context) {
+	id, err := ctrl.handler.ID(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.scoped(c).Get(id)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			errorStatus(err), gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (ctrl Controller) Update(c *gin.Context) {
+	req, err := ctrl.handler.Update(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.scoped(c).Update(req)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			errorStatus(err), gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (ctrl Controller) Delete(c *gin.Context) {
+	id, err := ctrl.handler.ID(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	err = ctrl.scoped(c).Delete(id)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			errorStatus(err), gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, http.NoBody)
+}
+
+func (ctrl Controller) Deliveries(c *gin.Context) {
+	id, err := ctrl.handler.ID(c)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	res, err := ctrl.scoped(c).Deliveries(id)
+	if err != nil {
+		log.Error(err)
+		c.JSON(
+			errorStatus(err), gin.H{
+				"error": err.Error(),
+			},
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// scoped returns the service of the event the request is scoped to.
+func (ctrl Controller) scoped(c *gin.Context) webhooks.Service {
+	return ctrl.service.ForEvent(c.GetUint(events.ContextKey))
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, webhooks.ErrInvalidType):
+		return http.StatusBadRequest
+	case errors.Is(err, webhooks.ErrNotFound):
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,280 @@
+package webhooks_test
+
+import (
+	"errors"
+	webhooksDef "github.com/getground/tech-tasks/backend/definitions/webhooks"
+	webhooksMocks "github.com/getground/tech-tasks/backend/mocks/definitions/webhooks"
+	"github.com/getground/tech-tasks/backend/pkg/modules/webhooks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func setupController() (*gin.Engine, *webhooksMocks.Service) {
+	r := gin.Default()
+	gin.SetMode(gin.TestMode)
+
+	service := new(webhooksMocks.Service)
+	// the requests are not nested under an event, they are scoped to the zero event
+	service.On("ForEvent", uint(0)).Return(service).Maybe()
+	ctrl := webhooks.NewController(webhooks.NewHandler(), service)
+	r.POST("/webhooks", ctrl.Create)
+	r.GET("/webhooks", ctrl.List)
+	r.GET("/webhooks/:id", ctrl.Get)
+	r.PUT("/webhooks/:id", ctrl.Update)
+	r.DELETE("/webhooks/:id", ctrl.Delete)
+	r.GET("/webhooks/:id/deliveries", ctrl.Deliveries)
+
+	return r, service
+}
+
+func TestController_Create(t *testing.T) {
+	//	setup
+	r, service := setupController()
+	created := time.Date(2022, 11, 5, 18, 0, 0, 0, time.UTC)
+	req := webhooksDef.CreateRequest{URL: "https://example.com", Types: []webhooksDef.Type{webhooksDef.TableFull}}
+
+	cases := []struct {
+		name     string
+		body     string
+		res      webhooksDef.SubscriptionDTO
+		err      error
+		mock     bool
+		code     int
+		expected string
+	}{
+		{name: "missing url", body: `{"types":["table.full"]}`, code: http.StatusBadRequest},
+		{name: "invalid url", body: `{"url":"not a url"}`, code: http.StatusBadRequest},
+		{
+			name: "invalid type",
+			body: `{"url":"https://example.com","types":["table.full"]}`,
+			err:  webhooksDef.ErrInvalidType,
+			mock: true,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "success",
+			body: `{"url":"https://example.com","types":["table.full"]}`,
+			res: webhooksDef.SubscriptionDTO{
+				ID: 1, URL: req.URL, Types: req.Types, Secret: "secret", CreatedAt: created,
+			},
+			mock: true,
+			code: http.StatusOK,
+			expected: `{"id":1,"url":"https://example.com","types":["table.full"],"secret":"secret",` +
+				`"created_at":"2022-11-05T18:00:00Z"}`,
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				//	mocks
+				if c.mock {
+					service.On("Create", req).Return(c.res, c.err).Once()
+				}
+
+				//	request
+				httpReq, err := http.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(c.body))
+				if err != nil {
+					t.Errorf("Error requesting test controller: %v\n", err)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, httpReq)
+
+				//	assert
+				assert.Equal(t, c.code, rr.Code)
+				if c.expected != "" {
+					assert.Equal(t, c.expected, rr.Body.String())
+				}
+				service.AssertExpectations(t)
+			},
+		)
+	}
+}
+
+func TestController_List(t *testing.T) {
+	//	setup
+	r, service := setupController()
+
+	//	mocks
+	service.On("List").Return(webhooksDef.ListDTO{}, errors.New("internal error")).Once()
+
+	//	request
+	req, err := http.NewRequest(http.MethodGet, "/webhooks", nil)
+	if err != nil {
+		t.Errorf("Error requesting test controller: %v\n", err)
+	}
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	//	assert
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	service.AssertExpectations(t)
+}
+
+func TestController_Get(t *testing.T) {
+	//	setup
+	r, service := setupController()
+
+	cases := []struct {
+		name string
+		path string
+		err  error
+		mock bool
+		code int
+	}{
+		{name: "invalid id", path: "/webhooks/x", code: http.StatusBadRequest},
+		{name: "not found", path: "/webhooks/3", err: webhooksDef.ErrNotFound, mock: true, code: http.StatusNotFound},
+		{name: "success", path: "/webhooks/3", mock: true, code: http.StatusOK},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				//	mocks
+				if c.mock {
+					service.On("Get", uint(3)).Return(webhooksDef.SubscriptionDTO{ID: 3}, c.err).Once()
+				}
+
+				//	request
+				req, err := http.NewRequest(http.MethodGet, c.path, nil)
+				if err != nil {
+					t.Errorf("Error requesting test controller: %v\n", err)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, req)
+
+				//	assert
+				assert.Equal(t, c.code, rr.Code)
+				service.AssertExpectations(t)
+			},
+		)
+	}
+}
+
+func TestController_Update(t *testing.T) {
+	//	setup
+	r, service := setupController()
+	req := webhooksDef.UpdateRequest{
+		ID: 3, URL: "https://example.org", Types: []webhooksDef.Type{webhooksDef.GuestInvited},
+	}
+
+	cases := []struct {
+		name string
+		body string
+		err  error
+		mock bool
+		code int
+	}{
+		{name: "invalid body", body: `{}`, code: http.StatusBadRequest},
+		{
+			name: "not found",
+			body: `{"url":"https://example.org","types":["guest.invited"]}`,
+			err:  webhooksDef.ErrNotFound,
+			mock: true,
+			code: http.StatusNotFound,
+		},
+		{
+			name: "success",
+			body: `{"url":"https://example.org","types":["guest.invited"]}`,
+			mock: true,
+			code: http.StatusOK,
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				//	mocks
+				if c.mock {
+					service.On("Update", req).Return(webhooksDef.SubscriptionDTO{ID: 3}, c.err).Once()
+				}
+
+				//	request
+				httpReq, err := http.NewRequest(http.MethodPut, "/webhooks/3", strings.NewReader(c.body))
+				if err != nil {
+					t.Errorf("Error requesting test controller: %v\n", err)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, httpReq)
+
+				//	assert
+				assert.Equal(t, c.code, rr.Code)
+				service.AssertExpectations(t)
+			},
+		)
+	}
+}
+
+func TestController_Delete(t *testing.T) {
+	//	setup
+	r, service := setupController()
+
+	cases := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{name: "not found", err: webhooksDef.ErrNotFound, code: http.StatusNotFound},
+		{name: "success", code: http.StatusNoContent},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				//	mocks
+				service.On("Delete", uint(3)).Return(c.err).Once()
+
+				//	request
+				req, err := http.NewRequest(http.MethodDelete, "/webhooks/3", nil)
+				if err != nil {
+					t.Errorf("Error requesting test controller: %v\n", err)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, req)
+
+				//	assert
+				assert.Equal(t, c.code, rr.Code)
+				service.AssertExpectations(t)
+			},
+		)
+	}
+}
+
+func TestController_Deliveries(t *testing.T) {
+	//	setup
+	r, service := setupController()
+	created := time.Date(2022, 11, 5, 18, 0, 0, 0, time.UTC)
+
+	//	mocks
+	service.On("Deliveries", uint(3)).Return(
+		webhooksDef.DeliveriesDTO{
+			Deliveries: []webhooksDef.DeliveryDTO{
+				{
+					ID: 1, PayloadID: "p", Type: webhooksDef.GuestCheckedIn, Attempt: 1, StatusCode: 200,
+					Succeeded: true, CreatedAt: created,
+				},
+			},
+		}, nil,
+	).Once()
+
+	//	request
+	req, err := http.NewRequest(http.MethodGet, "/webhooks/3/deliveries", nil)
+	if err != nil {
+		t.Errorf("Error requesting test controller: %v\n", err)
+	}
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	//	assert
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(
+		t, `{"deliveries":[{"id":1,"payload_id":"p","type":"guest.checked_in","attempt":1,"status_code":200,`+
+			`"succeeded":true,"created_at":"2022-11-05T18:00:00Z"}]}`, rr.Body.String(),
+	)
+	service.AssertExpectations(t)
+}
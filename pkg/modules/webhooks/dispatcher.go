@@ -0,0 +1,232 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/getground/tech-tasks/backend/definitions/audit"
+	"github.com/getground/tech-tasks/backend/definitions/clock"
+	"github.com/getground/tech-tasks/backend/definitions/outbox"
+	"github.com/getground/tech-tasks/backend/definitions/webhooks"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Dispatcher is the outbox sink delivering the changes to the subscriptions of their event. A failed delivery fails
+// the send so the relay sends the message again, the retries wait in the outbox and survive a restart.
+type Dispatcher struct {
+	repository    webhooks.Repository
+	client        *http.Client
+	subscriptions *subscriptions
+}
+
+// NewDispatcher returns the dispatcher keeping the subscriptions in memory, they are read again once older than
+// refresh.
+func NewDispatcher(repository webhooks.Repository, client *http.Client, refresh time.Duration) Dispatcher {
+	return Dispatcher{
+		repository:    repository,
+		client:        client,
+		subscriptions: &subscriptions{repository: repository, refresh: refresh, clock: clock.UTC{}},
+	}
+}
+
+func (d Dispatcher) Name() string {
+	return "webhooks"
+}
+
+// Send delivers the payloads of the message to the subscriptions wanting them at once and waits for every delivery,
+// it fails when any of them failed.
+func (d Dispatcher) Send(ctx context.Context, m outbox.Message) error {
+	payloads, err := mapMessageToPayloads(m)
+	if err != nil || len(payloads) == 0 {
+		return err
+	}
+	subs, err := d.subscriptions.forEvent(m.EventID)
+	if err != nil {
+		return err
+	}
+
+	bodies := make([][]byte, len(payloads))
+	for i, p := range payloads {
+		if bodies[i], err = json.Marshal(p); err != nil {
+			return err
+		}
+	}
+
+	var wg sync.WaitGroup
+	var failed int32
+	for i, p := range payloads {
+		for _, s := range subs {
+			if !s.Wants(p.Type) {
+				continue
+			}
+			wg.Add(1)
+			go func(s webhooks.Subscription, p webhooks.Payload, body []byte) {
+				defer wg.Done()
+				if !d.deliver(ctx, s, p, body) {
+					atomic.AddInt32(&failed, 1)
+				}
+			}(s, p, bodies[i])
+		}
+	}
+	wg.Wait()
+	if failed > 0 {
+		return fmt.Errorf("%d webhook deliveries failed", failed)
+	}
+	return nil
+}
+
+// deliver sends the payload once and records the attempt in the delivery log, it reports whether the endpoint
+// accepted it. The attempts of the payload are counted in the delivery log since the relay sends the message again.
+func (d Dispatcher) deliver(ctx context.Context, s webhooks.Subscription, p webhooks.Payload, body []byte) bool {
+	attempts, err := d.repository.Attempts(s.ID, p.ID)
+	if err != nil {
+		log.Error(err)
+	}
+	delivery := d.send(ctx, s, p, body)
+	delivery.Attempt = attempts + 1
+	if err := d.repository.Record(delivery); err != nil {
+		log.Error(err)
+	}
+	return delivery.Succeeded()
+}
+
+func (d Dispatcher) send(
+	ctx context.Context, s webhooks.Subscription, p webhooks.Payload, body []byte,
+) webhooks.Delivery {
+	delivery := webhooks.Delivery{SubscriptionID: s.ID, PayloadID: p.ID, Type: p.Type}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhooks.TypeHeader, string(p.Type))
+	req.Header.Set(webhooks.SignatureHeader, Sign(s.Secret, body))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+	_ = res.Body.Close()
+	delivery.StatusCode = res.StatusCode
+	if !delivery.Succeeded() {
+		delivery.Error = fmt.Sprintf("endpoint answered %d", res.StatusCode)
+	}
+	return delivery
+}
+
+// Sign returns the signature of the body sent to a subscription with the secret, the endpoints compute it again to
+// check the payload comes from the service.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// subscriptions keeps the subscriptions of every event in memory so a message doesn't read them, they are read again
+// at the first message once older than refresh.
+type subscriptions struct {
+	repository webhooks.Repository
+	refresh    time.Duration
+	clock      clock.Clock
+
+	mu       sync.Mutex
+	byEvent  map[uint][]webhooks.Subscription
+	loadedAt time.Time
+}
+
+func (s *subscriptions) forEvent(event uint) ([]webhooks.Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	if s.byEvent == nil || now.Sub(s.loadedAt) >= s.refresh {
+		list, err := s.repository.All()
+		if err != nil {
+			return nil, err
+		}
+		s.byEvent = make(map[uint][]webhooks.Subscription)
+		for _, sub := range list {
+			s.byEvent[sub.EventID] = append(s.byEvent[sub.EventID], sub)
+		}
+		s.loadedAt = now
+	}
+	return s.byEvent[event], nil
+}
+
+// mapMessageToPayloads maps an outbox message to the payloads the subscriptions can be notified of, the state after
+// the change gives the guest and the table. A check in taking the last empty seat of the table is also a table.full
+// payload, the promotions of the waitlist are guests invited and the walk-ins guests checked in.
+func mapMessageToPayloads(m outbox.Message) ([]webhooks.Payload, error) {
+	var types []webhooks.Type
+	switch audit.Action(m.Type) {
+	case audit.ActionGuestCreated, audit.ActionGuestPromoted:
+		types = append(types, webhooks.GuestInvited)
+	case audit.ActionGuestCheckedIn, audit.ActionGuestWalkedIn:
+		types = append(types, webhooks.GuestCheckedIn)
+	case audit.ActionGuestCheckedOut:
+		types = append(types, webhooks.GuestCheckedOut)
+	default:
+		return nil, nil
+	}
+
+	var p outbox.Payload
+	if err := json.Unmarshal([]byte(m.Payload), &p); err != nil {
+		return nil, err
+	}
+	var after audit.State
+	if len(p.After) > 0 {
+		if err := json.Unmarshal(p.After, &after); err != nil {
+			return nil, err
+		}
+	}
+	var accompanying int64
+	table := webhooks.TableDTO{ID: p.Table}
+	if after.Guest != nil {
+		accompanying = after.Guest.Accompanying
+	}
+	if after.Table != nil {
+		table = webhooks.TableDTO{
+			ID:         after.Table.ID,
+			Capacity:   after.Table.Capacity,
+			EmptySeats: after.Table.EmptySeats,
+		}
+		if types[0] == webhooks.GuestCheckedIn && table.EmptySeats <= 0 {
+			types = append(types, webhooks.TableFull)
+		}
+	}
+
+	payloads := make([]webhooks.Payload, 0, len(types))
+	for _, t := range types {
+		payloads = append(
+			payloads, webhooks.Payload{
+				ID:                 payloadID(m.ID, t),
+				Type:               t,
+				Event:              m.EventID,
+				OccurredAt:         p.OccurredAt,
+				Guest:              p.Guest,
+				AccompanyingGuests: accompanying,
+				Table:              table,
+			},
+		)
+	}
+	return payloads, nil
+}
+
+// payloadID identifies a payload across its attempts so the endpoints can drop the ones delivered twice, it is
+// derived from the id of the message so the sends of the message again share it.
+func payloadID(message string, t webhooks.Type) string {
+	sum := sha256.Sum256([]byte(message + "/" + string(t)))
+	return hex.EncodeToString(sum[:16])
+}
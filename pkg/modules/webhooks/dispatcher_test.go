@@ -0,0 +1,181 @@
+package webhooks_test
+
+import (
+	"context"
+	"encoding/json"
+	auditDef "github.com/getground/tech-tasks/backend/definitions/audit"
+	guestsDef "github.com/getground/tech-tasks/backend/definitions/guests"
+	outboxDef "github.com/getground/tech-tasks/backend/definitions/outbox"
+	tablesDef "github.com/getground/tech-tasks/backend/definitions/tables"
+	webhooksDef "github.com/getground/tech-tasks/backend/definitions/webhooks"
+	webhooksMocks "github.com/getground/tech-tasks/backend/mocks/definitions/webhooks"
+	"github.com/getground/tech-tasks/backend/pkg/modules/webhooks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// request is a request received by the endpoint of a subscription.
+type request struct {
+	signature string
+	payload   webhooksDef.Payload
+	body      []byte
+}
+
+func setupDispatcher(t *testing.T, status int) (
+	webhooks.Dispatcher, *webhooksMocks.Repository, string, <-chan request, <-chan webhooksDef.Delivery,
+) {
+	received := make(chan request, 10)
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				var p webhooksDef.Payload
+				_ = json.Unmarshal(body, &p)
+				received <- request{signature: r.Header.Get(webhooksDef.SignatureHeader), payload: p, body: body}
+				w.WriteHeader(status)
+			},
+		),
+	)
+	t.Cleanup(server.Close)
+
+	repo := new(webhooksMocks.Repository)
+	recorded := make(chan webhooksDef.Delivery, 10)
+	repo.On("Record", mock.Anything).Run(
+		func(args mock.Arguments) {
+			recorded <- args.Get(0).(webhooksDef.Delivery)
+		},
+	).Return(nil).Maybe()
+	return webhooks.NewDispatcher(repo, server.Client(), time.Hour), repo, server.URL, received, recorded
+}
+
+// now is the time the changes of the messages are made at.
+var now = time.Date(2026, 10, 19, 20, 30, 0, 0, time.UTC)
+
+// message returns the outbox message of the change.
+func message(t *testing.T, action auditDef.Action, before, after auditDef.State) outboxDef.Message {
+	m, err := outboxDef.NewMessage(auditDef.NewEntry(2, "admin", action, before, after, now))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating the message", err)
+	}
+	return m
+}
+
+func TestDispatcher_Send(t *testing.T) {
+	ctx := context.Background()
+	g := guestsDef.Guest{Name: "sam", TableID: 4, Accompanying: 1}
+	seated := tablesDef.Table{ID: 4, Capacity: 2, EmptySeats: 0}
+	m := message(t, auditDef.ActionGuestCheckedIn, auditDef.State{}, auditDef.Snapshot(&g, &seated))
+
+	t.Run(
+		"table full", func(t *testing.T) {
+			// setup
+			d, repo, url, received, recorded := setupDispatcher(t, http.StatusNoContent)
+
+			//	mocks
+			repo.On("All").Return(
+				[]webhooksDef.Subscription{
+					{ID: 1, EventID: 2, URL: url, Secret: "secret", Types: "table.full"},
+					{ID: 2, EventID: 2, URL: url, Secret: "secret", Types: "guest.checked_out"},
+					{ID: 3, EventID: 3, URL: url, Secret: "secret"},
+				}, nil,
+			).Once()
+			repo.On("Attempts", uint(1), mock.Anything).Return(1, nil).Once()
+
+			//	method call
+			err := d.Send(ctx, m)
+
+			//	assert
+			assert.NoError(t, err)
+			req := <-received
+			assert.Equal(t, webhooks.Sign("secret", req.body), req.signature)
+			assert.Equal(t, webhooksDef.TableFull, req.payload.Type)
+			assert.Equal(t, uint(2), req.payload.Event)
+			assert.Equal(t, "sam", req.payload.Guest)
+			assert.Equal(t, int64(1), req.payload.AccompanyingGuests)
+			assert.Equal(t, webhooksDef.TableDTO{ID: 4, Capacity: 2}, req.payload.Table)
+			assert.Equal(t, now, req.payload.OccurredAt)
+			delivery := <-recorded
+			assert.Equal(t, uint(1), delivery.SubscriptionID)
+			assert.Equal(t, req.payload.ID, delivery.PayloadID)
+			assert.Equal(t, 2, delivery.Attempt)
+			assert.True(t, delivery.Succeeded())
+			assert.Empty(t, received)
+		},
+	)
+
+	t.Run(
+		"failure", func(t *testing.T) {
+			// setup
+			d, repo, url, received, recorded := setupDispatcher(t, http.StatusServiceUnavailable)
+
+			//	mocks
+			repo.On("All").Return([]webhooksDef.Subscription{{ID: 1, EventID: 2, URL: url}}, nil).Once()
+			repo.On("Attempts", uint(1), mock.Anything).Return(0, nil).Twice()
+			repo.On("Attempts", uint(1), mock.Anything).Return(1, nil).Twice()
+
+			//	method call
+			first := d.Send(ctx, m)
+			second := d.Send(ctx, m)
+
+			//	assert
+			assert.EqualError(t, first, "2 webhook deliveries failed")
+			assert.EqualError(t, second, "2 webhook deliveries failed")
+			ids := map[string]bool{}
+			for i := 0; i < 4; i++ {
+				ids[(<-received).payload.ID] = true
+				delivery := <-recorded
+				assert.Equal(t, "endpoint answered 503", delivery.Error)
+				assert.Equal(t, 1+i/2, delivery.Attempt)
+			}
+			// the sends of the message again share the ids of its payloads
+			assert.Len(t, ids, 2)
+			// the subscriptions are read once
+			repo.AssertNumberOfCalls(t, "All", 1)
+		},
+	)
+
+	t.Run(
+		"promotion", func(t *testing.T) {
+			// setup
+			d, repo, url, received, _ := setupDispatcher(t, http.StatusOK)
+			before := tablesDef.Table{ID: 4, Capacity: 4}
+			resized := tablesDef.Table{ID: 4, Capacity: 2}
+			m := message(
+				t, auditDef.ActionGuestPromoted, auditDef.Snapshot(nil, &before), auditDef.Snapshot(&g, &resized),
+			)
+
+			//	mocks
+			repo.On("All").Return([]webhooksDef.Subscription{{ID: 1, EventID: 2, URL: url}}, nil).Once()
+			repo.On("Attempts", uint(1), mock.Anything).Return(0, nil).Once()
+
+			//	method call
+			err := d.Send(ctx, m)
+
+			//	assert
+			assert.NoError(t, err)
+			req := <-received
+			assert.Equal(t, webhooksDef.GuestInvited, req.payload.Type)
+			assert.Equal(t, webhooksDef.TableDTO{ID: 4, Capacity: 2}, req.payload.Table)
+		},
+	)
+
+	t.Run(
+		"not a webhook", func(t *testing.T) {
+			// setup
+			d, repo, _, _, _ := setupDispatcher(t, http.StatusOK)
+			m := message(t, auditDef.ActionTableCreated, auditDef.State{}, auditDef.Snapshot(nil, &seated))
+
+			//	method call
+			err := d.Send(ctx, m)
+
+			//	assert
+			assert.NoError(t, err)
+			repo.AssertNotCalled(t, "All")
+		},
+	)
+}
@@ -0,0 +1,38 @@
+package webhooks
+
+import (
+	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/webhooks"
+	"github.com/gin-gonic/gin"
+	"strconv"
+)
+
+type Handler struct{}
+
+func NewHandler() Handler {
+	return Handler{}
+}
+
+func (h Handler) Create(c *gin.Context) (req webhooks.CreateRequest, err error) {
+	err = c.ShouldBindJSON(&req)
+	return
+}
+
+func (h Handler) Update(c *gin.Context) (req webhooks.UpdateRequest, err error) {
+	req.ID, err = h.ID(c)
+	if err != nil {
+		return
+	}
+	err = c.ShouldBindJSON(&req)
+	return
+}
+
+func (h Handler) ID(c *gin.Context) (id uint, err error) {
+	n, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || n == 0 {
+		err = errors.New("id must be a webhook subscription id")
+		return
+	}
+	id = uint(n)
+	return
+}
@@ -0,0 +1,43 @@
+package webhooks
+
+import (
+	"github.com/getground/tech-tasks/backend/definitions/webhooks"
+	"strings"
+)
+
+func mapSubscriptionsToDTO(list []webhooks.Subscription) webhooks.ListDTO {
+	subs := make([]webhooks.SubscriptionDTO, 0, len(list))
+	for _, s := range list {
+		subs = append(subs, mapSubscriptionToDTO(s))
+	}
+	return webhooks.ListDTO{Subscriptions: subs}
+}
+
+func mapSubscriptionToDTO(s webhooks.Subscription) webhooks.SubscriptionDTO {
+	types := make([]webhooks.Type, 0)
+	if s.Types != "" {
+		for _, t := range strings.Split(s.Types, ",") {
+			types = append(types, webhooks.Type(t))
+		}
+	}
+	return webhooks.SubscriptionDTO{ID: s.ID, URL: s.URL, Types: types, CreatedAt: s.CreatedAt}
+}
+
+func mapDeliveriesToDTO(list []webhooks.Delivery) webhooks.DeliveriesDTO {
+	deliveries := make([]webhooks.DeliveryDTO, 0, len(list))
+	for _, d := range list {
+		deliveries = append(
+			deliveries, webhooks.DeliveryDTO{
+				ID:         d.ID,
+				PayloadID:  d.PayloadID,
+				Type:       d.Type,
+				Attempt:    d.Attempt,
+				StatusCode: d.StatusCode,
+				Error:      d.Error,
+				Succeeded:  d.Succeeded(),
+				CreatedAt:  d.CreatedAt,
+			},
+		)
+	}
+	return webhooks.DeliveriesDTO{Deliveries: deliveries}
+}
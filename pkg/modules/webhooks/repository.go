@@ -0,0 +1,97 @@
+package webhooks
+
+import (
+	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/webhooks"
+	"gorm.io/gorm"
+)
+
+type Repository struct {
+	db    *gorm.DB
+	event uint
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return Repository{
+		db: db,
+	}
+}
+
+func (r Repository) ForEvent(event uint) webhooks.Repository {
+	r.event = event
+	return r
+}
+
+func (r Repository) Create(s webhooks.Subscription) (webhooks.Subscription, error) {
+	s.EventID = r.event
+	err := r.db.Create(&s).Error
+	if err != nil {
+		return webhooks.Subscription{}, err
+	}
+	return s, nil
+}
+
+func (r Repository) GetByID(id uint) (s webhooks.Subscription, err error) {
+	err = r.scoped(r.db).Where("id = ?", id).First(&s).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = webhooks.ErrNotFound
+	}
+	return
+}
+
+func (r Repository) List() (list []webhooks.Subscription, err error) {
+	err = r.scoped(r.db).Order("id").Find(&list).Error
+	return
+}
+
+func (r Repository) All() (list []webhooks.Subscription, err error) {
+	err = r.db.Order("id").Find(&list).Error
+	return
+}
+
+// Update doesn't count the rows affected, MySQL leaves out the rows whose values don't change so an update sending
+// the subscription as it is would look missing.
+func (r Repository) Update(s webhooks.Subscription) error {
+	return r.scoped(r.db.Model(&webhooks.Subscription{})).
+		Where("id = ?", s.ID).
+		Select("url", "secret", "types").
+		Updates(webhooks.Subscription{URL: s.URL, Secret: s.Secret, Types: s.Types}).
+		Error
+}
+
+func (r Repository) Delete(id uint) error {
+	return r.db.Transaction(
+		func(tx *gorm.DB) error {
+			res := r.scoped(tx).Where("id = ?", id).Delete(&webhooks.Subscription{})
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return webhooks.ErrNotFound
+			}
+			return tx.Where("subscription_id = ?", id).Delete(&webhooks.Delivery{}).Error
+		},
+	)
+}
+
+func (r Repository) Record(d webhooks.Delivery) error {
+	return r.db.Create(&d).Error
+}
+
+func (r Repository) Attempts(subscription uint, payload string) (int, error) {
+	var n int64
+	err := r.db.Model(&webhooks.Delivery{}).
+		Where("subscription_id = ? AND payload_id = ?", subscription, payload).
+		Count(&n).Error
+	return int(n), err
+}
+
+func (r Repository) Deliveries(subscription uint, limit int) (list []webhooks.Delivery, err error) {
+	err = r.db.Where("subscription_id = ?", subscription).Order("id DESC").Limit(limit).Find(&list).Error
+	return
+}
+
+// scoped narrows down q to the subscriptions of the event of the repository.
+func (r Repository) scoped(q *gorm.DB) *gorm.DB {
+	return q.Where("event_id = ?", r.event)
+}
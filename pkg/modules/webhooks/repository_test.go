@@ -0,0 +1,304 @@
+package webhooks_test
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	webhooksDef "github.com/getground/tech-tasks/backend/definitions/webhooks"
+	"github.com/getground/tech-tasks/backend/pkg/database"
+	"github.com/getground/tech-tasks/backend/pkg/modules/webhooks"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type repoMocks struct {
+	db      *sql.DB
+	sqlMock sqlmock.Sqlmock
+}
+
+var columns = []string{"id", "event_id", "url", "secret", "types", "created_at"}
+
+func setupIntegrationRepo(t *testing.T) (webhooksDef.Repository, repoMocks) {
+	db, m, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	msc := mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true})
+	gDB, err := database.NewDatabaseForTests(msc)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating grom database connection", err)
+	}
+	r := webhooks.NewRepository(gDB).ForEvent(1)
+	return r, repoMocks{
+		db:      db,
+		sqlMock: m,
+	}
+}
+
+func TestRepository_Create(t *testing.T) {
+	// setup
+	repo, m := setupIntegrationRepo(t)
+	defer m.db.Close()
+	s := webhooksDef.Subscription{URL: "https://example.com/hook", Secret: "secret", Types: "guest.checked_in"}
+
+	//	mocks
+	q := "INSERT INTO `webhook_subscriptions` (`event_id`,`url`,`secret`,`types`,`created_at`) VALUES (?,?,?,?,?)"
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).
+		WithArgs(1, s.URL, s.Secret, s.Types, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	m.sqlMock.ExpectCommit()
+
+	//	method call
+	res, err := repo.Create(s)
+
+	//	assert
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), res.ID)
+	assert.Equal(t, uint(1), res.EventID)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestRepository_GetByID(t *testing.T) {
+	q := "SELECT * FROM `webhook_subscriptions` WHERE event_id = ? AND id = ? ORDER BY " +
+		"`webhook_subscriptions`.`id` LIMIT 1"
+
+	t.Run(
+		"not found", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).WithArgs(1, 3).WillReturnRows(sqlmock.NewRows(columns))
+
+			//	method call
+			_, err := repo.GetByID(3)
+
+			//	assert
+			assert.ErrorIs(t, err, webhooksDef.ErrNotFound)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+				WithArgs(1, 3).
+				WillReturnRows(sqlmock.NewRows(columns).AddRow(3, 1, "https://example.com", "secret", "", time.Now()))
+
+			//	method call
+			res, err := repo.GetByID(3)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, "https://example.com", res.URL)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
+
+func TestRepository_List(t *testing.T) {
+	// setup
+	repo, m := setupIntegrationRepo(t)
+	defer m.db.Close()
+
+	//	mocks
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `webhook_subscriptions` WHERE event_id = ? ORDER BY id")).
+		WithArgs(1).
+		WillReturnRows(
+			sqlmock.NewRows(columns).
+				AddRow(1, 1, "https://example.com/a", "a", "", time.Now()).
+				AddRow(2, 1, "https://example.com/b", "b", "table.full", time.Now()),
+		)
+
+	//	method call
+	res, err := repo.List()
+
+	//	assert
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestRepository_All(t *testing.T) {
+	// setup
+	repo, m := setupIntegrationRepo(t)
+	defer m.db.Close()
+
+	//	mocks
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `webhook_subscriptions` ORDER BY id")).
+		WillReturnRows(
+			sqlmock.NewRows(columns).
+				AddRow(1, 1, "https://example.com/a", "a", "", time.Now()).
+				AddRow(2, 2, "https://example.com/b", "b", "table.full", time.Now()),
+		)
+
+	//	method call
+	res, err := repo.All()
+
+	//	assert
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestRepository_Update(t *testing.T) {
+	q := "UPDATE `webhook_subscriptions` SET `url`=?,`secret`=?,`types`=? WHERE event_id = ? AND id = ?"
+	s := webhooksDef.Subscription{ID: 3, URL: "https://example.com", Secret: "secret", Types: "table.full"}
+	cases := []struct {
+		name     string
+		affected int64
+	}{
+		// MySQL counts no row when the subscription is sent as it is
+		{name: "no change"},
+		{name: "success", affected: 1},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(
+			c.name, func(t *testing.T) {
+				// setup
+				repo, m := setupIntegrationRepo(t)
+				defer m.db.Close()
+
+				//	mocks
+				m.sqlMock.ExpectBegin()
+				m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).
+					WithArgs(s.URL, s.Secret, s.Types, 1, s.ID).
+					WillReturnResult(sqlmock.NewResult(0, c.affected))
+				m.sqlMock.ExpectCommit()
+
+				//	method call
+				err := repo.Update(s)
+
+				//	assert
+				assert.NoError(t, err)
+				assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+			},
+		)
+	}
+}
+
+func TestRepository_Delete(t *testing.T) {
+	deleteSubscription := "DELETE FROM `webhook_subscriptions` WHERE event_id = ? AND id = ?"
+	deleteDeliveries := "DELETE FROM `webhook_deliveries` WHERE subscription_id = ?"
+
+	t.Run(
+		"not found", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(deleteSubscription)).
+				WithArgs(1, 3).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			m.sqlMock.ExpectRollback()
+
+			//	method call
+			err := repo.Delete(3)
+
+			//	assert
+			assert.ErrorIs(t, err, webhooksDef.ErrNotFound)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			repo, m := setupIntegrationRepo(t)
+			defer m.db.Close()
+
+			//	mocks
+			m.sqlMock.ExpectBegin()
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(deleteSubscription)).
+				WithArgs(1, 3).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			m.sqlMock.ExpectExec(regexp.QuoteMeta(deleteDeliveries)).
+				WithArgs(3).
+				WillReturnResult(sqlmock.NewResult(0, 4))
+			m.sqlMock.ExpectCommit()
+
+			//	method call
+			err := repo.Delete(3)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+		},
+	)
+}
+
+func TestRepository_Record(t *testing.T) {
+	// setup
+	repo, m := setupIntegrationRepo(t)
+	defer m.db.Close()
+	d := webhooksDef.Delivery{
+		SubscriptionID: 3, PayloadID: "p1", Type: webhooksDef.TableFull, Attempt: 2, StatusCode: 500,
+		Error: "endpoint answered 500",
+	}
+
+	//	mocks
+	q := "INSERT INTO `webhook_deliveries` (`subscription_id`,`payload_id`,`type`,`attempt`,`status_code`,`error`," +
+		"`created_at`) VALUES (?,?,?,?,?,?,?)"
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec(regexp.QuoteMeta(q)).
+		WithArgs(d.SubscriptionID, d.PayloadID, d.Type, d.Attempt, d.StatusCode, d.Error, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	m.sqlMock.ExpectCommit()
+
+	//	method call
+	err := repo.Record(d)
+
+	//	assert
+	assert.NoError(t, err)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestRepository_Attempts(t *testing.T) {
+	// setup
+	repo, m := setupIntegrationRepo(t)
+	defer m.db.Close()
+
+	//	mocks
+	q := "SELECT count(*) FROM `webhook_deliveries` WHERE subscription_id = ? AND payload_id = ?"
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).
+		WithArgs(3, "p1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	//	method call
+	n, err := repo.Attempts(3, "p1")
+
+	//	assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
+
+func TestRepository_Deliveries(t *testing.T) {
+	// setup
+	repo, m := setupIntegrationRepo(t)
+	defer m.db.Close()
+
+	//	mocks
+	q := "SELECT * FROM `webhook_deliveries` WHERE subscription_id = ? ORDER BY id DESC LIMIT 10"
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta(q)).WithArgs(3).WillReturnError(errors.New("internal error"))
+
+	//	method call
+	_, err := repo.Deliveries(3, 10)
+
+	//	assert
+	assert.Error(t, err)
+	assert.NoError(t, m.sqlMock.ExpectationsWereMet())
+}
@@ -0,0 +1,132 @@
+package webhooks
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/getground/tech-tasks/backend/definitions/webhooks"
+	"strings"
+)
+
+// deliveriesLimit bounds the delivery log returned for a subscription.
+const deliveriesLimit = 100
+
+type Service struct {
+	repository webhooks.Repository
+}
+
+func NewService(repository webhooks.Repository) Service {
+	return Service{repository: repository}
+}
+
+func (s Service) ForEvent(event uint) webhooks.Service {
+	s.repository = s.repository.ForEvent(event)
+	return s
+}
+
+// Create subscribes the url, the secret is returned once so the endpoint can verify the signatures.
+func (s Service) Create(req webhooks.CreateRequest) (res webhooks.SubscriptionDTO, err error) {
+	types, err := joinTypes(req.Types)
+	if err != nil {
+		return
+	}
+	secret := req.Secret
+	if secret == "" {
+		secret, err = newSecret()
+		if err != nil {
+			return
+		}
+	}
+	sub, err := s.repository.Create(webhooks.Subscription{URL: req.URL, Secret: secret, Types: types})
+	if err != nil {
+		return
+	}
+	res = mapSubscriptionToDTO(sub)
+	res.Secret = sub.Secret
+	return
+}
+
+func (s Service) Get(id uint) (res webhooks.SubscriptionDTO, err error) {
+	sub, err := s.repository.GetByID(id)
+	if err != nil {
+		return
+	}
+	res = mapSubscriptionToDTO(sub)
+	return
+}
+
+func (s Service) List() (res webhooks.ListDTO, err error) {
+	list, err := s.repository.List()
+	if err != nil {
+		return
+	}
+	res = mapSubscriptionsToDTO(list)
+	return
+}
+
+func (s Service) Update(req webhooks.UpdateRequest) (res webhooks.SubscriptionDTO, err error) {
+	types, err := joinTypes(req.Types)
+	if err != nil {
+		return
+	}
+	sub, err := s.repository.GetByID(req.ID)
+	if err != nil {
+		return
+	}
+	sub.URL, sub.Types = req.URL, types
+	if req.Secret != "" {
+		sub.Secret = req.Secret
+	}
+	err = s.repository.Update(sub)
+	if err != nil {
+		return
+	}
+	res = mapSubscriptionToDTO(sub)
+	return
+}
+
+func (s Service) Delete(id uint) error {
+	return s.repository.Delete(id)
+}
+
+// Deliveries returns the latest deliveries of the subscription first.
+func (s Service) Deliveries(id uint) (res webhooks.DeliveriesDTO, err error) {
+	_, err = s.repository.GetByID(id)
+	if err != nil {
+		return
+	}
+	list, err := s.repository.Deliveries(id, deliveriesLimit)
+	if err != nil {
+		return
+	}
+	res = mapDeliveriesToDTO(list)
+	return
+}
+
+// joinTypes checks the types and joins them for the subscription, no type subscribes to every type.
+func joinTypes(types []webhooks.Type) (string, error) {
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		if !known(t) {
+			return "", webhooks.ErrInvalidType
+		}
+		names = append(names, string(t))
+	}
+	return strings.Join(names, ","), nil
+}
+
+func known(t webhooks.Type) bool {
+	for _, k := range webhooks.Types {
+		if k == t {
+			return true
+		}
+	}
+	return false
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
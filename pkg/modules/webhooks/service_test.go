@@ -0,0 +1,244 @@
+package webhooks_test
+
+import (
+	"errors"
+	webhooksDef "github.com/getground/tech-tasks/backend/definitions/webhooks"
+	webhooksMocks "github.com/getground/tech-tasks/backend/mocks/definitions/webhooks"
+	"github.com/getground/tech-tasks/backend/pkg/modules/webhooks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func setupService() (webhooksDef.Service, *webhooksMocks.Repository) {
+	repo := new(webhooksMocks.Repository)
+	repo.On("ForEvent", mock.Anything).Return(repo).Maybe()
+	return webhooks.NewService(repo).ForEvent(1), repo
+}
+
+func TestService_Create(t *testing.T) {
+	created := time.Date(2022, 11, 5, 18, 0, 0, 0, time.UTC)
+
+	t.Run(
+		"invalid type", func(t *testing.T) {
+			// setup
+			service, repo := setupService()
+
+			//	method call
+			_, err := service.Create(
+				webhooksDef.CreateRequest{URL: "https://example.com", Types: []webhooksDef.Type{"table.created"}},
+			)
+
+			//	assert
+			assert.ErrorIs(t, err, webhooksDef.ErrInvalidType)
+			repo.AssertNotCalled(t, "Create", mock.Anything)
+		},
+	)
+
+	t.Run(
+		"generated secret", func(t *testing.T) {
+			// setup
+			service, repo := setupService()
+			req := webhooksDef.CreateRequest{URL: "https://example.com"}
+
+			//	mocks
+			repo.On(
+				"Create", mock.MatchedBy(
+					func(s webhooksDef.Subscription) bool {
+						return s.URL == req.URL && s.Types == "" && len(s.Secret) == 64
+					},
+				),
+			).Return(
+				func(s webhooksDef.Subscription) webhooksDef.Subscription {
+					s.ID, s.CreatedAt = 1, created
+					return s
+				}, nil,
+			).Once()
+
+			//	method call
+			res, err := service.Create(req)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, uint(1), res.ID)
+			assert.Empty(t, res.Types)
+			assert.Len(t, res.Secret, 64)
+			repo.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			service, repo := setupService()
+			req := webhooksDef.CreateRequest{
+				URL:    "https://example.com",
+				Types:  []webhooksDef.Type{webhooksDef.GuestCheckedIn, webhooksDef.TableFull},
+				Secret: "secret",
+			}
+			sub := webhooksDef.Subscription{
+				URL: req.URL, Secret: req.Secret, Types: "guest.checked_in,table.full",
+			}
+
+			//	mocks
+			saved := sub
+			saved.ID, saved.EventID, saved.CreatedAt = 1, 1, created
+			repo.On("Create", sub).Return(saved, nil).Once()
+
+			//	method call
+			res, err := service.Create(req)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(
+				t, webhooksDef.SubscriptionDTO{
+					ID: 1, URL: req.URL, Types: req.Types, Secret: "secret", CreatedAt: created,
+				}, res,
+			)
+		},
+	)
+}
+
+func TestService_Get(t *testing.T) {
+	// setup
+	service, repo := setupService()
+
+	//	mocks
+	repo.On("GetByID", uint(3)).
+		Return(webhooksDef.Subscription{ID: 3, URL: "https://example.com", Secret: "secret", Types: "table.full"}, nil).
+		Once()
+
+	//	method call
+	res, err := service.Get(3)
+
+	//	assert
+	assert.NoError(t, err)
+	// the secret is only returned once the subscription is created
+	assert.Equal(
+		t,
+		webhooksDef.SubscriptionDTO{ID: 3, URL: "https://example.com", Types: []webhooksDef.Type{webhooksDef.TableFull}},
+		res,
+	)
+}
+
+func TestService_List(t *testing.T) {
+	// setup
+	service, repo := setupService()
+
+	//	mocks
+	repo.On("List").Return(nil, errors.New("internal error")).Once()
+
+	//	method call
+	res, err := service.List()
+
+	//	assert
+	assert.Error(t, err)
+	assert.Empty(t, res)
+}
+
+func TestService_Update(t *testing.T) {
+	sub := webhooksDef.Subscription{ID: 3, URL: "https://example.com", Secret: "secret", Types: "table.full"}
+
+	t.Run(
+		"not found", func(t *testing.T) {
+			// setup
+			service, repo := setupService()
+
+			//	mocks
+			repo.On("GetByID", uint(3)).Return(webhooksDef.Subscription{}, webhooksDef.ErrNotFound).Once()
+
+			//	method call
+			_, err := service.Update(webhooksDef.UpdateRequest{ID: 3, URL: "https://example.org"})
+
+			//	assert
+			assert.ErrorIs(t, err, webhooksDef.ErrNotFound)
+			repo.AssertNotCalled(t, "Update", mock.Anything)
+		},
+	)
+
+	t.Run(
+		"keeps the secret", func(t *testing.T) {
+			// setup
+			service, repo := setupService()
+
+			//	mocks
+			repo.On("GetByID", uint(3)).Return(sub, nil).Once()
+			repo.On(
+				"Update", webhooksDef.Subscription{ID: 3, URL: "https://example.org", Secret: "secret", Types: ""},
+			).Return(nil).Once()
+
+			//	method call
+			res, err := service.Update(webhooksDef.UpdateRequest{ID: 3, URL: "https://example.org"})
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(
+				t, webhooksDef.SubscriptionDTO{ID: 3, URL: "https://example.org", Types: []webhooksDef.Type{}}, res,
+			)
+			repo.AssertExpectations(t)
+		},
+	)
+}
+
+func TestService_Deliveries(t *testing.T) {
+	created := time.Date(2022, 11, 5, 18, 0, 0, 0, time.UTC)
+
+	t.Run(
+		"not found", func(t *testing.T) {
+			// setup
+			service, repo := setupService()
+
+			//	mocks
+			repo.On("GetByID", uint(3)).Return(webhooksDef.Subscription{}, webhooksDef.ErrNotFound).Once()
+
+			//	method call
+			_, err := service.Deliveries(3)
+
+			//	assert
+			assert.ErrorIs(t, err, webhooksDef.ErrNotFound)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			// setup
+			service, repo := setupService()
+
+			//	mocks
+			repo.On("GetByID", uint(3)).Return(webhooksDef.Subscription{ID: 3}, nil).Once()
+			repo.On("Deliveries", uint(3), 100).Return(
+				[]webhooksDef.Delivery{
+					{
+						ID: 2, SubscriptionID: 3, PayloadID: "p", Type: webhooksDef.TableFull, Attempt: 2, StatusCode: 204,
+						CreatedAt: created,
+					},
+					{
+						ID: 1, SubscriptionID: 3, PayloadID: "p", Type: webhooksDef.TableFull, Attempt: 1,
+						Error: "connection refused", CreatedAt: created,
+					},
+				}, nil,
+			).Once()
+
+			//	method call
+			res, err := service.Deliveries(3)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(
+				t, webhooksDef.DeliveriesDTO{
+					Deliveries: []webhooksDef.DeliveryDTO{
+						{
+							ID: 2, PayloadID: "p", Type: webhooksDef.TableFull, Attempt: 2, StatusCode: 204,
+							Succeeded: true, CreatedAt: created,
+						},
+						{
+							ID: 1, PayloadID: "p", Type: webhooksDef.TableFull, Attempt: 1, Error: "connection refused",
+							CreatedAt: created,
+						},
+					},
+				}, res,
+			)
+		},
+	)
+}
@@ -0,0 +1,59 @@
+package notifications
+
+import (
+	"github.com/getground/tech-tasks/backend/definitions/notifications"
+	log "github.com/sirupsen/logrus"
+	"sync"
+)
+
+const subscriberBuffer = 64
+
+// Broker fans out notifications to in process subscribers. Publishing never blocks the caller, a subscriber that
+// doesn't keep up misses notifications instead.
+type Broker struct {
+	mu          sync.RWMutex
+	nextID      int
+	subscribers map[int]chan notifications.Notification
+}
+
+func NewBroker() *Broker {
+	return &Broker{
+		subscribers: map[int]chan notifications.Notification{},
+	}
+}
+
+func (b *Broker) Publish(n notifications.Notification) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for id, ch := range b.subscribers {
+		select {
+		case ch <- n:
+		default:
+			log.Warnf("notification %s dropped for slow subscriber %d", n.Type, id)
+		}
+	}
+}
+
+func (b *Broker) Subscribe() (<-chan notifications.Notification, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	ch := make(chan notifications.Notification, subscriberBuffer)
+	b.subscribers[id] = ch
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(
+			func() {
+				b.mu.Lock()
+				defer b.mu.Unlock()
+				delete(b.subscribers, id)
+				close(ch)
+			},
+		)
+	}
+	return ch, cancel
+}
@@ -0,0 +1,80 @@
+package notifications_test
+
+import (
+	notificationsDef "github.com/getground/tech-tasks/backend/definitions/notifications"
+	"github.com/getground/tech-tasks/backend/pkg/notifications"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBroker_Publish(t *testing.T) {
+	t.Run(
+		"no subscribers", func(t *testing.T) {
+			b := notifications.NewBroker()
+
+			assert.NotPanics(
+				t, func() {
+					b.Publish(notificationsDef.Notification{Type: notificationsDef.TableCreated})
+				},
+			)
+		},
+	)
+
+	t.Run(
+		"fan out", func(t *testing.T) {
+			// setup
+			b := notifications.NewBroker()
+			first, cancelFirst := b.Subscribe()
+			defer cancelFirst()
+			second, cancelSecond := b.Subscribe()
+			defer cancelSecond()
+
+			// test data
+			n := notificationsDef.Notification{Type: notificationsDef.GuestCheckedIn, Guest: "test", TableID: 1}
+
+			//	method call
+			b.Publish(n)
+
+			//	assert
+			assert.Equal(t, n, <-first)
+			assert.Equal(t, n, <-second)
+		},
+	)
+
+	t.Run(
+		"slow subscriber doesn't block", func(t *testing.T) {
+			// setup
+			b := notifications.NewBroker()
+			ch, cancel := b.Subscribe()
+			defer cancel()
+
+			//	method call
+			for i := 0; i < 100; i++ {
+				b.Publish(notificationsDef.Notification{Type: notificationsDef.GuestCheckedIn, TableID: uint(i)})
+			}
+
+			//	assert
+			assert.Equal(t, uint(0), (<-ch).TableID)
+			assert.Len(t, ch, 63)
+		},
+	)
+}
+
+func TestBroker_Subscribe(t *testing.T) {
+	t.Run(
+		"cancel closes the channel", func(t *testing.T) {
+			// setup
+			b := notifications.NewBroker()
+			ch, cancel := b.Subscribe()
+
+			//	method call
+			cancel()
+			cancel()
+			b.Publish(notificationsDef.Notification{Type: notificationsDef.TableCreated})
+
+			//	assert
+			_, open := <-ch
+			assert.False(t, open)
+		},
+	)
+}
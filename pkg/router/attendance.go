@@ -0,0 +1,11 @@
+package router
+
+import (
+	"github.com/getground/tech-tasks/backend/pkg/modules/attendance"
+	"github.com/gin-gonic/gin"
+)
+
+func AttendanceInitRoute(router gin.IRouter, ctrl attendance.Controller) {
+	router.GET("/attendance/occupancy", ctrl.Occupancy)
+	router.GET("/attendance/report", ctrl.Report)
+}
@@ -0,0 +1,10 @@
+package router
+
+import (
+	"github.com/getground/tech-tasks/backend/pkg/modules/audit"
+	"github.com/gin-gonic/gin"
+)
+
+func AuditInitRoute(router gin.IRouter, ctrl audit.Controller) {
+	router.GET("/audit", ctrl.List)
+}
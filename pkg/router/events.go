@@ -0,0 +1,16 @@
+package router
+
+import (
+	"github.com/getground/tech-tasks/backend/pkg/modules/events"
+	"github.com/gin-gonic/gin"
+)
+
+// EventsInitRoute registers the routes of the events and returns the group of the routes nested under an event.
+func EventsInitRoute(router *gin.Engine, ctrl events.Controller) *gin.RouterGroup {
+	router.POST("/events", ctrl.Create)
+	router.GET("/events", ctrl.List)
+	router.GET("/events/:event", ctrl.Get)
+	router.PUT("/events/:event/status", ctrl.Transition)
+	router.PUT("/events/:event/venue", ctrl.SetVenue)
+	return router.Group("/events/:event", ctrl.Scope)
+}
@@ -0,0 +1,11 @@
+package router
+
+import (
+	"github.com/getground/tech-tasks/backend/pkg/gql"
+	"github.com/gin-gonic/gin"
+)
+
+func GraphQLInitRoute(router gin.IRouter, ctrl gql.Controller) {
+	router.GET("/graphql", ctrl.Execute)
+	router.POST("/graphql", ctrl.Execute)
+}
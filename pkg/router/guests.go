@@ -5,10 +5,26 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func GuestsInitRoute(router *gin.Engine, ctrl guests.Controller) {
+// GuestsInitRoute registers the routes of the guests of an event, the router scopes them to the event.
+func GuestsInitRoute(router gin.IRouter, ctrl guests.Controller) {
 	router.POST("/guest_list/:name", ctrl.Create)
 	router.GET("/guest_list", ctrl.GetGuestList)
+	router.GET("/guest_list/:name", ctrl.Get)
+	router.DELETE("/guest_list/:name", ctrl.Uninvite)
+	router.PUT("/guest_list/:name/companions", ctrl.EditCompanions)
+	router.PUT("/guest_list/:name/profile", ctrl.EditProfile)
 	router.PUT("/guests/:name", ctrl.CheckIn)
 	router.GET("/guests", ctrl.GetGuests)
+	router.GET("/guests/search", ctrl.Search)
 	router.DELETE("/guests/:name", ctrl.CheckOut)
+	router.POST("/guest_list/:name/invitation", ctrl.Reinvite)
+	router.POST("/checkin/walk_in", ctrl.WalkIn)
+	router.GET("/reports/rsvp", ctrl.RSVPCounts)
+	router.GET("/reports/catering", ctrl.Catering)
+}
+
+// GuestsTokenInitRoute registers the routes taking an invitation token, the invitation tells the event of the guest.
+func GuestsTokenInitRoute(router gin.IRouter, ctrl guests.Controller) {
+	router.POST("/checkin/scan", ctrl.Scan)
+	router.POST("/rsvp/:token", ctrl.Respond)
 }
@@ -0,0 +1,16 @@
+package router
+
+import (
+	"github.com/getground/tech-tasks/backend/pkg/modules/invitations"
+	"github.com/gin-gonic/gin"
+)
+
+// InvitationsInitRoute registers the routes of the invitations of an event, the router scopes them to the event.
+func InvitationsInitRoute(router gin.IRouter, ctrl invitations.Controller) {
+	router.DELETE("/guest_list/:name/invitation", ctrl.Revoke)
+}
+
+// InvitationsTokenInitRoute registers the routes taking an invitation token, the invitation tells the event.
+func InvitationsTokenInitRoute(router gin.IRouter, ctrl invitations.Controller) {
+	router.GET("/invitations/:token/qr", ctrl.QR)
+}
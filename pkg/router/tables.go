@@ -5,7 +5,10 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func TablesInitRouter(router *gin.Engine, ctrl tables.Controller) {
+func TablesInitRouter(router gin.IRouter, ctrl tables.Controller) {
 	router.POST("/tables", ctrl.Create)
+	router.GET("/tables", ctrl.List)
+	router.GET("/tables/:id", ctrl.Get)
+	router.PUT("/tables/:id", ctrl.Resize)
 	router.GET("/seats_empty", ctrl.CountEmptySeats)
 }
@@ -0,0 +1,10 @@
+package router
+
+import (
+	"github.com/getground/tech-tasks/backend/pkg/modules/venue"
+	"github.com/gin-gonic/gin"
+)
+
+func VenueInitRoute(router gin.IRouter, ctrl venue.Controller) {
+	router.GET("/venue/headcount", ctrl.Headcount)
+}
@@ -0,0 +1,12 @@
+package router
+
+import (
+	"github.com/getground/tech-tasks/backend/pkg/modules/waitlist"
+	"github.com/gin-gonic/gin"
+)
+
+func WaitlistInitRoute(router gin.IRouter, ctrl waitlist.Controller) {
+	router.POST("/waitlist", ctrl.Create)
+	router.GET("/waitlist", ctrl.List)
+	router.DELETE("/waitlist/:id", ctrl.Delete)
+}
@@ -0,0 +1,15 @@
+package router
+
+import (
+	"github.com/getground/tech-tasks/backend/pkg/modules/webhooks"
+	"github.com/gin-gonic/gin"
+)
+
+func WebhooksInitRoute(router gin.IRouter, ctrl webhooks.Controller) {
+	router.POST("/webhooks", ctrl.Create)
+	router.GET("/webhooks", ctrl.List)
+	router.GET("/webhooks/:id", ctrl.Get)
+	router.PUT("/webhooks/:id", ctrl.Update)
+	router.DELETE("/webhooks/:id", ctrl.Delete)
+	router.GET("/webhooks/:id/deliveries", ctrl.Deliveries)
+}
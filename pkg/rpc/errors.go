@@ -0,0 +1,33 @@
+package rpc
+
+import (
+	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/etag"
+	"github.com/getground/tech-tasks/backend/definitions/events"
+	"github.com/getground/tech-tasks/backend/definitions/guests"
+	"github.com/getground/tech-tasks/backend/definitions/tables"
+	"github.com/getground/tech-tasks/backend/definitions/venue"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// toStatus maps the services errors to grpc status codes, a change made on a stale version fails its precondition as
+// it does over HTTP.
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, tables.ErrNotFound), errors.Is(err, events.ErrNotFound), errors.Is(err, gorm.ErrRecordNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, guests.ErrAlreadyListed):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, guests.ErrNotInvited), errors.Is(err, events.ErrNotAllowed),
+		errors.Is(err, guests.ErrCompanionCount), errors.Is(err, etag.ErrStale),
+		errors.Is(err, guests.ErrNoEmptySeats):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, guests.ErrNoCapacity), errors.Is(err, guests.ErrExtraAccompanying),
+		errors.Is(err, venue.ErrFull):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,68 @@
+package rpc
+
+import (
+	"github.com/getground/tech-tasks/backend/definitions/guests"
+	"github.com/getground/tech-tasks/backend/definitions/notifications"
+	"github.com/getground/tech-tasks/backend/definitions/tables"
+	"github.com/getground/tech-tasks/backend/pkg/rpc/partypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func mapTablesToProto(ts []tables.Table) []*partypb.Table {
+	list := make([]*partypb.Table, 0, len(ts))
+	for _, t := range ts {
+		list = append(list, mapTableToProto(t))
+	}
+	return list
+}
+
+func mapTableToProto(t tables.Table) *partypb.Table {
+	return &partypb.Table{
+		Id:         uint32(t.ID),
+		Capacity:   t.Capacity,
+		EmptySeats: t.EmptySeats,
+	}
+}
+
+func mapGuestListToProto(dto guests.ListDTO) *partypb.ListGuestListResponse {
+	list := make([]*partypb.GuestListEntry, 0, len(dto.Guests))
+	for _, g := range dto.Guests {
+		list = append(
+			list, &partypb.GuestListEntry{
+				Name:               g.Name,
+				Table:              uint32(g.Table),
+				AccompanyingGuests: g.Accompanying,
+			},
+		)
+	}
+	return &partypb.ListGuestListResponse{Guests: list}
+}
+
+func mapArrivedGuestsToProto(dto guests.DTO) *partypb.ListArrivedGuestsResponse {
+	list := make([]*partypb.ArrivedGuest, 0, len(dto.Guests))
+	for _, g := range dto.Guests {
+		list = append(
+			list, &partypb.ArrivedGuest{
+				Name:               g.Name,
+				AccompanyingGuests: g.Accompanying,
+				TimeArrived:        g.TimeArrived,
+			},
+		)
+	}
+	return &partypb.ListArrivedGuestsResponse{Guests: list}
+}
+
+func mapNotificationToProto(n notifications.Notification) *partypb.OccupancyChange {
+	kind := partypb.OccupancyChange_KIND_CHECKED_IN
+	if n.Type == notifications.GuestCheckedOut {
+		kind = partypb.OccupancyChange_KIND_CHECKED_OUT
+	}
+	return &partypb.OccupancyChange{
+		Kind:               kind,
+		Guest:              n.Guest,
+		AccompanyingGuests: n.Accompanying,
+		Table:              uint32(n.TableID),
+		EmptySeats:         n.EmptySeats,
+		OccurredAt:         timestamppb.New(n.OccurredAt),
+	}
+}
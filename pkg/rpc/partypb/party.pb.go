@@ -0,0 +1,1938 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: party/v1/party.proto
+
+package partypb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type OccupancyChange_Kind int32
+
+const (
+	OccupancyChange_KIND_UNSPECIFIED OccupancyChange_Kind = 0
+	OccupancyChange_KIND_CHECKED_IN  OccupancyChange_Kind = 1
+	OccupancyChange_KIND_CHECKED_OUT OccupancyChange_Kind = 2
+)
+
+// Enum value maps for OccupancyChange_Kind.
+var (
+	OccupancyChange_Kind_name = map[int32]string{
+		0: "KIND_UNSPECIFIED",
+		1: "KIND_CHECKED_IN",
+		2: "KIND_CHECKED_OUT",
+	}
+	OccupancyChange_Kind_value = map[string]int32{
+		"KIND_UNSPECIFIED": 0,
+		"KIND_CHECKED_IN":  1,
+		"KIND_CHECKED_OUT": 2,
+	}
+)
+
+func (x OccupancyChange_Kind) Enum() *OccupancyChange_Kind {
+	p := new(OccupancyChange_Kind)
+	*p = x
+	return p
+}
+
+func (x OccupancyChange_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OccupancyChange_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_party_v1_party_proto_enumTypes[0].Descriptor()
+}
+
+func (OccupancyChange_Kind) Type() protoreflect.EnumType {
+	return &file_party_v1_party_proto_enumTypes[0]
+}
+
+func (x OccupancyChange_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OccupancyChange_Kind.Descriptor instead.
+func (OccupancyChange_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_party_v1_party_proto_rawDescGZIP(), []int{23, 0}
+}
+
+type Table struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Capacity   int64  `protobuf:"varint,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	EmptySeats int64  `protobuf:"varint,3,opt,name=empty_seats,json=emptySeats,proto3" json:"empty_seats,omitempty"`
+}
+
+func (x *Table) Reset() {
+	*x = Table{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_party_v1_party_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Table) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Table) ProtoMessage() {}
+
+func (x *Table) ProtoReflect() protoreflect.Message {
+	mi := &file_party_v1_party_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Table.ProtoReflect.Descriptor instead.
+func (*Table) Descriptor() ([]byte, []int) {
+	return file_party_v1_party_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Table) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Table) GetCapacity() int64 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *Table) GetEmptySeats() int64 {
+	if x != nil {
+		return x.EmptySeats
+	}
+	return 0
+}
+
+type CreateTableRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Capacity int64 `protobuf:"varint,1,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	// event is the id of the event the request is about, zero is the default event.
+	Event uint32 `protobuf:"varint,2,opt,name=event,proto3" json:"event,omitempty"`
+}
+
+func (x *CreateTableRequest) Reset() {
+	*x = CreateTableRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_party_v1_party_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateTableRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTableRequest) ProtoMessage() {}
+
+func (x *CreateTableRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_party_v1_party_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTableRequest.ProtoReflect.Descriptor instead.
+func (*CreateTableRequest) Descriptor() ([]byte, []int) {
+	return file_party_v1_party_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateTableRequest) GetCapacity() int64 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *CreateTableRequest) GetEvent() uint32 {
+	if x != nil {
+		return x.Event
+	}
+	return 0
+}
+
+type CreateTableResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Table *Table `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"`
+}
+
+func (x *CreateTableResponse) Reset() {
+	*x = CreateTableResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_party_v1_party_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateTableResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTableResponse) ProtoMessage() {}
+
+func (x *CreateTableResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_party_v1_party_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTableResponse.ProtoReflect.Descriptor instead.
+func (*CreateTableResponse) Descriptor() ([]byte, []int) {
+	return file_party_v1_party_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateTableResponse) GetTable() *Table {
+	if x != nil {
+		return x.Table
+	}
+	return nil
+}
+
+type ListTablesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// event is the id of the event the request is about, zero is the default event.
+	Event uint32 `protobuf:"varint,1,opt,name=event,proto3" json:"event,omitempty"`
+}
+
+func (x *ListTablesRequest) Reset() {
+	*x = ListTablesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_party_v1_party_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTablesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTablesRequest) ProtoMessage() {}
+
+func (x *ListTablesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_party_v1_party_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTablesRequest.ProtoReflect.Descriptor instead.
+func (*ListTablesRequest) Descriptor() ([]byte, []int) {
+	return file_party_v1_party_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListTablesRequest) GetEvent() uint32 {
+	if x != nil {
+		return x.Event
+	}
+	return 0
+}
+
+type ListTablesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tables []*Table `protobuf:"bytes,1,rep,name=tables,proto3" json:"tables,omitempty"`
+}
+
+func (x *ListTablesResponse) Reset() {
+	*x = ListTablesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_party_v1_party_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTablesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTablesResponse) ProtoMessage() {}
+
+func (x *ListTablesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_party_v1_party_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTablesResponse.ProtoReflect.Descriptor instead.
+func (*ListTablesResponse) Descriptor() ([]byte, []int) {
+	return file_party_v1_party_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListTablesResponse) GetTables() []*Table {
+	if x != nil {
+		return x.Tables
+	}
+	return nil
+}
+
+type GetTableRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// event is the id of the event the request is about, zero is the default event.
+	Event uint32 `protobuf:"varint,2,opt,name=event,proto3" json:"event,omitempty"`
+}
+
+func (x *GetTableRequest) Reset() {
+	*x = GetTableRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_party_v1_party_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTableRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTableRequest) ProtoMessage() {}
+
+func (x *GetTableRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_party_v1_party_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTableRequest.ProtoReflect.Descriptor instead.
+func (*GetTableRequest) Descriptor() ([]byte, []int) {
+	return file_party_v1_party_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetTableRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetTableRequest) GetEvent() uint32 {
+	if x != nil {
+		return x.Event
+	}
+	return 0
+}
+
+type GetTableResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Table *Table `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"`
+}
+
+func (x *GetTableResponse) Reset() {
+	*x = GetTableResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_party_v1_party_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTableResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTableResponse) ProtoMessage() {}
+
+func (x *GetTableResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_party_v1_party_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTableResponse.ProtoReflect.Descriptor instead.
+func (*GetTableResponse) Descriptor() ([]byte, []int) {
+	return file_party_v1_party_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetTableResponse) GetTable() *Table {
+	if x != nil {
+		return x.Table
+	}
+	return nil
+}
+
+type CountEmptySeatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// event is the id of the event the request is about, zero is the default event.
+	Event uint32 `protobuf:"varint,1,opt,name=event,proto3" json:"event,omitempty"`
+}
+
+func (x *CountEmptySeatsRequest) Reset() {
+	*x = CountEmptySeatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_party_v1_party_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CountEmptySeatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountEmptySeatsRequest) ProtoMessage() {}
+
+func (x *CountEmptySeatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_party_v1_party_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountEmptySeatsRequest.ProtoReflect.Descriptor instead.
+func (*CountEmptySeatsRequest) Descriptor() ([]byte, []int) {
+	return file_party_v1_party_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CountEmptySeatsRequest) GetEvent() uint32 {
+	if x != nil {
+		return x.Event
+	}
+	return 0
+}
+
+type CountEmptySeatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SeatsEmpty int64 `protobuf:"varint,1,opt,name=seats_empty,json=seatsEmpty,proto3" json:"seats_empty,omitempty"`
+}
+
+func (x *CountEmptySeatsResponse) Reset() {
+	*x = CountEmptySeatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_party_v1_party_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CountEmptySeatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountEmptySeatsResponse) ProtoMessage() {}
+
+func (x *CountEmptySeatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_party_v1_party_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountEmptySeatsResponse.ProtoReflect.Descriptor instead.
+func (*CountEmptySeatsResponse) Descriptor() ([]byte, []int) {
+	return file_party_v1_party_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CountEmptySeatsResponse) GetSeatsEmpty() int64 {
+	if x != nil {
+		return x.SeatsEmpty
+	}
+	return 0
+}
+
+type InviteGuestRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name               string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Table              uint32 `protobuf:"varint,2,opt,name=table,proto3" json:"table,omitempty"`
+	AccompanyingGuests int64  `protobuf:"varint,3,opt,name=accompanying_guests,json=accompanyingGuests,proto3" json:"accompanying_guests,omitempty"`
+	// event is the id of the event the request is about, zero is the default event.
+	Event uint32 `protobuf:"varint,4,opt,name=event,proto3" json:"event,omitempty"`
+}
+
+func (x *InviteGuestRequest) Reset() {
+	*x = InviteGuestRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_party_v1_party_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InviteGuestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteGuestRequest) ProtoMessage() {}
+
+func (x *InviteGuestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_party_v1_party_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteGuestRequest.ProtoReflect.Descriptor instead.
+func (*InviteGuestRequest) Descriptor() ([]byte, []int) {
+	return file_party_v1_party_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *InviteGuestRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *InviteGuestRequest) GetTable() uint32 {
+	if x != nil {
+		return x.Table
+	}
+	return 0
+}
+
+func (x *InviteGuestRequest) GetAccompanyingGuests() int64 {
+	if x != nil {
+		return x.AccompanyingGuests
+	}
+	return 0
+}
+
+func (x *InviteGuestRequest) GetEvent() uint32 {
+	if x != nil {
+		return x.Event
+	}
+	return 0
+}
+
+type InviteGuestResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *InviteGuestResponse) Reset() {
+	*x = InviteGuestResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_party_v1_party_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InviteGuestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteGuestResponse) ProtoMessage() {}
+
+func (x *InviteGuestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_party_v1_party_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteGuestResponse.ProtoReflect.Descriptor instead.
+func (*InviteGuestResponse) Descriptor() ([]byte, []int) {
+	return file_party_v1_party_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *InviteGuestResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GuestListEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name               string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Table              uint32 `protobuf:"varint,2,opt,name=table,proto3" json:"table,omitempty"`
+	AccompanyingGuests int64  `protobuf:"varint,3,opt,name=accompanying_guests,json=accompanyingGuests,proto3" json:"accompanying_guests,omitempty"`
+}
+
+func (x *GuestListEntry) Reset() {
+	*x = GuestListEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_party_v1_party_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GuestListEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuestListEntry) ProtoMessage() {}
+
+func (x *GuestListEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_party_v1_party_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuestListEntry.ProtoReflect.Descriptor instead.
+func (*GuestListEntry) Descriptor() ([]byte, []int) {
+	return file_party_v1_party_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GuestListEntry) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GuestListEntry) GetTable() uint32 {
+	if x != nil {
+		return x.Table
+	}
+	return 0
+}
+
+func (x *GuestListEntry) GetAccompanyingGuests() int64 {
+	if x != nil {
+		return x.AccompanyingGuests
+	}
+	return 0
+}
+
+type ListGuestListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// event is the id of the event the request is about, zero is the default event.
+	Event uint32 `protobuf:"varint,1,opt,name=event,proto3" json:"event,omitempty"`
+}
+
+func (x *ListGuestListRequest) Reset() {
+	*x = ListGuestListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_party_v1_party_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListGuestListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGuestListRequest) ProtoMessage() {}
+
+func (x *ListGuestListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_party_v1_party_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGuestListRequest.ProtoReflect.Descriptor instead.
+func (*ListGuestListRequest) Descriptor() ([]byte, []int) {
+	return file_party_v1_party_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListGuestListRequest) GetEvent() uint32 {
+	if x != nil {
+		return x.Event
+	}
+	return 0
+}
+
+type ListGuestListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Guests []*GuestListEntry `protobuf:"bytes,1,rep,name=guests,proto3" json:"guests,omitempty"`
+}
+
+func (x *ListGuestListResponse) Reset() {
+	*x = ListGuestListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_party_v1_party_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListGuestListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGuestListResponse) ProtoMessage() {}
+
+func (x *ListGuestListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_party_v1_party_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGuestListResponse.ProtoReflect.Descriptor instead.
+func (*ListGuestListResponse) Descriptor() ([]byte, []int) {
+	return file_party_v1_party_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListGuestListResponse) GetGuests() []*GuestListEntry {
+	if x != nil {
+		return x.Guests
+	}
+	return nil
+}
+
+type ArrivedGuest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name               string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	AccompanyingGuests int64  `protobuf:"varint,2,opt,name=accompanying_guests,json=accompanyingGuests,proto3" json:"accompanying_guests,omitempty"`
+	TimeArrived        string `protobuf:"bytes,3,opt,name=time_arrived,json=timeArrived,proto3" json:"time_arrived,omitempty"`
+}
+
+func (x *ArrivedGuest) Reset() {
+	*x = ArrivedGuest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_party_v1_party_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ArrivedGuest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArrivedGuest) ProtoMessage() {}
+
+func (x *ArrivedGuest) ProtoReflect() protoreflect.Message {
+	mi := &file_party_v1_party_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArrivedGuest.ProtoReflect.Descriptor instead.
+func (*ArrivedGuest) Descriptor() ([]byte, []int) {
+	return file_party_v1_party_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ArrivedGuest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ArrivedGuest) GetAccompanyingGuests() int64 {
+	if x != nil {
+		return x.AccompanyingGuests
+	}
+	return 0
+}
+
+func (x *ArrivedGuest) GetTimeArrived() string {
+	if x != nil {
+		return x.TimeArrived
+	}
+	return ""
+}
+
+type ListArrivedGuestsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// event is the id of the event the request is about, zero is the default event.
+	Event uint32 `protobuf:"varint,1,opt,name=event,proto3" json:"event,omitempty"`
+}
+
+func (x *ListArrivedGuestsRequest) Reset() {
+	*x = ListArrivedGuestsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_party_v1_party_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListArrivedGuestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArrivedGuestsRequest) ProtoMessage() {}
+
+func (x *ListArrivedGuestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_party_v1_party_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArrivedGuestsRequest.ProtoReflect.Descriptor instead.
+func (*ListArrivedGuestsRequest) Descriptor() ([]byte, []int) {
+	return file_party_v1_party_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListArrivedGuestsRequest) GetEvent() uint32 {
+	if x != nil {
+		return x.Event
+	}
+	return 0
+}
+
+type ListArrivedGuestsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Guests []*ArrivedGuest `protobuf:"bytes,1,rep,name=guests,proto3" json:"guests,omitempty"`
+}
+
+func (x *ListArrivedGuestsResponse) Reset() {
+	*x = ListArrivedGuestsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_party_v1_party_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListArrivedGuestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArrivedGuestsResponse) ProtoMessage() {}
+
+func (x *ListArrivedGuestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_party_v1_party_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArrivedGuestsResponse.ProtoReflect.Descriptor instead.
+func (*ListArrivedGuestsResponse) Descriptor() ([]byte, []int) {
+	return file_party_v1_party_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListArrivedGuestsResponse) GetGuests() []*ArrivedGuest {
+	if x != nil {
+		return x.Guests
+	}
+	return nil
+}
+
+type CheckInRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name               string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	AccompanyingGuests int64  `protobuf:"varint,2,opt,name=accompanying_guests,json=accompanyingGuests,proto3" json:"accompanying_guests,omitempty"`
+	// event is the id of the event the request is about, zero is the default event.
+	Event uint32 `protobuf:"varint,3,opt,name=event,proto3" json:"event,omitempty"`
+}
+
+func (x *CheckInRequest) Reset() {
+	*x = CheckInRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_party_v1_party_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckInRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckInRequest) ProtoMessage() {}
+
+func (x *CheckInRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_party_v1_party_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckInRequest.ProtoReflect.Descriptor instead.
+func (*CheckInRequest) Descriptor() ([]byte, []int) {
+	return file_party_v1_party_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CheckInRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CheckInRequest) GetAccompanyingGuests() int64 {
+	if x != nil {
+		return x.AccompanyingGuests
+	}
+	return 0
+}
+
+func (x *CheckInRequest) GetEvent() uint32 {
+	if x != nil {
+		return x.Event
+	}
+	return 0
+}
+
+type CheckInResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *CheckInResponse) Reset() {
+	*x = CheckInResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_party_v1_party_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckInResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckInResponse) ProtoMessage() {}
+
+func (x *CheckInResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_party_v1_party_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckInResponse.ProtoReflect.Descriptor instead.
+func (*CheckInResponse) Descriptor() ([]byte, []int) {
+	return file_party_v1_party_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CheckInResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CheckOutRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// event is the id of the event the request is about, zero is the default event.
+	Event uint32 `protobuf:"varint,2,opt,name=event,proto3" json:"event,omitempty"`
+}
+
+func (x *CheckOutRequest) Reset() {
+	*x = CheckOutRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_party_v1_party_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckOutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckOutRequest) ProtoMessage() {}
+
+func (x *CheckOutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_party_v1_party_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckOutRequest.ProtoReflect.Descriptor instead.
+func (*CheckOutRequest) Descriptor() ([]byte, []int) {
+	return file_party_v1_party_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CheckOutRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CheckOutRequest) GetEvent() uint32 {
+	if x != nil {
+		return x.Event
+	}
+	return 0
+}
+
+type CheckOutResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CheckOutResponse) Reset() {
+	*x = CheckOutResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_party_v1_party_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckOutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckOutResponse) ProtoMessage() {}
+
+func (x *CheckOutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_party_v1_party_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckOutResponse.ProtoReflect.Descriptor instead.
+func (*CheckOutResponse) Descriptor() ([]byte, []int) {
+	return file_party_v1_party_proto_rawDescGZIP(), []int{20}
+}
+
+type WatchOccupancyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// table limits the stream to one table, zero streams every table.
+	Table uint32 `protobuf:"varint,1,opt,name=table,proto3" json:"table,omitempty"`
+	// event is the id of the event the request is about, zero is the default event.
+	Event uint32 `protobuf:"varint,2,opt,name=event,proto3" json:"event,omitempty"`
+}
+
+func (x *WatchOccupancyRequest) Reset() {
+	*x = WatchOccupancyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_party_v1_party_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchOccupancyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchOccupancyRequest) ProtoMessage() {}
+
+func (x *WatchOccupancyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_party_v1_party_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchOccupancyRequest.ProtoReflect.Descriptor instead.
+func (*WatchOccupancyRequest) Descriptor() ([]byte, []int) {
+	return file_party_v1_party_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WatchOccupancyRequest) GetTable() uint32 {
+	if x != nil {
+		return x.Table
+	}
+	return 0
+}
+
+func (x *WatchOccupancyRequest) GetEvent() uint32 {
+	if x != nil {
+		return x.Event
+	}
+	return 0
+}
+
+type WatchOccupancyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Change *OccupancyChange `protobuf:"bytes,1,opt,name=change,proto3" json:"change,omitempty"`
+}
+
+func (x *WatchOccupancyResponse) Reset() {
+	*x = WatchOccupancyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_party_v1_party_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchOccupancyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchOccupancyResponse) ProtoMessage() {}
+
+func (x *WatchOccupancyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_party_v1_party_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchOccupancyResponse.ProtoReflect.Descriptor instead.
+func (*WatchOccupancyResponse) Descriptor() ([]byte, []int) {
+	return file_party_v1_party_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *WatchOccupancyResponse) GetChange() *OccupancyChange {
+	if x != nil {
+		return x.Change
+	}
+	return nil
+}
+
+type OccupancyChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind               OccupancyChange_Kind   `protobuf:"varint,1,opt,name=kind,proto3,enum=party.v1.OccupancyChange_Kind" json:"kind,omitempty"`
+	Guest              string                 `protobuf:"bytes,2,opt,name=guest,proto3" json:"guest,omitempty"`
+	AccompanyingGuests int64                  `protobuf:"varint,3,opt,name=accompanying_guests,json=accompanyingGuests,proto3" json:"accompanying_guests,omitempty"`
+	Table              uint32                 `protobuf:"varint,4,opt,name=table,proto3" json:"table,omitempty"`
+	EmptySeats         int64                  `protobuf:"varint,5,opt,name=empty_seats,json=emptySeats,proto3" json:"empty_seats,omitempty"`
+	OccurredAt         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+}
+
+func (x *OccupancyChange) Reset() {
+	*x = OccupancyChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_party_v1_party_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OccupancyChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OccupancyChange) ProtoMessage() {}
+
+func (x *OccupancyChange) ProtoReflect() protoreflect.Message {
+	mi := &file_party_v1_party_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OccupancyChange.ProtoReflect.Descriptor instead.
+func (*OccupancyChange) Descriptor() ([]byte, []int) {
+	return file_party_v1_party_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *OccupancyChange) GetKind() OccupancyChange_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return OccupancyChange_KIND_UNSPECIFIED
+}
+
+func (x *OccupancyChange) GetGuest() string {
+	if x != nil {
+		return x.Guest
+	}
+	return ""
+}
+
+func (x *OccupancyChange) GetAccompanyingGuests() int64 {
+	if x != nil {
+		return x.AccompanyingGuests
+	}
+	return 0
+}
+
+func (x *OccupancyChange) GetTable() uint32 {
+	if x != nil {
+		return x.Table
+	}
+	return 0
+}
+
+func (x *OccupancyChange) GetEmptySeats() int64 {
+	if x != nil {
+		return x.EmptySeats
+	}
+	return 0
+}
+
+func (x *OccupancyChange) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+var File_party_v1_party_proto protoreflect.FileDescriptor
+
+var file_party_v1_party_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x70, 0x61, 0x72, 0x74, 0x79, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x61, 0x72, 0x74, 0x79,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x70, 0x61, 0x72, 0x74, 0x79, 0x2e, 0x76, 0x31,
+	0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x54, 0x0a, 0x05, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61,
+	0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x63, 0x61,
+	0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x5f,
+	0x73, 0x65, 0x61, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x65, 0x6d, 0x70,
+	0x74, 0x79, 0x53, 0x65, 0x61, 0x74, 0x73, 0x22, 0x46, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22,
+	0x3c, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x61, 0x72, 0x74, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x22, 0x29, 0x0a,
+	0x11, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x3d, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74,
+	0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27,
+	0x0a, 0x06, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x70, 0x61, 0x72, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52,
+	0x06, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x22, 0x37, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x54, 0x61,
+	0x62, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x22, 0x39, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x61, 0x72, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x54,
+	0x61, 0x62, 0x6c, 0x65, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x22, 0x2e, 0x0a, 0x16, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x53, 0x65, 0x61, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x3a, 0x0a, 0x17, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x53, 0x65, 0x61, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x61, 0x74, 0x73, 0x5f,
+	0x65, 0x6d, 0x70, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x65, 0x61,
+	0x74, 0x73, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x85, 0x01, 0x0a, 0x12, 0x49, 0x6e, 0x76, 0x69,
+	0x74, 0x65, 0x47, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x2f, 0x0a, 0x13, 0x61, 0x63, 0x63, 0x6f,
+	0x6d, 0x70, 0x61, 0x6e, 0x79, 0x69, 0x6e, 0x67, 0x5f, 0x67, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x61, 0x63, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79,
+	0x69, 0x6e, 0x67, 0x47, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22,
+	0x29, 0x0a, 0x13, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x47, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x6b, 0x0a, 0x0e, 0x47, 0x75,
+	0x65, 0x73, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x2f, 0x0a, 0x13, 0x61, 0x63, 0x63, 0x6f, 0x6d, 0x70,
+	0x61, 0x6e, 0x79, 0x69, 0x6e, 0x67, 0x5f, 0x67, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x12, 0x61, 0x63, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x69, 0x6e,
+	0x67, 0x47, 0x75, 0x65, 0x73, 0x74, 0x73, 0x22, 0x2c, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x47,
+	0x75, 0x65, 0x73, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x49, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x75, 0x65,
+	0x73, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30,
+	0x0a, 0x06, 0x67, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x70, 0x61, 0x72, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x75, 0x65, 0x73, 0x74, 0x4c,
+	0x69, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x67, 0x75, 0x65, 0x73, 0x74, 0x73,
+	0x22, 0x76, 0x0a, 0x0c, 0x41, 0x72, 0x72, 0x69, 0x76, 0x65, 0x64, 0x47, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x13, 0x61, 0x63, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e,
+	0x79, 0x69, 0x6e, 0x67, 0x5f, 0x67, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x12, 0x61, 0x63, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x69, 0x6e, 0x67, 0x47,
+	0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x61, 0x72,
+	0x72, 0x69, 0x76, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x69, 0x6d,
+	0x65, 0x41, 0x72, 0x72, 0x69, 0x76, 0x65, 0x64, 0x22, 0x30, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74,
+	0x41, 0x72, 0x72, 0x69, 0x76, 0x65, 0x64, 0x47, 0x75, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x4b, 0x0a, 0x19, 0x4c, 0x69,
+	0x73, 0x74, 0x41, 0x72, 0x72, 0x69, 0x76, 0x65, 0x64, 0x47, 0x75, 0x65, 0x73, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x67, 0x75, 0x65, 0x73, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x61, 0x72, 0x74, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x72, 0x72, 0x69, 0x76, 0x65, 0x64, 0x47, 0x75, 0x65, 0x73, 0x74, 0x52,
+	0x06, 0x67, 0x75, 0x65, 0x73, 0x74, 0x73, 0x22, 0x6b, 0x0a, 0x0e, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x49, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2f, 0x0a,
+	0x13, 0x61, 0x63, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x69, 0x6e, 0x67, 0x5f, 0x67, 0x75,
+	0x65, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x61, 0x63, 0x63, 0x6f,
+	0x6d, 0x70, 0x61, 0x6e, 0x79, 0x69, 0x6e, 0x67, 0x47, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x22, 0x25, 0x0a, 0x0f, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3b, 0x0a, 0x0f, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x12, 0x0a, 0x10, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x43, 0x0a, 0x15,
+	0x57, 0x61, 0x74, 0x63, 0x68, 0x4f, 0x63, 0x63, 0x75, 0x70, 0x61, 0x6e, 0x63, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x22, 0x4b, 0x0a, 0x16, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4f, 0x63, 0x63, 0x75, 0x70, 0x61,
+	0x6e, 0x63, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x06, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x61,
+	0x72, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x63, 0x63, 0x75, 0x70, 0x61, 0x6e, 0x63, 0x79,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x06, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x22, 0xc9,
+	0x02, 0x0a, 0x0f, 0x4f, 0x63, 0x63, 0x75, 0x70, 0x61, 0x6e, 0x63, 0x79, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x12, 0x32, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1e, 0x2e, 0x70, 0x61, 0x72, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x63, 0x63, 0x75,
+	0x70, 0x61, 0x6e, 0x63, 0x79, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x4b, 0x69, 0x6e, 0x64,
+	0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x75, 0x65, 0x73, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x13,
+	0x61, 0x63, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x69, 0x6e, 0x67, 0x5f, 0x67, 0x75, 0x65,
+	0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x61, 0x63, 0x63, 0x6f, 0x6d,
+	0x70, 0x61, 0x6e, 0x79, 0x69, 0x6e, 0x67, 0x47, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x74, 0x61,
+	0x62, 0x6c, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x5f, 0x73, 0x65, 0x61,
+	0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x53,
+	0x65, 0x61, 0x74, 0x73, 0x12, 0x3b, 0x0a, 0x0b, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x64,
+	0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x64, 0x41,
+	0x74, 0x22, 0x47, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x10, 0x4b, 0x49, 0x4e,
+	0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x13, 0x0a, 0x0f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x43, 0x48, 0x45, 0x43, 0x4b, 0x45, 0x44, 0x5f,
+	0x49, 0x4e, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x43, 0x48, 0x45,
+	0x43, 0x4b, 0x45, 0x44, 0x5f, 0x4f, 0x55, 0x54, 0x10, 0x02, 0x32, 0x94, 0x06, 0x0a, 0x0c, 0x50,
+	0x61, 0x72, 0x74, 0x79, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4a, 0x0a, 0x0b, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1c, 0x2e, 0x70, 0x61, 0x72,
+	0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x61, 0x72, 0x74, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x54,
+	0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x70, 0x61, 0x72, 0x74, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x61, 0x72, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x41, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x19, 0x2e, 0x70,
+	0x61, 0x72, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x61, 0x72, 0x74, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x56, 0x0a, 0x0f, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x53, 0x65, 0x61, 0x74, 0x73, 0x12, 0x20, 0x2e, 0x70, 0x61, 0x72, 0x74, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x53, 0x65, 0x61, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x70, 0x61, 0x72, 0x74, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x53, 0x65,
+	0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x0b, 0x49,
+	0x6e, 0x76, 0x69, 0x74, 0x65, 0x47, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x2e, 0x70, 0x61, 0x72,
+	0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x47, 0x75, 0x65, 0x73,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x61, 0x72, 0x74, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x47, 0x75, 0x65, 0x73, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x47,
+	0x75, 0x65, 0x73, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x1e, 0x2e, 0x70, 0x61, 0x72, 0x74, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x75, 0x65, 0x73, 0x74, 0x4c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x61, 0x72, 0x74, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x75, 0x65, 0x73, 0x74, 0x4c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5c, 0x0a, 0x11, 0x4c, 0x69, 0x73,
+	0x74, 0x41, 0x72, 0x72, 0x69, 0x76, 0x65, 0x64, 0x47, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x22,
+	0x2e, 0x70, 0x61, 0x72, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x72,
+	0x72, 0x69, 0x76, 0x65, 0x64, 0x47, 0x75, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x23, 0x2e, 0x70, 0x61, 0x72, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x41, 0x72, 0x72, 0x69, 0x76, 0x65, 0x64, 0x47, 0x75, 0x65, 0x73, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x07, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x49, 0x6e, 0x12, 0x18, 0x2e, 0x70, 0x61, 0x72, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x49, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70,
+	0x61, 0x72, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x08, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x4f, 0x75, 0x74, 0x12, 0x19, 0x2e, 0x70, 0x61, 0x72, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a,
+	0x2e, 0x70, 0x61, 0x72, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x4f,
+	0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a, 0x0e, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x4f, 0x63, 0x63, 0x75, 0x70, 0x61, 0x6e, 0x63, 0x79, 0x12, 0x1f, 0x2e, 0x70,
+	0x61, 0x72, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4f, 0x63, 0x63,
+	0x75, 0x70, 0x61, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e,
+	0x70, 0x61, 0x72, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4f, 0x63,
+	0x63, 0x75, 0x70, 0x61, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30,
+	0x01, 0x42, 0x41, 0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x67, 0x65, 0x74, 0x67, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x2f, 0x74, 0x65, 0x63, 0x68, 0x2d, 0x74,
+	0x61, 0x73, 0x6b, 0x73, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2f, 0x70, 0x6b, 0x67,
+	0x2f, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x61, 0x72, 0x74, 0x79, 0x70, 0x62, 0x3b, 0x70, 0x61, 0x72,
+	0x74, 0x79, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_party_v1_party_proto_rawDescOnce sync.Once
+	file_party_v1_party_proto_rawDescData = file_party_v1_party_proto_rawDesc
+)
+
+func file_party_v1_party_proto_rawDescGZIP() []byte {
+	file_party_v1_party_proto_rawDescOnce.Do(func() {
+		file_party_v1_party_proto_rawDescData = protoimpl.X.CompressGZIP(file_party_v1_party_proto_rawDescData)
+	})
+	return file_party_v1_party_proto_rawDescData
+}
+
+var file_party_v1_party_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_party_v1_party_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_party_v1_party_proto_goTypes = []interface{}{
+	(OccupancyChange_Kind)(0),         // 0: party.v1.OccupancyChange.Kind
+	(*Table)(nil),                     // 1: party.v1.Table
+	(*CreateTableRequest)(nil),        // 2: party.v1.CreateTableRequest
+	(*CreateTableResponse)(nil),       // 3: party.v1.CreateTableResponse
+	(*ListTablesRequest)(nil),         // 4: party.v1.ListTablesRequest
+	(*ListTablesResponse)(nil),        // 5: party.v1.ListTablesResponse
+	(*GetTableRequest)(nil),           // 6: party.v1.GetTableRequest
+	(*GetTableResponse)(nil),          // 7: party.v1.GetTableResponse
+	(*CountEmptySeatsRequest)(nil),    // 8: party.v1.CountEmptySeatsRequest
+	(*CountEmptySeatsResponse)(nil),   // 9: party.v1.CountEmptySeatsResponse
+	(*InviteGuestRequest)(nil),        // 10: party.v1.InviteGuestRequest
+	(*InviteGuestResponse)(nil),       // 11: party.v1.InviteGuestResponse
+	(*GuestListEntry)(nil),            // 12: party.v1.GuestListEntry
+	(*ListGuestListRequest)(nil),      // 13: party.v1.ListGuestListRequest
+	(*ListGuestListResponse)(nil),     // 14: party.v1.ListGuestListResponse
+	(*ArrivedGuest)(nil),              // 15: party.v1.ArrivedGuest
+	(*ListArrivedGuestsRequest)(nil),  // 16: party.v1.ListArrivedGuestsRequest
+	(*ListArrivedGuestsResponse)(nil), // 17: party.v1.ListArrivedGuestsResponse
+	(*CheckInRequest)(nil),            // 18: party.v1.CheckInRequest
+	(*CheckInResponse)(nil),           // 19: party.v1.CheckInResponse
+	(*CheckOutRequest)(nil),           // 20: party.v1.CheckOutRequest
+	(*CheckOutResponse)(nil),          // 21: party.v1.CheckOutResponse
+	(*WatchOccupancyRequest)(nil),     // 22: party.v1.WatchOccupancyRequest
+	(*WatchOccupancyResponse)(nil),    // 23: party.v1.WatchOccupancyResponse
+	(*OccupancyChange)(nil),           // 24: party.v1.OccupancyChange
+	(*timestamppb.Timestamp)(nil),     // 25: google.protobuf.Timestamp
+}
+var file_party_v1_party_proto_depIdxs = []int32{
+	1,  // 0: party.v1.CreateTableResponse.table:type_name -> party.v1.Table
+	1,  // 1: party.v1.ListTablesResponse.tables:type_name -> party.v1.Table
+	1,  // 2: party.v1.GetTableResponse.table:type_name -> party.v1.Table
+	12, // 3: party.v1.ListGuestListResponse.guests:type_name -> party.v1.GuestListEntry
+	15, // 4: party.v1.ListArrivedGuestsResponse.guests:type_name -> party.v1.ArrivedGuest
+	24, // 5: party.v1.WatchOccupancyResponse.change:type_name -> party.v1.OccupancyChange
+	0,  // 6: party.v1.OccupancyChange.kind:type_name -> party.v1.OccupancyChange.Kind
+	25, // 7: party.v1.OccupancyChange.occurred_at:type_name -> google.protobuf.Timestamp
+	2,  // 8: party.v1.PartyService.CreateTable:input_type -> party.v1.CreateTableRequest
+	4,  // 9: party.v1.PartyService.ListTables:input_type -> party.v1.ListTablesRequest
+	6,  // 10: party.v1.PartyService.GetTable:input_type -> party.v1.GetTableRequest
+	8,  // 11: party.v1.PartyService.CountEmptySeats:input_type -> party.v1.CountEmptySeatsRequest
+	10, // 12: party.v1.PartyService.InviteGuest:input_type -> party.v1.InviteGuestRequest
+	13, // 13: party.v1.PartyService.ListGuestList:input_type -> party.v1.ListGuestListRequest
+	16, // 14: party.v1.PartyService.ListArrivedGuests:input_type -> party.v1.ListArrivedGuestsRequest
+	18, // 15: party.v1.PartyService.CheckIn:input_type -> party.v1.CheckInRequest
+	20, // 16: party.v1.PartyService.CheckOut:input_type -> party.v1.CheckOutRequest
+	22, // 17: party.v1.PartyService.WatchOccupancy:input_type -> party.v1.WatchOccupancyRequest
+	3,  // 18: party.v1.PartyService.CreateTable:output_type -> party.v1.CreateTableResponse
+	5,  // 19: party.v1.PartyService.ListTables:output_type -> party.v1.ListTablesResponse
+	7,  // 20: party.v1.PartyService.GetTable:output_type -> party.v1.GetTableResponse
+	9,  // 21: party.v1.PartyService.CountEmptySeats:output_type -> party.v1.CountEmptySeatsResponse
+	11, // 22: party.v1.PartyService.InviteGuest:output_type -> party.v1.InviteGuestResponse
+	14, // 23: party.v1.PartyService.ListGuestList:output_type -> party.v1.ListGuestListResponse
+	17, // 24: party.v1.PartyService.ListArrivedGuests:output_type -> party.v1.ListArrivedGuestsResponse
+	19, // 25: party.v1.PartyService.CheckIn:output_type -> party.v1.CheckInResponse
+	21, // 26: party.v1.PartyService.CheckOut:output_type -> party.v1.CheckOutResponse
+	23, // 27: party.v1.PartyService.WatchOccupancy:output_type -> party.v1.WatchOccupancyResponse
+	18, // [18:28] is the sub-list for method output_type
+	8,  // [8:18] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_party_v1_party_proto_init() }
+func file_party_v1_party_proto_init() {
+	if File_party_v1_party_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_party_v1_party_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Table); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_party_v1_party_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateTableRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_party_v1_party_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateTableResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_party_v1_party_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTablesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_party_v1_party_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTablesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_party_v1_party_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTableRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_party_v1_party_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTableResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_party_v1_party_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CountEmptySeatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_party_v1_party_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CountEmptySeatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_party_v1_party_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InviteGuestRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_party_v1_party_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InviteGuestResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_party_v1_party_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GuestListEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_party_v1_party_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListGuestListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_party_v1_party_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListGuestListResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_party_v1_party_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ArrivedGuest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_party_v1_party_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListArrivedGuestsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_party_v1_party_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListArrivedGuestsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_party_v1_party_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckInRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_party_v1_party_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckInResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_party_v1_party_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckOutRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_party_v1_party_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckOutResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_party_v1_party_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchOccupancyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_party_v1_party_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchOccupancyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_party_v1_party_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OccupancyChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_party_v1_party_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   24,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_party_v1_party_proto_goTypes,
+		DependencyIndexes: file_party_v1_party_proto_depIdxs,
+		EnumInfos:         file_party_v1_party_proto_enumTypes,
+		MessageInfos:      file_party_v1_party_proto_msgTypes,
+	}.Build()
+	File_party_v1_party_proto = out.File
+	file_party_v1_party_proto_rawDesc = nil
+	file_party_v1_party_proto_goTypes = nil
+	file_party_v1_party_proto_depIdxs = nil
+}
@@ -0,0 +1,472 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: party/v1/party.proto
+
+package partypb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	PartyService_CreateTable_FullMethodName       = "/party.v1.PartyService/CreateTable"
+	PartyService_ListTables_FullMethodName        = "/party.v1.PartyService/ListTables"
+	PartyService_GetTable_FullMethodName          = "/party.v1.PartyService/GetTable"
+	PartyService_CountEmptySeats_FullMethodName   = "/party.v1.PartyService/CountEmptySeats"
+	PartyService_InviteGuest_FullMethodName       = "/party.v1.PartyService/InviteGuest"
+	PartyService_ListGuestList_FullMethodName     = "/party.v1.PartyService/ListGuestList"
+	PartyService_ListArrivedGuests_FullMethodName = "/party.v1.PartyService/ListArrivedGuests"
+	PartyService_CheckIn_FullMethodName           = "/party.v1.PartyService/CheckIn"
+	PartyService_CheckOut_FullMethodName          = "/party.v1.PartyService/CheckOut"
+	PartyService_WatchOccupancy_FullMethodName    = "/party.v1.PartyService/WatchOccupancy"
+)
+
+// PartyServiceClient is the client API for PartyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PartyServiceClient interface {
+	CreateTable(ctx context.Context, in *CreateTableRequest, opts ...grpc.CallOption) (*CreateTableResponse, error)
+	ListTables(ctx context.Context, in *ListTablesRequest, opts ...grpc.CallOption) (*ListTablesResponse, error)
+	GetTable(ctx context.Context, in *GetTableRequest, opts ...grpc.CallOption) (*GetTableResponse, error)
+	CountEmptySeats(ctx context.Context, in *CountEmptySeatsRequest, opts ...grpc.CallOption) (*CountEmptySeatsResponse, error)
+	InviteGuest(ctx context.Context, in *InviteGuestRequest, opts ...grpc.CallOption) (*InviteGuestResponse, error)
+	ListGuestList(ctx context.Context, in *ListGuestListRequest, opts ...grpc.CallOption) (*ListGuestListResponse, error)
+	ListArrivedGuests(ctx context.Context, in *ListArrivedGuestsRequest, opts ...grpc.CallOption) (*ListArrivedGuestsResponse, error)
+	CheckIn(ctx context.Context, in *CheckInRequest, opts ...grpc.CallOption) (*CheckInResponse, error)
+	CheckOut(ctx context.Context, in *CheckOutRequest, opts ...grpc.CallOption) (*CheckOutResponse, error)
+	// WatchOccupancy streams every check in and check out of the event, optionally limited to one table.
+	WatchOccupancy(ctx context.Context, in *WatchOccupancyRequest, opts ...grpc.CallOption) (PartyService_WatchOccupancyClient, error)
+}
+
+type partyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPartyServiceClient(cc grpc.ClientConnInterface) PartyServiceClient {
+	return &partyServiceClient{cc}
+}
+
+func (c *partyServiceClient) CreateTable(ctx context.Context, in *CreateTableRequest, opts ...grpc.CallOption) (*CreateTableResponse, error) {
+	out := new(CreateTableResponse)
+	err := c.cc.Invoke(ctx, PartyService_CreateTable_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partyServiceClient) ListTables(ctx context.Context, in *ListTablesRequest, opts ...grpc.CallOption) (*ListTablesResponse, error) {
+	out := new(ListTablesResponse)
+	err := c.cc.Invoke(ctx, PartyService_ListTables_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partyServiceClient) GetTable(ctx context.Context, in *GetTableRequest, opts ...grpc.CallOption) (*GetTableResponse, error) {
+	out := new(GetTableResponse)
+	err := c.cc.Invoke(ctx, PartyService_GetTable_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partyServiceClient) CountEmptySeats(ctx context.Context, in *CountEmptySeatsRequest, opts ...grpc.CallOption) (*CountEmptySeatsResponse, error) {
+	out := new(CountEmptySeatsResponse)
+	err := c.cc.Invoke(ctx, PartyService_CountEmptySeats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partyServiceClient) InviteGuest(ctx context.Context, in *InviteGuestRequest, opts ...grpc.CallOption) (*InviteGuestResponse, error) {
+	out := new(InviteGuestResponse)
+	err := c.cc.Invoke(ctx, PartyService_InviteGuest_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partyServiceClient) ListGuestList(ctx context.Context, in *ListGuestListRequest, opts ...grpc.CallOption) (*ListGuestListResponse, error) {
+	out := new(ListGuestListResponse)
+	err := c.cc.Invoke(ctx, PartyService_ListGuestList_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partyServiceClient) ListArrivedGuests(ctx context.Context, in *ListArrivedGuestsRequest, opts ...grpc.CallOption) (*ListArrivedGuestsResponse, error) {
+	out := new(ListArrivedGuestsResponse)
+	err := c.cc.Invoke(ctx, PartyService_ListArrivedGuests_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partyServiceClient) CheckIn(ctx context.Context, in *CheckInRequest, opts ...grpc.CallOption) (*CheckInResponse, error) {
+	out := new(CheckInResponse)
+	err := c.cc.Invoke(ctx, PartyService_CheckIn_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partyServiceClient) CheckOut(ctx context.Context, in *CheckOutRequest, opts ...grpc.CallOption) (*CheckOutResponse, error) {
+	out := new(CheckOutResponse)
+	err := c.cc.Invoke(ctx, PartyService_CheckOut_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partyServiceClient) WatchOccupancy(ctx context.Context, in *WatchOccupancyRequest, opts ...grpc.CallOption) (PartyService_WatchOccupancyClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PartyService_ServiceDesc.Streams[0], PartyService_WatchOccupancy_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &partyServiceWatchOccupancyClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PartyService_WatchOccupancyClient interface {
+	Recv() (*WatchOccupancyResponse, error)
+	grpc.ClientStream
+}
+
+type partyServiceWatchOccupancyClient struct {
+	grpc.ClientStream
+}
+
+func (x *partyServiceWatchOccupancyClient) Recv() (*WatchOccupancyResponse, error) {
+	m := new(WatchOccupancyResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// PartyServiceServer is the server API for PartyService service.
+// All implementations must embed UnimplementedPartyServiceServer
+// for forward compatibility
+type PartyServiceServer interface {
+	CreateTable(context.Context, *CreateTableRequest) (*CreateTableResponse, error)
+	ListTables(context.Context, *ListTablesRequest) (*ListTablesResponse, error)
+	GetTable(context.Context, *GetTableRequest) (*GetTableResponse, error)
+	CountEmptySeats(context.Context, *CountEmptySeatsRequest) (*CountEmptySeatsResponse, error)
+	InviteGuest(context.Context, *InviteGuestRequest) (*InviteGuestResponse, error)
+	ListGuestList(context.Context, *ListGuestListRequest) (*ListGuestListResponse, error)
+	ListArrivedGuests(context.Context, *ListArrivedGuestsRequest) (*ListArrivedGuestsResponse, error)
+	CheckIn(context.Context, *CheckInRequest) (*CheckInResponse, error)
+	CheckOut(context.Context, *CheckOutRequest) (*CheckOutResponse, error)
+	// WatchOccupancy streams every check in and check out of the event, optionally limited to one table.
+	WatchOccupancy(*WatchOccupancyRequest, PartyService_WatchOccupancyServer) error
+	mustEmbedUnimplementedPartyServiceServer()
+}
+
+// UnimplementedPartyServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPartyServiceServer struct {
+}
+
+func (UnimplementedPartyServiceServer) CreateTable(context.Context, *CreateTableRequest) (*CreateTableResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTable not implemented")
+}
+func (UnimplementedPartyServiceServer) ListTables(context.Context, *ListTablesRequest) (*ListTablesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTables not implemented")
+}
+func (UnimplementedPartyServiceServer) GetTable(context.Context, *GetTableRequest) (*GetTableResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTable not implemented")
+}
+func (UnimplementedPartyServiceServer) CountEmptySeats(context.Context, *CountEmptySeatsRequest) (*CountEmptySeatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountEmptySeats not implemented")
+}
+func (UnimplementedPartyServiceServer) InviteGuest(context.Context, *InviteGuestRequest) (*InviteGuestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteGuest not implemented")
+}
+func (UnimplementedPartyServiceServer) ListGuestList(context.Context, *ListGuestListRequest) (*ListGuestListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGuestList not implemented")
+}
+func (UnimplementedPartyServiceServer) ListArrivedGuests(context.Context, *ListArrivedGuestsRequest) (*ListArrivedGuestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListArrivedGuests not implemented")
+}
+func (UnimplementedPartyServiceServer) CheckIn(context.Context, *CheckInRequest) (*CheckInResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckIn not implemented")
+}
+func (UnimplementedPartyServiceServer) CheckOut(context.Context, *CheckOutRequest) (*CheckOutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckOut not implemented")
+}
+func (UnimplementedPartyServiceServer) WatchOccupancy(*WatchOccupancyRequest, PartyService_WatchOccupancyServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchOccupancy not implemented")
+}
+func (UnimplementedPartyServiceServer) mustEmbedUnimplementedPartyServiceServer() {}
+
+// UnsafePartyServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PartyServiceServer will
+// result in compilation errors.
+type UnsafePartyServiceServer interface {
+	mustEmbedUnimplementedPartyServiceServer()
+}
+
+func RegisterPartyServiceServer(s grpc.ServiceRegistrar, srv PartyServiceServer) {
+	s.RegisterService(&PartyService_ServiceDesc, srv)
+}
+
+func _PartyService_CreateTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartyServiceServer).CreateTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PartyService_CreateTable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartyServiceServer).CreateTable(ctx, req.(*CreateTableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PartyService_ListTables_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTablesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartyServiceServer).ListTables(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PartyService_ListTables_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartyServiceServer).ListTables(ctx, req.(*ListTablesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PartyService_GetTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartyServiceServer).GetTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PartyService_GetTable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartyServiceServer).GetTable(ctx, req.(*GetTableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PartyService_CountEmptySeats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountEmptySeatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartyServiceServer).CountEmptySeats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PartyService_CountEmptySeats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartyServiceServer).CountEmptySeats(ctx, req.(*CountEmptySeatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PartyService_InviteGuest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteGuestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartyServiceServer).InviteGuest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PartyService_InviteGuest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartyServiceServer).InviteGuest(ctx, req.(*InviteGuestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PartyService_ListGuestList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGuestListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartyServiceServer).ListGuestList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PartyService_ListGuestList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartyServiceServer).ListGuestList(ctx, req.(*ListGuestListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PartyService_ListArrivedGuests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListArrivedGuestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartyServiceServer).ListArrivedGuests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PartyService_ListArrivedGuests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartyServiceServer).ListArrivedGuests(ctx, req.(*ListArrivedGuestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PartyService_CheckIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckInRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartyServiceServer).CheckIn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PartyService_CheckIn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartyServiceServer).CheckIn(ctx, req.(*CheckInRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PartyService_CheckOut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckOutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartyServiceServer).CheckOut(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PartyService_CheckOut_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartyServiceServer).CheckOut(ctx, req.(*CheckOutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PartyService_WatchOccupancy_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchOccupancyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PartyServiceServer).WatchOccupancy(m, &partyServiceWatchOccupancyServer{stream})
+}
+
+type PartyService_WatchOccupancyServer interface {
+	Send(*WatchOccupancyResponse) error
+	grpc.ServerStream
+}
+
+type partyServiceWatchOccupancyServer struct {
+	grpc.ServerStream
+}
+
+func (x *partyServiceWatchOccupancyServer) Send(m *WatchOccupancyResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// PartyService_ServiceDesc is the grpc.ServiceDesc for PartyService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PartyService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "party.v1.PartyService",
+	HandlerType: (*PartyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTable",
+			Handler:    _PartyService_CreateTable_Handler,
+		},
+		{
+			MethodName: "ListTables",
+			Handler:    _PartyService_ListTables_Handler,
+		},
+		{
+			MethodName: "GetTable",
+			Handler:    _PartyService_GetTable_Handler,
+		},
+		{
+			MethodName: "CountEmptySeats",
+			Handler:    _PartyService_CountEmptySeats_Handler,
+		},
+		{
+			MethodName: "InviteGuest",
+			Handler:    _PartyService_InviteGuest_Handler,
+		},
+		{
+			MethodName: "ListGuestList",
+			Handler:    _PartyService_ListGuestList_Handler,
+		},
+		{
+			MethodName: "ListArrivedGuests",
+			Handler:    _PartyService_ListArrivedGuests_Handler,
+		},
+		{
+			MethodName: "CheckIn",
+			Handler:    _PartyService_CheckIn_Handler,
+		},
+		{
+			MethodName: "CheckOut",
+			Handler:    _PartyService_CheckOut_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchOccupancy",
+			Handler:       _PartyService_WatchOccupancy_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "party/v1/party.proto",
+}
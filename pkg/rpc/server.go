@@ -0,0 +1,183 @@
+// Package rpc serves the party service over gRPC, the handlers are thin adapters over the guests and tables services.
+package rpc
+
+import (
+	"context"
+	"github.com/getground/tech-tasks/backend/definitions/guests"
+	"github.com/getground/tech-tasks/backend/definitions/notifications"
+	"github.com/getground/tech-tasks/backend/definitions/tables"
+	"github.com/getground/tech-tasks/backend/pkg/rpc/partypb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Server struct {
+	partypb.UnimplementedPartyServiceServer
+	tableSvc tables.Service
+	guestSvc guests.Service
+	broker   notifications.Broker
+	// defaultEvent is the event of the requests without an event
+	defaultEvent uint
+}
+
+func NewServer(
+	tableSvc tables.Service, guestSvc guests.Service, broker notifications.Broker, defaultEvent uint,
+) *Server {
+	return &Server{
+		tableSvc:     tableSvc,
+		guestSvc:     guestSvc,
+		broker:       broker,
+		defaultEvent: defaultEvent,
+	}
+}
+
+func (s *Server) CreateTable(_ context.Context, req *partypb.CreateTableRequest) (*partypb.CreateTableResponse, error) {
+	if req.GetCapacity() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "capacity must be greater than zero")
+	}
+
+	res, err := s.tablesOf(req.GetEvent()).Create(tables.CreateRequest{Capacity: req.GetCapacity()})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &partypb.CreateTableResponse{
+		Table: &partypb.Table{
+			Id:         uint32(res.ID),
+			Capacity:   res.Capacity,
+			EmptySeats: res.Capacity,
+		},
+	}, nil
+}
+
+func (s *Server) ListTables(_ context.Context, req *partypb.ListTablesRequest) (*partypb.ListTablesResponse, error) {
+	list, err := s.tablesOf(req.GetEvent()).List()
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &partypb.ListTablesResponse{Tables: mapTablesToProto(list)}, nil
+}
+
+func (s *Server) GetTable(_ context.Context, req *partypb.GetTableRequest) (*partypb.GetTableResponse, error) {
+	t, err := s.tablesOf(req.GetEvent()).GetByID(uint(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &partypb.GetTableResponse{Table: mapTableToProto(t)}, nil
+}
+
+func (s *Server) CountEmptySeats(
+	_ context.Context, req *partypb.CountEmptySeatsRequest,
+) (*partypb.CountEmptySeatsResponse, error) {
+	return &partypb.CountEmptySeatsResponse{SeatsEmpty: int64(s.tablesOf(req.GetEvent()).CountEmptySeats())}, nil
+}
+
+func (s *Server) InviteGuest(_ context.Context, req *partypb.InviteGuestRequest) (*partypb.InviteGuestResponse, error) {
+	if req.GetName() == "" || req.GetTable() == 0 || req.GetAccompanyingGuests() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "name, table and a non negative accompanying guests are required")
+	}
+
+	res, err := s.guestsOf(req.GetEvent()).Create(
+		guests.CreateRequest{
+			Name:         req.GetName(),
+			Table:        uint(req.GetTable()),
+			Accompanying: req.GetAccompanyingGuests(),
+		},
+	)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &partypb.InviteGuestResponse{Name: res.Name}, nil
+}
+
+func (s *Server) ListGuestList(
+	_ context.Context, req *partypb.ListGuestListRequest,
+) (*partypb.ListGuestListResponse, error) {
+	list, err := s.guestsOf(req.GetEvent()).GetGuestList(guests.ListRequest{})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return mapGuestListToProto(list), nil
+}
+
+func (s *Server) ListArrivedGuests(
+	_ context.Context, req *partypb.ListArrivedGuestsRequest,
+) (*partypb.ListArrivedGuestsResponse, error) {
+	list, err := s.guestsOf(req.GetEvent()).GetGuests(guests.ListRequest{})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return mapArrivedGuestsToProto(list), nil
+}
+
+func (s *Server) CheckIn(_ context.Context, req *partypb.CheckInRequest) (*partypb.CheckInResponse, error) {
+	if req.GetName() == "" || req.GetAccompanyingGuests() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "name and a non negative accompanying guests are required")
+	}
+
+	res, err := s.guestsOf(req.GetEvent()).CheckIn(
+		guests.CheckInRequest{
+			Name:         req.GetName(),
+			Accompanying: req.GetAccompanyingGuests(),
+		},
+	)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &partypb.CheckInResponse{Name: res.Name}, nil
+}
+
+func (s *Server) CheckOut(_ context.Context, req *partypb.CheckOutRequest) (*partypb.CheckOutResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	if err := s.guestsOf(req.GetEvent()).CheckOut(req.GetName()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &partypb.CheckOutResponse{}, nil
+}
+
+// WatchOccupancy streams the check ins and check outs of the event of the request until the client is gone.
+func (s *Server) WatchOccupancy(req *partypb.WatchOccupancyRequest, stream partypb.PartyService_WatchOccupancyServer) error {
+	event := s.event(req.GetEvent())
+	ch, cancel := s.broker.Subscribe()
+	defer cancel()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case n, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			if !n.IsOccupancyChange() || n.EventID != event {
+				continue
+			}
+			if req.GetTable() != 0 && uint(req.GetTable()) != n.TableID {
+				continue
+			}
+			if err := stream.Send(&partypb.WatchOccupancyResponse{Change: mapNotificationToProto(n)}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// event returns the event a request is about, zero is the default event.
+func (s *Server) event(id uint32) uint {
+	if id == 0 {
+		return s.defaultEvent
+	}
+	return uint(id)
+}
+
+// tablesOf returns the tables service of the event a request is about.
+func (s *Server) tablesOf(event uint32) tables.Service {
+	return s.tableSvc.ForEvent(s.event(event))
+}
+
+// guestsOf returns the guests service of the event a request is about.
+func (s *Server) guestsOf(event uint32) guests.Service {
+	return s.guestSvc.ForEvent(s.event(event))
+}
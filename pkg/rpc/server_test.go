@@ -0,0 +1,587 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"github.com/getground/tech-tasks/backend/definitions/etag"
+	eventsDef "github.com/getground/tech-tasks/backend/definitions/events"
+	guestsDef "github.com/getground/tech-tasks/backend/definitions/guests"
+	notificationsDef "github.com/getground/tech-tasks/backend/definitions/notifications"
+	tablesDef "github.com/getground/tech-tasks/backend/definitions/tables"
+	venueDef "github.com/getground/tech-tasks/backend/definitions/venue"
+	guestsMocks "github.com/getground/tech-tasks/backend/mocks/definitions/guests"
+	tableMocks "github.com/getground/tech-tasks/backend/mocks/definitions/tables"
+	"github.com/getground/tech-tasks/backend/pkg/notifications"
+	"github.com/getground/tech-tasks/backend/pkg/rpc"
+	"github.com/getground/tech-tasks/backend/pkg/rpc/partypb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+	"testing"
+	"time"
+)
+
+type serverMocks struct {
+	tableService *tableMocks.Service
+	guestService *guestsMocks.Service
+	broker       *notifications.Broker
+}
+
+// setupServer serves the adapters on an in process listener and returns a client connected to it.
+func setupServer(t *testing.T) (partypb.PartyServiceClient, serverMocks) {
+	listener := bufconn.Listen(1024 * 1024)
+	tblService := new(tableMocks.Service)
+	guestService := new(guestsMocks.Service)
+	broker := notifications.NewBroker()
+	// the requests are scoped to their event, the ones without an event to the default event 1
+	tblService.On("ForEvent", mock.Anything).Return(tblService).Maybe()
+	guestService.On("ForEvent", mock.Anything).Return(guestService).Maybe()
+
+	server := grpc.NewServer()
+	partypb.RegisterPartyServiceServer(server, rpc.NewServer(tblService, guestService, broker, 1))
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	conn, err := grpc.DialContext(
+		context.Background(), "bufnet",
+		grpc.WithContextDialer(
+			func(ctx context.Context, _ string) (net.Conn, error) {
+				return listener.DialContext(ctx)
+			},
+		),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when dialing the grpc server", err)
+	}
+	t.Cleanup(
+		func() {
+			conn.Close()
+			server.Stop()
+		},
+	)
+	return partypb.NewPartyServiceClient(conn), serverMocks{tblService, guestService, broker}
+}
+
+func TestServer_CreateTable(t *testing.T) {
+	c, m := setupServer(t)
+	t.Run(
+		"invalid capacity", func(t *testing.T) {
+			//	method call
+			res, err := c.CreateTable(context.Background(), &partypb.CreateTableRequest{})
+
+			//	assert
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Nil(t, res)
+			m.tableService.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"service error", func(t *testing.T) {
+			//	mocks
+			m.tableService.On("Create", tablesDef.CreateRequest{Capacity: 10}).Return(
+				tablesDef.CreateResponse{}, errors.New("error creating table"),
+			).Once()
+
+			//	method call
+			res, err := c.CreateTable(context.Background(), &partypb.CreateTableRequest{Capacity: 10})
+
+			//	assert
+			assert.Equal(t, codes.Internal, status.Code(err))
+			assert.Nil(t, res)
+			m.tableService.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			//	mocks
+			m.tableService.On("Create", tablesDef.CreateRequest{Capacity: 10}).Return(
+				tablesDef.CreateResponse{ID: 1, Capacity: 10}, nil,
+			).Once()
+
+			//	method call
+			res, err := c.CreateTable(context.Background(), &partypb.CreateTableRequest{Capacity: 10, Event: 2})
+
+			//	assert
+			assert.NoError(t, err)
+			m.tableService.AssertCalled(t, "ForEvent", uint(2))
+			assert.Equal(t, uint32(1), res.GetTable().GetId())
+			assert.Equal(t, int64(10), res.GetTable().GetCapacity())
+			assert.Equal(t, int64(10), res.GetTable().GetEmptySeats())
+			m.tableService.AssertExpectations(t)
+		},
+	)
+}
+
+func TestServer_ListTables(t *testing.T) {
+	c, m := setupServer(t)
+	t.Run(
+		"service error", func(t *testing.T) {
+			//	mocks
+			m.tableService.On("List").Return(nil, errors.New("error listing tables")).Once()
+
+			//	method call
+			res, err := c.ListTables(context.Background(), &partypb.ListTablesRequest{})
+
+			//	assert
+			assert.Equal(t, codes.Internal, status.Code(err))
+			assert.Nil(t, res)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			//	mocks
+			list := []tablesDef.Table{{ID: 1, Capacity: 10, EmptySeats: 8}, {ID: 2, Capacity: 4, EmptySeats: 4}}
+			m.tableService.On("List").Return(list, nil).Once()
+
+			//	method call
+			res, err := c.ListTables(context.Background(), &partypb.ListTablesRequest{})
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Len(t, res.GetTables(), 2)
+			assert.Equal(t, int64(8), res.GetTables()[0].GetEmptySeats())
+			assert.Equal(t, uint32(2), res.GetTables()[1].GetId())
+			m.tableService.AssertExpectations(t)
+		},
+	)
+}
+
+func TestServer_GetTable(t *testing.T) {
+	c, m := setupServer(t)
+	t.Run(
+		"not found", func(t *testing.T) {
+			//	mocks
+			m.tableService.On("GetByID", uint(3)).Return(tablesDef.Table{}, tablesDef.ErrNotFound).Once()
+
+			//	method call
+			res, err := c.GetTable(context.Background(), &partypb.GetTableRequest{Id: 3})
+
+			//	assert
+			assert.Equal(t, codes.NotFound, status.Code(err))
+			assert.Nil(t, res)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			//	mocks
+			m.tableService.On("GetByID", uint(1)).Return(tablesDef.Table{ID: 1, Capacity: 5, EmptySeats: 3}, nil).Once()
+
+			//	method call
+			res, err := c.GetTable(context.Background(), &partypb.GetTableRequest{Id: 1})
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, int64(3), res.GetTable().GetEmptySeats())
+			m.tableService.AssertExpectations(t)
+		},
+	)
+}
+
+func TestServer_CountEmptySeats(t *testing.T) {
+	c, m := setupServer(t)
+
+	//	mocks
+	m.tableService.On("CountEmptySeats").Return(12).Once()
+
+	//	method call
+	res, err := c.CountEmptySeats(context.Background(), &partypb.CountEmptySeatsRequest{})
+
+	//	assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), res.GetSeatsEmpty())
+	m.tableService.AssertExpectations(t)
+}
+
+func TestServer_InviteGuest(t *testing.T) {
+	c, m := setupServer(t)
+	t.Run(
+		"invalid request", func(t *testing.T) {
+			//	method call
+			res, err := c.InviteGuest(context.Background(), &partypb.InviteGuestRequest{Name: "test"})
+
+			//	assert
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Nil(t, res)
+			m.guestService.AssertExpectations(t)
+		},
+	)
+
+	t.Run(
+		"no capacity", func(t *testing.T) {
+			//	mocks
+			req := guestsDef.CreateRequest{Name: "test", Table: 1, Accompanying: 9}
+			m.guestService.On("Create", req).Return(guestsDef.CreateResponse{}, guestsDef.ErrNoCapacity).Once()
+
+			//	method call
+			res, err := c.InviteGuest(
+				context.Background(), &partypb.InviteGuestRequest{Name: "test", Table: 1, AccompanyingGuests: 9},
+			)
+
+			//	assert
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+			assert.Equal(t, guestsDef.ErrNoCapacity.Error(), status.Convert(err).Message())
+			assert.Nil(t, res)
+		},
+	)
+
+	t.Run(
+		"already listed", func(t *testing.T) {
+			//	mocks
+			req := guestsDef.CreateRequest{Name: "test", Table: 1, Accompanying: 2}
+			m.guestService.On("Create", req).Return(guestsDef.CreateResponse{}, guestsDef.ErrAlreadyListed).Once()
+
+			//	method call
+			res, err := c.InviteGuest(
+				context.Background(), &partypb.InviteGuestRequest{Name: "test", Table: 1, AccompanyingGuests: 2},
+			)
+
+			//	assert
+			assert.Equal(t, codes.AlreadyExists, status.Code(err))
+			assert.Nil(t, res)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			//	mocks
+			req := guestsDef.CreateRequest{Name: "test", Table: 1, Accompanying: 2}
+			m.guestService.On("Create", req).Return(guestsDef.CreateResponse{Name: "test"}, nil).Once()
+
+			//	method call
+			res, err := c.InviteGuest(
+				context.Background(), &partypb.InviteGuestRequest{Name: "test", Table: 1, AccompanyingGuests: 2},
+			)
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, "test", res.GetName())
+			m.guestService.AssertExpectations(t)
+		},
+	)
+}
+
+func TestServer_ListGuestList(t *testing.T) {
+	c, m := setupServer(t)
+	t.Run(
+		"service error", func(t *testing.T) {
+			//	mocks
+			m.guestService.On("GetGuestList", guestsDef.ListRequest{}).Return(guestsDef.ListDTO{}, errors.New("error retrieving")).Once()
+
+			//	method call
+			res, err := c.ListGuestList(context.Background(), &partypb.ListGuestListRequest{})
+
+			//	assert
+			assert.Equal(t, codes.Internal, status.Code(err))
+			assert.Nil(t, res)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			//	mocks
+			list := guestsDef.ListDTO{Guests: []guestsDef.GuestListDTO{{Name: "test", Table: 1, Accompanying: 2}}}
+			m.guestService.On("GetGuestList", guestsDef.ListRequest{}).Return(list, nil).Once()
+
+			//	method call
+			res, err := c.ListGuestList(context.Background(), &partypb.ListGuestListRequest{})
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Len(t, res.GetGuests(), 1)
+			assert.Equal(t, "test", res.GetGuests()[0].GetName())
+			assert.Equal(t, uint32(1), res.GetGuests()[0].GetTable())
+			assert.Equal(t, int64(2), res.GetGuests()[0].GetAccompanyingGuests())
+			m.guestService.AssertExpectations(t)
+		},
+	)
+}
+
+func TestServer_ListArrivedGuests(t *testing.T) {
+	c, m := setupServer(t)
+	t.Run(
+		"service error", func(t *testing.T) {
+			//	mocks
+			m.guestService.On("GetGuests", guestsDef.ListRequest{}).Return(guestsDef.DTO{}, errors.New("error retrieving")).Once()
+
+			//	method call
+			res, err := c.ListArrivedGuests(context.Background(), &partypb.ListArrivedGuestsRequest{})
+
+			//	assert
+			assert.Equal(t, codes.Internal, status.Code(err))
+			assert.Nil(t, res)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			//	mocks
+			list := guestsDef.DTO{Guests: []guestsDef.GuestDTO{{Name: "test", Accompanying: 2, TimeArrived: "now"}}}
+			m.guestService.On("GetGuests", guestsDef.ListRequest{}).Return(list, nil).Once()
+
+			//	method call
+			res, err := c.ListArrivedGuests(context.Background(), &partypb.ListArrivedGuestsRequest{})
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Len(t, res.GetGuests(), 1)
+			assert.Equal(t, "now", res.GetGuests()[0].GetTimeArrived())
+			m.guestService.AssertExpectations(t)
+		},
+	)
+}
+
+func TestServer_CheckIn(t *testing.T) {
+	c, m := setupServer(t)
+	t.Run(
+		"invalid request", func(t *testing.T) {
+			//	method call
+			res, err := c.CheckIn(context.Background(), &partypb.CheckInRequest{AccompanyingGuests: 1})
+
+			//	assert
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Nil(t, res)
+		},
+	)
+
+	t.Run(
+		"not invited", func(t *testing.T) {
+			//	mocks
+			req := guestsDef.CheckInRequest{Name: "test", Accompanying: 1}
+			m.guestService.On("CheckIn", req).Return(guestsDef.CheckInResponse{}, guestsDef.ErrNotInvited).Once()
+
+			//	method call
+			res, err := c.CheckIn(context.Background(), &partypb.CheckInRequest{Name: "test", AccompanyingGuests: 1})
+
+			//	assert
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+			assert.Nil(t, res)
+		},
+	)
+
+	t.Run(
+		"doors not open", func(t *testing.T) {
+			//	mocks
+			req := guestsDef.CheckInRequest{Name: "test", Accompanying: 1}
+			m.guestService.On("CheckIn", req).Return(guestsDef.CheckInResponse{}, eventsDef.ErrNotAllowed).Once()
+
+			//	method call
+			res, err := c.CheckIn(context.Background(), &partypb.CheckInRequest{Name: "test", AccompanyingGuests: 1})
+
+			//	assert
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+			assert.Nil(t, res)
+		},
+	)
+
+	t.Run(
+		"no empty seats", func(t *testing.T) {
+			//	mocks
+			req := guestsDef.CheckInRequest{Name: "test", Accompanying: 1}
+			m.guestService.On("CheckIn", req).Return(guestsDef.CheckInResponse{}, guestsDef.ErrNoEmptySeats).Once()
+
+			//	method call
+			res, err := c.CheckIn(context.Background(), &partypb.CheckInRequest{Name: "test", AccompanyingGuests: 1})
+
+			//	assert
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+			assert.Nil(t, res)
+		},
+	)
+
+	t.Run(
+		"venue full", func(t *testing.T) {
+			//	mocks
+			req := guestsDef.CheckInRequest{Name: "test", Accompanying: 1}
+			m.guestService.On("CheckIn", req).Return(guestsDef.CheckInResponse{}, venueDef.ErrFull).Once()
+
+			//	method call
+			res, err := c.CheckIn(context.Background(), &partypb.CheckInRequest{Name: "test", AccompanyingGuests: 1})
+
+			//	assert
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+			assert.Nil(t, res)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			//	mocks
+			req := guestsDef.CheckInRequest{Name: "test", Accompanying: 1}
+			m.guestService.On("CheckIn", req).Return(guestsDef.CheckInResponse{Name: "test"}, nil).Once()
+
+			//	method call
+			res, err := c.CheckIn(context.Background(), &partypb.CheckInRequest{Name: "test", AccompanyingGuests: 1})
+
+			//	assert
+			assert.NoError(t, err)
+			assert.Equal(t, "test", res.GetName())
+			m.guestService.AssertExpectations(t)
+		},
+	)
+}
+
+func TestServer_CheckOut(t *testing.T) {
+	c, m := setupServer(t)
+	t.Run(
+		"invalid request", func(t *testing.T) {
+			//	method call
+			res, err := c.CheckOut(context.Background(), &partypb.CheckOutRequest{})
+
+			//	assert
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Nil(t, res)
+		},
+	)
+
+	t.Run(
+		"stale", func(t *testing.T) {
+			//	mocks
+			m.guestService.On("CheckOut", "test").Return(etag.ErrStale).Once()
+
+			//	method call
+			res, err := c.CheckOut(context.Background(), &partypb.CheckOutRequest{Name: "test"})
+
+			//	assert
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+			assert.Nil(t, res)
+		},
+	)
+
+	t.Run(
+		"service error", func(t *testing.T) {
+			//	mocks
+			m.guestService.On("CheckOut", "test").Return(errors.New("error checking out")).Once()
+
+			//	method call
+			res, err := c.CheckOut(context.Background(), &partypb.CheckOutRequest{Name: "test"})
+
+			//	assert
+			assert.Equal(t, codes.Internal, status.Code(err))
+			assert.Nil(t, res)
+		},
+	)
+
+	t.Run(
+		"success", func(t *testing.T) {
+			//	mocks
+			m.guestService.On("CheckOut", "test").Return(nil).Once()
+
+			//	method call
+			_, err := c.CheckOut(context.Background(), &partypb.CheckOutRequest{Name: "test"})
+
+			//	assert
+			assert.NoError(t, err)
+			m.guestService.AssertExpectations(t)
+		},
+	)
+}
+
+func TestServer_WatchOccupancy(t *testing.T) {
+	t.Run(
+		"streams occupancy changes of the table", func(t *testing.T) {
+			c, m := setupServer(t)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			//	method call
+			stream, err := c.WatchOccupancy(ctx, &partypb.WatchOccupancyRequest{Table: 1})
+			assert.NoError(t, err)
+
+			// the subscription is registered asynchronously, publish until the first change arrives
+			arrived := time.Date(2022, 12, 16, 20, 0, 0, 0, time.UTC)
+			received := make(chan *partypb.OccupancyChange)
+			go func() {
+				res, err := stream.Recv()
+				if err == nil {
+					received <- res.GetChange()
+				}
+			}()
+			var first *partypb.OccupancyChange
+			for first == nil {
+				m.broker.Publish(
+					notificationsDef.Notification{Type: notificationsDef.TableCreated, TableID: 1, EventID: 1},
+				)
+				m.broker.Publish(
+					notificationsDef.Notification{Type: notificationsDef.GuestCheckedIn, TableID: 2, EventID: 1},
+				)
+				// the same table of another event
+				m.broker.Publish(
+					notificationsDef.Notification{
+						Type: notificationsDef.GuestCheckedIn, Guest: "other", TableID: 1, EventID: 2,
+					},
+				)
+				m.broker.Publish(
+					notificationsDef.Notification{
+						Type:         notificationsDef.GuestCheckedIn,
+						EventID:      1,
+						OccurredAt:   arrived,
+						Guest:        "test",
+						Accompanying: 2,
+						TableID:      1,
+						EmptySeats:   7,
+					},
+				)
+				select {
+				case first = <-received:
+				case <-time.After(10 * time.Millisecond):
+				}
+			}
+
+			//	assert
+			assert.Equal(t, partypb.OccupancyChange_KIND_CHECKED_IN, first.GetKind())
+			assert.Equal(t, "test", first.GetGuest())
+			assert.Equal(t, int64(2), first.GetAccompanyingGuests())
+			assert.Equal(t, uint32(1), first.GetTable())
+			assert.Equal(t, int64(7), first.GetEmptySeats())
+			assert.Equal(t, arrived, first.GetOccurredAt().AsTime())
+		},
+	)
+
+	t.Run(
+		"streams check outs of every table of the event", func(t *testing.T) {
+			c, m := setupServer(t)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			//	method call
+			stream, err := c.WatchOccupancy(ctx, &partypb.WatchOccupancyRequest{Event: 3})
+			assert.NoError(t, err)
+
+			received := make(chan *partypb.OccupancyChange)
+			go func() {
+				res, err := stream.Recv()
+				if err == nil {
+					received <- res.GetChange()
+				}
+			}()
+			var first *partypb.OccupancyChange
+			for first == nil {
+				m.broker.Publish(
+					notificationsDef.Notification{Type: notificationsDef.GuestCheckedOut, TableID: 5, EventID: 1},
+				)
+				m.broker.Publish(
+					notificationsDef.Notification{Type: notificationsDef.GuestCheckedOut, TableID: 4, EventID: 3},
+				)
+				select {
+				case first = <-received:
+				case <-time.After(10 * time.Millisecond):
+				}
+			}
+
+			//	assert
+			assert.Equal(t, partypb.OccupancyChange_KIND_CHECKED_OUT, first.GetKind())
+			assert.Equal(t, uint32(4), first.GetTable())
+		},
+	)
+}
@@ -0,0 +1,328 @@
+// Package search is an in memory fuzzy index of the guests names, it tolerates typos, accents and word order.
+package search
+
+import (
+	"github.com/getground/tech-tasks/backend/definitions/guests"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	defaultLimit = 10
+	// minSimilarity is the lowest similarity of a query word and a name word that still counts as a match.
+	minSimilarity = 0.6
+	// prefixSimilarity scores a name word starting with the query word, the name is most likely still being typed.
+	prefixSimilarity = 0.9
+)
+
+// Index matches every query word with the closest word of each name. The words sharing bigrams with a query word
+// are looked up first, so only a few of them are compared letter by letter.
+type Index struct {
+	mu      sync.RWMutex
+	seeded  bool
+	guests  map[string]indexed
+	words   map[string]int
+	entries []entry
+	bigrams map[string][]int
+}
+
+// indexed is a guest and the number of words of its name.
+type indexed struct {
+	guest guests.Guest
+	words int
+}
+
+// entry is a distinct word of the names and the guests having it in their name.
+type entry struct {
+	word   []rune
+	guests []string
+}
+
+// Indexes keeps an index per event, the index of an event is created on its first use.
+type Indexes struct {
+	mu      sync.Mutex
+	indexes map[uint]*Index
+}
+
+func NewIndexes() *Indexes {
+	return &Indexes{indexes: map[uint]*Index{}}
+}
+
+func (i *Indexes) ForEvent(event uint) guests.Index {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	idx, ok := i.indexes[event]
+	if !ok {
+		idx = NewIndex()
+		i.indexes[event] = idx
+	}
+	return idx
+}
+
+func NewIndex() *Index {
+	return &Index{
+		guests:  map[string]indexed{},
+		words:   map[string]int{},
+		bigrams: map[string][]int{},
+	}
+}
+
+func (idx *Index) Seed(load func() ([]guests.Guest, error)) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if idx.seeded {
+		return nil
+	}
+	gs, err := load()
+	if err != nil {
+		return err
+	}
+	for _, g := range gs {
+		if _, ok := idx.guests[g.Name]; !ok {
+			idx.put(g)
+		}
+	}
+	idx.seeded = true
+	return nil
+}
+
+func (idx *Index) Seeded() bool {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return idx.seeded
+}
+
+func (idx *Index) Put(g guests.Guest) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.put(g)
+}
+
+// Remove drops a guest from the index, the words of its name stay indexed for the other guests having them.
+func (idx *Index) Remove(name string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if _, ok := idx.guests[name]; !ok {
+		return
+	}
+	delete(idx.guests, name)
+	for _, w := range words(name) {
+		e := &idx.entries[idx.words[w]]
+		kept := e.guests[:0]
+		for _, n := range e.guests {
+			if n != name {
+				kept = append(kept, n)
+			}
+		}
+		e.guests = kept
+	}
+}
+
+func (idx *Index) Search(query string, limit int) []guests.Match {
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	queryWords := words(query)
+	if len(queryWords) == 0 {
+		return nil
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	// sum up the best similarity of every query word per guest
+	totals := map[string]float64{}
+	for _, q := range queryWords {
+		best := map[string]float64{}
+		for id, sim := range idx.similar(q) {
+			for _, name := range idx.entries[id].guests {
+				if sim > best[name] {
+					best[name] = sim
+				}
+			}
+		}
+		for name, sim := range best {
+			totals[name] += sim
+		}
+	}
+
+	matches := make([]guests.Match, 0, len(totals))
+	for name, total := range totals {
+		g := idx.guests[name]
+		// extra words on either side lower the score, so "sam" ranks "Sam" before "Sam Smith"
+		n := len(queryWords)
+		if g.words > n {
+			n = g.words
+		}
+		matches = append(matches, guests.Match{Guest: g.guest, Score: total / float64(n)})
+	}
+	sort.Slice(
+		matches, func(i, j int) bool {
+			if matches[i].Score != matches[j].Score {
+				return matches[i].Score > matches[j].Score
+			}
+			return matches[i].Guest.Name < matches[j].Guest.Name
+		},
+	)
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// put indexes the words of a guest name the first time the guest is put, names never change after.
+func (idx *Index) put(g guests.Guest) {
+	if i, ok := idx.guests[g.Name]; ok {
+		i.guest = g
+		idx.guests[g.Name] = i
+		return
+	}
+
+	nameWords := words(g.Name)
+	idx.guests[g.Name] = indexed{guest: g, words: len(nameWords)}
+	for _, w := range nameWords {
+		id, ok := idx.words[w]
+		if !ok {
+			id = len(idx.entries)
+			idx.words[w] = id
+			idx.entries = append(idx.entries, entry{word: []rune(w)})
+			for _, b := range bigrams(w) {
+				idx.bigrams[b] = append(idx.bigrams[b], id)
+			}
+		}
+		e := &idx.entries[id]
+		if len(e.guests) == 0 || e.guests[len(e.guests)-1] != g.Name {
+			e.guests = append(e.guests, g.Name)
+		}
+	}
+}
+
+// similar returns the similarity of every indexed word close enough to q by word id.
+func (idx *Index) similar(q string) map[int]float64 {
+	grams := bigrams(q)
+	shared := map[int]int{}
+	for _, b := range grams {
+		for _, id := range idx.bigrams[b] {
+			shared[id]++
+		}
+	}
+
+	// a typo changes at most two bigrams, the words sharing less with a long query word are too far from it
+	minShared := (len(grams) - 2) / 2
+	if minShared < 1 {
+		minShared = 1
+	}
+	qr := []rune(q)
+	res := map[int]float64{}
+	for id, n := range shared {
+		if n < minShared {
+			continue
+		}
+		if sim := similarity(qr, idx.entries[id].word); sim >= minSimilarity {
+			res[id] = sim
+		}
+	}
+	return res
+}
+
+// similarity is 1 for equal words, prefixSimilarity when w starts with q and decreases with the edit distance
+// otherwise.
+func similarity(q, w []rune) float64 {
+	longest := len(w)
+	if len(q) > longest {
+		longest = len(q)
+	}
+	if hasPrefix(w, q) {
+		if len(q) == len(w) {
+			return 1
+		}
+		return prefixSimilarity
+	}
+	diff := len(w) - len(q)
+	if diff < 0 {
+		diff = -diff
+	}
+	if 1-float64(diff)/float64(longest) < minSimilarity {
+		return 0
+	}
+	return 1 - float64(distance(q, w))/float64(longest)
+}
+
+// distance is the optimal string alignment distance, a transposition of adjacent letters counts as one edit.
+func distance(a, b []rune) int {
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = minOf(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = minOf(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(b)]
+}
+
+func minOf(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+func hasPrefix(w, prefix []rune) bool {
+	if len(prefix) > len(w) {
+		return false
+	}
+	for i := range prefix {
+		if w[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// bigrams returns the pairs of letters of a word, the first and last letters are paired with a marker so they weigh
+// like the others.
+func bigrams(w string) []string {
+	r := []rune("^" + w + "$")
+	res := make([]string, 0, len(r)-1)
+	for i := 0; i < len(r)-1; i++ {
+		res = append(res, string(r[i:i+2]))
+	}
+	return res
+}
+
+// words folds the accents and the case of s and splits it on everything but letters and digits.
+func words(s string) []string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), s)
+	if err != nil {
+		folded = s
+	}
+	return strings.FieldsFunc(
+		strings.ToLower(folded), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		},
+	)
+}
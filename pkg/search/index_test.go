@@ -0,0 +1,323 @@
+package search_test
+
+import (
+	"fmt"
+	guestsDef "github.com/getground/tech-tasks/backend/definitions/guests"
+	"github.com/getground/tech-tasks/backend/pkg/search"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func names(ms []guestsDef.Match) []string {
+	res := make([]string, 0, len(ms))
+	for _, m := range ms {
+		res = append(res, m.Guest.Name)
+	}
+	return res
+}
+
+// load returns a load func listing gs.
+func load(gs []guestsDef.Guest) func() ([]guestsDef.Guest, error) {
+	return func() ([]guestsDef.Guest, error) {
+		return gs, nil
+	}
+}
+
+func TestIndex_Search(t *testing.T) {
+	// setup
+	idx := search.NewIndex()
+	_ = idx.Seed(
+		load(
+			[]guestsDef.Guest{
+				{Name: "Sam Smith", TableID: 1},
+				{Name: "Samantha Jones", TableID: 2},
+				{Name: "Zoë Müller", TableID: 3},
+				{Name: "John Johnson", TableID: 4},
+				{Name: "Kim Smithers", TableID: 5},
+			},
+		),
+	)
+
+	queries := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{name: "exact", query: "Sam Smith", expected: "Sam Smith"},
+		{name: "word order and punctuation", query: "Smith, Sam", expected: "Sam Smith"},
+		{name: "typo", query: "Sam Smtih", expected: "Sam Smith"},
+		{name: "short word typo", query: "sma smith", expected: "Sam Smith"},
+		{name: "missing letter", query: "jon johnson", expected: "John Johnson"},
+		{name: "accents in the name", query: "zoe muller", expected: "Zoë Müller"},
+		{name: "accents in the query", query: "Jóhn", expected: "John Johnson"},
+		{name: "prefix", query: "samant", expected: "Samantha Jones"},
+		{name: "short prefix", query: "ki", expected: "Kim Smithers"},
+	}
+	for _, q := range queries {
+		q := q
+		t.Run(
+			q.name, func(t *testing.T) {
+				//	method call
+				res := idx.Search(q.query, 3)
+
+				//	assert
+				if assert.NotEmpty(t, res) {
+					assert.Equal(t, q.expected, res[0].Guest.Name)
+				}
+			},
+		)
+	}
+
+	t.Run(
+		"ranking", func(t *testing.T) {
+			//	method call
+			res := idx.Search("sam smith", 0)
+
+			//	assert
+			assert.Equal(t, []string{"Sam Smith", "Kim Smithers", "Samantha Jones"}, names(res))
+			assert.Equal(t, 1.0, res[0].Score)
+			// a prefix of one of two words each, the equal scores are sorted by name
+			assert.Equal(t, res[1].Score, res[2].Score)
+		},
+	)
+
+	t.Run(
+		"limit", func(t *testing.T) {
+			assert.Len(t, idx.Search("smith", 1), 1)
+		},
+	)
+
+	t.Run(
+		"no match", func(t *testing.T) {
+			assert.Empty(t, idx.Search("xavier", 3))
+			assert.Empty(t, idx.Search(" ,. ", 3))
+		},
+	)
+}
+
+func TestIndex_Put(t *testing.T) {
+	t.Run(
+		"new guest", func(t *testing.T) {
+			// setup
+			idx := search.NewIndex()
+
+			//	method call
+			idx.Put(guestsDef.Guest{Name: "Sam Smith", TableID: 1})
+
+			//	assert
+			assert.Equal(t, []string{"Sam Smith"}, names(idx.Search("sam", 3)))
+			assert.False(t, idx.Seeded())
+		},
+	)
+
+	t.Run(
+		"update guest", func(t *testing.T) {
+			// setup
+			idx := search.NewIndex()
+			idx.Put(guestsDef.Guest{Name: "Sam Smith", TableID: 1})
+			arrived := time.Now()
+
+			//	method call
+			idx.Put(guestsDef.Guest{Name: "Sam Smith", TableID: 1, Accompanying: 2, TimeArrived: &arrived})
+
+			//	assert
+			res := idx.Search("sam smith", 3)
+			if assert.Len(t, res, 1) {
+				assert.Equal(t, guestsDef.StatusArrived, res[0].Guest.Status())
+				assert.Equal(t, int64(2), res[0].Guest.Accompanying)
+			}
+		},
+	)
+}
+
+func TestIndex_Remove(t *testing.T) {
+	t.Run(
+		"removed guest", func(t *testing.T) {
+			// setup
+			idx := search.NewIndex()
+			idx.Put(guestsDef.Guest{Name: "Sam Smith", TableID: 1})
+			idx.Put(guestsDef.Guest{Name: "Sam Jones", TableID: 2})
+
+			//	method call
+			idx.Remove("Sam Smith")
+
+			//	assert
+			assert.Equal(t, []string{"Sam Jones"}, names(idx.Search("sam", 3)))
+			assert.Empty(t, idx.Search("smith", 3))
+		},
+	)
+
+	t.Run(
+		"put again", func(t *testing.T) {
+			// setup
+			idx := search.NewIndex()
+			idx.Put(guestsDef.Guest{Name: "Sam Smith", TableID: 1})
+			idx.Remove("Sam Smith")
+
+			//	method call
+			idx.Put(guestsDef.Guest{Name: "Sam Smith", TableID: 2})
+
+			//	assert
+			res := idx.Search("sam smith", 3)
+			if assert.Len(t, res, 1) {
+				assert.Equal(t, uint(2), res[0].Guest.TableID)
+			}
+		},
+	)
+
+	t.Run(
+		"unknown guest", func(t *testing.T) {
+			// setup
+			idx := search.NewIndex()
+
+			//	method call
+			idx.Remove("Sam Smith")
+
+			//	assert
+			assert.Empty(t, idx.Search("sam", 3))
+		},
+	)
+}
+
+func TestIndex_Seed(t *testing.T) {
+	// setup
+	idx := search.NewIndex()
+	idx.Put(guestsDef.Guest{Name: "Sam Smith", TableID: 1, CheckedOut: 1})
+
+	//	method call
+	err := idx.Seed(load([]guestsDef.Guest{{Name: "Sam Smith", TableID: 1}, {Name: "Kim Jones", TableID: 2}}))
+
+	//	assert
+	assert.NoError(t, err)
+	assert.True(t, idx.Seeded())
+	res := idx.Search("sam smith", 1)
+	if assert.Len(t, res, 1) {
+		// the guest put before seeding is newer than the seed
+		assert.Equal(t, guestsDef.StatusLeft, res[0].Guest.Status())
+	}
+	assert.Equal(t, []string{"Kim Jones"}, names(idx.Search("kim jones", 1)))
+}
+
+func TestIndex_SeedRemove(t *testing.T) {
+	// setup
+	idx := search.NewIndex()
+	loading := make(chan struct{})
+	removed := make(chan struct{})
+	go func() {
+		<-loading
+		idx.Remove("Sam Smith")
+		close(removed)
+	}()
+
+	//	method call
+	err := idx.Seed(
+		func() ([]guestsDef.Guest, error) {
+			// the guest is removed while its row is being read, the removal waits for the seed
+			close(loading)
+			return []guestsDef.Guest{{Name: "Sam Smith", TableID: 1}}, nil
+		},
+	)
+	<-removed
+
+	//	assert
+	assert.NoError(t, err)
+	assert.Empty(t, idx.Search("sam smith", 1))
+	err = idx.Seed(
+		func() ([]guestsDef.Guest, error) {
+			t.Error("an index is seeded once")
+			return nil, nil
+		},
+	)
+	assert.NoError(t, err)
+}
+
+var (
+	firstNames = []string{
+		"Sam", "Samantha", "John", "Joan", "Zoë", "Chloé", "Renée", "Kim", "Ahmed", "Amr", "Mohamed", "Fatima",
+		"Olivia", "Liam", "Noah", "Emma", "Ava", "Lucas", "Mia", "Hugo", "Léa", "Jürgen", "Sofía", "Mateo",
+		"Isabella", "Ethan", "Amelia", "Oliver", "Charlotte", "James", "Harper", "Benjamin", "Evelyn", "Elijah",
+		"Abigail", "William", "Emily", "Henry", "Ella", "Alexander",
+	}
+	lastNames = []string{
+		"Smith", "Johnson", "Williams", "Brown", "Jones", "García", "Miller", "Davis", "Rodríguez", "Martínez",
+		"Hernández", "López", "González", "Wilson", "Anderson", "Thomas", "Taylor", "Moore", "Jackson", "Martin",
+		"Lee", "Pérez", "Thompson", "White", "Harris", "Sánchez", "Clark", "Ramírez", "Lewis", "Robinson", "Walker",
+		"Young", "Allen", "King", "Wright", "Scott", "Torres", "Nguyen", "Hill", "Flores", "Green", "Adams",
+		"Nelson", "Baker", "Hall", "Rivera", "Campbell", "Mitchell", "Carter", "Roberts", "Müller", "Schmidt",
+		"Schneider", "Fischer", "Weber", "Meyer", "Wagner", "Becker", "Schulz", "Hoffmann", "Mahmoud", "Hassan",
+		"Ibrahim", "Ali",
+	}
+)
+
+// seededIndex indexes n distinct guests made of first names, last names and a numbered middle name.
+func seededIndex(n int) *search.Index {
+	gs := make([]guestsDef.Guest, 0, n)
+	for i := 0; i < n; i++ {
+		first := firstNames[i%len(firstNames)]
+		last := lastNames[(i/len(firstNames))%len(lastNames)]
+		name := fmt.Sprintf("%s %s", first, last)
+		if round := i / (len(firstNames) * len(lastNames)); round > 0 {
+			name = fmt.Sprintf("%s %s%d %s", first, first[:1], round, last)
+		}
+		gs = append(gs, guestsDef.Guest{Name: name, TableID: uint(i%200 + 1)})
+	}
+	idx := search.NewIndex()
+	_ = idx.Seed(load(gs))
+	return idx
+}
+
+func TestIndexes_ForEvent(t *testing.T) {
+	t.Run(
+		"same event", func(t *testing.T) {
+			// setup
+			indexes := search.NewIndexes()
+			indexes.ForEvent(1).Put(guestsDef.Guest{Name: "Sam Smith", TableID: 1, EventID: 1})
+
+			//	method call
+			res := indexes.ForEvent(1).Search("sam", 3)
+
+			//	assert
+			assert.Equal(t, []string{"Sam Smith"}, names(res))
+		},
+	)
+
+	t.Run(
+		"other event", func(t *testing.T) {
+			// setup
+			indexes := search.NewIndexes()
+			indexes.ForEvent(1).Put(guestsDef.Guest{Name: "Sam Smith", TableID: 1, EventID: 1})
+			indexes.ForEvent(2).Put(guestsDef.Guest{Name: "Sam Jones", TableID: 2, EventID: 2})
+
+			//	method call
+			res := indexes.ForEvent(2).Search("sam", 3)
+
+			//	assert
+			assert.Equal(t, []string{"Sam Jones"}, names(res))
+			assert.False(t, indexes.ForEvent(3).Seeded())
+		},
+	)
+}
+
+func TestIndex_SearchTenThousandGuests(t *testing.T) {
+	// setup
+	idx := seededIndex(10000)
+
+	//	method call
+	res := idx.Search("Jnoes, Samnatha", 3)
+
+	//	assert
+	if assert.NotEmpty(t, res) {
+		assert.Equal(t, "Samantha Jones", res[0].Guest.Name)
+	}
+}
+
+func BenchmarkIndex_Search(b *testing.B) {
+	idx := seededIndex(10000)
+	queries := []string{"Sam Smith", "smith, sam", "Samnatha Jnoes", "zoe muller", "Rodriguez", "ibrahim a", "jo"}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		idx.Search(queries[i%len(queries)], 10)
+	}
+}